@@ -10,7 +10,11 @@ import (
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/migration"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/pebbledb"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
 	"github.com/kaspanet/kaspad/infrastructure/os/execenv"
 	"github.com/kaspanet/kaspad/infrastructure/os/limits"
 	"github.com/kaspanet/kaspad/infrastructure/os/signal"
@@ -18,10 +22,12 @@ import (
 	"github.com/kaspanet/kaspad/util/panics"
 	"github.com/kaspanet/kaspad/util/profiling"
 	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
 )
 
 const (
 	leveldbCacheSizeMiB = 256
+	pebbleCacheSizeMiB  = 256
 	defaultDataDirname  = "datadir2"
 )
 
@@ -89,6 +95,11 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 	}
 	profiling.TrackHeap(app.cfg.AppDir, log)
 
+	// Export metrics over http if requested.
+	if app.cfg.Metrics != "" {
+		metrics.Start(app.cfg.Metrics)
+	}
+
 	// Return now if an interrupt signal was triggered.
 	if signal.InterruptRequested(interrupt) {
 		return nil
@@ -102,6 +113,15 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 		}
 	}
 
+	if app.cfg.ConvertDatabase {
+		err := convertDatabase(app.cfg, interrupt)
+		if err != nil {
+			log.Errorf("Converting the database failed: %+v", err)
+			return err
+		}
+		return nil
+	}
+
 	// Open the database
 	databaseContext, err := openDB(app.cfg)
 	if err != nil {
@@ -122,6 +142,43 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 		return nil
 	}
 
+	if app.cfg.MigrateDatabaseDryRun {
+		err := dryRunDatabaseMigrations(app.cfg, databaseContext)
+		if err != nil {
+			log.Errorf("Dry running the database migrations failed: %+v", err)
+			return err
+		}
+		return nil
+	}
+
+	err = migrateDatabase(app.cfg, databaseContext, interrupt)
+	if err != nil {
+		if errors.Is(err, migration.ErrInterrupted) {
+			log.Infof("The database migration was interrupted, and will resume the next time kaspad starts")
+			return nil
+		}
+		log.Errorf("Migrating the database failed: %+v", err)
+		return err
+	}
+
+	if app.cfg.VerifyDatabase {
+		err := verifyDatabase(app.cfg, databaseContext)
+		if err != nil {
+			log.Errorf("Verifying the database failed: %+v", err)
+			return err
+		}
+		return nil
+	}
+
+	if app.cfg.Command != "" {
+		err := runSnapshotCommand(app.cfg, databaseContext)
+		if err != nil {
+			log.Errorf("The %s command failed: %+v", app.cfg.Command, err)
+			return err
+		}
+		return nil
+	}
+
 	// Create componentManager and start it.
 	componentManager, err := NewComponentManager(app.cfg, databaseContext, interrupt)
 	if err != nil {
@@ -161,9 +218,18 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 	return nil
 }
 
-// dbPath returns the path to the block database given a database type.
+// databasePath returns the path to the block database given a database type.
+// Every backend has its own directory, so that switching --dbtype never opens
+// a database with the wrong backend
 func databasePath(cfg *config.Config) string {
-	return filepath.Join(cfg.AppDir, defaultDataDirname)
+	return databasePathOfType(cfg, cfg.DbType)
+}
+
+func databasePathOfType(cfg *config.Config, dbType string) string {
+	if dbType == config.LevelDBDatabaseType {
+		return filepath.Join(cfg.AppDir, defaultDataDirname)
+	}
+	return filepath.Join(cfg.AppDir, defaultDataDirname+"-"+dbType)
 }
 
 func removeDatabase(cfg *config.Config) error {
@@ -172,18 +238,46 @@ func removeDatabase(cfg *config.Config) error {
 }
 
 func openDB(cfg *config.Config) (database.Database, error) {
+	if cfg.DbType == config.MemoryDatabaseType {
+		log.Warnf("Using an in-memory database. The DAG will be lost on shutdown")
+		return memorydb.NewMemoryDB(), nil
+	}
+
 	dbPath := databasePath(cfg)
 
-	err := checkDatabaseVersion(dbPath)
-	if err != nil {
-		return nil, err
+	if cfg.DbType != config.LevelDBDatabaseType && !databaseExists(dbPath) &&
+		databaseExists(databasePathOfType(cfg, config.LevelDBDatabaseType)) {
+
+		log.Warnf("There's no %s database, but there is a LevelDB one. Run kaspad with --convert-db "+
+			"to convert it instead of syncing from scratch", cfg.DbType)
 	}
 
-	log.Infof("Loading database from '%s'", dbPath)
-	db, err := ldb.NewLevelDB(dbPath, leveldbCacheSizeMiB)
+	err := checkDatabaseVersion(dbPath)
 	if err != nil {
 		return nil, err
 	}
 
-	return db, nil
+	log.Infof("Loading %s database from '%s'", cfg.DbType, dbPath)
+	return openDatabaseOfType(cfg.DbType, dbPath)
+}
+
+func openDatabaseOfType(dbType string, dbPath string) (database.Database, error) {
+	switch dbType {
+	case config.LevelDBDatabaseType:
+		db, err := ldb.NewLevelDB(dbPath, leveldbCacheSizeMiB)
+		if err != nil {
+			return nil, err
+		}
+		db.RegisterMetrics()
+		return db, nil
+	case config.PebbleDatabaseType:
+		db, err := pebbledb.NewPebbleDB(dbPath, pebbleCacheSizeMiB)
+		if err != nil {
+			return nil, err
+		}
+		db.RegisterMetrics()
+		return db, nil
+	default:
+		return nil, errors.Errorf("unknown database type %s", dbType)
+	}
 }
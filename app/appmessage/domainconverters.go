@@ -551,6 +551,26 @@ func DomainBlockWithTrustedDataToBlockWithTrustedDataV4(block *externalapi.Domai
 	}
 }
 
+// BlockWithTrustedDataV4ToDomainBlockWithTrustedData converts *MsgBlockWithTrustedDataV4 and the *MsgTrustedData
+// its indices point into to *externalapi.BlockWithTrustedData
+func BlockWithTrustedDataV4ToDomainBlockWithTrustedData(block *MsgBlockWithTrustedDataV4, data *MsgTrustedData) *externalapi.BlockWithTrustedData {
+	blockWithTrustedData := &externalapi.BlockWithTrustedData{
+		Block:        MsgBlockToDomainBlock(block.Block),
+		DAAWindow:    make([]*externalapi.TrustedDataDataDAAHeader, 0, len(block.DAAWindowIndices)),
+		GHOSTDAGData: make([]*externalapi.BlockGHOSTDAGDataHashPair, 0, len(block.GHOSTDAGDataIndices)),
+	}
+
+	for _, index := range block.DAAWindowIndices {
+		blockWithTrustedData.DAAWindow = append(blockWithTrustedData.DAAWindow, TrustedDataDataDAABlockV4ToTrustedDataDataDAAHeader(data.DAAWindow[index]))
+	}
+
+	for _, index := range block.GHOSTDAGDataIndices {
+		blockWithTrustedData.GHOSTDAGData = append(blockWithTrustedData.GHOSTDAGData, GHOSTDAGHashPairToDomainGHOSTDAGHashPair(data.GHOSTDAGData[index]))
+	}
+
+	return blockWithTrustedData
+}
+
 // DomainTrustedDataToTrustedData converts *externalapi.BlockWithTrustedData to *MsgBlockWithTrustedData
 func DomainTrustedDataToTrustedData(domainDAAWindow []*externalapi.TrustedDataDataDAAHeader, domainGHOSTDAGData []*externalapi.BlockGHOSTDAGDataHashPair) *MsgTrustedData {
 	daaWindow := make([]*TrustedDataDAAHeader, len(domainDAAWindow))
@@ -0,0 +1,56 @@
+package appmessage
+
+// GetAddressTransactionsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAddressTransactionsRequestMessage struct {
+	baseMessage
+	Address string
+	Offset  uint64
+	Limit   uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAddressTransactionsRequestMessage) Command() MessageCommand {
+	return CmdGetAddressTransactionsRequestMessage
+}
+
+// NewGetAddressTransactionsRequestMessage returns a instance of the message
+func NewGetAddressTransactionsRequestMessage(address string, offset uint64, limit uint64) *GetAddressTransactionsRequestMessage {
+	return &GetAddressTransactionsRequestMessage{
+		Address: address,
+		Offset:  offset,
+		Limit:   limit,
+	}
+}
+
+// AddressTransaction represents a credit to or a debit from some address
+type AddressTransaction struct {
+	TransactionID          string
+	AcceptingBlockHash     string
+	AcceptingBlockDAAScore uint64
+	Amount                 uint64
+	IsDebit                bool
+}
+
+// GetAddressTransactionsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAddressTransactionsResponseMessage struct {
+	baseMessage
+	Address string
+	Entries []*AddressTransaction
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAddressTransactionsResponseMessage) Command() MessageCommand {
+	return CmdGetAddressTransactionsResponseMessage
+}
+
+// NewGetAddressTransactionsResponseMessage returns a instance of the message
+func NewGetAddressTransactionsResponseMessage(address string, entries []*AddressTransaction) *GetAddressTransactionsResponseMessage {
+	return &GetAddressTransactionsResponseMessage{
+		Address: address,
+		Entries: entries,
+	}
+}
@@ -0,0 +1,52 @@
+package appmessage
+
+// GetRPCUsageRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetRPCUsageRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetRPCUsageRequestMessage) Command() MessageCommand {
+	return CmdGetRPCUsageRequestMessage
+}
+
+// NewGetRPCUsageRequestMessage returns a instance of the message
+func NewGetRPCUsageRequestMessage() *GetRPCUsageRequestMessage {
+	return &GetRPCUsageRequestMessage{}
+}
+
+// RPCCostClassUsage is the usage of an RPC cost class by a client
+type RPCCostClassUsage struct {
+	CostClass         string
+	Requests          uint64
+	ThrottledRequests uint64
+	AvailableTokens   float64
+}
+
+// RPCClientUsage is the rate limit usage of an RPC client
+type RPCClientUsage struct {
+	ClientID         string
+	CostClassesUsage []*RPCCostClassUsage
+}
+
+// GetRPCUsageResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetRPCUsageResponseMessage struct {
+	baseMessage
+	Clients []*RPCClientUsage
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetRPCUsageResponseMessage) Command() MessageCommand {
+	return CmdGetRPCUsageResponseMessage
+}
+
+// NewGetRPCUsageResponseMessage returns a instance of the message
+func NewGetRPCUsageResponseMessage(clients []*RPCClientUsage) *GetRPCUsageResponseMessage {
+	return &GetRPCUsageResponseMessage{
+		Clients: clients,
+	}
+}
@@ -10,8 +10,13 @@ import (
 
 	"github.com/kaspanet/kaspad/app/protocol"
 	"github.com/kaspanet/kaspad/app/rpc"
+	"github.com/kaspanet/kaspad/app/rpc/rpcauth"
+	"github.com/kaspanet/kaspad/app/rpc/rpcratelimit"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/addresshistoryindex"
 	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/mempoolstore"
+	"github.com/kaspanet/kaspad/domain/txindex"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	infrastructuredatabase "github.com/kaspanet/kaspad/infrastructure/db/database"
@@ -30,6 +35,7 @@ type ComponentManager struct {
 	rpcManager        *rpc.Manager
 	connectionManager *connmanager.ConnectionManager
 	netAdapter        *netadapter.NetAdapter
+	mempoolStore      *mempoolstore.MempoolStore
 
 	started, shutdown int32
 }
@@ -43,6 +49,14 @@ func (a *ComponentManager) Start() {
 
 	log.Trace("Starting kaspad")
 
+	if a.mempoolStore != nil {
+		err := a.mempoolStore.Restore()
+		if err != nil {
+			panics.Exit(log, fmt.Sprintf("Error restoring the mempool: %+v", err))
+		}
+		a.mempoolStore.Start()
+	}
+
 	err := a.netAdapter.Start()
 	if err != nil {
 		panics.Exit(log, fmt.Sprintf("Error starting the net adapter: %+v", err))
@@ -68,27 +82,38 @@ func (a *ComponentManager) Stop() {
 		log.Errorf("Error stopping the net adapter: %+v", err)
 	}
 
+	if a.mempoolStore != nil {
+		err := a.mempoolStore.Stop()
+		if err != nil {
+			log.Errorf("Error saving the mempool: %+v", err)
+		}
+	}
+
 	a.protocolManager.Close()
 	close(a.protocolManager.Context().Domain().ConsensusEventsChannel())
 
 	return
 }
 
+func newConsensusConfig(cfg *config.Config) *consensus.Config {
+	return &consensus.Config{
+		Params:                          *cfg.ActiveNetParams,
+		IsArchival:                      cfg.IsArchivalNode,
+		EnableSanityCheckPruningUTXOSet: cfg.EnableSanityCheckPruningUTXOSet,
+	}
+}
+
 // NewComponentManager returns a new ComponentManager instance.
 // Use Start() to begin all services within this ComponentManager
 func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database, interrupt chan<- struct{}) (
 	*ComponentManager, error) {
 
-	consensusConfig := consensus.Config{
-		Params:                          *cfg.ActiveNetParams,
-		IsArchival:                      cfg.IsArchivalNode,
-		EnableSanityCheckPruningUTXOSet: cfg.EnableSanityCheckPruningUTXOSet,
-	}
+	consensusConfig := newConsensusConfig(cfg)
 	mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
 	mempoolConfig.MaximumOrphanTransactionCount = cfg.MaxOrphanTxs
 	mempoolConfig.MinimumRelayTransactionFee = cfg.MinRelayTxFee
 
-	domain, err := domain.New(&consensusConfig, mempoolConfig, db)
+	domain, err := domain.New(consensusConfig, mempoolConfig, db)
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +138,31 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		log.Infof("UTXO index started")
 	}
 
+	var txIndex *txindex.TXIndex
+	if cfg.TXIndex {
+		txIndex, err = txindex.New(domain, db)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("TX index started")
+	}
+
+	var addressHistoryIndex *addresshistoryindex.AddressHistoryIndex
+	if cfg.AddressHistoryIndex {
+		addressHistoryIndex, err = addresshistoryindex.New(domain, db)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("Address history index started")
+	}
+
+	var mempoolStore *mempoolstore.MempoolStore
+	if !cfg.NoMempoolPersistence {
+		mempoolStore = mempoolstore.New(domain, db)
+	}
+
 	connectionManager, err := connmanager.New(cfg, netAdapter, addressManager)
 	if err != nil {
 		return nil, err
@@ -121,7 +171,24 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	if err != nil {
 		return nil, err
 	}
-	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, domain.ConsensusEventsChannel(), interrupt)
+	var rpcAuthorizer *rpcauth.Authorizer
+	if cfg.RPCAuthFile != "" {
+		rpcAuthorizer, err = rpcauth.LoadAuthFile(cfg.RPCAuthFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rpcRateLimiter *rpcratelimit.RateLimiter
+	if len(cfg.RPCRateLimits) > 0 {
+		rpcRateLimiter, err = rpcratelimit.New(cfg.RPCRateLimits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, txIndex,
+		addressHistoryIndex, rpcAuthorizer, rpcRateLimiter, domain.ConsensusEventsChannel(), interrupt)
 
 	return &ComponentManager{
 		cfg:               cfg,
@@ -130,6 +197,7 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		connectionManager: connectionManager,
 		netAdapter:        netAdapter,
 		addressManager:    addressManager,
+		mempoolStore:      mempoolStore,
 	}, nil
 
 }
@@ -142,6 +210,10 @@ func setupRPC(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
+	addressHistoryIndex *addresshistoryindex.AddressHistoryIndex,
+	rpcAuthorizer *rpcauth.Authorizer,
+	rpcRateLimiter *rpcratelimit.RateLimiter,
 	consensusEventsChan chan externalapi.ConsensusEvent,
 	shutDownChan chan<- struct{},
 ) *rpc.Manager {
@@ -154,6 +226,10 @@ func setupRPC(
 		connectionManager,
 		addressManager,
 		utxoIndex,
+		txIndex,
+		addressHistoryIndex,
+		rpcAuthorizer,
+		rpcRateLimiter,
 		consensusEventsChan,
 		shutDownChan,
 	)
@@ -0,0 +1,164 @@
+package app
+
+import (
+	"os"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/os/signal"
+	"github.com/pkg/errors"
+)
+
+// conversionBatchSize is the number of entries copied in every transaction
+// of a database conversion
+const conversionBatchSize = 10_000
+
+// errConversionInterrupted is returned when the conversion stops because
+// kaspad is shutting down
+var errConversionInterrupted = errors.New("the database conversion was interrupted")
+
+// convertDatabase copies the LevelDB database into a new database of the type
+// given by --dbtype. The new database is built in a temporary directory, so an
+// interrupted conversion never leaves a partial database behind
+func convertDatabase(cfg *config.Config, interrupt <-chan struct{}) error {
+	sourcePath := databasePathOfType(cfg, config.LevelDBDatabaseType)
+	destinationPath := databasePath(cfg)
+	if !databaseExists(sourcePath) {
+		return errors.Errorf("there's no LevelDB database at '%s' to convert", sourcePath)
+	}
+	if databaseExists(destinationPath) {
+		return errors.Errorf("there's already a %s database at '%s'. Remove it to convert the LevelDB "+
+			"database again", cfg.DbType, destinationPath)
+	}
+	err := checkDatabaseVersion(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	temporaryPath := destinationPath + ".converting"
+	err = os.RemoveAll(temporaryPath)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Converting the LevelDB database at '%s' into a %s database at '%s'",
+		sourcePath, cfg.DbType, destinationPath)
+	err = convertDatabaseInto(sourcePath, cfg.DbType, temporaryPath, interrupt)
+	if err != nil {
+		removeErr := os.RemoveAll(temporaryPath)
+		if removeErr != nil {
+			log.Warnf("Couldn't remove the partial database at '%s': %s", temporaryPath, removeErr)
+		}
+		return err
+	}
+
+	err = os.Rename(temporaryPath, destinationPath)
+	if err != nil {
+		return err
+	}
+	log.Infof("Converted the database. Run kaspad with --dbtype=%s to use it. "+
+		"The LevelDB database at '%s' can then be removed", cfg.DbType, sourcePath)
+	return nil
+}
+
+func convertDatabaseInto(sourcePath string, dbType string, destinationPath string, interrupt <-chan struct{}) error {
+	source, err := openDatabaseOfType(config.LevelDBDatabaseType, sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	// The converted database keeps the version of the source, so that the
+	// migrations it's missing run when it's opened
+	sourceVersion, _, err := readDatabaseVersion(sourcePath)
+	if err != nil {
+		return err
+	}
+	err = writeDatabaseVersionFile(destinationPath, sourceVersion)
+	if err != nil {
+		return err
+	}
+	destination, err := openDatabaseOfType(dbType, destinationPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = copyDatabase(destination, source, interrupt)
+	if err != nil {
+		destination.Close()
+		return err
+	}
+
+	log.Infof("Compacting the %s database", dbType)
+	err = destination.Compact()
+	if err != nil {
+		destination.Close()
+		return err
+	}
+	return destination.Close()
+}
+
+// copyDatabase copies all the entries of source into destination, and returns
+// their number
+func copyDatabase(destination database.Database, source database.Database, interrupt <-chan struct{}) (uint64, error) {
+	cursor, err := source.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	const progressInterval = 10 * time.Second
+	lastProgressLog := time.Now()
+	copiedCount := uint64(0)
+	transaction, err := destination.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// transaction is the last transaction begun
+		transaction.RollbackUnlessClosed()
+	}()
+
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return 0, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return 0, err
+		}
+		err = transaction.Put(key, value)
+		if err != nil {
+			return 0, err
+		}
+		copiedCount++
+
+		if copiedCount%conversionBatchSize != 0 {
+			continue
+		}
+		err = transaction.Commit()
+		if err != nil {
+			return 0, err
+		}
+		if signal.InterruptRequested(interrupt) {
+			return 0, errConversionInterrupted
+		}
+		if time.Since(lastProgressLog) >= progressInterval {
+			log.Infof("Copied %d database entries", copiedCount)
+			lastProgressLog = time.Now()
+		}
+		transaction, err = destination.Begin()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return 0, err
+	}
+	log.Infof("Copied all the %d database entries", copiedCount)
+	return copiedCount, nil
+}
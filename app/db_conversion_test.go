@@ -0,0 +1,77 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestConvertDatabase(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.AppDir = t.TempDir()
+	cfg.DbType = config.PebbleDatabaseType
+
+	err := convertDatabase(cfg, nil)
+	if err == nil {
+		t.Fatalf("expected converting a missing LevelDB database to fail")
+	}
+
+	sourcePath := databasePathOfType(cfg, config.LevelDBDatabaseType)
+	err = checkDatabaseVersion(sourcePath)
+	if err != nil {
+		t.Fatalf("checkDatabaseVersion: %+v", err)
+	}
+	source, err := openDatabaseOfType(config.LevelDBDatabaseType, sourcePath)
+	if err != nil {
+		t.Fatalf("openDatabaseOfType: %+v", err)
+	}
+	// More entries than a conversion batch, in a few buckets
+	const entryCount = conversionBatchSize + 123
+	keyOf := func(i int) *database.Key {
+		return database.MakeBucket([]byte(fmt.Sprintf("bucket%d", i%3))).Key([]byte(fmt.Sprintf("key%d", i)))
+	}
+	for i := 0; i < entryCount; i++ {
+		err = source.Put(keyOf(i), []byte(fmt.Sprintf("value%d", i)))
+		if err != nil {
+			t.Fatalf("Put: %+v", err)
+		}
+	}
+	err = source.Close()
+	if err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+
+	err = convertDatabase(cfg, nil)
+	if err != nil {
+		t.Fatalf("convertDatabase: %+v", err)
+	}
+	err = convertDatabase(cfg, nil)
+	if err == nil {
+		t.Fatalf("expected converting into an existing database to fail")
+	}
+	if databaseExists(databasePath(cfg) + ".converting") {
+		t.Fatalf("expected the temporary database to be gone")
+	}
+
+	destination, err := openDB(cfg)
+	if err != nil {
+		t.Fatalf("openDB: %+v", err)
+	}
+	defer destination.Close()
+	if filepath.Base(databasePath(cfg)) != defaultDataDirname+"-"+config.PebbleDatabaseType {
+		t.Fatalf("unexpected pebble database path %s", databasePath(cfg))
+	}
+	for i := 0; i < entryCount; i++ {
+		value, err := destination.Get(keyOf(i))
+		if err != nil {
+			t.Fatalf("Get: %+v", err)
+		}
+		if !bytes.Equal(value, []byte(fmt.Sprintf("value%d", i))) {
+			t.Fatalf("entry %d has the wrong value %s", i, value)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/migration"
+)
+
+// firstDatabaseVersion is the oldest database version that can be migrated
+const firstDatabaseVersion = 1
+
+// databaseMigrations upgrade the databases of older versions of kaspad in
+// place. A change to the format of the database appends its migration here,
+// which bumps currentDatabaseVersion.
+//
+// Note that rebuilding the consensus from its own data is done by
+// domain.migrate rather than here.
+var databaseMigrations = newDatabaseMigrationRegistry()
+
+// currentDatabaseVersion is the version of the databases this kaspad creates
+var currentDatabaseVersion = databaseMigrations.LatestVersion()
+
+func newDatabaseMigrationRegistry(migrations ...*migration.Migration) *migration.Registry {
+	registry, err := migration.NewRegistry(firstDatabaseVersion, migrations...)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// migrateDatabase applies the migrations the database is missing. An
+// interrupted migration resumes the next time kaspad starts
+func migrateDatabase(cfg *config.Config, db database.Database, interrupt <-chan struct{}) error {
+	if cfg.DbType == config.MemoryDatabaseType {
+		return nil
+	}
+	_, err := runDatabaseMigrations(databasePath(cfg), db, databaseMigrations, &migration.Options{
+		Interrupt: interrupt,
+	})
+	return err
+}
+
+// dryRunDatabaseMigrations logs the migrations the database is missing and the
+// changes they would make, without applying them
+func dryRunDatabaseMigrations(cfg *config.Config, db database.Database) error {
+	report, err := runDatabaseMigrations(databasePath(cfg), db, databaseMigrations, &migration.Options{
+		DryRun: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(report.Migrations) == 0 {
+		log.Infof("The database is of version %d and has no pending migrations", report.FromVersion)
+		return nil
+	}
+	log.Infof("Migrating the database from version %d to version %d would make the following changes:",
+		report.FromVersion, report.ToVersion)
+	for _, migrationReport := range report.Migrations {
+		log.Infof("Version %d: %s", migrationReport.Version, migrationReport.Description)
+		for _, step := range migrationReport.Steps {
+			if step.AlreadyDone {
+				log.Infof("\tStep '%s' was already done", step.Name)
+				continue
+			}
+			log.Infof("\tStep '%s': visit %d entries, put %d and delete %d",
+				step.Name, step.Visited, step.Puts, step.Deletes)
+		}
+	}
+	return nil
+}
+
+// runDatabaseMigrations runs the migrations of registry over the database at
+// dbPath, and keeps its version file up to date with them
+func runDatabaseMigrations(dbPath string, db database.Database, registry *migration.Registry,
+	options *migration.Options) (*migration.Report, error) {
+
+	version, _, err := readDatabaseVersion(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	options.OnMigrated = func(migratedVersion uint32) error {
+		return writeDatabaseVersionFile(dbPath, migratedVersion)
+	}
+	report, err := registry.Run(db, version, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// The version file lags behind the database if kaspad stopped right after
+	// a migration was committed
+	if !options.DryRun && report.ToVersion != version {
+		err = writeDatabaseVersionFile(dbPath, report.ToVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/migration"
+)
+
+func TestRunDatabaseMigrations(t *testing.T) {
+	dbPath := t.TempDir()
+	db := memorydb.NewMemoryDB()
+	defer db.Close()
+
+	migratedKey := database.MakeBucket(nil).Key([]byte("migrated"))
+	registry := newDatabaseMigrationRegistry(&migration.Migration{
+		Version:     firstDatabaseVersion + 1,
+		Description: "test migration",
+		Migrate: func(migrationContext *migration.Context) error {
+			return migrationContext.Update("put", func(writer migration.Writer) error {
+				return writer.Put(migratedKey, []byte{1})
+			})
+		},
+	})
+
+	err := writeDatabaseVersionFile(dbPath, firstDatabaseVersion)
+	if err != nil {
+		t.Fatalf("writeDatabaseVersionFile: %+v", err)
+	}
+
+	report, err := runDatabaseMigrations(dbPath, db, registry, &migration.Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("runDatabaseMigrations: %+v", err)
+	}
+	if len(report.Migrations) != 1 {
+		t.Fatalf("expected the dry run to report a single migration, got %d", len(report.Migrations))
+	}
+	version, _, err := readDatabaseVersion(dbPath)
+	if err != nil {
+		t.Fatalf("readDatabaseVersion: %+v", err)
+	}
+	if version != firstDatabaseVersion {
+		t.Fatalf("expected a dry run to leave the version at %d, got %d", firstDatabaseVersion, version)
+	}
+
+	_, err = runDatabaseMigrations(dbPath, db, registry, &migration.Options{})
+	if err != nil {
+		t.Fatalf("runDatabaseMigrations: %+v", err)
+	}
+	version, _, err = readDatabaseVersion(dbPath)
+	if err != nil {
+		t.Fatalf("readDatabaseVersion: %+v", err)
+	}
+	if version != firstDatabaseVersion+1 {
+		t.Fatalf("expected the version file to be updated to %d, got %d", firstDatabaseVersion+1, version)
+	}
+	hasMigratedKey, err := db.Has(migratedKey)
+	if err != nil || !hasMigratedKey {
+		t.Fatalf("expected the migration to be applied, got %t, %v", hasMigratedKey, err)
+	}
+
+	// A version file that wasn't updated after the migration was committed
+	// is fixed without migrating again
+	err = writeDatabaseVersionFile(dbPath, firstDatabaseVersion)
+	if err != nil {
+		t.Fatalf("writeDatabaseVersionFile: %+v", err)
+	}
+	report, err = runDatabaseMigrations(dbPath, db, registry, &migration.Options{})
+	if err != nil {
+		t.Fatalf("runDatabaseMigrations: %+v", err)
+	}
+	if len(report.Migrations) != 0 {
+		t.Fatalf("expected the committed migration not to run again")
+	}
+	version, _, err = readDatabaseVersion(dbPath)
+	if err != nil {
+		t.Fatalf("readDatabaseVersion: %+v", err)
+	}
+	if version != firstDatabaseVersion+1 {
+		t.Fatalf("expected the version file to be fixed to %d, got %d", firstDatabaseVersion+1, version)
+	}
+}
+
+func TestCheckDatabaseVersion(t *testing.T) {
+	dbPath := t.TempDir()
+	err := checkDatabaseVersion(dbPath)
+	if err != nil {
+		t.Fatalf("checkDatabaseVersion: %+v", err)
+	}
+	version, exists, err := readDatabaseVersion(dbPath)
+	if err != nil || !exists || version != currentDatabaseVersion {
+		t.Fatalf("expected a new database to be of version %d, got %d, %t, %v",
+			currentDatabaseVersion, version, exists, err)
+	}
+
+	err = writeDatabaseVersionFile(dbPath, currentDatabaseVersion+1)
+	if err != nil {
+		t.Fatalf("writeDatabaseVersionFile: %+v", err)
+	}
+	err = checkDatabaseVersion(dbPath)
+	if err == nil {
+		t.Fatalf("expected a database newer than this kaspad to be rejected")
+	}
+}
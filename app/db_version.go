@@ -8,50 +8,74 @@ import (
 	"github.com/pkg/errors"
 )
 
-const currentDatabaseVersion = 1
-
+// checkDatabaseVersion creates the version file of a new database, and fails
+// for databases of versions newer than this kaspad knows. Older databases are
+// upgraded by migrateDatabase once they're open
 func checkDatabaseVersion(dbPath string) (err error) {
-	versionFileName := versionFilePath(dbPath)
-
-	versionBytes, err := os.ReadFile(versionFileName)
+	databaseVersion, exists, err := readDatabaseVersion(dbPath)
 	if err != nil {
-		if os.IsNotExist(err) { // If version file doesn't exist, we assume that the database is new
-			return createDatabaseVersionFile(dbPath, versionFileName)
-		}
 		return err
 	}
-
-	databaseVersion, err := strconv.Atoi(string(versionBytes))
-	if err != nil {
-		return err
+	if !exists { // If version file doesn't exist, we assume that the database is new
+		return writeDatabaseVersionFile(dbPath, currentDatabaseVersion)
 	}
 
-	if databaseVersion != currentDatabaseVersion {
-		// TODO: Once there's more then one database version, it might make sense to add upgrade logic at this point
-		return errors.Errorf("Invalid database version %d. Expected version: %d", databaseVersion, currentDatabaseVersion)
+	if databaseVersion > currentDatabaseVersion {
+		return errors.Errorf("Database version %d is newer than %d, the latest version this kaspad knows. "+
+			"Upgrade kaspad or reset the database", databaseVersion, currentDatabaseVersion)
+	}
+	if databaseVersion < currentDatabaseVersion {
+		log.Infof("The database at '%s' is of version %d, and will be migrated to version %d",
+			dbPath, databaseVersion, currentDatabaseVersion)
 	}
 
 	return nil
 }
 
-func createDatabaseVersionFile(dbPath string, versionFileName string) error {
+// readDatabaseVersion returns the version in the version file of the database
+// at dbPath, if it exists
+func readDatabaseVersion(dbPath string) (version uint32, exists bool, err error) {
+	versionBytes, err := os.ReadFile(versionFilePath(dbPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	databaseVersion, err := strconv.ParseUint(string(versionBytes), 10, 32)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "invalid database version file at '%s'", versionFilePath(dbPath))
+	}
+	return uint32(databaseVersion), true, nil
+}
+
+// writeDatabaseVersionFile sets the version of the database at dbPath. The file
+// is replaced atomically, since it's rewritten after every migration
+func writeDatabaseVersionFile(dbPath string, version uint32) error {
 	err := os.MkdirAll(dbPath, 0700)
 	if err != nil {
 		return err
 	}
 
-	versionFile, err := os.Create(versionFileName)
+	versionFileName := versionFilePath(dbPath)
+	temporaryFileName := versionFileName + ".tmp"
+	versionString := strconv.FormatUint(uint64(version), 10)
+	err = os.WriteFile(temporaryFileName, []byte(versionString), 0600)
 	if err != nil {
-		return nil
+		return err
 	}
-	defer versionFile.Close()
-
-	versionString := strconv.Itoa(currentDatabaseVersion)
-	_, err = versionFile.Write([]byte(versionString))
-	return err
+	return os.Rename(temporaryFileName, versionFileName)
 }
 
 func versionFilePath(dbPath string) string {
 	dbVersionFileName := path.Join(dbPath, "version")
 	return dbVersionFileName
 }
+
+// databaseExists returns whether there's a database at dbPath, which is marked
+// by its version file
+func databaseExists(dbPath string) bool {
+	_, err := os.Stat(versionFilePath(dbPath))
+	return err == nil
+}
@@ -1,5 +1,16 @@
 package blockrelay
 
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var (
+	ibdProgress = metrics.NewGaugeVec("kaspad_ibd_progress_ratio",
+		"Progress of the current or last IBD, by the type of objects being synced", "object")
+	ibdProcessed = metrics.NewGaugeVec("kaspad_ibd_processed",
+		"Objects processed during the current or last IBD, by their type", "object")
+)
+
 type ibdProgressReporter struct {
 	lowDAAScore                 uint64
 	highDAAScore                uint64
@@ -14,6 +25,8 @@ func newIBDProgressReporter(lowDAAScore uint64, highDAAScore uint64, objectName
 		// Avoid a zero or negative diff
 		highDAAScore = lowDAAScore + 1
 	}
+	ibdProgress.With(objectName).Set(0)
+	ibdProcessed.With(objectName).Set(0)
 	return &ibdProgressReporter{
 		lowDAAScore:                 lowDAAScore,
 		highDAAScore:                highDAAScore,
@@ -37,7 +50,11 @@ func (ipr *ibdProgressReporter) reportProgress(processedDelta int, highestProces
 		// Avoid a negative diff
 		relativeDAAScore = highestProcessedDAAScore - ipr.lowDAAScore
 	}
-	progressPercent := int((float64(relativeDAAScore) / float64(ipr.totalDAAScoreDifference)) * 100)
+	progress := float64(relativeDAAScore) / float64(ipr.totalDAAScoreDifference)
+	ibdProgress.With(ipr.objectName).Set(progress)
+	ibdProcessed.With(ipr.objectName).Set(float64(ipr.processed))
+
+	progressPercent := int(progress * 100)
 	if progressPercent > ipr.lastReportedProgressPercent {
 		log.Infof("IBD: Processed %d %s (%d%%)", ipr.processed, ipr.objectName, progressPercent)
 		ipr.lastReportedProgressPercent = progressPercent
@@ -0,0 +1,103 @@
+package blockrelay
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+// PruningPointAnticoneTrustedData is the data a node needs to insert the
+// pruning point and its anticone without their past: the DAA windows and
+// GHOSTDAG data of the blocks, which they all share as a single
+// appmessage.MsgTrustedData
+type PruningPointAnticoneTrustedData struct {
+	// Blocks are the pruning point and its anticone, starting with the
+	// pruning point
+	Blocks      []*externalapi.DomainHash
+	TrustedData *appmessage.MsgTrustedData
+
+	daaWindowIndices    map[externalapi.DomainHash][]uint64
+	ghostdagDataIndices map[externalapi.DomainHash][]uint64
+}
+
+// BuildPruningPointAnticoneTrustedData builds the trusted data of the pruning
+// point and its anticone
+func BuildPruningPointAnticoneTrustedData(consensus externalapi.Consensus, params *dagconfig.Params) (
+	*PruningPointAnticoneTrustedData, error) {
+
+	pointAndItsAnticone, err := consensus.PruningPointAndItsAnticone()
+	if err != nil {
+		return nil, err
+	}
+
+	windowSize := params.DifficultyAdjustmentWindowSize
+	daaWindowBlocks := make([]*externalapi.TrustedDataDataDAAHeader, 0, windowSize)
+	daaWindowHashesToIndex := make(map[externalapi.DomainHash]int, windowSize)
+	trustedDataDAABlockIndexes := make(map[externalapi.DomainHash][]uint64)
+
+	ghostdagData := make([]*externalapi.BlockGHOSTDAGDataHashPair, 0)
+	ghostdagDataHashToIndex := make(map[externalapi.DomainHash]int)
+	trustedDataGHOSTDAGDataIndexes := make(map[externalapi.DomainHash][]uint64)
+	for _, blockHash := range pointAndItsAnticone {
+		blockDAAWindowHashes, err := consensus.BlockDAAWindowHashes(blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		trustedDataDAABlockIndexes[*blockHash] = make([]uint64, 0, windowSize)
+		for i, daaBlockHash := range blockDAAWindowHashes {
+			index, exists := daaWindowHashesToIndex[*daaBlockHash]
+			if !exists {
+				trustedDataDataDAAHeader, err := consensus.TrustedDataDataDAAHeader(blockHash, daaBlockHash, uint64(i))
+				if err != nil {
+					return nil, err
+				}
+				daaWindowBlocks = append(daaWindowBlocks, trustedDataDataDAAHeader)
+				index = len(daaWindowBlocks) - 1
+				daaWindowHashesToIndex[*daaBlockHash] = index
+			}
+
+			trustedDataDAABlockIndexes[*blockHash] = append(trustedDataDAABlockIndexes[*blockHash], uint64(index))
+		}
+
+		ghostdagDataBlockHashes, err := consensus.TrustedBlockAssociatedGHOSTDAGDataBlockHashes(blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		trustedDataGHOSTDAGDataIndexes[*blockHash] = make([]uint64, 0, params.K)
+		for _, ghostdagDataBlockHash := range ghostdagDataBlockHashes {
+			index, exists := ghostdagDataHashToIndex[*ghostdagDataBlockHash]
+			if !exists {
+				data, err := consensus.TrustedGHOSTDAGData(ghostdagDataBlockHash)
+				if err != nil {
+					return nil, err
+				}
+				ghostdagData = append(ghostdagData, &externalapi.BlockGHOSTDAGDataHashPair{
+					Hash:         ghostdagDataBlockHash,
+					GHOSTDAGData: data,
+				})
+				index = len(ghostdagData) - 1
+				ghostdagDataHashToIndex[*ghostdagDataBlockHash] = index
+			}
+
+			trustedDataGHOSTDAGDataIndexes[*blockHash] = append(trustedDataGHOSTDAGDataIndexes[*blockHash], uint64(index))
+		}
+	}
+
+	return &PruningPointAnticoneTrustedData{
+		Blocks:              pointAndItsAnticone,
+		TrustedData:         appmessage.DomainTrustedDataToTrustedData(daaWindowBlocks, ghostdagData),
+		daaWindowIndices:    trustedDataDAABlockIndexes,
+		ghostdagDataIndices: trustedDataGHOSTDAGDataIndexes,
+	}, nil
+}
+
+// BlockWithTrustedData returns the message of one of the blocks of the
+// trusted data, which points into its appmessage.MsgTrustedData
+func (data *PruningPointAnticoneTrustedData) BlockWithTrustedData(block *externalapi.DomainBlock) *appmessage.MsgBlockWithTrustedDataV4 {
+	blockHash := consensushashing.BlockHash(block)
+	return appmessage.DomainBlockWithTrustedDataToBlockWithTrustedDataV4(block,
+		data.daaWindowIndices[*blockHash], data.ghostdagDataIndices[*blockHash])
+}
@@ -68,6 +68,12 @@ func (m *Manager) AddTransaction(tx *externalapi.DomainTransaction, allowOrphan
 	return m.context.AddTransaction(tx, allowOrphan)
 }
 
+// AddTransactionReplacement adds a transaction replacing the one it double spends in the mempool
+// and propagates it. The replaced transaction is returned.
+func (m *Manager) AddTransactionReplacement(tx *externalapi.DomainTransaction) (*externalapi.DomainTransaction, error) {
+	return m.context.AddTransactionReplacement(tx)
+}
+
 // AddBlock adds the given block to the DAG and propagates it.
 func (m *Manager) AddBlock(block *externalapi.DomainBlock) error {
 	return m.context.AddBlock(block)
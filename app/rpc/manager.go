@@ -3,9 +3,13 @@ package rpc
 import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol"
+	"github.com/kaspanet/kaspad/app/rpc/rpcauth"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/app/rpc/rpcratelimit"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/addresshistoryindex"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/txindex"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
@@ -17,7 +21,8 @@ import (
 
 // Manager is an RPC manager
 type Manager struct {
-	context *rpccontext.Context
+	context    *rpccontext.Context
+	authorizer *rpcauth.Authorizer
 }
 
 // NewManager creates a new RPC Manager
@@ -29,10 +34,15 @@ func NewManager(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
+	addressHistoryIndex *addresshistoryindex.AddressHistoryIndex,
+	authorizer *rpcauth.Authorizer,
+	rateLimiter *rpcratelimit.RateLimiter,
 	consensusEventsChan chan externalapi.ConsensusEvent,
 	shutDownChan chan<- struct{}) *Manager {
 
 	manager := Manager{
+		authorizer: authorizer,
 		context: rpccontext.NewContext(
 			cfg,
 			domain,
@@ -41,6 +51,9 @@ func NewManager(
 			connectionManager,
 			addressManager,
 			utxoIndex,
+			txIndex,
+			addressHistoryIndex,
+			rateLimiter,
 			shutDownChan,
 		),
 	}
@@ -113,6 +126,20 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		}
 	}
 
+	if m.context.Config.TXIndex {
+		err := m.context.TXIndex.Update(virtualChangeSet)
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.context.Config.AddressHistoryIndex {
+		err := m.context.AddressHistoryIndex.Update(virtualChangeSet)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := m.notifyVirtualSelectedParentBlueScoreChanged(virtualChangeSet.VirtualSelectedParentBlueScore)
 	if err != nil {
 		return err
@@ -158,6 +185,21 @@ func (m *Manager) NotifyPruningPointUTXOSetOverride() error {
 		}
 	}
 
+	// The selected chain the TX index and the address history index were built on is no
+	// longer connected to the new pruning point
+	if m.context.Config.TXIndex {
+		err := m.context.TXIndex.Reset()
+		if err != nil {
+			return err
+		}
+	}
+	if m.context.Config.AddressHistoryIndex {
+		err := m.context.AddressHistoryIndex.Reset()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1,14 +1,24 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpcauth"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/app/rpc/rpchandlers"
+	"github.com/kaspanet/kaspad/app/rpc/rpcratelimit"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/pkg/errors"
 )
 
+var rpcRequestDuration = metrics.NewHistogramVec("kaspad_rpc_request_duration_seconds",
+	"Time it took to handle an RPC request, by command", metrics.DurationBuckets, "command")
+
 type handler func(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error)
 
 var handlers = map[appmessage.MessageCommand]handler{
@@ -51,6 +61,11 @@ var handlers = map[appmessage.MessageCommand]handler{
 	appmessage.CmdNotifyNewBlockTemplateRequestMessage:                      rpchandlers.HandleNotifyNewBlockTemplate,
 	appmessage.CmdGetCoinSupplyRequestMessage:                               rpchandlers.HandleGetCoinSupply,
 	appmessage.CmdGetMempoolEntriesByAddressesRequestMessage:                rpchandlers.HandleGetMempoolEntriesByAddresses,
+	appmessage.CmdGetFeeEstimateRequestMessage:                              rpchandlers.HandleGetFeeEstimate,
+	appmessage.CmdSubmitTransactionReplacementRequestMessage:                rpchandlers.HandleSubmitTransactionReplacement,
+	appmessage.CmdGetTransactionRequestMessage:                              rpchandlers.HandleGetTransaction,
+	appmessage.CmdGetAddressTransactionsRequestMessage:                      rpchandlers.HandleGetAddressTransactions,
+	appmessage.CmdGetRPCUsageRequestMessage:                                 rpchandlers.HandleGetRPCUsage,
 }
 
 func (m *Manager) routerInitializer(router *router.Router, netConnection *netadapter.NetConnection) {
@@ -64,15 +79,31 @@ func (m *Manager) routerInitializer(router *router.Router, netConnection *netada
 	}
 	m.context.NotificationManager.AddListener(router)
 
+	// permissions is nil when RPC authorization is disabled
+	var permissions *rpcauth.Permissions
+	if m.authorizer != nil {
+		permissions = m.authorizer.Permissions(netConnection.Credentials())
+	}
+	clientID := m.rateLimitClientID(netConnection.Credentials(), netConnection.Address())
+
 	spawn("routerInitializer-handleIncomingMessages", func() {
 		defer m.context.NotificationManager.RemoveListener(router)
 
-		err := m.handleIncomingMessages(router, incomingRoute)
+		err := m.handleIncomingMessages(router, incomingRoute, permissions, clientID, netConnection)
 		m.handleError(err, netConnection)
 	})
 }
 
-func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *router.Route) error {
+// rateLimitClientID returns the ID the rate limits of the client with the given
+// credentials and address are kept under. Credentials the authorizer doesn't know
+// are ignored, so such clients are limited by their IP
+func (m *Manager) rateLimitClientID(credentials *server.Credentials, address string) string {
+	return rpcratelimit.ClientID(m.authorizer.KnownCredentials(credentials), address)
+}
+
+func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *router.Route,
+	permissions *rpcauth.Permissions, clientID string, netConnection *netadapter.NetConnection) error {
+
 	outgoingRoute := router.OutgoingRoute()
 	for {
 		request, err := incomingRoute.Dequeue()
@@ -83,7 +114,7 @@ func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *r
 		if !ok {
 			return err
 		}
-		response, err := handler(m.context, router, request)
+		response, err := m.handleRequest(handler, router, request, permissions, clientID, netConnection)
 		if err != nil {
 			return err
 		}
@@ -94,7 +125,50 @@ func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *r
 	}
 }
 
+// handleRequest dispatches the given request to its handler, unless the client isn't
+// allowed to send it or is over its rate limit, in which case an error response is returned
+func (m *Manager) handleRequest(handler handler, router *router.Router, request appmessage.Message,
+	permissions *rpcauth.Permissions, clientID string, netConnection *netadapter.NetConnection) (appmessage.Message, error) {
+
+	if permissions != nil && !permissions.IsAllowed(request.Command()) {
+		log.Debugf("Refusing %s from %s: the command isn't allowed for its credentials",
+			request.Command(), netConnection)
+		return errorResponse(request,
+			appmessage.RPCErrorf("Unauthorized: the RPC credentials don't allow this command"))
+	}
+	if m.context.RateLimiter != nil {
+		isAllowed, costClass := m.context.RateLimiter.Allow(clientID, request)
+		if !isAllowed {
+			log.Debugf("Throttling %s from %s: over the rate limit of %s requests",
+				request.Command(), netConnection, costClass)
+			return errorResponse(request,
+				appmessage.RPCErrorf("Rate limit exceeded for %s requests, try again later", costClass))
+		}
+	}
+	defer rpcRequestDuration.With(request.Command().String()).ObserveDurationSince(time.Now())
+	return handler(m.context, router, request)
+}
+
+// errNoErrorResponse is returned when a refused request can't be answered with
+// an error response. The client is then disconnected, since it would otherwise
+// wait for a response forever
+var errNoErrorResponse = errors.New("couldn't build an error response to a refused request")
+
+// errorResponse returns the response to request that carries only rpcError
+func errorResponse(request appmessage.Message, rpcError *appmessage.RPCError) (appmessage.Message, error) {
+	response, err := protowire.NewErrorResponse(request, rpcError)
+	if err != nil {
+		return nil, errors.Wrapf(errNoErrorResponse, "%s: %s", request.Command(), err)
+	}
+	return response, nil
+}
+
 func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection) {
+	if errors.Is(err, errNoErrorResponse) {
+		log.Warnf("%s from %s. Disconnecting...", err, netConnection)
+		netConnection.Disconnect()
+		return
+	}
 	if errors.Is(err, router.ErrTimeout) {
 		log.Warnf("Got timeout from %s. Disconnecting...", netConnection)
 		netConnection.Disconnect()
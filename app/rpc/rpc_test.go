@@ -0,0 +1,194 @@
+package rpc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpcauth"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/app/rpc/rpcratelimit"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// handledRequests returns a request of every command in handlers
+func handledRequests(t *testing.T) []appmessage.Message {
+	// These requests can't be converted from their empty protowire messages
+	requests := []appmessage.Message{
+		appmessage.NewSubmitBlockRequestMessage(&appmessage.RPCBlock{Header: &appmessage.RPCBlockHeader{}}, false),
+		appmessage.NewSubmitTransactionRequestMessage(&appmessage.RPCTransaction{}, false),
+		appmessage.NewSubmitTransactionReplacementRequestMessage(&appmessage.RPCTransaction{}),
+	}
+	commands := make(map[appmessage.MessageCommand]struct{})
+	for _, request := range requests {
+		commands[request.Command()] = struct{}{}
+	}
+
+	payloadOneof := (&protowire.KaspadMessage{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+	for i := 0; i < payloadOneof.Fields().Len(); i++ {
+		field := payloadOneof.Fields().Get(i)
+		if !strings.HasSuffix(string(field.Name()), "Request") {
+			continue
+		}
+		message := &protowire.KaspadMessage{}
+		message.ProtoReflect().Set(field, protoreflect.ValueOfMessage(message.ProtoReflect().NewField(field).Message()))
+		request, err := message.ToAppMessage()
+		if err != nil {
+			continue
+		}
+		if _, ok := handlers[request.Command()]; !ok {
+			continue
+		}
+		if _, ok := commands[request.Command()]; ok {
+			continue
+		}
+		commands[request.Command()] = struct{}{}
+		requests = append(requests, request)
+	}
+
+	for command := range handlers {
+		if _, ok := commands[command]; !ok {
+			t.Fatalf("there's no test request for %s", command)
+		}
+	}
+	return requests
+}
+
+// checkErrorResponse checks that response answers request with the given error,
+// and that it can be sent
+func checkErrorResponse(t *testing.T, request appmessage.Message, response appmessage.Message, expectedError string) {
+	if !strings.HasSuffix(appmessage.RPCMessageCommandToString[response.Command()], "Response") {
+		t.Fatalf("unexpected response %s to %s", response.Command(), request.Command())
+	}
+	rpcError := responseError(t, response)
+	if rpcError != expectedError {
+		t.Fatalf("unexpected error in the response to %s: %s", request.Command(), rpcError)
+	}
+}
+
+// TestErrorResponses makes sure that every request can be refused with an
+// error response of the right type
+func TestErrorResponses(t *testing.T) {
+	for _, request := range handledRequests(t) {
+		response, err := protowire.NewErrorResponse(request, appmessage.RPCErrorf("Unauthorized"))
+		if err != nil {
+			t.Fatalf("NewErrorResponse of %s: %+v", request.Command(), err)
+		}
+		checkErrorResponse(t, request, response, "Unauthorized")
+	}
+}
+
+func TestHandleUnauthorizedRequests(t *testing.T) {
+	manager := &Manager{context: &rpccontext.Context{}}
+	handler := func(*rpccontext.Context, *router.Router, appmessage.Message) (appmessage.Message, error) {
+		t.Fatalf("an unauthorized request reached its handler")
+		return nil, nil
+	}
+	for _, request := range handledRequests(t) {
+		response, err := manager.handleRequest(handler, nil, request, &rpcauth.Permissions{}, "client",
+			&netadapter.NetConnection{})
+		if err != nil {
+			t.Fatalf("handleRequest of unauthorized %s: %+v", request.Command(), err)
+		}
+		checkErrorResponse(t, request, response, "Unauthorized: the RPC credentials don't allow this command")
+	}
+}
+
+func TestHandleThrottledRequests(t *testing.T) {
+	rateLimiter, err := rpcratelimit.New([]string{"light:1:1", "heavy:1:1", "utxo:1:1"})
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	manager := &Manager{context: &rpccontext.Context{RateLimiter: rateLimiter}}
+	handlerCalls := 0
+	handler := func(*rpccontext.Context, *router.Router, appmessage.Message) (appmessage.Message, error) {
+		handlerCalls++
+		return appmessage.NewGetInfoResponseMessage("", 0, "", false, false), nil
+	}
+
+	requests := []appmessage.Message{
+		appmessage.NewGetFeeEstimateRequestMessage(),
+		appmessage.NewGetBalanceByAddressRequest("kaspa:address"),
+		appmessage.NewGetBlocksRequestMessage("", false, false),
+	}
+	for _, request := range requests {
+		// The first request of every client is allowed by the burst
+		clientID := request.Command().String()
+		_, err := manager.handleRequest(handler, nil, request, nil, clientID, &netadapter.NetConnection{})
+		if err != nil {
+			t.Fatalf("handleRequest of %s: %+v", request.Command(), err)
+		}
+		response, err := manager.handleRequest(handler, nil, request, nil, clientID, &netadapter.NetConnection{})
+		if err != nil {
+			t.Fatalf("handleRequest of throttled %s: %+v", request.Command(), err)
+		}
+		if !strings.HasPrefix(responseError(t, response), "Rate limit exceeded") {
+			t.Fatalf("expected %s to be throttled, got %v", request.Command(), response)
+		}
+	}
+	if handlerCalls != len(requests) {
+		t.Fatalf("expected the handler to be called %d times, got %d", len(requests), handlerCalls)
+	}
+}
+
+func TestRateLimitClientID(t *testing.T) {
+	authFile := filepath.Join(t.TempDir(), "rpcauth")
+	err := os.WriteFile(authFile, []byte("token dashboard-token readonly\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	authorizer, err := rpcauth.LoadAuthFile(authFile)
+	if err != nil {
+		t.Fatalf("LoadAuthFile: %+v", err)
+	}
+
+	for _, manager := range []*Manager{{}, {authorizer: authorizer}} {
+		rateLimiter, err := rpcratelimit.New([]string{"light:1:1"})
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		// Two connections from the same IP with different unknown tokens share one bucket
+		firstClientID := manager.rateLimitClientID(&server.Credentials{AuthToken: "random-1"}, "10.0.0.1:5555")
+		secondClientID := manager.rateLimitClientID(&server.Credentials{AuthToken: "random-2"}, "10.0.0.1:6666")
+		if firstClientID != "10.0.0.1" || secondClientID != firstClientID {
+			t.Fatalf("expected unknown tokens to be limited by their IP, got %s and %s",
+				firstClientID, secondClientID)
+		}
+		allowed, _ := rateLimiter.Allow(firstClientID, appmessage.NewGetInfoRequestMessage())
+		if !allowed {
+			t.Fatalf("expected the first request to be allowed by the burst")
+		}
+		allowed, _ = rateLimiter.Allow(secondClientID, appmessage.NewGetInfoRequestMessage())
+		if allowed {
+			t.Fatalf("expected the second connection to be limited by the bucket of the first")
+		}
+	}
+
+	knownClientID := (&Manager{authorizer: authorizer}).rateLimitClientID(
+		&server.Credentials{AuthToken: "dashboard-token"}, "10.0.0.1:5555")
+	if !strings.HasPrefix(knownClientID, "token:") {
+		t.Fatalf("expected a known token to be limited by itself, got %s", knownClientID)
+	}
+}
+
+// responseError returns the error message response carries once converted
+// to its protowire message
+func responseError(t *testing.T, response appmessage.Message) string {
+	responseMessage, err := protowire.FromAppMessage(response)
+	if err != nil {
+		t.Fatalf("FromAppMessage: %+v", err)
+	}
+	payloadOneof := responseMessage.ProtoReflect().Descriptor().Oneofs().ByName("payload")
+	payload := responseMessage.ProtoReflect().Get(responseMessage.ProtoReflect().WhichOneof(payloadOneof)).Message()
+	rpcError, ok := payload.Get(payload.Descriptor().Fields().ByName("error")).Message().Interface().(*protowire.RPCError)
+	if !ok {
+		return ""
+	}
+	return rpcError.Message
+}
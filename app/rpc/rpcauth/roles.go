@@ -0,0 +1,69 @@
+package rpcauth
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// Role is a named set of RPC commands a client is allowed to send
+type Role string
+
+// The roles RPC clients can be given. Every role other than RoleReadOnly
+// includes the commands of RoleReadOnly as well, and RoleAdmin is allowed
+// to send every command
+const (
+	RoleReadOnly Role = "readonly"
+	RoleWallet   Role = "wallet"
+	RoleMining   Role = "mining"
+	RoleAdmin    Role = "admin"
+)
+
+// readOnlyCommands are the commands that don't affect the state of the node
+var readOnlyCommands = []appmessage.MessageCommand{
+	appmessage.CmdGetCurrentNetworkRequestMessage,
+	appmessage.CmdNotifyBlockAddedRequestMessage,
+	appmessage.CmdGetPeerAddressesRequestMessage,
+	appmessage.CmdGetSelectedTipHashRequestMessage,
+	appmessage.CmdGetMempoolEntryRequestMessage,
+	appmessage.CmdGetConnectedPeerInfoRequestMessage,
+	appmessage.CmdNotifyVirtualSelectedParentChainChangedRequestMessage,
+	appmessage.CmdGetBlockRequestMessage,
+	appmessage.CmdGetSubnetworkRequestMessage,
+	appmessage.CmdGetVirtualSelectedParentChainFromBlockRequestMessage,
+	appmessage.CmdGetBlocksRequestMessage,
+	appmessage.CmdGetBlockCountRequestMessage,
+	appmessage.CmdGetBalanceByAddressRequestMessage,
+	appmessage.CmdGetBlockDAGInfoRequestMessage,
+	appmessage.CmdNotifyFinalityConflictsRequestMessage,
+	appmessage.CmdGetMempoolEntriesRequestMessage,
+	appmessage.CmdGetHeadersRequestMessage,
+	appmessage.CmdNotifyUTXOsChangedRequestMessage,
+	appmessage.CmdStopNotifyingUTXOsChangedRequestMessage,
+	appmessage.CmdGetUTXOsByAddressesRequestMessage,
+	appmessage.CmdGetBalancesByAddressesRequestMessage,
+	appmessage.CmdGetVirtualSelectedParentBlueScoreRequestMessage,
+	appmessage.CmdNotifyVirtualSelectedParentBlueScoreChangedRequestMessage,
+	appmessage.CmdGetInfoRequestMessage,
+	appmessage.CmdNotifyPruningPointUTXOSetOverrideRequestMessage,
+	appmessage.CmdStopNotifyingPruningPointUTXOSetOverrideRequestMessage,
+	appmessage.CmdEstimateNetworkHashesPerSecondRequestMessage,
+	appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage,
+	appmessage.CmdGetMempoolEntriesByAddressesRequestMessage,
+	appmessage.CmdGetCoinSupplyRequestMessage,
+	appmessage.CmdGetFeeEstimateRequestMessage,
+	appmessage.CmdGetTransactionRequestMessage,
+	appmessage.CmdGetAddressTransactionsRequestMessage,
+}
+
+// roleCommands are the commands each role is allowed to send on top of the
+// read-only ones. Commands that are missing from here are only allowed to RoleAdmin
+var roleCommands = map[Role][]appmessage.MessageCommand{
+	RoleReadOnly: nil,
+	RoleWallet: {
+		appmessage.CmdSubmitTransactionRequestMessage,
+		appmessage.CmdSubmitTransactionReplacementRequestMessage,
+	},
+	RoleMining: {
+		appmessage.CmdGetBlockTemplateRequestMessage,
+		appmessage.CmdNotifyNewBlockTemplateRequestMessage,
+		appmessage.CmdSubmitBlockRequestMessage,
+	},
+	RoleAdmin: nil,
+}
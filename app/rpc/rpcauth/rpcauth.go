@@ -0,0 +1,154 @@
+package rpcauth
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"os"
+	"strings"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/pkg/errors"
+)
+
+// Authorizer maps the credentials of RPC clients to the roles they were given
+type Authorizer struct {
+	tokenRoles      map[string][]Role
+	commonNameRoles map[string][]Role
+}
+
+// Permissions are the RPC commands a client is allowed to send
+type Permissions struct {
+	isAdmin  bool
+	commands map[appmessage.MessageCommand]struct{}
+}
+
+// IsAllowed returns whether the given command is allowed
+func (p *Permissions) IsAllowed(command appmessage.MessageCommand) bool {
+	if p.isAdmin {
+		return true
+	}
+	_, ok := p.commands[command]
+	return ok
+}
+
+// LoadAuthFile creates an Authorizer from the given auth file.
+//
+// Every non-empty line of the file that isn't a #-comment has three fields:
+//
+//	token <bearer token> <role>[,<role>...]
+//	cert <client certificate common name> <role>[,<role>...]
+//
+// The available roles are readonly, wallet, mining and admin
+func LoadAuthFile(path string) (*Authorizer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening the RPC auth file")
+	}
+	defer file.Close()
+
+	authorizer := &Authorizer{
+		tokenRoles:      make(map[string][]Role),
+		commonNameRoles: make(map[string][]Role),
+	}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, errors.Errorf("line %d of the RPC auth file must have 3 fields, got %d",
+				lineNumber, len(fields))
+		}
+
+		var roles []Role
+		for _, roleName := range strings.Split(fields[2], ",") {
+			role := Role(roleName)
+			if _, ok := roleCommands[role]; !ok {
+				return nil, errors.Errorf("unknown role %s in line %d of the RPC auth file", roleName, lineNumber)
+			}
+			roles = append(roles, role)
+		}
+
+		switch fields[0] {
+		case "token":
+			authorizer.tokenRoles[fields[1]] = append(authorizer.tokenRoles[fields[1]], roles...)
+		case "cert":
+			authorizer.commonNameRoles[fields[1]] = append(authorizer.commonNameRoles[fields[1]], roles...)
+		default:
+			return nil, errors.Errorf("unknown credentials type %s in line %d of the RPC auth file, "+
+				"expected token or cert", fields[0], lineNumber)
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the RPC auth file")
+	}
+
+	return authorizer, nil
+}
+
+// Roles returns the roles of the client with the given credentials
+func (a *Authorizer) Roles(credentials *server.Credentials) []Role {
+	var roles []Role
+	if credentials.AuthToken != "" {
+		// Every token is compared in constant time, so the response time
+		// reveals nothing about the configured tokens
+		for token, tokenRoles := range a.tokenRoles {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(credentials.AuthToken)) == 1 {
+				roles = append(roles, tokenRoles...)
+			}
+		}
+	}
+	if credentials.CertificateCommonName != "" {
+		roles = append(roles, a.commonNameRoles[credentials.CertificateCommonName]...)
+	}
+	return roles
+}
+
+// KnownCredentials returns the given credentials without the token and the
+// certificate common name that have no roles. A nil Authorizer knows no
+// credentials
+func (a *Authorizer) KnownCredentials(credentials *server.Credentials) *server.Credentials {
+	known := &server.Credentials{}
+	if a == nil {
+		return known
+	}
+	if credentials.AuthToken != "" {
+		for token := range a.tokenRoles {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(credentials.AuthToken)) == 1 {
+				known.AuthToken = credentials.AuthToken
+			}
+		}
+	}
+	if _, ok := a.commonNameRoles[credentials.CertificateCommonName]; ok {
+		known.CertificateCommonName = credentials.CertificateCommonName
+	}
+	return known
+}
+
+// Permissions returns the commands the client with the given credentials
+// is allowed to send. Clients without known credentials aren't allowed
+// any command
+func (a *Authorizer) Permissions(credentials *server.Credentials) *Permissions {
+	roles := a.Roles(credentials)
+	permissions := &Permissions{commands: make(map[appmessage.MessageCommand]struct{})}
+	if len(roles) == 0 {
+		return permissions
+	}
+
+	for _, command := range readOnlyCommands {
+		permissions.commands[command] = struct{}{}
+	}
+	for _, role := range roles {
+		if role == RoleAdmin {
+			permissions.isAdmin = true
+		}
+		for _, command := range roleCommands[role] {
+			permissions.commands[command] = struct{}{}
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,103 @@
+package rpcauth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+)
+
+func writeAuthFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "rpcauth")
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	return path
+}
+
+func TestPermissions(t *testing.T) {
+	authorizer, err := LoadAuthFile(writeAuthFile(t, `
+# Dashboards
+token dashboard-token readonly
+token wallet-token wallet
+cert pool-bridge mining,wallet
+cert ops admin
+`))
+	if err != nil {
+		t.Fatalf("LoadAuthFile: %+v", err)
+	}
+
+	tests := []struct {
+		name        string
+		credentials *server.Credentials
+		allowed     []appmessage.MessageCommand
+		refused     []appmessage.MessageCommand
+	}{
+		{
+			name:        "no credentials",
+			credentials: &server.Credentials{},
+			refused:     []appmessage.MessageCommand{appmessage.CmdGetInfoRequestMessage},
+		},
+		{
+			name:        "unknown token",
+			credentials: &server.Credentials{AuthToken: "dashboard"},
+			refused:     []appmessage.MessageCommand{appmessage.CmdGetInfoRequestMessage},
+		},
+		{
+			name:        "read-only",
+			credentials: &server.Credentials{AuthToken: "dashboard-token"},
+			allowed:     []appmessage.MessageCommand{appmessage.CmdGetInfoRequestMessage},
+			refused: []appmessage.MessageCommand{appmessage.CmdSubmitTransactionRequestMessage,
+				appmessage.CmdGetBlockTemplateRequestMessage, appmessage.CmdBanRequestMessage},
+		},
+		{
+			name:        "wallet",
+			credentials: &server.Credentials{AuthToken: "wallet-token"},
+			allowed: []appmessage.MessageCommand{appmessage.CmdGetInfoRequestMessage,
+				appmessage.CmdSubmitTransactionRequestMessage},
+			refused: []appmessage.MessageCommand{appmessage.CmdSubmitBlockRequestMessage},
+		},
+		{
+			name:        "mining and wallet",
+			credentials: &server.Credentials{CertificateCommonName: "pool-bridge"},
+			allowed: []appmessage.MessageCommand{appmessage.CmdSubmitBlockRequestMessage,
+				appmessage.CmdSubmitTransactionRequestMessage},
+			refused: []appmessage.MessageCommand{appmessage.CmdShutDownRequestMessage},
+		},
+		{
+			name:        "admin",
+			credentials: &server.Credentials{CertificateCommonName: "ops"},
+			allowed: []appmessage.MessageCommand{appmessage.CmdShutDownRequestMessage,
+				appmessage.CmdAddPeerRequestMessage, appmessage.CmdSubmitBlockRequestMessage},
+		},
+	}
+	for _, test := range tests {
+		permissions := authorizer.Permissions(test.credentials)
+		for _, command := range test.allowed {
+			if !permissions.IsAllowed(command) {
+				t.Errorf("%s: expected %s to be allowed", test.name, command)
+			}
+		}
+		for _, command := range test.refused {
+			if permissions.IsAllowed(command) {
+				t.Errorf("%s: expected %s to be refused", test.name, command)
+			}
+		}
+	}
+}
+
+func TestLoadAuthFileErrors(t *testing.T) {
+	for _, content := range []string{
+		"token only-two-fields",
+		"token some-token superuser",
+		"password some-password admin",
+	} {
+		_, err := LoadAuthFile(writeAuthFile(t, content))
+		if err == nil {
+			t.Errorf("expected an error loading %q", content)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// maxAddressTransactionsLimit is the maximum number of entries returned by a single
+// GetAddressTransactions request
+const maxAddressTransactionsLimit = 1000
+
+// HandleGetAddressTransactions handles the respectively named RPC command
+func HandleGetAddressTransactions(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.AddressHistoryIndex {
+		errorMessage := &appmessage.GetAddressTransactionsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kaspad is run without --addresshistoryindex")
+		return errorMessage, nil
+	}
+
+	getAddressTransactionsRequest := request.(*appmessage.GetAddressTransactionsRequestMessage)
+
+	address, err := util.DecodeAddress(getAddressTransactionsRequest.Address, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.GetAddressTransactionsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not decode address '%s': %s",
+			getAddressTransactionsRequest.Address, err)
+		return errorMessage, nil
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		errorMessage := &appmessage.GetAddressTransactionsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not create a scriptPublicKey for address '%s': %s",
+			getAddressTransactionsRequest.Address, err)
+		return errorMessage, nil
+	}
+
+	limit := getAddressTransactionsRequest.Limit
+	if limit == 0 || limit > maxAddressTransactionsLimit {
+		limit = maxAddressTransactionsLimit
+	}
+	historyEntries, err := context.AddressHistoryIndex.History(scriptPublicKey, getAddressTransactionsRequest.Offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*appmessage.AddressTransaction, len(historyEntries))
+	for i, historyEntry := range historyEntries {
+		entries[i] = &appmessage.AddressTransaction{
+			TransactionID:          historyEntry.TransactionID.String(),
+			AcceptingBlockHash:     historyEntry.AcceptingBlockHash.String(),
+			AcceptingBlockDAAScore: historyEntry.AcceptingBlockDAAScore,
+			Amount:                 historyEntry.Amount,
+			IsDebit:                historyEntry.IsDebit,
+		}
+	}
+
+	return appmessage.NewGetAddressTransactionsResponseMessage(getAddressTransactionsRequest.Address, entries), nil
+}
@@ -0,0 +1,36 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetFeeEstimate handles the respectively named RPC command
+func HandleGetFeeEstimate(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	estimate := context.Domain.MiningManager().FeeEstimate()
+
+	response := appmessage.NewGetFeeEstimateResponseMessage()
+	response.Estimate = appmessage.RPCFeeEstimate{
+		PriorityBucket: feerateBucketToRPC(estimate.PriorityBucket),
+		NormalBuckets:  feerateBucketsToRPC(estimate.NormalBuckets),
+		LowBuckets:     feerateBucketsToRPC(estimate.LowBuckets),
+	}
+	return response, nil
+}
+
+func feerateBucketToRPC(bucket miningmanagermodel.FeerateBucket) appmessage.RPCFeeRateBucket {
+	return appmessage.RPCFeeRateBucket{
+		Feerate:          bucket.Feerate,
+		EstimatedSeconds: bucket.EstimatedSeconds,
+	}
+}
+
+func feerateBucketsToRPC(buckets []miningmanagermodel.FeerateBucket) []appmessage.RPCFeeRateBucket {
+	rpcBuckets := make([]appmessage.RPCFeeRateBucket, len(buckets))
+	for i, bucket := range buckets {
+		rpcBuckets[i] = feerateBucketToRPC(bucket)
+	}
+	return rpcBuckets
+}
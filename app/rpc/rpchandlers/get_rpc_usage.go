@@ -0,0 +1,35 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetRPCUsage handles the respectively named RPC command
+func HandleGetRPCUsage(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.RateLimiter == nil {
+		errorMessage := &appmessage.GetRPCUsageResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kaspad is run without --rpcratelimit")
+		return errorMessage, nil
+	}
+
+	usage := context.RateLimiter.Usage()
+	clients := make([]*appmessage.RPCClientUsage, len(usage))
+	for i, clientUsage := range usage {
+		costClassesUsage := make([]*appmessage.RPCCostClassUsage, len(clientUsage.ClassesUsage))
+		for j, classUsage := range clientUsage.ClassesUsage {
+			costClassesUsage[j] = &appmessage.RPCCostClassUsage{
+				CostClass:         string(classUsage.CostClass),
+				Requests:          classUsage.Requests,
+				ThrottledRequests: classUsage.ThrottledRequests,
+				AvailableTokens:   classUsage.AvailableTokens,
+			}
+		}
+		clients[i] = &appmessage.RPCClientUsage{
+			ClientID:         clientUsage.ClientID,
+			CostClassesUsage: costClassesUsage,
+		}
+	}
+	return appmessage.NewGetRPCUsageResponseMessage(clients), nil
+}
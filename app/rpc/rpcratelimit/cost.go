@@ -0,0 +1,54 @@
+package rpcratelimit
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// CostClass is a class of RPC commands that share a rate limit
+type CostClass string
+
+// The cost classes of RPC commands. Every command that isn't listed in
+// heavyCommands or utxoCommands is light
+const (
+	CostClassLight CostClass = "light"
+	CostClassHeavy CostClass = "heavy"
+	CostClassUTXO  CostClass = "utxo"
+)
+
+var costClasses = []CostClass{CostClassLight, CostClassHeavy, CostClassUTXO}
+
+// heavyCommands are the commands that return large amounts of DAG or mempool data
+var heavyCommands = map[appmessage.MessageCommand]struct{}{
+	appmessage.CmdGetBlocksRequestMessage:                              {},
+	appmessage.CmdGetHeadersRequestMessage:                             {},
+	appmessage.CmdGetVirtualSelectedParentChainFromBlockRequestMessage: {},
+	appmessage.CmdGetMempoolEntriesRequestMessage:                      {},
+	appmessage.CmdGetAddressTransactionsRequestMessage:                 {},
+	appmessage.CmdEstimateNetworkHashesPerSecondRequestMessage:         {},
+	appmessage.CmdGetCoinSupplyRequestMessage:                          {},
+}
+
+// requestCost returns the cost class of the given request and the number of
+// tokens it takes. Requests of the utxo class cost a token per address, so
+// large address batches are throttled like many small requests
+func requestCost(request appmessage.Message) (CostClass, float64) {
+	var addresses []string
+	switch request := request.(type) {
+	case *appmessage.GetUTXOsByAddressesRequestMessage:
+		addresses = request.Addresses
+	case *appmessage.GetBalancesByAddressesRequestMessage:
+		addresses = request.Addresses
+	case *appmessage.GetMempoolEntriesByAddressesRequestMessage:
+		addresses = request.Addresses
+	case *appmessage.NotifyUTXOsChangedRequestMessage:
+		addresses = request.Addresses
+	default:
+		if _, ok := heavyCommands[request.Command()]; ok {
+			return CostClassHeavy, 1
+		}
+		return CostClassLight, 1
+	}
+
+	if len(addresses) == 0 {
+		return CostClassUTXO, 1
+	}
+	return CostClassUTXO, float64(len(addresses))
+}
@@ -0,0 +1,220 @@
+package rpcratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/pkg/errors"
+)
+
+// idleClientTimeout is the time after which the usage of a client that sent
+// no requests is forgotten
+const idleClientTimeout = 10 * time.Minute
+
+// Limit is the token bucket of a cost class: Rate tokens are added every second,
+// up to Burst tokens
+type Limit struct {
+	Rate  float64
+	Burst float64
+}
+
+// ClassUsage is the usage of a single cost class by a client
+type ClassUsage struct {
+	CostClass         CostClass
+	Requests          uint64
+	ThrottledRequests uint64
+	AvailableTokens   float64
+}
+
+// ClientUsage is the usage of all the cost classes by a client
+type ClientUsage struct {
+	ClientID     string
+	ClassesUsage []*ClassUsage
+}
+
+type tokenBucket struct {
+	tokens            float64
+	lastRefill        time.Time
+	requests          uint64
+	throttledRequests uint64
+}
+
+type clientBuckets struct {
+	buckets     map[CostClass]*tokenBucket
+	lastRequest time.Time
+}
+
+// RateLimiter limits the rate of the RPC requests of every client, with a
+// token bucket per client and cost class
+type RateLimiter struct {
+	limits map[CostClass]*Limit
+
+	clients   map[string]*clientBuckets
+	lastPrune time.Time
+	lock      sync.Mutex
+
+	now func() time.Time
+}
+
+// New creates a RateLimiter from the given limits, each formatted as
+// <class>:<requests per second>:<burst>. Cost classes without a limit aren't limited
+func New(limits []string) (*RateLimiter, error) {
+	rateLimiter := &RateLimiter{
+		limits:  make(map[CostClass]*Limit),
+		clients: make(map[string]*clientBuckets),
+		now:     time.Now,
+	}
+	for _, limitString := range limits {
+		fields := strings.Split(limitString, ":")
+		if len(fields) != 3 {
+			return nil, errors.Errorf("RPC rate limit %s is not formatted as <class>:<rate>:<burst>", limitString)
+		}
+		costClass := CostClass(fields[0])
+		if !isCostClass(costClass) {
+			return nil, errors.Errorf("unknown RPC cost class %s, expected one of %s", fields[0], costClasses)
+		}
+		rate, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || rate <= 0 {
+			return nil, errors.Errorf("the rate of RPC rate limit %s must be a positive number", limitString)
+		}
+		burst, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || burst < 1 {
+			return nil, errors.Errorf("the burst of RPC rate limit %s must be at least 1", limitString)
+		}
+		rateLimiter.limits[costClass] = &Limit{Rate: rate, Burst: burst}
+	}
+	return rateLimiter, nil
+}
+
+func isCostClass(costClass CostClass) bool {
+	for _, knownCostClass := range costClasses {
+		if costClass == knownCostClass {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientID returns the ID rate limits are kept under for the client with the given
+// credentials and address. Authenticated clients are identified by their credentials,
+// so all their connections share the same limits, and other clients by their IP.
+//
+// Only credentials the RPC authorizer knows may be given, since a client could
+// otherwise escape the limits of its IP by sending a new token on every connection
+func ClientID(credentials *server.Credentials, address string) string {
+	if credentials.AuthToken != "" {
+		tokenHash := sha256.Sum256([]byte(credentials.AuthToken))
+		return "token:" + hex.EncodeToString(tokenHash[:])
+	}
+	if credentials.CertificateCommonName != "" {
+		return "cert:" + credentials.CertificateCommonName
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// Allow takes the tokens the given request costs from the bucket of the given
+// client, and returns whether the client is allowed to send it along with the
+// cost class of the request.
+//
+// A request that costs more tokens than the burst of its class is allowed once
+// the bucket is full, leaving the bucket in debt until it refills
+func (rl *RateLimiter) Allow(clientID string, request appmessage.Message) (bool, CostClass) {
+	costClass, cost := requestCost(request)
+	limit, ok := rl.limits[costClass]
+	if !ok {
+		return true, costClass
+	}
+
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := rl.now()
+	rl.pruneIdleClients(now)
+
+	client, ok := rl.clients[clientID]
+	if !ok {
+		client = &clientBuckets{buckets: make(map[CostClass]*tokenBucket)}
+		rl.clients[clientID] = client
+	}
+	client.lastRequest = now
+
+	bucket, ok := client.buckets[costClass]
+	if !ok {
+		bucket = &tokenBucket{tokens: limit.Burst, lastRefill: now}
+		client.buckets[costClass] = bucket
+	}
+	bucket.refill(limit, now)
+
+	bucket.requests++
+	requiredTokens := cost
+	if requiredTokens > limit.Burst {
+		requiredTokens = limit.Burst
+	}
+	if bucket.tokens < requiredTokens {
+		bucket.throttledRequests++
+		return false, costClass
+	}
+	bucket.tokens -= cost
+	return true, costClass
+}
+
+func (tb *tokenBucket) refill(limit *Limit, now time.Time) {
+	tb.tokens += now.Sub(tb.lastRefill).Seconds() * limit.Rate
+	if tb.tokens > limit.Burst {
+		tb.tokens = limit.Burst
+	}
+	tb.lastRefill = now
+}
+
+func (rl *RateLimiter) pruneIdleClients(now time.Time) {
+	if now.Sub(rl.lastPrune) < idleClientTimeout {
+		return
+	}
+	for clientID, client := range rl.clients {
+		if now.Sub(client.lastRequest) >= idleClientTimeout {
+			delete(rl.clients, clientID)
+		}
+	}
+	rl.lastPrune = now
+}
+
+// Usage returns the current usage of every client that recently sent
+// rate limited requests, ordered by client ID
+func (rl *RateLimiter) Usage() []*ClientUsage {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := rl.now()
+	usage := make([]*ClientUsage, 0, len(rl.clients))
+	for clientID, client := range rl.clients {
+		clientUsage := &ClientUsage{ClientID: clientID}
+		for _, costClass := range costClasses {
+			bucket, ok := client.buckets[costClass]
+			if !ok {
+				continue
+			}
+			bucket.refill(rl.limits[costClass], now)
+			clientUsage.ClassesUsage = append(clientUsage.ClassesUsage, &ClassUsage{
+				CostClass:         costClass,
+				Requests:          bucket.requests,
+				ThrottledRequests: bucket.throttledRequests,
+				AvailableTokens:   bucket.tokens,
+			})
+		}
+		usage = append(usage, clientUsage)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].ClientID < usage[j].ClientID })
+	return usage
+}
@@ -0,0 +1,92 @@
+package rpcratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+)
+
+func TestAllow(t *testing.T) {
+	rateLimiter, err := New([]string{"light:1:2", "utxo:10:100"})
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	now := time.Unix(1000, 0)
+	rateLimiter.now = func() time.Time { return now }
+
+	checkAllowed := func(clientID string, request appmessage.Message, expectedAllowed bool) {
+		allowed, costClass := rateLimiter.Allow(clientID, request)
+		if allowed != expectedAllowed {
+			t.Fatalf("expected %s of %s (%s) to be allowed: %t", request.Command(), clientID, costClass, expectedAllowed)
+		}
+	}
+
+	// The burst of the light class is 2
+	checkAllowed("a", appmessage.NewGetInfoRequestMessage(), true)
+	checkAllowed("a", appmessage.NewGetInfoRequestMessage(), true)
+	checkAllowed("a", appmessage.NewGetInfoRequestMessage(), false)
+
+	// Other clients have buckets of their own, and heavy requests aren't limited
+	checkAllowed("b", appmessage.NewGetInfoRequestMessage(), true)
+	checkAllowed("a", appmessage.NewGetBlocksRequestMessage("", false, false), true)
+
+	now = now.Add(time.Second)
+	checkAllowed("a", appmessage.NewGetInfoRequestMessage(), true)
+	checkAllowed("a", appmessage.NewGetInfoRequestMessage(), false)
+
+	// A batch costs a token per address, and a batch larger than the burst
+	// is allowed only from a full bucket
+	addresses := make([]string, 150)
+	checkAllowed("a", appmessage.NewGetUTXOsByAddressesRequestMessage(addresses), true)
+	checkAllowed("a", appmessage.NewGetUTXOsByAddressesRequestMessage([]string{"x"}), false)
+	now = now.Add(6 * time.Second)
+	checkAllowed("a", appmessage.NewGetUTXOsByAddressesRequestMessage([]string{"x"}), true)
+
+	usage := rateLimiter.Usage()
+	if len(usage) != 2 || usage[0].ClientID != "a" || usage[1].ClientID != "b" {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+	lightUsage := usage[0].ClassesUsage[0]
+	if lightUsage.CostClass != CostClassLight || lightUsage.Requests != 5 || lightUsage.ThrottledRequests != 2 {
+		t.Fatalf("unexpected light usage %+v", lightUsage)
+	}
+	utxoUsage := usage[0].ClassesUsage[1]
+	if utxoUsage.CostClass != CostClassUTXO || utxoUsage.Requests != 3 || utxoUsage.ThrottledRequests != 1 {
+		t.Fatalf("unexpected utxo usage %+v", utxoUsage)
+	}
+
+	// Idle clients are forgotten
+	now = now.Add(idleClientTimeout)
+	checkAllowed("b", appmessage.NewGetInfoRequestMessage(), true)
+	if len(rateLimiter.Usage()) != 1 {
+		t.Fatalf("expected the usage of idle clients to be pruned")
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, limit := range []string{"light:1", "everything:1:1", "light:0:1", "light:1:0.5", "light:x:1"} {
+		_, err := New([]string{limit})
+		if err == nil {
+			t.Errorf("expected an error for limit %s", limit)
+		}
+	}
+}
+
+func TestClientID(t *testing.T) {
+	if ClientID(&server.Credentials{}, "10.0.0.1:5555") != "10.0.0.1" {
+		t.Fatalf("clients without credentials are expected to be identified by their IP")
+	}
+	if ClientID(&server.Credentials{CertificateCommonName: "ops"}, "10.0.0.1:5555") != "cert:ops" {
+		t.Fatalf("clients with certificates are expected to be identified by their common name")
+	}
+	tokenClientID := ClientID(&server.Credentials{AuthToken: "secret"}, "10.0.0.1:5555")
+	if tokenClientID != ClientID(&server.Credentials{AuthToken: "secret"}, "10.0.0.2:6666") {
+		t.Fatalf("the connections of a token are expected to share a client ID")
+	}
+	// The whole hash is used, so that tokens don't share limits by colliding
+	if tokenClientID != "token:2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b" {
+		t.Fatalf("unexpected client ID %s for a token", tokenClientID)
+	}
+}
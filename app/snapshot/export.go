@@ -0,0 +1,172 @@
+package snapshot
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/flows/v5/blockrelay"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// utxoChunkSize is the number of UTXOs in every pruning point UTXO set chunk
+// of a snapshot
+const utxoChunkSize = 1000
+
+// Export writes the pruning point state of the given consensus to a snapshot
+// file at the given path. The file is only created once it's complete, so a
+// failed export never leaves a partial snapshot behind
+func Export(consensus externalapi.Consensus, params *dagconfig.Params, path string) error {
+	pruningPoint, err := consensus.PruningPoint()
+	if err != nil {
+		return err
+	}
+	if pruningPoint.Equal(params.GenesisHash) {
+		return errors.New("the pruning point is still the genesis, so there's no pruning point state to export")
+	}
+
+	log.Infof("Exporting the state of pruning point %s to %s", pruningPoint, path)
+	w, err := createFile(path, params.Name)
+	if err != nil {
+		return err
+	}
+	defer w.abort()
+
+	err = exportPruningPointProofAndHeaders(consensus, w)
+	if err != nil {
+		return err
+	}
+	err = exportPruningPointAndItsAnticone(consensus, params, w)
+	if err != nil {
+		return err
+	}
+	err = exportPruningPointFutureHeaders(consensus, pruningPoint, w)
+	if err != nil {
+		return err
+	}
+	utxoCount, err := exportPruningPointUTXOSet(consensus, pruningPoint, w)
+	if err != nil {
+		return err
+	}
+
+	err = w.close()
+	if err != nil {
+		return err
+	}
+	log.Infof("Exported pruning point %s with %d UTXOs", pruningPoint, utxoCount)
+	return nil
+}
+
+func exportPruningPointProofAndHeaders(consensus externalapi.Consensus, w *writer) error {
+	pruningPointProof, err := consensus.BuildPruningPointProof()
+	if err != nil {
+		return err
+	}
+	err = w.writeRecord(appmessage.DomainPruningPointProofToMsgPruningPointProof(pruningPointProof))
+	if err != nil {
+		return err
+	}
+
+	pruningPointHeaders, err := consensus.PruningPointHeaders()
+	if err != nil {
+		return err
+	}
+	msgPruningPointHeaders := make([]*appmessage.MsgBlockHeader, len(pruningPointHeaders))
+	for i, header := range pruningPointHeaders {
+		msgPruningPointHeaders[i] = appmessage.DomainBlockHeaderToBlockHeader(header)
+	}
+	return w.writeRecord(appmessage.NewMsgPruningPoints(msgPruningPointHeaders))
+}
+
+func exportPruningPointAndItsAnticone(consensus externalapi.Consensus, params *dagconfig.Params, w *writer) error {
+	trustedData, err := blockrelay.BuildPruningPointAnticoneTrustedData(consensus, params)
+	if err != nil {
+		return err
+	}
+	err = w.writeRecord(trustedData.TrustedData)
+	if err != nil {
+		return err
+	}
+
+	for _, blockHash := range trustedData.Blocks {
+		block, found, err := consensus.GetBlock(blockHash)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errors.Errorf("pruning point anticone block %s not found", blockHash)
+		}
+		err = w.writeRecord(trustedData.BlockWithTrustedData(block))
+		if err != nil {
+			return err
+		}
+	}
+	log.Infof("Exported the pruning point and its anticone (%d blocks)", len(trustedData.Blocks))
+	return w.writeRecord(appmessage.NewMsgDoneBlocksWithTrustedData())
+}
+
+// exportPruningPointFutureHeaders exports the headers between the pruning
+// point and the headers selected tip, without which the importing node can't
+// tell that the pruning point is deep enough to be valid
+func exportPruningPointFutureHeaders(consensus externalapi.Consensus, pruningPoint *externalapi.DomainHash,
+	w *writer) error {
+
+	headersSelectedTip, err := consensus.GetHeadersSelectedTip()
+	if err != nil {
+		return err
+	}
+
+	lowHash := pruningPoint
+	headerCount := 0
+	for !lowHash.Equal(headersSelectedTip) {
+		// maxBlocks MUST be >= MergeSetSizeLimit + 1
+		const maxBlocks = 1 << 10
+		blockHashes, _, err := consensus.GetHashesBetween(lowHash, headersSelectedTip, maxBlocks)
+		if err != nil {
+			return err
+		}
+
+		blockHeaders := make([]*appmessage.MsgBlockHeader, len(blockHashes))
+		for i, blockHash := range blockHashes {
+			blockHeader, err := consensus.GetBlockHeader(blockHash)
+			if err != nil {
+				return err
+			}
+			blockHeaders[i] = appmessage.DomainBlockHeaderToBlockHeader(blockHeader)
+		}
+		err = w.writeRecord(appmessage.NewBlockHeadersMessage(blockHeaders))
+		if err != nil {
+			return err
+		}
+		headerCount += len(blockHeaders)
+
+		lowHash = blockHashes[len(blockHashes)-1]
+	}
+	log.Infof("Exported %d headers above the pruning point", headerCount)
+	return w.writeRecord(appmessage.NewMsgDoneHeaders())
+}
+
+func exportPruningPointUTXOSet(consensus externalapi.Consensus, pruningPoint *externalapi.DomainHash,
+	w *writer) (int, error) {
+
+	utxoCount := 0
+	var fromOutpoint *externalapi.DomainOutpoint
+	for {
+		pruningPointUTXOs, err := consensus.GetPruningPointUTXOs(pruningPoint, fromOutpoint, utxoChunkSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(pruningPointUTXOs) > 0 {
+			err = w.writeRecord(appmessage.NewMsgPruningPointUTXOSetChunk(
+				appmessage.DomainOutpointAndUTXOEntryPairsToOutpointAndUTXOEntryPairs(pruningPointUTXOs)))
+			if err != nil {
+				return 0, err
+			}
+			utxoCount += len(pruningPointUTXOs)
+			fromOutpoint = pruningPointUTXOs[len(pruningPointUTXOs)-1].Outpoint
+		}
+		if len(pruningPointUTXOs) < utxoChunkSize {
+			break
+		}
+	}
+	return utxoCount, w.writeRecord(appmessage.NewMsgDonePruningPointUTXOSetChunks())
+}
@@ -0,0 +1,310 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// A snapshot file consists of:
+//  1. fileMagic and the version of the file format
+//  2. The name of the network the snapshot belongs to
+//  3. Records, each of them a protowire.KaspadMessage. They are the messages
+//     a node receives in IBD with headers proof, in the same order: the pruning
+//     point proof, the pruning points, the trusted data, the pruning point and
+//     its anticone, the headers above the pruning point, and the pruning point
+//     UTXO set chunks
+//  4. The SHA-256 checksum of everything before it
+//
+// Lengths and numbers are little endian uint32s, and every string or record
+// is prefixed by its length.
+var fileMagic = []byte("KASSNAP\x00")
+
+const fileFormatVersion uint32 = 1
+
+const checksumSize = sha256.Size
+
+// ErrChecksumMismatch is returned when the content of a snapshot file doesn't
+// match its checksum
+var ErrChecksumMismatch = errors.New("the snapshot file doesn't match its checksum")
+
+// writer writes a snapshot file. The file is written under a temporary name,
+// and only gets its final name when it's complete
+type writer struct {
+	path     string
+	file     *os.File
+	buffered *bufio.Writer
+	checksum hash.Hash
+	out      io.Writer
+}
+
+func createFile(path string, networkName string) (*writer, error) {
+	file, err := os.OpenFile(temporaryPath(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	buffered := bufio.NewWriter(file)
+	checksum := sha256.New()
+	w := &writer{
+		path:     path,
+		file:     file,
+		buffered: buffered,
+		checksum: checksum,
+		out:      io.MultiWriter(buffered, checksum),
+	}
+
+	err = w.write(fileMagic)
+	if err != nil {
+		w.abort()
+		return nil, err
+	}
+	err = w.writeUint32(fileFormatVersion)
+	if err != nil {
+		w.abort()
+		return nil, err
+	}
+	err = w.writeBytes([]byte(networkName))
+	if err != nil {
+		w.abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+func temporaryPath(path string) string {
+	return path + ".tmp"
+}
+
+func (w *writer) write(data []byte) error {
+	_, err := w.out.Write(data)
+	return errors.WithStack(err)
+}
+
+func (w *writer) writeUint32(number uint32) error {
+	var serialized [4]byte
+	binary.LittleEndian.PutUint32(serialized[:], number)
+	return w.write(serialized[:])
+}
+
+func (w *writer) writeBytes(data []byte) error {
+	err := w.writeUint32(uint32(len(data)))
+	if err != nil {
+		return err
+	}
+	return w.write(data)
+}
+
+func (w *writer) writeRecord(message appmessage.Message) error {
+	protoMessage, err := protowire.FromAppMessage(message)
+	if err != nil {
+		return err
+	}
+	serialized, err := proto.Marshal(protoMessage)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(serialized) > appmessage.MaxMessagePayload {
+		return errors.Errorf("the %s record is %d bytes, more than the maximum of %d",
+			message.Command(), len(serialized), appmessage.MaxMessagePayload)
+	}
+	return w.writeBytes(serialized)
+}
+
+// close completes the file with its checksum and gives it its final name
+func (w *writer) close() error {
+	_, err := w.buffered.Write(w.checksum.Sum(nil))
+	if err != nil {
+		w.abort()
+		return errors.WithStack(err)
+	}
+	err = w.buffered.Flush()
+	if err != nil {
+		w.abort()
+		return errors.WithStack(err)
+	}
+	err = w.file.Sync()
+	if err != nil {
+		w.abort()
+		return errors.WithStack(err)
+	}
+	err = w.file.Close()
+	w.file = nil
+	if err != nil {
+		w.removeTemporaryFile()
+		return errors.WithStack(err)
+	}
+	err = os.Rename(temporaryPath(w.path), w.path)
+	if err != nil {
+		w.removeTemporaryFile()
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// abort closes and removes the incomplete file. It does nothing once the
+// file is closed
+func (w *writer) abort() {
+	if w.file == nil {
+		return
+	}
+	w.file.Close()
+	w.file = nil
+	w.removeTemporaryFile()
+}
+
+func (w *writer) removeTemporaryFile() {
+	err := os.Remove(temporaryPath(w.path))
+	if err != nil && !os.IsNotExist(err) {
+		log.Warnf("Couldn't remove the incomplete snapshot file %s: %s", temporaryPath(w.path), err)
+	}
+}
+
+// reader reads a snapshot file, after verifying its checksum
+type reader struct {
+	file     *os.File
+	buffered *bufio.Reader
+}
+
+func openFile(path string, networkName string) (*reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	r := &reader{file: file}
+	err = r.verifyChecksum()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		file.Close()
+		return nil, errors.WithStack(err)
+	}
+	r.buffered = bufio.NewReader(file)
+
+	magic := make([]byte, len(fileMagic))
+	err = r.read(magic)
+	if err != nil || !bytes.Equal(magic, fileMagic) {
+		file.Close()
+		return nil, errors.Errorf("%s is not a snapshot file", path)
+	}
+	version, err := r.readUint32()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if version != fileFormatVersion {
+		file.Close()
+		return nil, errors.Errorf("the snapshot file format version is %d, but only version %d is supported",
+			version, fileFormatVersion)
+	}
+	fileNetworkName, err := r.readBytes(len(networkName))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if string(fileNetworkName) != networkName {
+		file.Close()
+		return nil, errors.Errorf("the snapshot belongs to the network %s rather than %s", fileNetworkName, networkName)
+	}
+	return r, nil
+}
+
+// verifyChecksum reads the whole file, and checks it against the checksum at
+// its end
+func (r *reader) verifyChecksum() error {
+	info, err := r.file.Stat()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if info.Size() < checksumSize {
+		return errors.WithStack(ErrChecksumMismatch)
+	}
+
+	checksum := sha256.New()
+	_, err = io.CopyN(checksum, r.file, info.Size()-checksumSize)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	expectedChecksum := make([]byte, checksumSize)
+	_, err = io.ReadFull(r.file, expectedChecksum)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !bytes.Equal(checksum.Sum(nil), expectedChecksum) {
+		return errors.WithStack(ErrChecksumMismatch)
+	}
+	return nil
+}
+
+func (r *reader) read(data []byte) error {
+	_, err := io.ReadFull(r.buffered, data)
+	return errors.WithStack(err)
+}
+
+func (r *reader) readUint32() (uint32, error) {
+	var serialized [4]byte
+	err := r.read(serialized[:])
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(serialized[:]), nil
+}
+
+// readBytes reads data that's prefixed by its length, which must not be
+// greater than maxLength
+func (r *reader) readBytes(maxLength int) ([]byte, error) {
+	length, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(length) > uint64(maxLength) {
+		return nil, errors.Errorf("the snapshot file has %d bytes where at most %d are expected", length, maxLength)
+	}
+	data := make([]byte, length)
+	err = r.read(data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *reader) readRecord() (appmessage.Message, error) {
+	serialized, err := r.readBytes(appmessage.MaxMessagePayload)
+	if err != nil {
+		return nil, err
+	}
+	protoMessage := &protowire.KaspadMessage{}
+	err = proto.Unmarshal(serialized, protoMessage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return protoMessage.ToAppMessage()
+}
+
+// checkEnd checks that nothing but the checksum follows the last record
+func (r *reader) checkEnd() error {
+	_, err := r.buffered.Discard(checksumSize)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = r.buffered.ReadByte()
+	if !errors.Is(err, io.EOF) {
+		return errors.New("the snapshot file has data after its last record")
+	}
+	return nil
+}
+
+func (r *reader) close() error {
+	return errors.WithStack(r.file.Close())
+}
@@ -0,0 +1,265 @@
+package snapshot
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// Import bootstraps the empty database of the given domain from the snapshot
+// file at the given path. The snapshot goes through the same validations as
+// the pruning point state a node receives in IBD with headers proof, among
+// them the verification of the pruning point UTXO set against its UTXO
+// commitment. The state is imported into a staging consensus, which replaces
+// the current one only if the whole import succeeds
+func Import(domain domain.Domain, params *dagconfig.Params, path string) error {
+	headersSelectedTip, err := domain.Consensus().GetHeadersSelectedTip()
+	if err != nil {
+		return err
+	}
+	if !headersSelectedTip.Equal(params.GenesisHash) {
+		return errors.New("a snapshot can only be imported into an empty database")
+	}
+
+	r, err := openFile(path, params.Name)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+
+	log.Infof("Importing the snapshot %s", path)
+	err = domain.InitStagingConsensusWithoutGenesis()
+	if err != nil {
+		return err
+	}
+	pruningPoint, err := importIntoStagingConsensus(domain, r)
+	if err != nil {
+		deleteStagingConsensusErr := domain.DeleteStagingConsensus()
+		if deleteStagingConsensusErr != nil {
+			log.Errorf("Couldn't delete the staging consensus: %s", deleteStagingConsensusErr)
+		}
+		return err
+	}
+
+	err = domain.CommitStagingConsensus()
+	if err != nil {
+		return err
+	}
+	log.Infof("Imported pruning point %s", pruningPoint)
+	return nil
+}
+
+func importIntoStagingConsensus(domain domain.Domain, r *reader) (*externalapi.DomainHash, error) {
+	pruningPoint, err := importPruningPointProofAndHeaders(domain, r)
+	if err != nil {
+		return nil, err
+	}
+	err = importPruningPointAndItsAnticone(domain.StagingConsensus(), pruningPoint, r)
+	if err != nil {
+		return nil, err
+	}
+	err = importPruningPointFutureHeaders(domain.StagingConsensus(), r)
+	if err != nil {
+		return nil, err
+	}
+	err = importPruningPointUTXOSet(domain.StagingConsensus(), pruningPoint, r)
+	if err != nil {
+		return nil, err
+	}
+	err = r.checkEnd()
+	if err != nil {
+		return nil, err
+	}
+	return pruningPoint, nil
+}
+
+func importPruningPointProofAndHeaders(domain domain.Domain, r *reader) (*externalapi.DomainHash, error) {
+	message, err := r.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	pruningPointProofMessage, ok := message.(*appmessage.MsgPruningPointProof)
+	if !ok {
+		return nil, unexpectedRecordError(appmessage.CmdPruningPointProof, message)
+	}
+	pruningPointProof := appmessage.MsgPruningPointProofToDomainPruningPointProof(pruningPointProofMessage)
+	if len(pruningPointProof.Headers) == 0 || len(pruningPointProof.Headers[0]) == 0 {
+		return nil, errors.New("the snapshot has an empty pruning point proof")
+	}
+	err = domain.Consensus().ValidatePruningPointProof(pruningPointProof)
+	if err != nil {
+		return nil, err
+	}
+	err = domain.StagingConsensus().ApplyPruningPointProof(pruningPointProof)
+	if err != nil {
+		return nil, err
+	}
+	proofPruningPointHeaders := pruningPointProof.Headers[0]
+	pruningPoint := consensushashing.HeaderHash(proofPruningPointHeaders[len(proofPruningPointHeaders)-1])
+
+	message, err = r.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	pruningPointsMessage, ok := message.(*appmessage.MsgPruningPoints)
+	if !ok {
+		return nil, unexpectedRecordError(appmessage.CmdPruningPoints, message)
+	}
+	pruningPointHeaders := make([]externalapi.BlockHeader, len(pruningPointsMessage.Headers))
+	for i, header := range pruningPointsMessage.Headers {
+		pruningPointHeaders[i] = appmessage.BlockHeaderToDomainBlockHeader(header)
+	}
+	if len(pruningPointHeaders) == 0 {
+		return nil, errors.New("the snapshot has no pruning points")
+	}
+	arePruningPointsViolatingFinality, err := domain.Consensus().ArePruningPointsViolatingFinality(pruningPointHeaders)
+	if err != nil {
+		return nil, err
+	}
+	if arePruningPointsViolatingFinality {
+		return nil, errors.New("the pruning points of the snapshot violate finality")
+	}
+	lastPruningPoint := consensushashing.HeaderHash(pruningPointHeaders[len(pruningPointHeaders)-1])
+	if !lastPruningPoint.Equal(pruningPoint) {
+		return nil, errors.Errorf("the last pruning point %s of the snapshot is not the proof pruning point %s",
+			lastPruningPoint, pruningPoint)
+	}
+	err = domain.StagingConsensus().ImportPruningPoints(pruningPointHeaders)
+	if err != nil {
+		return nil, err
+	}
+	return pruningPoint, nil
+}
+
+func importPruningPointAndItsAnticone(consensus externalapi.Consensus, pruningPoint *externalapi.DomainHash,
+	r *reader) error {
+
+	message, err := r.readRecord()
+	if err != nil {
+		return err
+	}
+	trustedData, ok := message.(*appmessage.MsgTrustedData)
+	if !ok {
+		return unexpectedRecordError(appmessage.CmdTrustedData, message)
+	}
+
+	blockCount := 0
+	for {
+		message, err := r.readRecord()
+		if err != nil {
+			return err
+		}
+		if _, ok := message.(*appmessage.MsgDoneBlocksWithTrustedData); ok {
+			break
+		}
+		blockWithTrustedDataMessage, ok := message.(*appmessage.MsgBlockWithTrustedDataV4)
+		if !ok {
+			return unexpectedRecordError(appmessage.CmdBlockWithTrustedDataV4, message)
+		}
+		blockWithTrustedData := appmessage.BlockWithTrustedDataV4ToDomainBlockWithTrustedData(
+			blockWithTrustedDataMessage, trustedData)
+		if blockCount == 0 {
+			blockHash := consensushashing.BlockHash(blockWithTrustedData.Block)
+			if !blockHash.Equal(pruningPoint) {
+				return errors.Errorf("the first block with trusted data of the snapshot is %s "+
+					"rather than the pruning point %s", blockHash, pruningPoint)
+			}
+		}
+		err = consensus.ValidateAndInsertBlockWithTrustedData(blockWithTrustedData, false)
+		if err != nil {
+			return err
+		}
+		blockCount++
+	}
+	if blockCount == 0 {
+		return errors.New("the snapshot doesn't have the pruning point block")
+	}
+	log.Infof("Imported the pruning point and its anticone (%d blocks)", blockCount)
+	return nil
+}
+
+func importPruningPointFutureHeaders(consensus externalapi.Consensus, r *reader) error {
+	headerCount := 0
+	for {
+		message, err := r.readRecord()
+		if err != nil {
+			return err
+		}
+		if _, ok := message.(*appmessage.MsgDoneHeaders); ok {
+			break
+		}
+		blockHeadersMessage, ok := message.(*appmessage.BlockHeadersMessage)
+		if !ok {
+			return unexpectedRecordError(appmessage.CmdBlockHeaders, message)
+		}
+		for _, header := range blockHeadersMessage.BlockHeaders {
+			block := &externalapi.DomainBlock{
+				Header:       appmessage.BlockHeaderToDomainBlockHeader(header),
+				Transactions: nil,
+			}
+			blockHash := consensushashing.BlockHash(block)
+			blockInfo, err := consensus.GetBlockInfo(blockHash)
+			if err != nil {
+				return err
+			}
+			if blockInfo.Exists {
+				continue
+			}
+			err = consensus.ValidateAndInsertBlock(block, false)
+			if err != nil {
+				return errors.Wrapf(err, "failed to import header %s", blockHash)
+			}
+			headerCount++
+		}
+	}
+	log.Infof("Imported %d headers above the pruning point", headerCount)
+	return nil
+}
+
+func importPruningPointUTXOSet(consensus externalapi.Consensus, pruningPoint *externalapi.DomainHash, r *reader) error {
+	isValidPruningPoint, err := consensus.IsValidPruningPoint(pruningPoint)
+	if err != nil {
+		return err
+	}
+	if !isValidPruningPoint {
+		return errors.Errorf("%s is not a valid pruning point", pruningPoint)
+	}
+
+	defer func() {
+		err := consensus.ClearImportedPruningPointData()
+		if err != nil {
+			log.Errorf("Couldn't clear the imported pruning point data: %s", err)
+		}
+	}()
+
+	utxoCount := 0
+	for {
+		message, err := r.readRecord()
+		if err != nil {
+			return err
+		}
+		if _, ok := message.(*appmessage.MsgDonePruningPointUTXOSetChunks); ok {
+			break
+		}
+		chunk, ok := message.(*appmessage.MsgPruningPointUTXOSetChunk)
+		if !ok {
+			return unexpectedRecordError(appmessage.CmdPruningPointUTXOSetChunk, message)
+		}
+		domainOutpointAndUTXOEntryPairs :=
+			appmessage.OutpointAndUTXOEntryPairsToDomainOutpointAndUTXOEntryPairs(chunk.OutpointAndUTXOEntryPairs)
+		err = consensus.AppendImportedPruningPointUTXOs(domainOutpointAndUTXOEntryPairs)
+		if err != nil {
+			return err
+		}
+		utxoCount += len(domainOutpointAndUTXOEntryPairs)
+	}
+	log.Infof("Verifying the UTXO commitment of %d imported UTXOs", utxoCount)
+	return consensus.ValidateAndInsertImportedPruningPoint(pruningPoint)
+}
+
+func unexpectedRecordError(expected appmessage.MessageCommand, actual appmessage.Message) error {
+	return errors.Errorf("expected a %s record in the snapshot, but got %s", expected, actual.Command())
+}
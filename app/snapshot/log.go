@@ -0,0 +1,7 @@
+package snapshot
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("SNAP")
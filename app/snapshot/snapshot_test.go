@@ -0,0 +1,150 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
+	"github.com/pkg/errors"
+)
+
+func TestExportAndImport(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+
+	// This is done to reduce the pruning depth to 6 blocks
+	consensusConfig.FinalityDuration = 5 * consensusConfig.TargetTimePerBlock
+	consensusConfig.K = 0
+	consensusConfig.PruningProofM = 1
+
+	tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "TestExportAndImport")
+	if err != nil {
+		t.Fatalf("NewTestConsensus: %+v", err)
+	}
+	defer teardown(false)
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	err = Export(tc, &consensusConfig.Params, path)
+	if err == nil {
+		t.Fatalf("Export unexpectedly succeeded while the pruning point is the genesis")
+	}
+
+	tipHash := consensusConfig.GenesisHash
+	for i := 0; i < 40; i++ {
+		tipHash, _, err = tc.AddBlock([]*externalapi.DomainHash{tipHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+	}
+
+	err = Export(tc, &consensusConfig.Params, path)
+	if err != nil {
+		t.Fatalf("Export: %+v", err)
+	}
+	if _, err := os.Stat(temporaryPath(path)); !os.IsNotExist(err) {
+		t.Fatalf("The temporary snapshot file was not removed")
+	}
+
+	db := memorydb.NewMemoryDB()
+	defer db.Close()
+	importDomain, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		t.Fatalf("domain.New: %+v", err)
+	}
+
+	corruptedPath := filepath.Join(t.TempDir(), "corrupted")
+	serialized, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %+v", err)
+	}
+	serialized[len(serialized)/2] ^= 1
+	err = os.WriteFile(corruptedPath, serialized, 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	err = Import(importDomain, &consensusConfig.Params, corruptedPath)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Expected ErrChecksumMismatch when importing a corrupted snapshot, but got: %+v", err)
+	}
+
+	err = Import(importDomain, &consensusConfig.Params, path)
+	if err != nil {
+		t.Fatalf("Import: %+v", err)
+	}
+
+	expectedPruningPoint, err := tc.PruningPoint()
+	if err != nil {
+		t.Fatalf("PruningPoint: %+v", err)
+	}
+	pruningPoint, err := importDomain.Consensus().PruningPoint()
+	if err != nil {
+		t.Fatalf("PruningPoint: %+v", err)
+	}
+	if !pruningPoint.Equal(expectedPruningPoint) {
+		t.Fatalf("Expected the imported pruning point to be %s, but got %s", expectedPruningPoint, pruningPoint)
+	}
+
+	expectedUTXOs, err := tc.GetPruningPointUTXOs(expectedPruningPoint, nil, 1000)
+	if err != nil {
+		t.Fatalf("GetPruningPointUTXOs: %+v", err)
+	}
+	utxos, err := importDomain.Consensus().GetPruningPointUTXOs(pruningPoint, nil, 1000)
+	if err != nil {
+		t.Fatalf("GetPruningPointUTXOs: %+v", err)
+	}
+	if len(expectedUTXOs) == 0 {
+		t.Fatalf("Expected the pruning point UTXO set not to be empty")
+	}
+	if len(utxos) != len(expectedUTXOs) {
+		t.Fatalf("Expected %d imported UTXOs, but got %d", len(expectedUTXOs), len(utxos))
+	}
+	for i, utxo := range utxos {
+		if !utxo.Outpoint.Equal(expectedUTXOs[i].Outpoint) || !utxo.UTXOEntry.Equal(expectedUTXOs[i].UTXOEntry) {
+			t.Fatalf("Imported UTXO %d is %v rather than %v", i, utxo, expectedUTXOs[i])
+		}
+	}
+
+	// Both the pruned exporting consensus and the imported one are expected
+	// to be consistent
+	for _, verifiedConsensus := range []externalapi.Consensus{tc, importDomain.Consensus()} {
+		integrityReport, err := verifiedConsensus.VerifyIntegrity(&externalapi.IntegrityVerificationOptions{})
+		if err != nil {
+			t.Fatalf("VerifyIntegrity: %+v", err)
+		}
+		if integrityReport.PruningPointUTXOCount == 0 {
+			t.Fatalf("Expected the pruning point UTXO set to be verified")
+		}
+		for _, discrepancy := range integrityReport.Discrepancies {
+			t.Errorf("Unexpected %s discrepancy in block %s: %s",
+				discrepancy.Check, discrepancy.BlockHash, discrepancy.Details)
+		}
+	}
+
+	err = Import(importDomain, &consensusConfig.Params, path)
+	if err == nil {
+		t.Fatalf("Import unexpectedly succeeded into a database that isn't empty")
+	}
+}
+
+func TestImportWrongNetwork(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot")
+	w, err := createFile(path, dagconfig.TestnetParams.Name)
+	if err != nil {
+		t.Fatalf("createFile: %+v", err)
+	}
+	err = w.close()
+	if err != nil {
+		t.Fatalf("close: %+v", err)
+	}
+
+	_, err = openFile(path, dagconfig.SimnetParams.Name)
+	if err == nil {
+		t.Fatalf("openFile unexpectedly accepted a snapshot of another network")
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"github.com/kaspanet/kaspad/app/snapshot"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// runSnapshotCommand runs the export-snapshot or import-snapshot command over
+// the database of the node, instead of running the node itself
+func runSnapshotCommand(cfg *config.Config, db database.Database) error {
+	consensusConfig := newConsensusConfig(cfg)
+	domain, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		return err
+	}
+
+	switch cfg.Command {
+	case config.ExportSnapshotCommand:
+		return snapshot.Export(domain.Consensus(), cfg.NetParams(), cfg.ExportSnapshot.Args.File)
+	case config.ImportSnapshotCommand:
+		return snapshot.Import(domain, cfg.NetParams(), cfg.ImportSnapshot.Args.File)
+	default:
+		return errors.Errorf("unknown command %s", cfg.Command)
+	}
+}
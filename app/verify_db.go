@@ -0,0 +1,179 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/addresshistoryindex"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/domain/txindex"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// The check names of the index discrepancies in the --verify-db report
+const (
+	utxoIndexCheck           = "utxoindex"
+	txIndexCheck             = "txindex"
+	addressHistoryIndexCheck = "addresshistoryindex"
+)
+
+// databaseVerificationReport is the report --verify-db writes as JSON
+type databaseVerificationReport struct {
+	Network                     string                      `json:"network"`
+	CheckedBlocks               uint64                      `json:"checkedBlocks"`
+	RecomputedGHOSTDAGData      uint64                      `json:"recomputedGhostdagData"`
+	CheckedMultisets            uint64                      `json:"checkedMultisets"`
+	VirtualUTXOCount            uint64                      `json:"virtualUtxoCount"`
+	PruningPointUTXOCount       uint64                      `json:"pruningPointUtxoCount"`
+	HasUTXOIndex                bool                        `json:"hasUtxoIndex"`
+	IndexedUTXOCount            uint64                      `json:"indexedUtxoCount"`
+	RepairedUTXOIndex           bool                        `json:"repairedUtxoIndex"`
+	HasTXIndex                  bool                        `json:"hasTxIndex"`
+	RepairedTXIndex             bool                        `json:"repairedTxIndex"`
+	HasAddressHistoryIndex      bool                        `json:"hasAddressHistoryIndex"`
+	RepairedAddressHistoryIndex bool                        `json:"repairedAddressHistoryIndex"`
+	Discrepancies               []*databaseDiscrepancyEntry `json:"discrepancies"`
+}
+
+type databaseDiscrepancyEntry struct {
+	Check     string `json:"check"`
+	BlockHash string `json:"blockHash,omitempty"`
+	Details   string `json:"details"`
+}
+
+// verifyDatabase verifies the integrity of the consensus stores and of the
+// UTXO, TX and address history indexes, instead of running the node. Only the
+// indexes, which are derived from the consensus, can be repaired. The consensus
+// itself has to be resynced if it's inconsistent
+func verifyDatabase(cfg *config.Config, db database.Database) error {
+	consensusConfig := newConsensusConfig(cfg)
+	domain, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		return err
+	}
+
+	consensusReport, err := domain.Consensus().VerifyIntegrity(&externalapi.IntegrityVerificationOptions{
+		GHOSTDAGSampleSize: cfg.VerifyDatabaseGHOSTDAGSample,
+	})
+	if err != nil {
+		return err
+	}
+	utxoIndexReport, err := utxoindex.Verify(domain, db)
+	if err != nil {
+		return err
+	}
+	txIndexReport, err := txindex.Verify(domain, db)
+	if err != nil {
+		return err
+	}
+	addressHistoryIndexReport, err := addresshistoryindex.Verify(domain, db)
+	if err != nil {
+		return err
+	}
+
+	report := &databaseVerificationReport{
+		Network:                cfg.NetParams().Name,
+		CheckedBlocks:          consensusReport.CheckedBlocks,
+		RecomputedGHOSTDAGData: consensusReport.RecomputedGHOSTDAGData,
+		CheckedMultisets:       consensusReport.CheckedMultisets,
+		VirtualUTXOCount:       consensusReport.VirtualUTXOCount,
+		PruningPointUTXOCount:  consensusReport.PruningPointUTXOCount,
+		HasUTXOIndex:           utxoIndexReport.Exists,
+		IndexedUTXOCount:       utxoIndexReport.IndexedUTXOCount,
+		HasTXIndex:             txIndexReport.Exists,
+		HasAddressHistoryIndex: addressHistoryIndexReport.Exists,
+		Discrepancies:          make([]*databaseDiscrepancyEntry, 0),
+	}
+	for _, discrepancy := range consensusReport.Discrepancies {
+		entry := &databaseDiscrepancyEntry{
+			Check:   string(discrepancy.Check),
+			Details: discrepancy.Details,
+		}
+		if discrepancy.BlockHash != nil {
+			entry.BlockHash = discrepancy.BlockHash.String()
+		}
+		report.Discrepancies = append(report.Discrepancies, entry)
+	}
+	indexDiscrepancies := []struct {
+		check         string
+		discrepancies []string
+	}{
+		{check: utxoIndexCheck, discrepancies: utxoIndexReport.Discrepancies},
+		{check: txIndexCheck, discrepancies: txIndexReport.Discrepancies},
+		{check: addressHistoryIndexCheck, discrepancies: addressHistoryIndexReport.Discrepancies},
+	}
+	for _, index := range indexDiscrepancies {
+		for _, discrepancy := range index.discrepancies {
+			report.Discrepancies = append(report.Discrepancies, &databaseDiscrepancyEntry{
+				Check:   index.check,
+				Details: discrepancy,
+			})
+		}
+	}
+
+	if cfg.VerifyDatabaseRepair && len(utxoIndexReport.Discrepancies) > 0 {
+		log.Infof("Rebuilding the UTXO index")
+		err := utxoindex.Repair(domain, db)
+		if err != nil {
+			return err
+		}
+		report.RepairedUTXOIndex = true
+	}
+	if cfg.VerifyDatabaseRepair && len(txIndexReport.Discrepancies) > 0 {
+		log.Infof("Rebuilding the TX index")
+		err := txindex.Repair(domain, db)
+		if err != nil {
+			return err
+		}
+		report.RepairedTXIndex = true
+	}
+	if cfg.VerifyDatabaseRepair && len(addressHistoryIndexReport.Discrepancies) > 0 {
+		log.Infof("Rebuilding the address history index")
+		err := addresshistoryindex.Repair(domain, db)
+		if err != nil {
+			return err
+		}
+		report.RepairedAddressHistoryIndex = true
+	}
+
+	if cfg.VerifyDatabaseReport != "" {
+		serializedReport, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		err = os.WriteFile(cfg.VerifyDatabaseReport, append(serializedReport, '\n'), 0600)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	log.Infof("Verified %d blocks, the GHOSTDAG data of %d of them, %d multisets, %d virtual UTXOs, "+
+		"%d indexed UTXOs, and %d and %d chain blocks of the TX and address history indexes", report.CheckedBlocks,
+		report.RecomputedGHOSTDAGData, report.CheckedMultisets, report.VirtualUTXOCount, report.IndexedUTXOCount,
+		txIndexReport.CheckedChainBlocks, addressHistoryIndexReport.CheckedChainBlocks)
+
+	unrepairedDiscrepancyCount := len(consensusReport.Discrepancies)
+	if !report.RepairedUTXOIndex {
+		unrepairedDiscrepancyCount += len(utxoIndexReport.Discrepancies)
+	}
+	if !report.RepairedTXIndex {
+		unrepairedDiscrepancyCount += len(txIndexReport.Discrepancies)
+	}
+	if !report.RepairedAddressHistoryIndex {
+		unrepairedDiscrepancyCount += len(addressHistoryIndexReport.Discrepancies)
+	}
+	if unrepairedDiscrepancyCount > 0 {
+		return errors.Errorf("found %d discrepancies in the database", unrepairedDiscrepancyCount)
+	}
+	if report.RepairedUTXOIndex || report.RepairedTXIndex || report.RepairedAddressHistoryIndex {
+		log.Infof("The inconsistent indexes were repaired, and no other discrepancies were found")
+	} else {
+		log.Infof("No discrepancies were found")
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ var (
 
 type configFlags struct {
 	RPCServer                          string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	AuthToken                          string `long:"auth-token" description:"Bearer token to authenticate to the RPC server with"`
 	Timeout                            uint64 `short:"t" long:"timeout" description:"Timeout for the request (in seconds)"`
 	RequestJSON                        string `short:"j" long:"json" description:"The request in JSON format"`
 	ListCommands                       bool   `short:"l" long:"list-commands" description:"List all commands and exit"`
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -33,17 +34,39 @@ var (
 type configFlags struct {
 	ShowVersion           bool     `short:"V" long:"version" description:"Display version information and exit"`
 	RPCServer             string   `short:"s" long:"rpcserver" description:"RPC server to connect to"`
-	MiningAddr            string   `long:"miningaddr" description:"Address to mine to"`
+	MiningAddrs           []string `long:"miningaddr" description:"Address to mine to. If given several times, the payout address of the block templates rotates between the addresses"`
+	MiningAddrsFile       string   `long:"miningaddrs-file" description:"File with addresses to mine to, one per line, each optionally followed by its weight in the rotation of the payout addresses (default 1)"`
+	ExtraData             string   `long:"extra-data" description:"Tag to put in the coinbase transaction of the mined blocks, for example to attribute them to a pool (default kaspaminer-<version>)"`
 	NumberOfBlocks        uint64   `short:"n" long:"numblocks" description:"Number of blocks to mine. If omitted, will mine until the process is interrupted."`
 	MineWhenNotSynced     bool     `long:"mine-when-not-synced" description:"Mine even if the node is not synced with the rest of the network."`
 	Profile               string   `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	TargetBlocksPerSecond *float64 `long:"target-blocks-per-second" description:"Sets a maximum block rate. 0 means no limit (The default one is 2 * target network block rate)"`
+	Threads               int      `short:"t" long:"threads" description:"Number of mining threads"`
+	StatusListen          string   `long:"status-listen" description:"Serve the hash rates of the mining threads as JSON on http://<address>/status, for example localhost:8095"`
 	config.NetworkFlags
 }
 
+// maxExtraDataLength returns the longest extra data that fits in the coinbase
+// payload alongside its fixed fields (the blue score, the subsidy and the script
+// public key of the payout address), once the node prefixed it with its version
+func maxExtraDataLength(maxCoinbasePayloadLength uint64) int {
+	const (
+		blueScoreLength          = 8
+		subsidyLength            = 8
+		scriptVersionLength      = 2
+		scriptLengthLength       = 1
+		maxPayoutScriptLength    = 35
+		fixedCoinbasePayloadSize = blueScoreLength + subsidyLength + scriptVersionLength + scriptLengthLength +
+			maxPayoutScriptLength
+	)
+	nodeVersionPrefixLength := len(version.Version() + "/")
+	return int(maxCoinbasePayloadLength) - fixedCoinbasePayloadSize - nodeVersionPrefixLength
+}
+
 func parseConfig() (*configFlags, error) {
 	cfg := &configFlags{
 		RPCServer: defaultRPCServer,
+		Threads:   1,
 	}
 	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
 	_, err := parser.Parse()
@@ -77,8 +100,27 @@ func parseConfig() (*configFlags, error) {
 		}
 	}
 
-	if cfg.MiningAddr == "" {
-		return nil, errors.New("--miningaddr is required")
+	if cfg.Threads < 1 {
+		return nil, errors.New("--threads must be at least 1")
+	}
+
+	if cfg.StatusListen != "" {
+		_, _, err := net.SplitHostPort(cfg.StatusListen)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid --status-listen address %s", cfg.StatusListen)
+		}
+	}
+
+	if len(cfg.MiningAddrs) == 0 && cfg.MiningAddrsFile == "" {
+		return nil, errors.New("--miningaddr or --miningaddrs-file is required")
+	}
+
+	if cfg.ExtraData == "" {
+		cfg.ExtraData = "kaspaminer-" + version.Version()
+	}
+	maxExtraDataLength := maxExtraDataLength(cfg.NetParams().MaxCoinbasePayloadLength)
+	if len(cfg.ExtraData) > maxExtraDataLength {
+		return nil, errors.Errorf("--extra-data must be at most %d bytes long", maxExtraDataLength)
 	}
 
 	initLog(defaultLogFile, defaultErrLogFile)
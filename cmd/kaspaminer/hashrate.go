@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const logHashRateInterval = 10 * time.Second
+
+// minerWorker is a mining thread. The workers partition the nonce space between
+// them: the nonces a worker tries are all congruent to its index modulo the
+// number of workers
+type minerWorker struct {
+	index       uint64
+	numWorkers  uint64
+	hashesTried atomic.Uint64
+}
+
+// nextNonce returns the nonce the worker tries after the given one
+func (mw *minerWorker) nextNonce(nonce uint64) uint64 {
+	return nonce + mw.numWorkers
+}
+
+// firstNonce returns the nonce of the worker the closest to the given one
+func (mw *minerWorker) firstNonce(nonce uint64) uint64 {
+	return nonce - nonce%mw.numWorkers + mw.index
+}
+
+type workerStatus struct {
+	Index    uint64  `json:"index"`
+	HashRate float64 `json:"hashRate"`
+}
+
+type minerStatusJSON struct {
+	Threads       int             `json:"threads"`
+	TotalHashRate float64         `json:"totalHashRate"`
+	Workers       []*workerStatus `json:"workers"`
+	BlocksFound   uint64          `json:"blocksFound"`
+	SampledAt     time.Time       `json:"sampledAt"`
+}
+
+// minerStatus samples the hash rates of the workers
+type minerStatus struct {
+	workers     []*minerWorker
+	blocksFound atomic.Uint64
+
+	lock            sync.Mutex
+	lastSampleTime  time.Time
+	workerHashRates []float64
+}
+
+func newMinerStatus(numWorkers int) *minerStatus {
+	workers := make([]*minerWorker, numWorkers)
+	for i := range workers {
+		workers[i] = &minerWorker{index: uint64(i), numWorkers: uint64(numWorkers)}
+	}
+	return &minerStatus{
+		workers:         workers,
+		lastSampleTime:  time.Now(),
+		workerHashRates: make([]float64, numWorkers),
+	}
+}
+
+// sample computes the hash rate of every worker since the previous sample
+func (ms *minerStatus) sample(now time.Time) {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	elapsedSeconds := now.Sub(ms.lastSampleTime).Seconds()
+	for i, worker := range ms.workers {
+		ms.workerHashRates[i] = float64(worker.hashesTried.Swap(0)) / elapsedSeconds
+	}
+	ms.lastSampleTime = now
+}
+
+func (ms *minerStatus) status() *minerStatusJSON {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	status := &minerStatusJSON{
+		Threads:     len(ms.workers),
+		Workers:     make([]*workerStatus, len(ms.workers)),
+		BlocksFound: ms.blocksFound.Load(),
+		SampledAt:   ms.lastSampleTime,
+	}
+	for i, hashRate := range ms.workerHashRates {
+		status.TotalHashRate += hashRate
+		status.Workers[i] = &workerStatus{Index: uint64(i), HashRate: hashRate}
+	}
+	return status
+}
+
+func (ms *minerStatus) logHashRate() {
+	status := ms.status()
+	if len(status.Workers) == 1 {
+		log.Infof("Current hash rate is %.2f Khash/s", status.TotalHashRate/1000)
+		return
+	}
+
+	workerHashRates := make([]string, len(status.Workers))
+	for i, worker := range status.Workers {
+		workerHashRates[i] = fmt.Sprintf("%d: %.2f", worker.Index, worker.HashRate/1000)
+	}
+	log.Infof("Current hash rate is %.2f Khash/s (per thread: %s)",
+		status.TotalHashRate/1000, strings.Join(workerHashRates, ", "))
+}
+
+// logHashRateLoop logs the hash rate every logHashRateInterval, until stop is closed
+func (ms *minerStatus) logHashRateLoop(stop <-chan struct{}) {
+	spawn("logHashRate", func() {
+		ticker := time.NewTicker(logHashRateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				ms.sample(now)
+				ms.logHashRate()
+			}
+		}
+	})
+}
+
+// startStatusServer serves the hash rates of the miner as JSON on /status. It
+// returns an error if it can't listen on the given address
+func (ms *minerStatus) startStatusServer(listenAddress string) error {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return errors.Wrapf(err, "error listening to the miner status on %s", listenAddress)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(writer).Encode(ms.status())
+		if err != nil {
+			log.Warnf("Error writing the miner status: %s", err)
+		}
+	})
+
+	spawn("startStatusServer", func() {
+		log.Infof("Serving the miner status on http://%s/status", listener.Addr())
+		err := http.Serve(listener, mux)
+		if err != nil {
+			log.Errorf("Error serving the miner status on %s: %s", listener.Addr(), err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestNoncePartition(t *testing.T) {
+	const numWorkers = 3
+	status := newMinerStatus(numWorkers)
+
+	triedNonces := make(map[uint64]uint64)
+	for _, worker := range status.workers {
+		nonce := worker.firstNonce(1000)
+		for i := 0; i < 100; i++ {
+			nonce = worker.nextNonce(nonce)
+			if otherWorkerIndex, ok := triedNonces[nonce]; ok {
+				t.Fatalf("workers %d and %d both try nonce %d", otherWorkerIndex, worker.index, nonce)
+			}
+			triedNonces[nonce] = worker.index
+		}
+	}
+}
+
+func TestMinerStatus(t *testing.T) {
+	status := newMinerStatus(2)
+	status.workers[0].hashesTried.Add(1000)
+	status.workers[1].hashesTried.Add(3000)
+	status.blocksFound.Add(1)
+
+	status.sample(status.lastSampleTime.Add(2 * time.Second))
+	minerStatus := status.status()
+	if minerStatus.Threads != 2 || minerStatus.BlocksFound != 1 {
+		t.Fatalf("unexpected status %+v", minerStatus)
+	}
+	if minerStatus.Workers[0].HashRate != 500 || minerStatus.Workers[1].HashRate != 1500 || minerStatus.TotalHashRate != 2000 {
+		t.Fatalf("unexpected hash rates %+v, %+v (total %f)", minerStatus.Workers[0], minerStatus.Workers[1], minerStatus.TotalHashRate)
+	}
+
+	// Every sample covers only the hashes tried since the previous one
+	status.sample(status.lastSampleTime.Add(time.Second))
+	if status.status().TotalHashRate != 0 {
+		t.Fatalf("expected no hash rate without new hashes")
+	}
+}
+
+func TestStartStatusServerOnTakenAddress(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %+v", err)
+	}
+	defer listener.Close()
+
+	err = newMinerStatus(1).startStatusServer(listener.Addr().String())
+	if err == nil {
+		t.Fatalf("expected serving the status on a taken address to fail")
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/kaspanet/kaspad/util"
-
 	"github.com/kaspanet/kaspad/version"
 
 	"github.com/pkg/errors"
@@ -41,14 +39,23 @@ func main() {
 	}
 	defer client.Disconnect()
 
-	miningAddr, err := util.DecodeAddress(cfg.MiningAddr, cfg.ActiveNetParams.Prefix)
+	payouts, err := newPayoutRotation(cfg.MiningAddrs, cfg.MiningAddrsFile, cfg.ActiveNetParams.Prefix)
 	if err != nil {
-		printErrorAndExit(errors.Errorf("Error decoding mining address: %s", err))
+		printErrorAndExit(errors.Errorf("Error loading the mining addresses: %s", err))
+	}
+
+	status := newMinerStatus(cfg.Threads)
+	if cfg.StatusListen != "" {
+		err := status.startStatusServer(cfg.StatusListen)
+		if err != nil {
+			printErrorAndExit(err)
+		}
 	}
 
 	doneChan := make(chan struct{})
 	spawn("mineLoop", func() {
-		err = mineLoop(client, cfg.NumberOfBlocks, *cfg.TargetBlocksPerSecond, cfg.MineWhenNotSynced, miningAddr)
+		err = mineLoop(client, status, cfg.NumberOfBlocks, *cfg.TargetBlocksPerSecond, cfg.MineWhenNotSynced,
+			payouts, cfg.ExtraData)
 		if err != nil {
 			panic(errors.Wrap(err, "error in mine loop"))
 		}
@@ -2,9 +2,7 @@ package main
 
 import (
 	nativeerrors "errors"
-	"github.com/kaspanet/kaspad/version"
 	"math/rand"
-	"sync/atomic"
 	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
@@ -13,61 +11,64 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/kaspanet/kaspad/util"
 	"github.com/pkg/errors"
 )
 
-var hashesTried uint64
-
-const logHashRateInterval = 10 * time.Second
-
-func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond float64, mineWhenNotSynced bool,
-	miningAddr util.Address) error {
+func mineLoop(client *minerClient, status *minerStatus, numberOfBlocks uint64, targetBlocksPerSecond float64,
+	mineWhenNotSynced bool, payouts *payoutRotation, extraData string) error {
 	rand.Seed(time.Now().UnixNano()) // Seed the global concurrent-safe random source.
 
 	errChan := make(chan error)
 	doneChan := make(chan struct{})
 
+	// stop is closed once the mine loop returns, which stops the workers and the loops it spawned
+	stop := make(chan struct{})
+	defer close(stop)
+
 	// We don't want to send router.DefaultMaxMessages blocks at once because there's
 	// a high chance we'll get disconnected from the node, so we make the channel
 	// capacity router.DefaultMaxMessages/2 (we give some slack for getBlockTemplate
 	// requests)
 	foundBlockChan := make(chan *externalapi.DomainBlock, router.DefaultMaxMessages/2)
 
-	spawn("templatesLoop", func() {
-		templatesLoop(client, miningAddr, errChan)
-	})
+	// The block rate is enforced by limiting the mining rather than the submission,
+	// so that blocks are submitted as soon as they're found, while their template
+	// is still current. Without a block rate target, permits stays nil and the
+	// workers mine without waiting
+	var permits chan struct{}
+	if targetBlocksPerSecond != 0 {
+		permits = make(chan struct{})
+		spawn("blockRateLoop", func() {
+			blockRateLoop(targetBlocksPerSecond, permits, stop)
+		})
+	}
 
-	spawn("blocksLoop", func() {
-		const windowSize = 10
-		hasBlockRateTarget := targetBlocksPerSecond != 0
-		var windowTicker, blockTicker *time.Ticker
-		// We use tickers to limit the block rate:
-		// 1. windowTicker -> makes sure that the last windowSize blocks take at least windowSize*targetBlocksPerSecond.
-		// 2. blockTicker -> makes sure that each block takes at least targetBlocksPerSecond/windowSize.
-		// that way we both allow for fluctuation in block rate but also make sure they're not too big (by an order of magnitude)
-		if hasBlockRateTarget {
-			windowRate := time.Duration(float64(time.Second) / (targetBlocksPerSecond / windowSize))
-			blockRate := time.Duration(float64(time.Second) / (targetBlocksPerSecond * windowSize))
-			log.Infof("Minimum average time per %d blocks: %s, smaller minimum time per block: %s", windowSize, windowRate, blockRate)
-			windowTicker = time.NewTicker(windowRate)
-			blockTicker = time.NewTicker(blockRate)
-			defer windowTicker.Stop()
-			defer blockTicker.Stop()
-		}
-		windowStart := time.Now()
-		for blockIndex := 1; ; blockIndex++ {
-			foundBlockChan <- mineNextBlock(mineWhenNotSynced)
-			if hasBlockRateTarget {
-				<-blockTicker.C
-				if (blockIndex % windowSize) == 0 {
-					tickerStart := time.Now()
-					<-windowTicker.C
-					log.Infof("Finished mining %d blocks in: %s. slept for: %s", windowSize, time.Since(windowStart), time.Since(tickerStart))
-					windowStart = time.Now()
+	for _, worker := range status.workers {
+		worker := worker
+		spawn("minerWorker", func() {
+			for {
+				if permits != nil {
+					select {
+					case <-permits:
+					case <-stop:
+						return
+					}
+				}
+				block, ok := mineNextBlock(worker, mineWhenNotSynced, stop)
+				if !ok {
+					return
+				}
+				select {
+				case foundBlockChan <- block:
+				case <-stop:
+					return
 				}
 			}
-		}
+		})
+	}
+
+	spawn("templatesLoop", func() {
+		templatesLoop(client, payouts, extraData, errChan, stop)
 	})
 
 	spawn("handleFoundBlock", func() {
@@ -75,14 +76,18 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond
 			block := <-foundBlockChan
 			err := handleFoundBlock(client, block)
 			if err != nil {
-				errChan <- err
+				select {
+				case errChan <- err:
+				case <-stop:
+				}
 				return
 			}
+			status.blocksFound.Add(1)
 		}
 		doneChan <- struct{}{}
 	})
 
-	logHashRate()
+	status.logHashRateLoop(stop)
 
 	select {
 	case err := <-errChan:
@@ -92,20 +97,45 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond
 	}
 }
 
-func logHashRate() {
-	spawn("logHashRate", func() {
-		lastCheck := time.Now()
-		for range time.Tick(logHashRateInterval) {
-			currentHashesTried := atomic.LoadUint64(&hashesTried)
-			currentTime := time.Now()
-			kiloHashesTried := float64(currentHashesTried) / 1000.0
-			hashRate := kiloHashesTried / currentTime.Sub(lastCheck).Seconds()
-			log.Infof("Current hash rate is %.2f Khash/s", hashRate)
-			lastCheck = currentTime
-			// subtract from hashesTried the hashes we already sampled
-			atomic.AddUint64(&hashesTried, -currentHashesTried)
+// blockRateLoop hands out a permit to mine a block through permits at the given
+// target rate, until stop is closed
+func blockRateLoop(targetBlocksPerSecond float64, permits chan<- struct{}, stop <-chan struct{}) {
+	const windowSize = 10
+	// We use tickers to limit the block rate:
+	// 1. windowTicker -> makes sure that the last windowSize blocks take at least windowSize*targetBlocksPerSecond.
+	// 2. blockTicker -> makes sure that each block takes at least targetBlocksPerSecond/windowSize.
+	// that way we both allow for fluctuation in block rate but also make sure they're not too big (by an order of magnitude)
+	windowRate := time.Duration(float64(time.Second) / (targetBlocksPerSecond / windowSize))
+	blockRate := time.Duration(float64(time.Second) / (targetBlocksPerSecond * windowSize))
+	log.Infof("Minimum average time per %d blocks: %s, smaller minimum time per block: %s", windowSize, windowRate, blockRate)
+	windowTicker := time.NewTicker(windowRate)
+	blockTicker := time.NewTicker(blockRate)
+	defer windowTicker.Stop()
+	defer blockTicker.Stop()
+
+	windowStart := time.Now()
+	for blockIndex := 1; ; blockIndex++ {
+		select {
+		case permits <- struct{}{}:
+		case <-stop:
+			return
 		}
-	})
+		select {
+		case <-blockTicker.C:
+		case <-stop:
+			return
+		}
+		if (blockIndex % windowSize) == 0 {
+			tickerStart := time.Now()
+			select {
+			case <-windowTicker.C:
+			case <-stop:
+				return
+			}
+			log.Infof("Finished mining %d blocks in: %s. slept for: %s", windowSize, time.Since(windowStart), time.Since(tickerStart))
+			windowStart = time.Now()
+		}
+	}
 }
 
 func handleFoundBlock(client *minerClient, block *externalapi.DomainBlock) error {
@@ -134,29 +164,48 @@ func handleFoundBlock(client *minerClient, block *externalapi.DomainBlock) error
 	return nil
 }
 
-func mineNextBlock(mineWhenNotSynced bool) *externalapi.DomainBlock {
-	nonce := rand.Uint64() // Use the global concurrent-safe random source.
+// mineNextBlock searches the nonces of the given worker for one that solves the
+// current template. A worker picks new templates up between nonces, so the state
+// it hashes always belongs to a single template. It returns false if stop is
+// closed before a block is found
+func mineNextBlock(worker *minerWorker, mineWhenNotSynced bool, stop <-chan struct{}) (*externalapi.DomainBlock, bool) {
+	block, state, generation, ok := getBlockForMining(mineWhenNotSynced, stop)
+	if !ok {
+		return nil, false
+	}
+	nonce := worker.firstNonce(rand.Uint64()) // Use the global concurrent-safe random source.
 	for {
-		nonce++
-		// For each nonce we try to build a block from the most up to date
-		// block template.
+		select {
+		case <-stop:
+			return nil, false
+		default:
+		}
+
+		nonce = worker.nextNonce(nonce)
 		// In the rare case where the nonce space is exhausted for a specific
 		// block, it'll keep looping the nonce until a new block template
 		// is discovered.
-		block, state := getBlockForMining(mineWhenNotSynced)
+		if templatemanager.Generation() != generation {
+			block, state, generation, ok = getBlockForMining(mineWhenNotSynced, stop)
+			if !ok {
+				return nil, false
+			}
+		}
 		state.Nonce = nonce
-		atomic.AddUint64(&hashesTried, 1)
+		worker.hashesTried.Add(1)
 		if state.CheckProofOfWork() {
 			mutHeader := block.Header.ToMutable()
 			mutHeader.SetNonce(nonce)
 			block.Header = mutHeader.ToImmutable()
-			log.Infof("Found block %s with parents %s", consensushashing.BlockHash(block), block.Header.DirectParents())
-			return block
+			log.Infof("Thread %d found block %s with parents %s", worker.index, consensushashing.BlockHash(block), block.Header.DirectParents())
+			return block, true
 		}
 	}
 }
 
-func getBlockForMining(mineWhenNotSynced bool) (*externalapi.DomainBlock, *pow.State) {
+// getBlockForMining waits for a template to mine on. It returns false if stop is
+// closed meanwhile
+func getBlockForMining(mineWhenNotSynced bool, stop <-chan struct{}) (*externalapi.DomainBlock, *pow.State, uint64, bool) {
 	tryCount := 0
 
 	const sleepTime = 500 * time.Millisecond
@@ -166,34 +215,61 @@ func getBlockForMining(mineWhenNotSynced bool) (*externalapi.DomainBlock, *pow.S
 		tryCount++
 
 		shouldLog := (tryCount-1)%10 == 0
-		template, state, isSynced := templatemanager.Get()
+		template, state, isSynced, generation := templatemanager.Get()
 		if template == nil {
 			if shouldLog {
 				log.Info("Waiting for the initial template")
 			}
-			time.Sleep(sleepTime)
+			if !sleep(sleepTime, stop) {
+				return nil, nil, 0, false
+			}
 			continue
 		}
 		if !isSynced && !mineWhenNotSynced {
 			if shouldLog {
 				log.Warnf("Kaspad is not synced. Skipping current block template")
 			}
-			time.Sleep(sleepTimeWhenNotSynced)
+			if !sleep(sleepTimeWhenNotSynced, stop) {
+				return nil, nil, 0, false
+			}
 			continue
 		}
 
-		return template, state
+		return template, state, generation, true
 	}
 }
 
-func templatesLoop(client *minerClient, miningAddr util.Address, errChan chan error) {
+// sleep waits for the given duration, and returns false if stop is closed meanwhile
+func sleep(duration time.Duration, stop <-chan struct{}) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// templatesLoop keeps the template manager up to date. Every block template is
+// requested with the next payout address of the rotation
+func templatesLoop(client *minerClient, payouts *payoutRotation, extraData string, errChan chan<- error,
+	stop <-chan struct{}) {
+
+	reportError := func(err error) {
+		select {
+		case errChan <- err:
+		case <-stop:
+		}
+	}
 	getBlockTemplate := func() {
-		template, err := client.GetBlockTemplate(miningAddr.String(), "kaspaminer-"+version.Version())
+		template, err := client.GetBlockTemplate(payouts.next().String(), extraData)
 		if nativeerrors.Is(err, router.ErrTimeout) {
 			log.Warnf("Got timeout while requesting block template from %s: %s", client.Address(), err)
 			reconnectErr := client.Reconnect()
 			if reconnectErr != nil {
-				errChan <- reconnectErr
+				reportError(reconnectErr)
 			}
 			return
 		}
@@ -203,12 +279,12 @@ func templatesLoop(client *minerClient, miningAddr util.Address, errChan chan er
 			return
 		}
 		if err != nil {
-			errChan <- errors.Wrapf(err, "Error getting block template from %s", client.Address())
+			reportError(errors.Wrapf(err, "Error getting block template from %s", client.Address()))
 			return
 		}
 		err = templatemanager.Set(template)
 		if err != nil {
-			errChan <- errors.Wrapf(err, "Error setting block template from %s", client.Address())
+			reportError(errors.Wrapf(err, "Error setting block template from %s", client.Address()))
 			return
 		}
 	}
@@ -216,8 +292,11 @@ func templatesLoop(client *minerClient, miningAddr util.Address, errChan chan er
 	getBlockTemplate()
 	const tickerTime = 500 * time.Millisecond
 	ticker := time.NewTicker(tickerTime)
+	defer ticker.Stop()
 	for {
 		select {
+		case <-stop:
+			return
 		case <-client.newBlockTemplateNotificationChan:
 			getBlockTemplate()
 			ticker.Reset(tickerTime)
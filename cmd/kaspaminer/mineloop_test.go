@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/cmd/kaspaminer/templatemanager"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestMineNextBlockStops(t *testing.T) {
+	rpcBlock := appmessage.DomainBlockToRPCBlock(dagconfig.SimnetParams.GenesisBlock)
+	// No nonce the test has time to try solves the block
+	rpcBlock.Header.Bits = 0x1b00ffff
+	err := templatemanager.Set(appmessage.NewGetBlockTemplateResponseMessage(rpcBlock, true))
+	if err != nil {
+		t.Fatalf("Set: %+v", err)
+	}
+
+	status := newMinerStatus(2)
+	stop := make(chan struct{})
+	results := make(chan bool, len(status.workers))
+	for _, worker := range status.workers {
+		worker := worker
+		go func() {
+			_, ok := mineNextBlock(worker, false, stop)
+			results <- ok
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(stop)
+	for range status.workers {
+		select {
+		case ok := <-results:
+			if ok {
+				t.Fatalf("expected the worker to stop without finding a block")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the worker to stop once stop is closed")
+		}
+	}
+}
+
+func TestBlockRateLoop(t *testing.T) {
+	permits := make(chan struct{})
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		blockRateLoop(1000, permits, stop)
+		close(done)
+	}()
+
+	for i := 0; i < 20; i++ {
+		select {
+		case <-permits:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected permit %d to be handed out", i)
+		}
+	}
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the block rate loop to stop once stop is closed")
+	}
+}
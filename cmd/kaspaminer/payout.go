@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+type payoutAddress struct {
+	address util.Address
+	weight  int64
+
+	// currentWeight is the state of the smooth weighted round-robin selection
+	currentWeight int64
+}
+
+// payoutRotation rotates the payout addresses of the block templates. Every
+// address is picked in proportion to its weight, spread as evenly as possible
+// between the templates, so addresses of equal weights are picked round-robin
+type payoutRotation struct {
+	lock        sync.Mutex
+	addresses   []*payoutAddress
+	totalWeight int64
+}
+
+// newPayoutRotation creates the rotation of the given addresses, which have a
+// weight of 1, and of the weighted addresses in the given file, if there's one
+func newPayoutRotation(addresses []string, addressesFile string, prefix util.Bech32Prefix) (*payoutRotation, error) {
+	rotation := &payoutRotation{}
+	for _, address := range addresses {
+		err := rotation.add(address, 1, prefix)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if addressesFile != "" {
+		err := rotation.addFromFile(addressesFile, prefix)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(rotation.addresses) == 0 {
+		return nil, errors.New("no mining address was given")
+	}
+	return rotation, nil
+}
+
+func (pr *payoutRotation) add(addressString string, weight int64, prefix util.Bech32Prefix) error {
+	address, err := util.DecodeAddress(addressString, prefix)
+	if err != nil {
+		return errors.Wrapf(err, "error decoding mining address %s", addressString)
+	}
+	pr.addresses = append(pr.addresses, &payoutAddress{address: address, weight: weight})
+	pr.totalWeight += weight
+	return nil
+}
+
+// addFromFile adds the addresses in the given file. Every line holds an address
+// followed by an optional positive weight, which defaults to 1. Empty lines and
+// lines starting with # are ignored
+func (pr *payoutRotation) addFromFile(path string, prefix util.Bech32Prefix) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "error opening mining addresses file")
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return errors.Errorf("%s:%d: expected an address and an optional weight", path, lineNumber)
+		}
+		weight := int64(1)
+		if len(fields) == 2 {
+			weight, err = strconv.ParseInt(fields[1], 10, 64)
+			if err != nil || weight <= 0 {
+				return errors.Errorf("%s:%d: the weight must be a positive integer", path, lineNumber)
+			}
+		}
+		err = pr.add(fields[0], weight, prefix)
+		if err != nil {
+			return errors.Wrapf(err, "%s:%d", path, lineNumber)
+		}
+	}
+	return errors.WithStack(scanner.Err())
+}
+
+// next returns the payout address of the next block template
+func (pr *payoutRotation) next() util.Address {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	var selected *payoutAddress
+	for _, address := range pr.addresses {
+		address.currentWeight += address.weight
+		if selected == nil || address.currentWeight > selected.currentWeight {
+			selected = address
+		}
+	}
+	selected.currentWeight -= pr.totalWeight
+	return selected.address
+}
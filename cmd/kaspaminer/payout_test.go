@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/util"
+)
+
+var testAddresses = func() []string {
+	addresses := make([]string, 3)
+	for i := range addresses {
+		publicKey := make([]byte, 32)
+		publicKey[0] = byte(i + 1)
+		address, err := util.NewAddressPublicKey(publicKey, util.Bech32PrefixKaspaSim)
+		if err != nil {
+			panic(err)
+		}
+		addresses[i] = address.String()
+	}
+	return addresses
+}()
+
+func countPicks(rotation *payoutRotation, picks int) map[string][]int {
+	picksByAddress := make(map[string][]int)
+	for i := 0; i < picks; i++ {
+		address := rotation.next().String()
+		picksByAddress[address] = append(picksByAddress[address], i)
+	}
+	return picksByAddress
+}
+
+func TestPayoutRotationRoundRobin(t *testing.T) {
+	rotation, err := newPayoutRotation(testAddresses, "", util.Bech32PrefixKaspaSim)
+	if err != nil {
+		t.Fatalf("newPayoutRotation: %+v", err)
+	}
+	for i := 0; i < 2*len(testAddresses); i++ {
+		address := rotation.next().String()
+		if address != testAddresses[i%len(testAddresses)] {
+			t.Fatalf("pick %d: expected %s but got %s", i, testAddresses[i%len(testAddresses)], address)
+		}
+	}
+}
+
+func TestPayoutRotationWeighted(t *testing.T) {
+	addressesFile := filepath.Join(t.TempDir(), "addresses")
+	content := "# pool addresses\n" +
+		testAddresses[0] + " 3\n" +
+		"\n" +
+		testAddresses[1] + "\n"
+	err := os.WriteFile(addressesFile, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+
+	rotation, err := newPayoutRotation(testAddresses[2:], addressesFile, util.Bech32PrefixKaspaSim)
+	if err != nil {
+		t.Fatalf("newPayoutRotation: %+v", err)
+	}
+	picks := countPicks(rotation, 50)
+	expectedPicks := map[string]int{testAddresses[0]: 30, testAddresses[1]: 10, testAddresses[2]: 10}
+	for address, expected := range expectedPicks {
+		if len(picks[address]) != expected {
+			t.Errorf("expected %s to be picked %d times but got %d", address, expected, len(picks[address]))
+		}
+	}
+
+	// The picks of the heaviest address are spread between the others
+	heaviestPicks := picks[testAddresses[0]]
+	for i := 2; i < len(heaviestPicks); i++ {
+		if heaviestPicks[i]-heaviestPicks[i-2] == 2 && heaviestPicks[i-1]-heaviestPicks[i-2] == 1 &&
+			heaviestPicks[i]-heaviestPicks[i-1] == 1 {
+			t.Fatalf("expected the picks of %s not to come in runs of 3", testAddresses[0])
+		}
+	}
+}
+
+func TestPayoutRotationErrors(t *testing.T) {
+	_, err := newPayoutRotation(nil, "", util.Bech32PrefixKaspaSim)
+	if err == nil {
+		t.Errorf("expected an error when no address is given")
+	}
+
+	_, err = newPayoutRotation([]string{"kaspasim:invalid"}, "", util.Bech32PrefixKaspaSim)
+	if err == nil {
+		t.Errorf("expected an error for an invalid address")
+	}
+
+	for _, line := range []string{testAddresses[0] + " 0", testAddresses[0] + " 1 2", testAddresses[0] + " x"} {
+		addressesFile := filepath.Join(t.TempDir(), "addresses")
+		err := os.WriteFile(addressesFile, []byte(line+"\n"), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %+v", err)
+		}
+		_, err = newPayoutRotation(nil, addressesFile, util.Bech32PrefixKaspaSim)
+		if err == nil {
+			t.Errorf("expected an error for the line %q", line)
+		}
+	}
+}
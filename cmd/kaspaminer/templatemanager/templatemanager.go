@@ -5,6 +5,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
 	"sync"
+	"sync/atomic"
 )
 
 var currentTemplate *externalapi.DomainBlock
@@ -12,17 +13,26 @@ var currentState *pow.State
 var isSynced bool
 var lock = &sync.Mutex{}
 
-// Get returns the template to work on
-func Get() (*externalapi.DomainBlock, *pow.State, bool) {
+// generation is incremented whenever the template is set, so miners can
+// cheaply tell whether the template they work on is still the current one
+var generation atomic.Uint64
+
+// Get returns the template to work on, along with its generation
+func Get() (*externalapi.DomainBlock, *pow.State, bool, uint64) {
 	lock.Lock()
 	defer lock.Unlock()
 	// Shallow copy the block so when the user replaces the header it won't affect the template here.
 	if currentTemplate == nil {
-		return nil, nil, false
+		return nil, nil, false, 0
 	}
 	block := *currentTemplate
 	state := *currentState
-	return &block, &state, isSynced
+	return &block, &state, isSynced, generation.Load()
+}
+
+// Generation returns the generation of the current template
+func Generation() uint64 {
+	return generation.Load()
 }
 
 // Set sets the current template to work on
@@ -36,5 +46,6 @@ func Set(template *appmessage.GetBlockTemplateResponseMessage) error {
 	currentTemplate = block
 	currentState = pow.NewState(block.Header.ToMutable())
 	isSynced = template.IsSynced
+	generation.Add(1)
 	return nil
 }
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxRequestSize bounds the lines miners send. Stratum requests are far smaller
+const maxRequestSize = 4096
+
+// defaultLoginTimeout is the time a connection has to subscribe and authorize
+// before it's dropped, so that idle sockets don't pile up
+const defaultLoginTimeout = 30 * time.Second
+
+const (
+	// maxWorkerNameLength fits an address followed by a rig name, as in
+	// <address>.<worker>
+	maxWorkerNameLength = 128
+
+	// maxWorkersPerConnection bounds the worker names a single connection
+	// may authorize as, since every name has stats and metric series of its own
+	maxWorkersPerConnection = 4
+)
+
+// Stratum error codes, as used by other stratum pools
+const (
+	errorCodeOther         = 20
+	errorCodeJobNotFound   = 21
+	errorCodeDuplicate     = 22
+	errorCodeLowDifficulty = 23
+	errorCodeUnauthorized  = 24
+	errorCodeNotSubscribed = 25
+)
+
+type stratumRequest struct {
+	ID     interface{}       `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type stratumResponse struct {
+	ID     interface{}   `json:"id"`
+	Result interface{}   `json:"result"`
+	Error  []interface{} `json:"error"`
+}
+
+type stratumNotification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+type stratumError struct {
+	code    int
+	message string
+}
+
+func (se *stratumError) toResponseError() []interface{} {
+	return []interface{}{se.code, se.message, nil}
+}
+
+// client is a connection of a stratum miner
+type client struct {
+	server        *stratumServer
+	conn          net.Conn
+	remoteAddress string
+	extranonce    uint64
+
+	writeLock sync.Mutex
+
+	lock         sync.Mutex
+	isSubscribed bool
+	workerName   string
+	difficulty   float64
+
+	// workerNames are all the worker names the client authorized as. The
+	// shares of the client are attributed to the last one, workerName
+	workerNames map[string]struct{}
+
+	// previousDifficulty is the difficulty before the last retarget. Shares
+	// that meet it are accepted until the next job, since miners may keep
+	// working with it until then
+	previousDifficulty float64
+
+	sharesSinceRetarget int
+	lastRetarget        time.Time
+}
+
+func newClient(server *stratumServer, conn net.Conn, extranonce uint64) *client {
+	return &client{
+		server:        server,
+		conn:          conn,
+		remoteAddress: conn.RemoteAddr().String(),
+		extranonce:    extranonce,
+		difficulty:    server.cfg.ShareDifficulty,
+		workerNames:   make(map[string]struct{}),
+	}
+}
+
+// handle serves the requests of the client until it disconnects
+func (c *client) handle() {
+	defer c.server.removeClient(c)
+	defer c.close()
+
+	// Once the client authorized, it may stay quiet for as long as it takes
+	// its miners to find a share
+	err := c.conn.SetReadDeadline(time.Now().Add(c.server.loginTimeout))
+	if err != nil {
+		log.Debugf("Error setting the login deadline of miner %s: %s", c.remoteAddress, err)
+		return
+	}
+	isLoggedIn := false
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, maxRequestSize), maxRequestSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		request := &stratumRequest{}
+		err := json.Unmarshal([]byte(line), request)
+		if err != nil {
+			log.Warnf("Malformed request from miner %s: %s", c.remoteAddress, err)
+			return
+		}
+
+		result, stratumErr := c.handleRequest(request)
+		response := &stratumResponse{ID: request.ID, Result: result}
+		if stratumErr != nil {
+			response.Result = nil
+			response.Error = stratumErr.toResponseError()
+		}
+		err = c.send(response)
+		if err != nil {
+			log.Debugf("Error responding to miner %s: %s", c.remoteAddress, err)
+			return
+		}
+
+		c.afterRequest(request, stratumErr)
+
+		if !isLoggedIn && c.isAuthorized() {
+			isLoggedIn = true
+			err = c.conn.SetReadDeadline(time.Time{})
+			if err != nil {
+				log.Debugf("Error clearing the login deadline of miner %s: %s", c.remoteAddress, err)
+				return
+			}
+		}
+	}
+	if errors.Is(scanner.Err(), os.ErrDeadlineExceeded) {
+		log.Debugf("Dropping miner %s, which didn't authorize within %s", c.remoteAddress, c.server.loginTimeout)
+	}
+}
+
+// releaseWorkers stops attributing stats to the workers the client authorized as
+func (c *client) releaseWorkers() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for workerName := range c.workerNames {
+		c.server.stats.removeConnection(workerName)
+	}
+	c.workerNames = make(map[string]struct{})
+	c.workerName = ""
+}
+
+func (c *client) close() {
+	err := c.conn.Close()
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		log.Debugf("Error closing the connection of miner %s: %s", c.remoteAddress, err)
+	}
+}
+
+func (c *client) send(message interface{}) error {
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_, err = c.conn.Write(append(messageBytes, '\n'))
+	return errors.WithStack(err)
+}
+
+func (c *client) notify(method string, params ...interface{}) {
+	err := c.send(&stratumNotification{Method: method, Params: params})
+	if err != nil {
+		log.Debugf("Error sending %s to miner %s: %s", method, c.remoteAddress, err)
+	}
+}
+
+func (c *client) handleRequest(request *stratumRequest) (interface{}, *stratumError) {
+	switch request.Method {
+	case "mining.subscribe":
+		return c.handleSubscribe()
+	case "mining.authorize":
+		return c.handleAuthorize(request.Params)
+	case "mining.submit":
+		return c.handleSubmit(request.Params)
+	case "mining.extranonce.subscribe":
+		return true, nil
+	default:
+		return nil, &stratumError{code: errorCodeOther, message: fmt.Sprintf("unknown method %s", request.Method)}
+	}
+}
+
+// afterRequest sends the notifications that have to follow the response to the given request
+func (c *client) afterRequest(request *stratumRequest, stratumErr *stratumError) {
+	if stratumErr != nil {
+		return
+	}
+
+	switch request.Method {
+	case "mining.subscribe":
+		extranonceSize := int(c.server.cfg.ExtranonceSize)
+		if extranonceSize > 0 {
+			c.notify("mining.set_extranonce", c.extranonceHex(), 8-extranonceSize)
+		}
+	case "mining.authorize":
+		c.lock.Lock()
+		difficulty := c.difficulty
+		c.lock.Unlock()
+		c.notify("mining.set_difficulty", difficulty)
+
+		currentJob := c.server.currentJob()
+		if currentJob != nil {
+			c.notifyJob(currentJob)
+		}
+	}
+}
+
+func (c *client) extranonceHex() string {
+	return fmt.Sprintf("%0*x", 2*int(c.server.cfg.ExtranonceSize), c.extranonce)
+}
+
+func (c *client) handleSubscribe() (interface{}, *stratumError) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.isSubscribed = true
+	return []interface{}{true, "EthereumStratum/1.0.0"}, nil
+}
+
+func (c *client) handleAuthorize(params []json.RawMessage) (interface{}, *stratumError) {
+	var workerName string
+	if len(params) < 1 || json.Unmarshal(params[0], &workerName) != nil || workerName == "" {
+		return nil, &stratumError{code: errorCodeOther, message: "the worker name is missing"}
+	}
+	if !isValidWorkerName(workerName) {
+		return nil, &stratumError{code: errorCodeOther, message: fmt.Sprintf("worker names are made of at most %d "+
+			"letters, digits and the characters '.', '_', '-' and ':'", maxWorkerNameLength)}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.isSubscribed {
+		return nil, &stratumError{code: errorCodeNotSubscribed, message: "not subscribed"}
+	}
+	if _, ok := c.workerNames[workerName]; !ok {
+		if len(c.workerNames) >= maxWorkersPerConnection {
+			return nil, &stratumError{code: errorCodeOther, message: fmt.Sprintf("a connection may authorize "+
+				"as at most %d workers", maxWorkersPerConnection)}
+		}
+		c.workerNames[workerName] = struct{}{}
+		c.server.stats.addConnection(workerName)
+	}
+	c.workerName = workerName
+	c.lastRetarget = time.Now()
+	c.server.stats.recordDifficulty(workerName, c.difficulty)
+	log.Infof("Miner %s authorized as worker %s", c.remoteAddress, workerName)
+	return true, nil
+}
+
+func isValidWorkerName(workerName string) bool {
+	if len(workerName) > maxWorkerNameLength {
+		return false
+	}
+	for _, character := range workerName {
+		isAlphanumeric := (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z') ||
+			(character >= '0' && character <= '9')
+		if !isAlphanumeric && !strings.ContainsRune("._-:", character) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *client) isAuthorized() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.workerName != ""
+}
+
+func (c *client) notifyJob(job *job) {
+	c.lock.Lock()
+	c.previousDifficulty = 0
+	c.lock.Unlock()
+
+	c.notify("mining.notify", job.notifyParams()...)
+}
+
+func (c *client) handleSubmit(params []json.RawMessage) (interface{}, *stratumError) {
+	c.lock.Lock()
+	workerName := c.workerName
+	shareDifficulty := c.difficulty
+	if c.previousDifficulty != 0 && c.previousDifficulty < shareDifficulty {
+		shareDifficulty = c.previousDifficulty
+	}
+	c.lock.Unlock()
+
+	if workerName == "" {
+		return nil, &stratumError{code: errorCodeUnauthorized, message: "unauthorized worker"}
+	}
+
+	var jobID, nonceString string
+	if len(params) < 3 || json.Unmarshal(params[1], &jobID) != nil || json.Unmarshal(params[2], &nonceString) != nil {
+		c.server.stats.recordShare(workerName, shareResultInvalid, shareDifficulty)
+		return nil, &stratumError{code: errorCodeOther, message: "malformed submission"}
+	}
+
+	job, ok := c.server.jobs.job(jobID)
+	if !ok {
+		c.server.stats.recordShare(workerName, shareResultStale, shareDifficulty)
+		return nil, &stratumError{code: errorCodeJobNotFound, message: "job not found"}
+	}
+
+	nonce, err := strconv.ParseUint(strings.TrimPrefix(nonceString, "0x"), 16, 64)
+	if err != nil {
+		c.server.stats.recordShare(workerName, shareResultInvalid, shareDifficulty)
+		return nil, &stratumError{code: errorCodeOther, message: "malformed nonce"}
+	}
+	extranonceSize := uint64(c.server.cfg.ExtranonceSize)
+	if extranonceSize > 0 && nonce>>(64-8*extranonceSize) != c.extranonce {
+		c.server.stats.recordShare(workerName, shareResultInvalid, shareDifficulty)
+		return nil, &stratumError{code: errorCodeOther, message: "the nonce doesn't start with the extranonce"}
+	}
+
+	// The proof of work is checked first, so that only valid shares are kept
+	// to detect duplicates by
+	powValue := job.powValue(nonce)
+	isBlock := powValue.Cmp(job.target) <= 0
+	if !isBlock && powValue.Cmp(shareTarget(shareDifficulty)) > 0 {
+		c.server.stats.recordShare(workerName, shareResultLowDifficulty, shareDifficulty)
+		return nil, &stratumError{code: errorCodeLowDifficulty, message: "low difficulty share"}
+	}
+
+	if !c.server.jobs.markSubmitted(job, nonce) {
+		c.server.stats.recordShare(workerName, shareResultDuplicate, shareDifficulty)
+		return nil, &stratumError{code: errorCodeDuplicate, message: "duplicate share"}
+	}
+
+	if isBlock {
+		c.server.submitBlockInBackground(job, nonce, workerName)
+	}
+
+	c.server.stats.recordShare(workerName, shareResultAccepted, shareDifficulty)
+	c.lock.Lock()
+	c.sharesSinceRetarget++
+	c.lock.Unlock()
+	return true, nil
+}
+
+// retarget adjusts the share difficulty of the client to its share rate,
+// once vardiffRetargetInterval passed since it was last adjusted
+func (c *client) retarget(now time.Time) {
+	sharesPerMinute := c.server.cfg.VardiffSharesPerMinute
+	if sharesPerMinute == 0 {
+		return
+	}
+
+	c.lock.Lock()
+	elapsed := now.Sub(c.lastRetarget)
+	if elapsed < vardiffRetargetInterval {
+		c.lock.Unlock()
+		return
+	}
+	newDifficulty := nextDifficulty(c.difficulty, c.sharesSinceRetarget, elapsed,
+		sharesPerMinute, c.server.cfg.MinShareDifficulty)
+	c.sharesSinceRetarget = 0
+	c.lastRetarget = now
+	if newDifficulty == c.difficulty {
+		c.lock.Unlock()
+		return
+	}
+	c.previousDifficulty = c.difficulty
+	c.difficulty = newDifficulty
+	workerName := c.workerName
+	c.lock.Unlock()
+
+	log.Debugf("Share difficulty of worker %s changed to %g", workerName, newDifficulty)
+	c.server.stats.recordDifficulty(workerName, newDifficulty)
+	c.notify("mining.set_difficulty", newDifficulty)
+}
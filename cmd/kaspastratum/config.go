@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/version"
+)
+
+const (
+	defaultLogFilename            = "kaspastratum.log"
+	defaultErrLogFilename         = "kaspastratum_err.log"
+	defaultListen                 = "0.0.0.0:5555"
+	defaultShareDifficulty        = 4
+	defaultMinShareDifficulty     = 0.01
+	defaultVardiffSharesPerMinute = 20
+	defaultExtranonceSize         = 2
+)
+
+var (
+	// Default configuration options
+	defaultAppDir     = util.AppDir("kaspastratum", false)
+	defaultLogFile    = filepath.Join(defaultAppDir, defaultLogFilename)
+	defaultErrLogFile = filepath.Join(defaultAppDir, defaultErrLogFilename)
+	defaultRPCServer  = "localhost"
+)
+
+type configFlags struct {
+	ShowVersion            bool    `short:"V" long:"version" description:"Display version information and exit"`
+	RPCServer              string  `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	Listen                 string  `long:"listen" description:"Interface/port to accept stratum connections on"`
+	MiningAddr             string  `long:"miningaddr" description:"Address to mine to"`
+	MineWhenNotSynced      bool    `long:"mine-when-not-synced" description:"Send jobs to the miners even if the node is not synced with the rest of the network."`
+	ShareDifficulty        float64 `long:"share-difficulty" description:"The share difficulty workers start with"`
+	MinShareDifficulty     float64 `long:"min-share-difficulty" description:"The lowest share difficulty vardiff may set"`
+	VardiffSharesPerMinute float64 `long:"vardiff-shares-per-minute" description:"The share rate vardiff aims every worker at. 0 disables vardiff"`
+	ExtranonceSize         uint8   `long:"extranonce-size" description:"The number of leading nonce bytes assigned to every miner, so miners don't repeat each other's work (0-3)"`
+	Metrics                string  `long:"metrics" description:"Serve Prometheus metrics of the workers on the given interface/port, for example localhost:2112"`
+	Profile                string  `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		RPCServer:              defaultRPCServer,
+		Listen:                 defaultListen,
+		ShareDifficulty:        defaultShareDifficulty,
+		MinShareDifficulty:     defaultMinShareDifficulty,
+		VardiffSharesPerMinute: defaultVardiffSharesPerMinute,
+		ExtranonceSize:         defaultExtranonceSize,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+
+	// Show the version and exit if the version flag was specified.
+	if cfg.ShowVersion {
+		appName := filepath.Base(os.Args[0])
+		appName = strings.TrimSuffix(appName, filepath.Ext(appName))
+		fmt.Println(appName, "version", version.Version())
+		os.Exit(0)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Profile != "" {
+		profilePort, err := strconv.Atoi(cfg.Profile)
+		if err != nil || profilePort < 1024 || profilePort > 65535 {
+			return nil, errors.New("The profile port must be between 1024 and 65535")
+		}
+	}
+
+	if cfg.Metrics != "" {
+		_, _, err := net.SplitHostPort(cfg.Metrics)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid --metrics address %s", cfg.Metrics)
+		}
+	}
+
+	if cfg.MiningAddr == "" {
+		return nil, errors.New("--miningaddr is required")
+	}
+
+	if cfg.MinShareDifficulty <= 0 {
+		return nil, errors.New("--min-share-difficulty must be positive")
+	}
+	if cfg.ShareDifficulty < cfg.MinShareDifficulty {
+		return nil, errors.New("--share-difficulty must be at least --min-share-difficulty")
+	}
+	if cfg.VardiffSharesPerMinute < 0 {
+		return nil, errors.New("--vardiff-shares-per-minute must not be negative")
+	}
+	if cfg.ExtranonceSize > maxExtranonceSize {
+		return nil, errors.Errorf("--extranonce-size must be at most %d", maxExtranonceSize)
+	}
+
+	initLog(defaultLogFile, defaultErrLogFile)
+
+	return cfg, nil
+}
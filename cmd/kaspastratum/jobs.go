@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/util/difficulty"
+)
+
+// maxJobs is the number of recent jobs whose shares are still accepted. Shares
+// of older jobs are rejected as stale
+const maxJobs = 32
+
+// job is a block template handed to the miners. Jobs only differ by the parts
+// of the header the miners hash, so a template that differs from the current
+// one only by its timestamp doesn't make a new job
+type job struct {
+	id         string
+	block      *externalapi.DomainBlock
+	prePowHash *externalapi.DomainHash
+	target     *big.Int
+
+	// powState is shared by all the shares of the job, since preparing it takes
+	// far longer than hashing a nonce with it
+	powState *pow.State
+
+	submittedNonces map[uint64]struct{}
+}
+
+// notifyParams returns the parameters of the mining.notify message of the job:
+// its ID, the pre-PoW hash as four little-endian 64-bit words, and the timestamp
+func (j *job) notifyParams() []interface{} {
+	prePowHashBytes := j.prePowHash.ByteArray()
+	var words [4]uint64
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(prePowHashBytes[i*8:])
+	}
+	return []interface{}{j.id, words, j.block.Header.TimeInMilliseconds()}
+}
+
+// jobManager keeps the recent jobs, and makes a new job out of every template
+// that needs one
+type jobManager struct {
+	lock      sync.Mutex
+	jobs      map[string]*job
+	jobIDs    []string
+	nextJobID uint64
+	current   *job
+	isSynced  bool
+}
+
+func newJobManager() *jobManager {
+	return &jobManager{jobs: make(map[string]*job)}
+}
+
+// setTemplate updates the current template. It returns the new job if the
+// template makes one, or nil otherwise
+func (jm *jobManager) setTemplate(template *appmessage.GetBlockTemplateResponseMessage) (*job, error) {
+	block, err := appmessage.RPCBlockToDomainBlock(template.Block)
+	if err != nil {
+		return nil, err
+	}
+	prePowHash := prePowHash(block.Header)
+
+	jm.lock.Lock()
+	defer jm.lock.Unlock()
+
+	jm.isSynced = template.IsSynced
+	if jm.current != nil && jm.current.prePowHash.Equal(prePowHash) {
+		return nil, nil
+	}
+
+	jm.nextJobID++
+	newJob := &job{
+		id:              strconv.FormatUint(jm.nextJobID, 16),
+		block:           block,
+		prePowHash:      prePowHash,
+		target:          difficulty.CompactToBig(block.Header.Bits()),
+		powState:        pow.NewState(block.Header.ToMutable()),
+		submittedNonces: make(map[uint64]struct{}),
+	}
+	jm.jobs[newJob.id] = newJob
+	jm.jobIDs = append(jm.jobIDs, newJob.id)
+	if len(jm.jobIDs) > maxJobs {
+		delete(jm.jobs, jm.jobIDs[0])
+		jm.jobIDs = jm.jobIDs[1:]
+	}
+	jm.current = newJob
+	return newJob, nil
+}
+
+// currentJob returns the most recent job, or nil if there's none yet
+func (jm *jobManager) currentJob() (*job, bool) {
+	jm.lock.Lock()
+	defer jm.lock.Unlock()
+
+	return jm.current, jm.isSynced
+}
+
+func (jm *jobManager) job(jobID string) (*job, bool) {
+	jm.lock.Lock()
+	defer jm.lock.Unlock()
+
+	job, ok := jm.jobs[jobID]
+	return job, ok
+}
+
+// markSubmitted marks the given nonce as submitted for the given job. It
+// returns false if it was already submitted
+func (jm *jobManager) markSubmitted(job *job, nonce uint64) bool {
+	jm.lock.Lock()
+	defer jm.lock.Unlock()
+
+	if _, ok := job.submittedNonces[nonce]; ok {
+		return false
+	}
+	job.submittedNonces[nonce] = struct{}{}
+	return true
+}
+
+// powValue returns the proof of work value of the job's block with the given nonce
+func (j *job) powValue(nonce uint64) *big.Int {
+	state := *j.powState
+	state.Nonce = nonce
+	return state.CalculateProofOfWorkValue()
+}
+
+// blockWithNonce returns the job's block with the given nonce
+func (j *job) blockWithNonce(nonce uint64) *externalapi.DomainBlock {
+	mutableHeader := j.block.Header.ToMutable()
+	mutableHeader.SetNonce(nonce)
+	block := *j.block
+	block.Header = mutableHeader.ToImmutable()
+	return &block
+}
+
+// prePowHash is the hash of the header with its timestamp and nonce zeroed,
+// which is the part of the header the miners get
+func prePowHash(header externalapi.BlockHeader) *externalapi.DomainHash {
+	mutableHeader := header.ToMutable()
+	mutableHeader.SetTimeInMilliseconds(0)
+	mutableHeader.SetNonce(0)
+	return consensushashing.HeaderHash(mutableHeader)
+}
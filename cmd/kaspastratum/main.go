@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaspanet/kaspad/util"
+
+	"github.com/kaspanet/kaspad/version"
+
+	"github.com/pkg/errors"
+
+	_ "net/http/pprof"
+
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+	"github.com/kaspanet/kaspad/infrastructure/os/signal"
+	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/kaspanet/kaspad/util/profiling"
+)
+
+func main() {
+	defer panics.HandlePanic(log, "MAIN", nil)
+	interrupt := signal.InterruptListener()
+
+	cfg, err := parseConfig()
+	if err != nil {
+		printErrorAndExit(errors.Errorf("Error parsing command-line arguments: %s", err))
+	}
+	defer backendLog.Close()
+
+	// Show version at startup.
+	log.Infof("Version %s", version.Version())
+
+	// Enable http profiling server if requested.
+	if cfg.Profile != "" {
+		profiling.Start(cfg.Profile, log)
+	}
+
+	if cfg.Metrics != "" {
+		metrics.Start(cfg.Metrics)
+	}
+
+	_, err = util.DecodeAddress(cfg.MiningAddr, cfg.ActiveNetParams.Prefix)
+	if err != nil {
+		printErrorAndExit(errors.Errorf("Error decoding mining address: %s", err))
+	}
+
+	client, err := newNodeClient(cfg)
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error connecting to the RPC server"))
+	}
+	defer client.Disconnect()
+
+	stratumServer := newStratumServer(cfg, client)
+	err = stratumServer.listen()
+	if err != nil {
+		printErrorAndExit(err)
+	}
+	defer stratumServer.close()
+
+	spawn("templatesLoop", func() {
+		templatesLoop(client, stratumServer)
+	})
+	spawn("stratumServer.serve", stratumServer.serve)
+	spawn("stratumServer.retargetLoop", stratumServer.retargetLoop)
+	spawn("stratumServer.statsLoop", stratumServer.statsLoop)
+
+	<-interrupt
+}
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%+v\n", err)
+	os.Exit(1)
+}
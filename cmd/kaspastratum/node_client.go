@@ -0,0 +1,91 @@
+package main
+
+import (
+	nativeerrors "errors"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+const nodeTimeout = 10 * time.Second
+
+type nodeClient struct {
+	*rpcclient.RPCClient
+
+	cfg                              *configFlags
+	newBlockTemplateNotificationChan chan struct{}
+}
+
+func newNodeClient(cfg *configFlags) (*nodeClient, error) {
+	rpcAddress, err := cfg.NetParams().NormalizeRPCServerAddress(cfg.RPCServer)
+	if err != nil {
+		return nil, err
+	}
+	rpcClient, err := rpcclient.NewRPCClient(rpcAddress)
+	if err != nil {
+		return nil, err
+	}
+	client := &nodeClient{
+		RPCClient:                        rpcClient,
+		cfg:                              cfg,
+		newBlockTemplateNotificationChan: make(chan struct{}, 1),
+	}
+	client.SetTimeout(nodeTimeout)
+	client.SetLogger(backendLog, logger.LevelTrace)
+
+	err = client.RegisterForNewBlockTemplateNotifications(func(_ *appmessage.NewBlockTemplateNotificationMessage) {
+		select {
+		case client.newBlockTemplateNotificationChan <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting new-block-template notifications")
+	}
+
+	log.Infof("Connected to %s", rpcAddress)
+	return client, nil
+}
+
+// templatesLoop refreshes the template of the stratum server whenever the node
+// notifies of a new one, and periodically in case a notification is missed.
+// Miners keep working on the last job while the node is unavailable, so
+// failures are only logged
+func templatesLoop(client *nodeClient, stratumServer *stratumServer) {
+	refreshTemplate := func() {
+		err := stratumServer.refreshTemplate()
+		if nativeerrors.Is(err, router.ErrTimeout) {
+			log.Warnf("Got timeout while requesting block template from %s: %s", client.Address(), err)
+			err = client.Reconnect()
+			if err != nil {
+				log.Warnf("Error reconnecting to %s: %s", client.Address(), err)
+			}
+			return
+		}
+		if nativeerrors.Is(err, router.ErrRouteClosed) {
+			log.Debugf("Got route is closed while requesting block template from %s. "+
+				"The client is most likely reconnecting", client.Address())
+			return
+		}
+		if err != nil {
+			log.Warnf("Error getting block template from %s: %s", client.Address(), err)
+		}
+	}
+
+	refreshTemplate()
+	const tickerTime = 500 * time.Millisecond
+	ticker := time.NewTicker(tickerTime)
+	for {
+		select {
+		case <-client.newBlockTemplateNotificationChan:
+			refreshTemplate()
+			ticker.Reset(tickerTime)
+		case <-ticker.C:
+			refreshTemplate()
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+// maxExtranonceSize leaves miners at least 5 nonce bytes to search through
+const maxExtranonceSize = 3
+
+// node is the part of the RPC client the stratum server uses
+type node interface {
+	GetBlockTemplate(miningAddress, extraData string) (*appmessage.GetBlockTemplateResponseMessage, error)
+	SubmitBlock(block *externalapi.DomainBlock) (appmessage.RejectReason, error)
+}
+
+// stratumServer hands out the block templates of the node to stratum miners
+// as jobs, and submits the blocks they solve to the node
+type stratumServer struct {
+	cfg   *configFlags
+	node  node
+	jobs  *jobManager
+	stats *statsTracker
+
+	listener     net.Listener
+	loginTimeout time.Duration
+
+	lock           sync.Mutex
+	clients        map[*client]struct{}
+	nextExtranonce uint32
+
+	// pendingSubmissions are the blocks being submitted to the node
+	pendingSubmissions sync.WaitGroup
+}
+
+func newStratumServer(cfg *configFlags, node node) *stratumServer {
+	return &stratumServer{
+		cfg:          cfg,
+		node:         node,
+		jobs:         newJobManager(),
+		stats:        newStatsTracker(),
+		clients:      make(map[*client]struct{}),
+		loginTimeout: defaultLoginTimeout,
+	}
+}
+
+// listen starts listening to stratum connections on the configured address
+func (s *stratumServer) listen() error {
+	listener, err := net.Listen("tcp", s.cfg.Listen)
+	if err != nil {
+		return errors.Wrapf(err, "error listening to TCP on %s", s.cfg.Listen)
+	}
+	s.listener = listener
+	log.Infof("Listening to stratum connections on %s", listener.Addr())
+	return nil
+}
+
+// serve accepts stratum connections until the listener is closed
+func (s *stratumServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			log.Warnf("Error accepting a stratum connection: %s", err)
+			continue
+		}
+
+		client := s.addClient(conn)
+		spawn("client.handle", client.handle)
+	}
+}
+
+// close stops accepting connections, disconnects the miners, and waits for
+// the blocks they found to be submitted
+func (s *stratumServer) close() error {
+	err := s.listener.Close()
+
+	s.lock.Lock()
+	for client := range s.clients {
+		client.close()
+	}
+	s.lock.Unlock()
+
+	s.pendingSubmissions.Wait()
+	return err
+}
+
+func (s *stratumServer) addClient(conn net.Conn) *client {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The extranonces of the clients only repeat once the extranonce space is
+	// exhausted, and then only for clients that were connected long before
+	extranonce := uint64(0)
+	if s.cfg.ExtranonceSize > 0 {
+		extranonce = uint64(s.nextExtranonce) % (1 << (8 * uint64(s.cfg.ExtranonceSize)))
+		s.nextExtranonce++
+	}
+
+	client := newClient(s, conn, extranonce)
+	s.clients[client] = struct{}{}
+	log.Infof("Miner %s connected", client.remoteAddress)
+	return client
+}
+
+func (s *stratumServer) removeClient(client *client) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.clients, client)
+	client.releaseWorkers()
+	log.Infof("Miner %s disconnected", client.remoteAddress)
+}
+
+func (s *stratumServer) authorizedClients() []*client {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	clients := make([]*client, 0, len(s.clients))
+	for client := range s.clients {
+		if client.isAuthorized() {
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
+// refreshTemplate gets a new block template from the node, and sends it to
+// the miners if it makes a new job
+func (s *stratumServer) refreshTemplate() error {
+	template, err := s.node.GetBlockTemplate(s.cfg.MiningAddr, "kaspastratum-"+version.Version())
+	if err != nil {
+		return err
+	}
+
+	newJob, err := s.jobs.setTemplate(template)
+	if err != nil {
+		return err
+	}
+	if newJob == nil {
+		return nil
+	}
+	if !template.IsSynced && !s.cfg.MineWhenNotSynced {
+		log.Warnf("Kaspad is not synced. Skipping current block template")
+		return nil
+	}
+
+	for _, client := range s.authorizedClients() {
+		client.notifyJob(newJob)
+	}
+	return nil
+}
+
+// currentJob returns the job new miners should start working on, if there's one
+func (s *stratumServer) currentJob() *job {
+	currentJob, isSynced := s.jobs.currentJob()
+	if !isSynced && !s.cfg.MineWhenNotSynced {
+		return nil
+	}
+	return currentJob
+}
+
+// submitBlockInBackground submits the block of the given job, solved with the
+// given nonce, to the node without holding up the shares of the miner meanwhile
+func (s *stratumServer) submitBlockInBackground(job *job, nonce uint64, workerName string) {
+	s.pendingSubmissions.Add(1)
+	spawn("stratumServer.submitBlock", func() {
+		defer s.pendingSubmissions.Done()
+		s.submitBlock(job, nonce, workerName)
+	})
+}
+
+// submitBlock submits the block of the given job, solved with the given nonce,
+// to the node
+func (s *stratumServer) submitBlock(job *job, nonce uint64, workerName string) {
+	block := job.blockWithNonce(nonce)
+	blockHash := consensushashing.BlockHash(block)
+	log.Infof("Worker %s found block %s", workerName, blockHash)
+
+	rejectReason, err := s.node.SubmitBlock(block)
+	if err != nil {
+		log.Warnf("Block %s found by worker %s was rejected (%s): %s", blockHash, workerName, rejectReason, err)
+		return
+	}
+	s.stats.recordBlock(workerName)
+}
+
+// retargetLoop periodically adjusts the share difficulty of every worker
+// to its hash rate
+func (s *stratumServer) retargetLoop() {
+	ticker := time.NewTicker(vardiffRetargetInterval / 3)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		for _, client := range s.authorizedClients() {
+			client.retarget(now)
+		}
+	}
+}
+
+// statsLoop periodically logs the stats of the workers
+func (s *stratumServer) statsLoop() {
+	const statsInterval = time.Minute
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.stats.logStats()
+	}
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+	"github.com/pkg/errors"
+)
+
+const (
+	// easyBits makes about every other hash solve the block
+	easyBits = 0x207fffff
+
+	// hardBits makes no hash the test tries solve the block
+	hardBits = 0x1e7fffff
+)
+
+type fakeNode struct {
+	lock            sync.Mutex
+	template        *appmessage.GetBlockTemplateResponseMessage
+	submittedBlocks []*externalapi.DomainBlock
+}
+
+func newFakeNode(bits uint32) *fakeNode {
+	node := &fakeNode{}
+	node.setTemplate(bits, 1)
+	return node
+}
+
+func (fn *fakeNode) setTemplate(bits uint32, daaScore uint64) {
+	rpcBlock := appmessage.DomainBlockToRPCBlock(dagconfig.SimnetParams.GenesisBlock)
+	rpcBlock.Header.Bits = bits
+	rpcBlock.Header.DAAScore = daaScore
+
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+	fn.template = appmessage.NewGetBlockTemplateResponseMessage(rpcBlock, true)
+}
+
+func (fn *fakeNode) GetBlockTemplate(_, _ string) (*appmessage.GetBlockTemplateResponseMessage, error) {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+	return fn.template, nil
+}
+
+func (fn *fakeNode) SubmitBlock(block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+	fn.submittedBlocks = append(fn.submittedBlocks, block)
+	return appmessage.RejectReasonNone, nil
+}
+
+func (fn *fakeNode) blocks() []*externalapi.DomainBlock {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+	return fn.submittedBlocks
+}
+
+func startTestServer(t *testing.T, node *fakeNode, shareDifficulty float64) *stratumServer {
+	return startTestServerWithLoginTimeout(t, node, shareDifficulty, defaultLoginTimeout)
+}
+
+func startTestServerWithLoginTimeout(t *testing.T, node *fakeNode, shareDifficulty float64,
+	loginTimeout time.Duration) *stratumServer {
+
+	cfg := &configFlags{
+		Listen:             "127.0.0.1:0",
+		MiningAddr:         "kaspasim:qzpj2cfa9m40w9m2cmr8pvfuqpp32mzzwsuw6ukhfduqpp32mzzws59e8fapc",
+		ShareDifficulty:    shareDifficulty,
+		MinShareDifficulty: shareDifficulty,
+		ExtranonceSize:     2,
+	}
+	stratumServer := newStratumServer(cfg, node)
+	stratumServer.loginTimeout = loginTimeout
+	err := stratumServer.listen()
+	if err != nil {
+		t.Fatalf("listen: %+v", err)
+	}
+	t.Cleanup(func() { stratumServer.close() })
+	go stratumServer.serve()
+
+	err = stratumServer.refreshTemplate()
+	if err != nil {
+		t.Fatalf("refreshTemplate: %+v", err)
+	}
+	return stratumServer
+}
+
+// simulatedMiner speaks stratum to the server the way mining software does
+type simulatedMiner struct {
+	t             *testing.T
+	conn          net.Conn
+	reader        *bufio.Reader
+	nextID        int
+	notifications []*stratumRequest
+}
+
+func connectMiner(t *testing.T, stratumServer *stratumServer) *simulatedMiner {
+	conn, err := net.Dial("tcp", stratumServer.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %+v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &simulatedMiner{t: t, conn: conn, reader: bufio.NewReader(conn)}
+}
+
+type minerResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  []interface{}   `json:"error"`
+}
+
+// call sends a request and returns its response, keeping the notifications
+// received meanwhile
+func (sm *simulatedMiner) call(method string, params ...interface{}) *minerResponse {
+	sm.nextID++
+	request, err := json.Marshal(map[string]interface{}{"id": sm.nextID, "method": method, "params": params})
+	if err != nil {
+		sm.t.Fatalf("Marshal: %+v", err)
+	}
+	_, err = sm.conn.Write(append(request, '\n'))
+	if err != nil {
+		sm.t.Fatalf("Write: %+v", err)
+	}
+
+	for {
+		line := sm.readLine()
+		notification := &stratumRequest{}
+		err = json.Unmarshal(line, notification)
+		if err == nil && notification.Method != "" {
+			sm.notifications = append(sm.notifications, notification)
+			continue
+		}
+		response := &minerResponse{}
+		err = json.Unmarshal(line, response)
+		if err != nil {
+			sm.t.Fatalf("Unmarshal %s: %+v", line, err)
+		}
+		if response.ID != sm.nextID {
+			sm.t.Fatalf("expected a response to request %d but got %s", sm.nextID, line)
+		}
+		return response
+	}
+}
+
+func (sm *simulatedMiner) readLine() []byte {
+	err := sm.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err != nil {
+		sm.t.Fatalf("SetReadDeadline: %+v", err)
+	}
+	line, err := sm.reader.ReadBytes('\n')
+	if err != nil {
+		sm.t.Fatalf("ReadBytes: %+v", err)
+	}
+	return line
+}
+
+// expectNotification returns the next notification of the given method
+func (sm *simulatedMiner) expectNotification(method string) []json.RawMessage {
+	for {
+		for i, notification := range sm.notifications {
+			if notification.Method == method {
+				sm.notifications = append(sm.notifications[:i], sm.notifications[i+1:]...)
+				return notification.Params
+			}
+		}
+		notification := &stratumRequest{}
+		err := json.Unmarshal(sm.readLine(), notification)
+		if err != nil {
+			sm.t.Fatalf("Unmarshal: %+v", err)
+		}
+		sm.notifications = append(sm.notifications, notification)
+	}
+}
+
+func (sm *simulatedMiner) expectJobID() string {
+	var jobID string
+	err := json.Unmarshal(sm.expectNotification("mining.notify")[0], &jobID)
+	if err != nil {
+		sm.t.Fatalf("Unmarshal: %+v", err)
+	}
+	return jobID
+}
+
+func (sm *simulatedMiner) login(workerName string) string {
+	response := sm.call("mining.subscribe", "simulated-miner/1.0")
+	if response.Error != nil {
+		sm.t.Fatalf("mining.subscribe failed: %v", response.Error)
+	}
+	response = sm.call("mining.authorize", workerName, "x")
+	if response.Error != nil {
+		sm.t.Fatalf("mining.authorize failed: %v", response.Error)
+	}
+	sm.expectNotification("mining.set_difficulty")
+	return sm.expectJobID()
+}
+
+func expectErrorCode(t *testing.T, response *minerResponse, expectedCode int) {
+	if len(response.Error) == 0 || response.Error[0] != float64(expectedCode) {
+		t.Fatalf("expected error code %d but got %v", expectedCode, response.Error)
+	}
+}
+
+func TestMiningEndToEnd(t *testing.T) {
+	node := newFakeNode(easyBits)
+	stratumServer := startTestServer(t, node, 1e-12)
+
+	miner := connectMiner(t, stratumServer)
+	expectErrorCode(t, miner.call("mining.submit", "wallet.rig", "1", "0000000000000001"), errorCodeUnauthorized)
+	jobID := miner.login("wallet.rig")
+
+	var extranonce string
+	err := json.Unmarshal(miner.expectNotification("mining.set_extranonce")[0], &extranonce)
+	if err != nil || extranonce != "0000" {
+		t.Fatalf("expected the first miner to get extranonce 0000 but got %s", extranonce)
+	}
+
+	// Every share meets the share difficulty, and about every other one solves the block
+	const submissions = 30
+	for nonce := uint64(1); nonce <= submissions; nonce++ {
+		response := miner.call("mining.submit", "wallet.rig", jobID, fmt.Sprintf("%016x", nonce))
+		if response.Error != nil {
+			t.Fatalf("share %d was rejected: %v", nonce, response.Error)
+		}
+	}
+	stratumServer.pendingSubmissions.Wait()
+	blocks := node.blocks()
+	if len(blocks) == 0 {
+		t.Fatalf("expected the miner to solve blocks")
+	}
+	for _, block := range blocks {
+		if !pow.NewState(block.Header.ToMutable()).CheckProofOfWork() {
+			t.Fatalf("submitted block with nonce %d doesn't meet its target", block.Header.Nonce())
+		}
+	}
+	if stratumServer.stats.shares("wallet.rig", shareResultAccepted) != submissions ||
+		stratumServer.stats.blocks("wallet.rig") != uint64(len(blocks)) {
+		t.Fatalf("unexpected stats of the worker")
+	}
+
+	expectErrorCode(t, miner.call("mining.submit", "wallet.rig", jobID, fmt.Sprintf("%016x", 1)), errorCodeDuplicate)
+	expectErrorCode(t, miner.call("mining.submit", "wallet.rig", "ffff", fmt.Sprintf("%016x", 100)), errorCodeJobNotFound)
+	expectErrorCode(t, miner.call("mining.submit", "wallet.rig", jobID, "ffff000000000001"), errorCodeOther)
+	if stratumServer.stats.shares("wallet.rig", shareResultDuplicate) != 1 ||
+		stratumServer.stats.shares("wallet.rig", shareResultStale) != 1 {
+		t.Fatalf("unexpected rejected shares stats of the worker")
+	}
+
+	// A second miner gets a nonce range of its own
+	secondMiner := connectMiner(t, stratumServer)
+	secondMiner.login("wallet.rig2")
+	err = json.Unmarshal(secondMiner.expectNotification("mining.set_extranonce")[0], &extranonce)
+	if err != nil || extranonce != "0001" {
+		t.Fatalf("expected the second miner to get extranonce 0001 but got %s", extranonce)
+	}
+
+	// Templates that differ only by their timestamp don't make new jobs
+	err = stratumServer.refreshTemplate()
+	if err != nil {
+		t.Fatalf("refreshTemplate: %+v", err)
+	}
+	node.setTemplate(easyBits, 2)
+	err = stratumServer.refreshTemplate()
+	if err != nil {
+		t.Fatalf("refreshTemplate: %+v", err)
+	}
+	newJobID := miner.expectJobID()
+	if newJobID == jobID {
+		t.Fatalf("expected a new job once the template changed")
+	}
+	response := miner.call("mining.submit", "wallet.rig", newJobID, fmt.Sprintf("%016x", 1))
+	if response.Error != nil {
+		t.Fatalf("share of the new job was rejected: %v", response.Error)
+	}
+}
+
+func TestLowDifficultyShares(t *testing.T) {
+	node := newFakeNode(hardBits)
+	stratumServer := startTestServer(t, node, 1e9)
+
+	miner := connectMiner(t, stratumServer)
+	jobID := miner.login("wallet.rig")
+	expectErrorCode(t, miner.call("mining.submit", "wallet.rig", jobID, fmt.Sprintf("%016x", 1)), errorCodeLowDifficulty)
+	if len(node.blocks()) != 0 {
+		t.Fatalf("expected no blocks to be submitted")
+	}
+
+	// Low difficulty shares aren't kept to detect duplicates by, so they can't grow the job
+	expectErrorCode(t, miner.call("mining.submit", "wallet.rig", jobID, fmt.Sprintf("%016x", 1)), errorCodeLowDifficulty)
+	job, ok := stratumServer.jobs.job(jobID)
+	if !ok {
+		t.Fatalf("job %s not found", jobID)
+	}
+	stratumServer.jobs.lock.Lock()
+	submittedNonces := len(job.submittedNonces)
+	stratumServer.jobs.lock.Unlock()
+	if submittedNonces != 0 {
+		t.Fatalf("expected no low difficulty share to be kept, got %d", submittedNonces)
+	}
+}
+
+func TestLoginTimeout(t *testing.T) {
+	node := newFakeNode(hardBits)
+	stratumServer := startTestServerWithLoginTimeout(t, node, 1e-12, 100*time.Millisecond)
+
+	idleMiner := connectMiner(t, stratumServer)
+	loggedInMiner := connectMiner(t, stratumServer)
+	loggedInMiner.login("wallet.rig")
+
+	err := idleMiner.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err != nil {
+		t.Fatalf("SetReadDeadline: %+v", err)
+	}
+	_, err = idleMiner.reader.ReadBytes('\n')
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("expected a connection that didn't log in to be dropped, got %v", err)
+	}
+
+	// A miner that logged in may stay quiet for longer
+	time.Sleep(300 * time.Millisecond)
+	response := loggedInMiner.call("mining.authorize", "wallet.rig", "x")
+	if response.Error != nil {
+		t.Fatalf("mining.authorize failed: %v", response.Error)
+	}
+}
+
+func TestWorkerNames(t *testing.T) {
+	node := newFakeNode(hardBits)
+	stratumServer := startTestServer(t, node, 1e-12)
+
+	miner := connectMiner(t, stratumServer)
+	miner.login("wallet.rig0")
+	expectErrorCode(t, miner.call("mining.authorize", strings.Repeat("a", maxWorkerNameLength+1), "x"), errorCodeOther)
+	expectErrorCode(t, miner.call("mining.authorize", "wallet rig", "x"), errorCodeOther)
+
+	for i := 1; i < maxWorkersPerConnection; i++ {
+		response := miner.call("mining.authorize", fmt.Sprintf("wallet.rig%d", i), "x")
+		if response.Error != nil {
+			t.Fatalf("mining.authorize failed: %v", response.Error)
+		}
+	}
+	expectErrorCode(t, miner.call("mining.authorize", "wallet.one-too-many", "x"), errorCodeOther)
+	if stratumServer.stats.isTracked("wallet.one-too-many") {
+		t.Fatalf("expected a worker that failed to authorize not to be tracked")
+	}
+
+	// Authorizing again as a worker the connection already authorized as is allowed
+	response := miner.call("mining.authorize", "wallet.rig0", "x")
+	if response.Error != nil {
+		t.Fatalf("mining.authorize failed: %v", response.Error)
+	}
+}
+
+func TestWorkerStatsRemovedOnDisconnect(t *testing.T) {
+	node := newFakeNode(hardBits)
+	stratumServer := startTestServer(t, node, 1e-12)
+
+	const workerName = "wallet.disconnecting"
+	miners := []*simulatedMiner{connectMiner(t, stratumServer), connectMiner(t, stratumServer)}
+	for i, miner := range miners {
+		jobID := miner.login(workerName)
+		// The nonce starts with the extranonce of the miner, which is its connection index
+		response := miner.call("mining.submit", workerName, jobID, fmt.Sprintf("%04x%012x", i, 1))
+		if response.Error != nil {
+			t.Fatalf("share was rejected: %v", response.Error)
+		}
+	}
+	stratumServer.stats.logStats()
+	if stratumServer.stats.shares(workerName, shareResultAccepted) != uint64(len(miners)) {
+		t.Fatalf("expected the shares of both connections to be attributed to the worker")
+	}
+	if !strings.Contains(metricsExposition(t), workerName) {
+		t.Fatalf("expected the metrics to include the worker")
+	}
+
+	waitForClients := func(expectedClients int) {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			stratumServer.lock.Lock()
+			clients := len(stratumServer.clients)
+			stratumServer.lock.Unlock()
+			if clients == expectedClients {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d connected miners but got %d", expectedClients, clients)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	miners[0].conn.Close()
+	waitForClients(1)
+	if !stratumServer.stats.isTracked(workerName) {
+		t.Fatalf("expected the worker to be tracked while it has a connection")
+	}
+
+	miners[1].conn.Close()
+	waitForClients(0)
+	if stratumServer.stats.isTracked(workerName) {
+		t.Fatalf("expected the worker not to be tracked once its last connection closed")
+	}
+	if strings.Contains(metricsExposition(t), workerName) {
+		t.Fatalf("expected the metric series of the worker to be deleted once its last connection closed")
+	}
+}
+
+func metricsExposition(t *testing.T) string {
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if recorder.Code != 200 {
+		t.Fatalf("expected the metrics to be served but got status %d", recorder.Code)
+	}
+	return recorder.Body.String()
+}
+
+func TestNextDifficulty(t *testing.T) {
+	tests := []struct {
+		name               string
+		shares             int
+		expectedDifficulty float64
+	}{
+		{name: "on target", shares: 20, expectedDifficulty: 8},
+		{name: "within tolerance", shares: 35, expectedDifficulty: 8},
+		{name: "too many shares", shares: 60, expectedDifficulty: 24},
+		{name: "far too many shares", shares: 1000, expectedDifficulty: 32},
+		{name: "too few shares", shares: 5, expectedDifficulty: 2},
+		{name: "no shares", shares: 0, expectedDifficulty: 2},
+	}
+	for _, test := range tests {
+		difficulty := nextDifficulty(8, test.shares, time.Minute, 20, 1)
+		if difficulty != test.expectedDifficulty {
+			t.Errorf("%s: expected difficulty %g but got %g", test.name, test.expectedDifficulty, difficulty)
+		}
+	}
+	if nextDifficulty(1, 0, time.Minute, 20, 0.5) != 0.5 {
+		t.Errorf("expected the difficulty not to go below the minimum")
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+type shareResult string
+
+const (
+	shareResultAccepted      shareResult = "accepted"
+	shareResultStale         shareResult = "stale"
+	shareResultDuplicate     shareResult = "duplicate"
+	shareResultLowDifficulty shareResult = "low-difficulty"
+	shareResultInvalid       shareResult = "invalid"
+)
+
+var (
+	sharesTotal = metrics.NewCounterVec("kaspastratum_shares_total",
+		"Number of shares submitted, by worker and result", "worker", "result")
+	blocksTotal = metrics.NewCounterVec("kaspastratum_blocks_total",
+		"Number of blocks found and accepted by the node, by worker", "worker")
+	workerDifficulty = metrics.NewGaugeVec("kaspastratum_worker_difficulty",
+		"Current share difficulty, by worker", "worker")
+	workerHashrate = metrics.NewGaugeVec("kaspastratum_worker_hashrate",
+		"Hash rate estimated from the accepted shares of the last stats interval, by worker", "worker")
+)
+
+type workerStats struct {
+	// connections is the number of connections authorized as the worker.
+	// The stats of the worker are dropped once its last connection closes
+	connections int
+
+	sharesByResult map[shareResult]uint64
+	blocksFound    uint64
+
+	// windowDifficulty is the sum of the difficulties of the shares accepted
+	// since windowStart, which the hash rate is estimated from
+	windowDifficulty float64
+	windowStart      time.Time
+	hashrate         float64
+}
+
+// statsTracker tracks the shares and blocks of every worker. Workers are
+// identified by the names they authorize with, so all the connections of a
+// worker share its stats. Only workers with open connections are tracked, so
+// that the stats and metric series don't grow with every name ever used
+type statsTracker struct {
+	lock    sync.Mutex
+	workers map[string]*workerStats
+}
+
+func newStatsTracker() *statsTracker {
+	return &statsTracker{workers: make(map[string]*workerStats)}
+}
+
+// addConnection starts tracking the given worker, if it isn't tracked already,
+// for a new connection authorized as it
+func (st *statsTracker) addConnection(workerName string) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	stats, ok := st.workers[workerName]
+	if !ok {
+		stats = &workerStats{
+			sharesByResult: make(map[shareResult]uint64),
+			windowStart:    time.Now(),
+		}
+		st.workers[workerName] = stats
+	}
+	stats.connections++
+}
+
+// removeConnection stops tracking the given worker, and deletes its metric
+// series, once the last connection authorized as it closes
+func (st *statsTracker) removeConnection(workerName string) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	stats, ok := st.workers[workerName]
+	if !ok {
+		return
+	}
+	stats.connections--
+	if stats.connections > 0 {
+		return
+	}
+	delete(st.workers, workerName)
+	sharesTotal.DeleteMatching("worker", workerName)
+	blocksTotal.Delete(workerName)
+	workerDifficulty.Delete(workerName)
+	workerHashrate.Delete(workerName)
+}
+
+func (st *statsTracker) recordShare(workerName string, result shareResult, shareDifficulty float64) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	stats, ok := st.workers[workerName]
+	if !ok {
+		return
+	}
+	stats.sharesByResult[result]++
+	if result == shareResultAccepted {
+		stats.windowDifficulty += shareDifficulty
+	}
+	sharesTotal.With(workerName, string(result)).Inc()
+}
+
+func (st *statsTracker) recordBlock(workerName string) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	stats, ok := st.workers[workerName]
+	if !ok {
+		return
+	}
+	stats.blocksFound++
+	blocksTotal.With(workerName).Inc()
+}
+
+func (st *statsTracker) recordDifficulty(workerName string, shareDifficulty float64) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if _, ok := st.workers[workerName]; !ok {
+		return
+	}
+	workerDifficulty.With(workerName).Set(shareDifficulty)
+}
+
+// logStats logs the stats of every worker, and starts a new hash rate window
+func (st *statsTracker) logStats() {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	workerNames := make([]string, 0, len(st.workers))
+	for workerName := range st.workers {
+		workerNames = append(workerNames, workerName)
+	}
+	sort.Strings(workerNames)
+
+	now := time.Now()
+	for _, workerName := range workerNames {
+		stats := st.workers[workerName]
+		stats.hashrate = stats.windowDifficulty * difficultyOneHashes / now.Sub(stats.windowStart).Seconds()
+		stats.windowDifficulty = 0
+		stats.windowStart = now
+		workerHashrate.With(workerName).Set(stats.hashrate)
+
+		rejectedShares := uint64(0)
+		for result, shares := range stats.sharesByResult {
+			if result != shareResultAccepted {
+				rejectedShares += shares
+			}
+		}
+		log.Infof("Worker %s: %.2f Mhash/s, %d accepted shares, %d rejected shares, %d blocks",
+			workerName, stats.hashrate/1e6, stats.sharesByResult[shareResultAccepted], rejectedShares, stats.blocksFound)
+	}
+}
+
+func (st *statsTracker) shares(workerName string, result shareResult) uint64 {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	stats, ok := st.workers[workerName]
+	if !ok {
+		return 0
+	}
+	return stats.sharesByResult[result]
+}
+
+func (st *statsTracker) blocks(workerName string) uint64 {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	stats, ok := st.workers[workerName]
+	if !ok {
+		return 0
+	}
+	return stats.blocksFound
+}
+
+func (st *statsTracker) isTracked(workerName string) bool {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	_, ok := st.workers[workerName]
+	return ok
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+const (
+	// vardiffRetargetInterval is the minimal time between two retargets of a worker
+	vardiffRetargetInterval = 30 * time.Second
+
+	// vardiffTolerance is how far off the aimed share rate the share rate of a
+	// worker may be without retargeting it, as a ratio in both directions
+	vardiffTolerance = 2.0
+
+	// vardiffMaxStep is the largest ratio the difficulty changes by in a single retarget
+	vardiffMaxStep = 4.0
+)
+
+var (
+	// maxTarget is the largest 256-bit target, which every hash meets
+	maxTarget = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	// difficultyOneHashes is the expected number of hashes per share of
+	// difficulty 1, the same as in other stratum pools
+	difficultyOneHashes = math.Pow(2, 32)
+)
+
+// shareTarget returns the target a share of the given difficulty has to meet.
+// Difficulty 1 means a share every 2^32 hashes on average
+func shareTarget(shareDifficulty float64) *big.Int {
+	maxTargetFloat := new(big.Float).SetInt(maxTarget)
+	target, _ := maxTargetFloat.Quo(maxTargetFloat, big.NewFloat(shareDifficulty*difficultyOneHashes)).Int(nil)
+	if target.Cmp(maxTarget) > 0 {
+		return new(big.Int).Set(maxTarget)
+	}
+	return target
+}
+
+// nextDifficulty returns the share difficulty that brings the share rate of a
+// worker that submitted the given number of shares in the given time closer to
+// sharesPerMinute. Rates within vardiffTolerance of the aimed one keep the
+// current difficulty, so the difficulty doesn't fluctuate with the luck of
+// the worker
+func nextDifficulty(currentDifficulty float64, shares int, elapsed time.Duration,
+	sharesPerMinute float64, minDifficulty float64) float64 {
+
+	ratio := float64(shares) / elapsed.Minutes() / sharesPerMinute
+	if ratio >= 1/vardiffTolerance && ratio <= vardiffTolerance {
+		return currentDifficulty
+	}
+	ratio = math.Max(ratio, 1/vardiffMaxStep)
+	ratio = math.Min(ratio, vardiffMaxStep)
+	return math.Max(currentDifficulty*ratio, minDifficulty)
+}
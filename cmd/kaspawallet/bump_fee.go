@@ -3,13 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/client"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
 	"github.com/pkg/errors"
 )
 
@@ -19,7 +16,7 @@ func bumpFee(conf *bumpFeeConfig) error {
 		return err
 	}
 
-	if len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
+	if !keysFile.IsWatchOnly() && len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
 		return errors.Errorf("Cannot use 'bump-fee' command for multisig wallet without all of the keys")
 	}
 
@@ -60,27 +57,12 @@ func bumpFee(conf *bumpFeeConfig) error {
 		return err
 	}
 
-	if len(conf.Password) == 0 {
-		conf.Password = keys.GetPassword("Password:")
-	}
-	mnemonics, err := keysFile.DecryptMnemonics(conf.Password)
+	signedTransactions, err := signTransactions(daemonClient, keysFile, conf.NetParams(), &conf.Password,
+		createUnsignedTransactionsResponse.Transactions)
 	if err != nil {
-		if strings.Contains(err.Error(), "message authentication failed") {
-			fmt.Fprintf(os.Stderr, "Password decryption failed. Sometimes this is a result of not "+
-				"specifying the same keys file used by the wallet daemon process.\n")
-		}
 		return err
 	}
 
-	signedTransactions := make([][]byte, len(createUnsignedTransactionsResponse.Transactions))
-	for i, unsignedTransaction := range createUnsignedTransactionsResponse.Transactions {
-		signedTransaction, err := libkaspawallet.Sign(conf.NetParams(), mnemonics, unsignedTransaction, keysFile.ECDSA)
-		if err != nil {
-			return err
-		}
-		signedTransactions[i] = signedTransaction
-	}
-
 	fmt.Printf("Broadcasting %d transaction(s)\n", len(signedTransactions))
 	// Since we waited for user input when getting the password, which could take unbound amount of time -
 	// create a new context for broadcast, to reset the timeout.
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/client"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/pkg/errors"
+)
+
+var utxoSelectionStrategies = map[string]pb.UtxoSelectionStrategy{
+	"largest-first":    pb.UtxoSelectionStrategy_LARGEST_FIRST,
+	"smallest-first":   pb.UtxoSelectionStrategy_SMALLEST_FIRST,
+	"branch-and-bound": pb.UtxoSelectionStrategy_BRANCH_AND_BOUND,
+	"privacy":          pb.UtxoSelectionStrategy_PRIVACY,
+}
+
+// coinControl returns the coin control the flags ask for
+func (flags *coinControlFlags) coinControl() (*pb.CoinControl, error) {
+	strategy, ok := utxoSelectionStrategies[flags.UTXOSelection]
+	if !ok {
+		return nil, errors.Errorf("unknown UTXO selection strategy %s", flags.UTXOSelection)
+	}
+	include, err := parseOutpoints(flags.IncludeUTXOs)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := parseOutpoints(flags.ExcludeUTXOs)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CoinControl{Include: include, Exclude: exclude, Strategy: strategy}, nil
+}
+
+// parseOutpoints parses outpoints given as <transaction ID>:<index>
+func parseOutpoints(outpointStrings []string) ([]*pb.Outpoint, error) {
+	outpoints := make([]*pb.Outpoint, len(outpointStrings))
+	for i, outpointString := range outpointStrings {
+		separatorIndex := strings.LastIndexByte(outpointString, ':')
+		if separatorIndex < 0 {
+			return nil, errors.Errorf("UTXO %s is not of the form <transaction ID>:<index>", outpointString)
+		}
+		index, err := strconv.ParseUint(outpointString[separatorIndex+1:], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "UTXO %s has an invalid index", outpointString)
+		}
+		outpoints[i] = &pb.Outpoint{TransactionId: outpointString[:separatorIndex], Index: uint32(index)}
+	}
+	return outpoints, nil
+}
+
+func freezeUTXOs(conf *freezeUTXOsConfig) error {
+	outpoints, err := parseOutpoints(conf.UTXOs)
+	if err != nil {
+		return err
+	}
+
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	_, err = daemonClient.FreezeUTXOs(ctx, &pb.FreezeUTXOsRequest{Outpoints: outpoints})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Froze %d UTXOs\n", len(outpoints))
+	return nil
+}
+
+func unfreezeUTXOs(conf *unfreezeUTXOsConfig) error {
+	outpoints, err := parseOutpoints(conf.UTXOs)
+	if err != nil {
+		return err
+	}
+
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	_, err = daemonClient.UnfreezeUTXOs(ctx, &pb.UnfreezeUTXOsRequest{Outpoints: outpoints})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Unfroze %d UTXOs\n", len(outpoints))
+	return nil
+}
+
+func frozenUTXOs(conf *frozenUTXOsConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	response, err := daemonClient.GetFrozenUTXOs(ctx, &pb.GetFrozenUTXOsRequest{})
+	if err != nil {
+		return err
+	}
+
+	if len(response.Outpoints) == 0 {
+		fmt.Println("No UTXOs are frozen")
+		return nil
+	}
+	for _, outpoint := range response.Outpoints {
+		fmt.Printf("%s:%d\n", outpoint.TransactionId, outpoint.Index)
+	}
+	return nil
+}
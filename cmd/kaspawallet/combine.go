@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/pskt"
+	"github.com/pkg/errors"
+)
+
+func combine(conf *combineConfig) error {
+	transactionsHexes := conf.Transactions
+	for _, transactionFile := range conf.TransactionFiles {
+		transactionsHexBytes, err := os.ReadFile(transactionFile)
+		if err != nil {
+			return errors.Wrapf(err, "Could not read hex from %s", transactionFile)
+		}
+		transactionsHexes = append(transactionsHexes, strings.TrimSpace(string(transactionsHexBytes)))
+	}
+	if len(transactionsHexes) < 2 {
+		return errors.Errorf("The transactions of at least two cosigners are required")
+	}
+
+	// cosignerPSKTs[i][j] is transaction j, as signed by cosigner i
+	cosignerPSKTs := make([][]*pskt.PSKT, len(transactionsHexes))
+	for i, transactionsHex := range transactionsHexes {
+		var err error
+		cosignerPSKTs[i], err = server.DecodePSKTsFromHex(transactionsHex)
+		if err != nil {
+			return err
+		}
+		if len(cosignerPSKTs[i]) != len(cosignerPSKTs[0]) {
+			return errors.Errorf("Cosigner %d has %d transactions while cosigner 1 has %d",
+				i+1, len(cosignerPSKTs[i]), len(cosignerPSKTs[0]))
+		}
+	}
+
+	combinedTransactions := make([][]byte, len(cosignerPSKTs[0]))
+	areAllTransactionsSigned := true
+	for j := range combinedTransactions {
+		transactionPSKTs := make([]*pskt.PSKT, len(cosignerPSKTs))
+		for i := range cosignerPSKTs {
+			transactionPSKTs[i] = cosignerPSKTs[i][j]
+		}
+		combined, err := pskt.Combine(transactionPSKTs...)
+		if err != nil {
+			return errors.Wrapf(err, "Could not combine transaction #%d", j+1)
+		}
+		combinedTransactions[j] = combined.Serialize()
+
+		err = combined.Clone().Finalize()
+		if err != nil {
+			areAllTransactionsSigned = false
+		}
+	}
+
+	if areAllTransactionsSigned {
+		fmt.Fprintln(os.Stderr, "The transaction has all of its signatures and can be finalized")
+	} else {
+		fmt.Fprintln(os.Stderr, "Successfully combined the signatures. The transaction still misses signatures")
+	}
+	fmt.Println(server.EncodeTransactionsToHex(combinedTransactions))
+	return nil
+}
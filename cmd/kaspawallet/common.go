@@ -3,7 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 const daemonTimeout = 2 * time.Minute
@@ -12,3 +15,23 @@ func printErrorAndExit(err error) {
 	fmt.Fprintf(os.Stderr, "%s\n", err)
 	os.Exit(1)
 }
+
+// readTransactionsHex returns the hex encoded transactions given either
+// directly or in a file
+func readTransactionsHex(transactionsHex string, transactionsFile string) (string, error) {
+	if transactionsHex == "" && transactionsFile == "" {
+		return "", errors.Errorf("Either --transaction or --transaction-file is required")
+	}
+	if transactionsHex != "" && transactionsFile != "" {
+		return "", errors.Errorf("Both --transaction and --transaction-file cannot be passed at the same time")
+	}
+
+	if transactionsFile != "" {
+		transactionsHexBytes, err := os.ReadFile(transactionsFile)
+		if err != nil {
+			return "", errors.Wrapf(err, "Could not read hex from %s", transactionsFile)
+		}
+		transactionsHex = string(transactionsHexBytes)
+	}
+	return strings.TrimSpace(transactionsHex), nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/pkg/errors"
@@ -27,6 +28,15 @@ const (
 	bumpFeeSubCmd                   = "bump-fee"
 	bumpFeeUnsignedSubCmd           = "bump-fee-unsigned"
 	broadcastReplacementSubCmd      = "broadcast-replacement"
+	historySubCmd                   = "history"
+	setLabelSubCmd                  = "set-label"
+	combineSubCmd                   = "combine"
+	finalizeSubCmd                  = "finalize"
+	inspectSubCmd                   = "inspect"
+	freezeUTXOsSubCmd               = "freeze-utxos"
+	unfreezeUTXOsSubCmd             = "unfreeze-utxos"
+	frozenUTXOsSubCmd               = "frozen-utxos"
+	consolidationStatusSubCmd       = "consolidation-status"
 )
 
 const (
@@ -40,14 +50,16 @@ type configFlags struct {
 }
 
 type createConfig struct {
-	KeysFile          string `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kaspawallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kaspawallet\\key.json (Windows))"`
-	Password          string `long:"password" short:"p" description:"Wallet password"`
-	Yes               bool   `long:"yes" short:"y" description:"Assume \"yes\" to all questions"`
-	MinimumSignatures uint32 `long:"min-signatures" short:"m" description:"Minimum required signatures" default:"1"`
-	NumPrivateKeys    uint32 `long:"num-private-keys" short:"k" description:"Number of private keys" default:"1"`
-	NumPublicKeys     uint32 `long:"num-public-keys" short:"n" description:"Total number of keys" default:"1"`
-	ECDSA             bool   `long:"ecdsa" description:"Create an ECDSA wallet"`
-	Import            bool   `long:"import" short:"i" description:"Import private keys (as opposed to generating them)"`
+	KeysFile           string   `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kaspawallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kaspawallet\\key.json (Windows))"`
+	Password           string   `long:"password" short:"p" description:"Wallet password"`
+	Yes                bool     `long:"yes" short:"y" description:"Assume \"yes\" to all questions"`
+	MinimumSignatures  uint32   `long:"min-signatures" short:"m" description:"Minimum required signatures" default:"1"`
+	NumPrivateKeys     uint32   `long:"num-private-keys" short:"k" description:"Number of private keys" default:"1"`
+	NumPublicKeys      uint32   `long:"num-public-keys" short:"n" description:"Total number of keys" default:"1"`
+	ECDSA              bool     `long:"ecdsa" description:"Create an ECDSA wallet"`
+	Import             bool     `long:"import" short:"i" description:"Import private keys (as opposed to generating them)"`
+	WatchOnly          bool     `long:"watch-only" description:"Create a watch-only wallet, which has only the extended public keys and can't sign"`
+	ExtendedPublicKeys []string `long:"xpub" description:"Extended public key of a cosigner. Repeat multiple times (adding --xpub before each) to give several keys. Keys that aren't given are asked for"`
 	config.NetworkFlags
 }
 
@@ -70,9 +82,17 @@ type sendConfig struct {
 	FeeRate                  float64  `long:"fee-rate" short:"r" description:"Fee rate in Sompi/gram to use for the transaction. This option will override any fee estimate from the connected node."`
 	MaxFee                   uint64   `long:"max-fee" short:"x" description:"Maximum fee in Sompi (not Sompi/gram) to use for the transaction. The wallet will take the minimum between the fee estimate from the connected node and this value. If no other fee policy is specified, it will set the max fee to 1 KAS"`
 	Verbose                  bool     `long:"show-serialized" short:"s" description:"Show a list of hex encoded sent transactions"`
+	coinControlFlags
 	config.NetworkFlags
 }
 
+// coinControlFlags are the flags of the commands that select UTXOs to spend
+type coinControlFlags struct {
+	IncludeUTXOs  []string `long:"include-utxo" description:"A UTXO, given as <transaction ID>:<index>, that the transaction must spend. Repeat multiple times (adding --include-utxo before each) to include several UTXOs"`
+	ExcludeUTXOs  []string `long:"exclude-utxo" description:"A UTXO, given as <transaction ID>:<index>, that the transaction must not spend. Repeat multiple times (adding --exclude-utxo before each) to exclude several UTXOs"`
+	UTXOSelection string   `long:"utxo-selection" description:"How to select the UTXOs to spend: largest-first to spend few UTXOs, smallest-first to consolidate small UTXOs, branch-and-bound to avoid a change output when possible, or privacy to spend from as few addresses as possible" default:"largest-first" choice:"largest-first" choice:"smallest-first" choice:"branch-and-bound" choice:"privacy"`
+}
+
 type sweepConfig struct {
 	PrivateKey    string `long:"private-key" short:"k" description:"Private key in hex format"`
 	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
@@ -89,6 +109,7 @@ type createUnsignedTransactionConfig struct {
 	MaxFeeRate               float64  `long:"max-fee-rate" short:"m" description:"Maximum fee rate in Sompi/gram to use for the transaction. The wallet will take the minimum between the fee rate estimate from the connected node and this value."`
 	FeeRate                  float64  `long:"fee-rate" short:"r" description:"Fee rate in Sompi/gram to use for the transaction. This option will override any fee estimate from the connected node."`
 	MaxFee                   uint64   `long:"max-fee" short:"x" description:"Maximum fee in Sompi (not Sompi/gram) to use for the transaction. The wallet will take the minimum between the fee estimate from the connected node and this value. If no other fee policy is specified, it will set the max fee to 1 KAS"`
+	coinControlFlags
 	config.NetworkFlags
 }
 
@@ -97,6 +118,7 @@ type signConfig struct {
 	Password        string `long:"password" short:"p" description:"Wallet password"`
 	Transaction     string `long:"transaction" short:"t" description:"The unsigned transaction(s) to sign on (encoded in hex)"`
 	TransactionFile string `long:"transaction-file" short:"F" description:"The file containing the unsigned transaction(s) to sign on (encoded in hex)"`
+	Signer          string `long:"signer" description:"External signer to sign with instead of the keys file: exec:<command line> to run it, or unix:<path> to connect to its socket"`
 	config.NetworkFlags
 }
 
@@ -115,6 +137,31 @@ type parseConfig struct {
 	config.NetworkFlags
 }
 
+type combineConfig struct {
+	Transactions     []string `long:"transaction" short:"t" description:"The partially signed transaction(s) of a cosigner (encoded in hex). Repeat for every cosigner"`
+	TransactionFiles []string `long:"transaction-file" short:"F" description:"The file containing the partially signed transaction(s) of a cosigner (encoded in hex). Repeat for every cosigner"`
+	config.NetworkFlags
+}
+
+type finalizeConfig struct {
+	Transaction     string `long:"transaction" short:"t" description:"The signed transaction(s) to finalize (encoded in hex)"`
+	TransactionFile string `long:"transaction-file" short:"F" description:"The file containing the signed transaction(s) to finalize (encoded in hex)"`
+	config.NetworkFlags
+}
+
+const (
+	inspectFormatJSON   = "json"
+	inspectFormatPSKT   = "pskt"
+	inspectFormatLegacy = "legacy"
+)
+
+type inspectConfig struct {
+	Transaction     string `long:"transaction" short:"t" description:"The partially signed transaction(s) to inspect (encoded in hex)"`
+	TransactionFile string `long:"transaction-file" short:"F" description:"The file containing the partially signed transaction(s) to inspect (encoded in hex)"`
+	Format          string `long:"format" description:"Output format: json for the JSON view of the PSKTs, pskt to convert to PSKT hex, or legacy to convert to the kaspawallet partially signed transaction hex" default:"json" choice:"json" choice:"pskt" choice:"legacy"`
+	config.NetworkFlags
+}
+
 type showAddressesConfig struct {
 	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
 	config.NetworkFlags
@@ -125,6 +172,37 @@ type newAddressConfig struct {
 	config.NetworkFlags
 }
 
+type historyConfig struct {
+	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	Verbose       bool   `long:"verbose" short:"v" description:"Show the outputs of every transaction"`
+	config.NetworkFlags
+}
+
+type freezeUTXOsConfig struct {
+	DaemonAddress string   `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	UTXOs         []string `long:"utxo" short:"u" description:"A UTXO to freeze, given as <transaction ID>:<index>. Repeat multiple times (adding -u before each) to freeze several UTXOs" required:"true"`
+	config.NetworkFlags
+}
+
+type unfreezeUTXOsConfig struct {
+	DaemonAddress string   `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	UTXOs         []string `long:"utxo" short:"u" description:"A UTXO to unfreeze, given as <transaction ID>:<index>. Repeat multiple times (adding -u before each) to unfreeze several UTXOs" required:"true"`
+	config.NetworkFlags
+}
+
+type frozenUTXOsConfig struct {
+	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	config.NetworkFlags
+}
+
+type setLabelConfig struct {
+	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	TxID          string `long:"txid" description:"The ID of the transaction to label"`
+	Address       string `long:"address" description:"The wallet address to label"`
+	Label         string `long:"label" description:"The label to set. An empty label removes the existing one"`
+	config.NetworkFlags
+}
+
 type startDaemonConfig struct {
 	KeysFile  string `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kaspawallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kaspawallet\\key.json (Windows))"`
 	Password  string `long:"password" short:"p" description:"Wallet password"`
@@ -132,6 +210,19 @@ type startDaemonConfig struct {
 	Listen    string `long:"listen" short:"l" description:"Address to listen on (default: 0.0.0.0:8082)"`
 	Timeout   uint32 `long:"wait-timeout" short:"w" description:"Waiting timeout for RPC calls, seconds (default: 30 s)"`
 	Profile   string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	Signer    string `long:"signer" description:"External signer to sign with instead of the keys file: exec:<command line> to run it, or unix:<path> to connect to its socket"`
+
+	Consolidate             bool          `long:"consolidate" description:"Consolidate small UTXOs in the background. Unless --signer is given, the consolidation transactions are signed with the keys file, decrypted with --password"`
+	ConsolidationInterval   time.Duration `long:"consolidation-interval" description:"Time between consolidation runs" default:"1h"`
+	ConsolidationMaxFeeRate float64       `long:"consolidation-max-fee-rate" description:"Consolidate only when the fee rate estimate of the node, in Sompi/gram, is at most this" default:"1"`
+	ConsolidationFeeBudget  string        `long:"consolidation-fee-budget" description:"Maximum total fee, in Kaspa, of the consolidation transactions of any 24 hours" default:"1"`
+	ConsolidationThreshold  string        `long:"consolidation-threshold" description:"Consolidate UTXOs smaller than this amount in Kaspa" default:"10"`
+	ConsolidationMinUTXOs   uint32        `long:"consolidation-min-utxos" description:"Consolidate only when there are at least this many small UTXOs" default:"100"`
+	config.NetworkFlags
+}
+
+type consolidationStatusConfig struct {
+	DaemonAddress string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
 	config.NetworkFlags
 }
 
@@ -240,6 +331,46 @@ func parseCommandLine() (subCommand string, config interface{}) {
 	parser.AddCommand(broadcastReplacementSubCmd, "Broadcast the given transaction replacement",
 		"Broadcast the given transaction replacement", broadcastConf)
 
+	historyConf := &historyConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(historySubCmd, "Shows the transactions sent and received by the wallet",
+		"Shows the transactions sent and received by the wallet since its daemon started keeping its history, "+
+			"along with their confirmation status and labels", historyConf)
+
+	setLabelConf := &setLabelConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(setLabelSubCmd, "Labels a wallet transaction or address",
+		"Labels a wallet transaction or address. The labels are shown by the 'history' command", setLabelConf)
+
+	freezeUTXOsConf := &freezeUTXOsConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(freezeUTXOsSubCmd, "Freeze UTXOs",
+		"Freeze UTXOs so that the wallet doesn't spend them, not even when they're included with --include-utxo. "+
+			"UTXOs stay frozen until they're unfrozen, even if the daemon restarts", freezeUTXOsConf)
+	unfreezeUTXOsConf := &unfreezeUTXOsConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(unfreezeUTXOsSubCmd, "Unfreeze UTXOs", "Unfreeze UTXOs that were frozen with 'freeze-utxos'",
+		unfreezeUTXOsConf)
+	frozenUTXOsConf := &frozenUTXOsConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(frozenUTXOsSubCmd, "Shows the frozen UTXOs", "Shows the UTXOs that were frozen with 'freeze-utxos'",
+		frozenUTXOsConf)
+
+	consolidationStatusConf := &consolidationStatusConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(consolidationStatusSubCmd, "Shows the progress of UTXO consolidation",
+		"Shows the progress of the consolidation of small UTXOs, which 'start-daemon --consolidate' enables",
+		consolidationStatusConf)
+
+	combineConf := &combineConfig{}
+	parser.AddCommand(combineSubCmd, "Combine the signatures of the cosigners of partially signed transactions",
+		"Combine the signatures that several cosigners added to the same partially signed transaction(s) into a PSKT",
+		combineConf)
+
+	finalizeConf := &finalizeConfig{}
+	parser.AddCommand(finalizeSubCmd, "Finalize signed transactions",
+		"Build the final signature scripts of partially signed transaction(s) that have all of their signatures. "+
+			"The finalized PSKT can then be broadcast", finalizeConf)
+
+	inspectConf := &inspectConfig{}
+	parser.AddCommand(inspectSubCmd, "Show or convert partially signed transactions",
+		"Show the JSON view of PSKTs or of kaspawallet partially signed transactions, or convert them "+
+			"between the two formats", inspectConf)
+
 	_, err := parser.Parse()
 	if err != nil {
 		var flagsErr *flags.Error
@@ -258,6 +389,10 @@ func parseCommandLine() (subCommand string, config interface{}) {
 		if err != nil {
 			printErrorAndExit(err)
 		}
+		err = validateCreateConfig(createConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
 		config = createConf
 	case balanceSubCmd:
 		combineNetworkFlags(&balanceConf.NetworkFlags, &cfg.NetworkFlags)
@@ -350,6 +485,11 @@ func parseCommandLine() (subCommand string, config interface{}) {
 		if err != nil {
 			printErrorAndExit(err)
 		}
+
+		err = validateStartDaemonConfig(startDaemonConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
 		config = startDaemonConf
 	case versionSubCmd:
 	case getDaemonVersionSubCmd:
@@ -367,6 +507,53 @@ func parseCommandLine() (subCommand string, config interface{}) {
 		}
 
 		config = bumpFeeConf
+	case historySubCmd:
+		combineNetworkFlags(&historyConf.NetworkFlags, &cfg.NetworkFlags)
+		err := historyConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = historyConf
+	case setLabelSubCmd:
+		combineNetworkFlags(&setLabelConf.NetworkFlags, &cfg.NetworkFlags)
+		err := setLabelConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = validateSetLabelConfig(setLabelConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = setLabelConf
+	case consolidationStatusSubCmd:
+		combineNetworkFlags(&consolidationStatusConf.NetworkFlags, &cfg.NetworkFlags)
+		err := consolidationStatusConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = consolidationStatusConf
+	case freezeUTXOsSubCmd:
+		combineNetworkFlags(&freezeUTXOsConf.NetworkFlags, &cfg.NetworkFlags)
+		err := freezeUTXOsConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = freezeUTXOsConf
+	case unfreezeUTXOsSubCmd:
+		combineNetworkFlags(&unfreezeUTXOsConf.NetworkFlags, &cfg.NetworkFlags)
+		err := unfreezeUTXOsConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = unfreezeUTXOsConf
+	case frozenUTXOsSubCmd:
+		combineNetworkFlags(&frozenUTXOsConf.NetworkFlags, &cfg.NetworkFlags)
+		err := frozenUTXOsConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = frozenUTXOsConf
 	case bumpFeeUnsignedSubCmd:
 		combineNetworkFlags(&bumpFeeUnsignedConf.NetworkFlags, &cfg.NetworkFlags)
 		err := bumpFeeUnsignedConf.ResolveNetwork(parser)
@@ -380,11 +567,60 @@ func parseCommandLine() (subCommand string, config interface{}) {
 		}
 
 		config = bumpFeeUnsignedConf
+	case combineSubCmd:
+		combineNetworkFlags(&combineConf.NetworkFlags, &cfg.NetworkFlags)
+		err := combineConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = combineConf
+	case finalizeSubCmd:
+		combineNetworkFlags(&finalizeConf.NetworkFlags, &cfg.NetworkFlags)
+		err := finalizeConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = finalizeConf
+	case inspectSubCmd:
+		combineNetworkFlags(&inspectConf.NetworkFlags, &cfg.NetworkFlags)
+		err := inspectConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = inspectConf
 	}
 
 	return parser.Command.Active.Name, config
 }
 
+func validateCreateConfig(conf *createConfig) error {
+	if conf.WatchOnly {
+		if conf.Import {
+			return errors.New("--import cannot be used with --watch-only")
+		}
+		conf.NumPrivateKeys = 0
+	}
+
+	// The given extended public keys take the places of the cosigners, so
+	// giving more of them than --num-public-keys expects raises it
+	numKeys := conf.NumPrivateKeys + uint32(len(conf.ExtendedPublicKeys))
+	if conf.NumPublicKeys < numKeys {
+		conf.NumPublicKeys = numKeys
+	}
+
+	if conf.NumPublicKeys == 0 {
+		return errors.New("a wallet must have at least one key")
+	}
+	if conf.NumPrivateKeys > conf.NumPublicKeys {
+		return errors.New("--num-private-keys cannot be greater than --num-public-keys")
+	}
+	if conf.MinimumSignatures == 0 || conf.MinimumSignatures > conf.NumPublicKeys {
+		return errors.Errorf("--min-signatures must be between 1 and the number of keys (%d)", conf.NumPublicKeys)
+	}
+
+	return nil
+}
+
 func validateCreateUnsignedTransactionConf(conf *createUnsignedTransactionConfig) error {
 	if (!conf.IsSendAll && conf.SendAmount == "") ||
 		(conf.IsSendAll && conf.SendAmount != "") {
@@ -476,3 +712,26 @@ func combineNetworkFlags(dst, src *config.NetworkFlags) {
 		dst.OverrideDAGParamsFile = src.OverrideDAGParamsFile
 	}
 }
+
+func validateStartDaemonConfig(conf *startDaemonConfig) error {
+	if !conf.Consolidate {
+		return nil
+	}
+	if conf.ConsolidationInterval <= 0 {
+		return errors.New("--consolidation-interval must be positive")
+	}
+	if conf.ConsolidationMaxFeeRate < 1 {
+		return errors.New("--consolidation-max-fee-rate cannot be lower than the minimum fee rate of 1 Sompi/gram")
+	}
+	if conf.ConsolidationMinUTXOs < 2 {
+		return errors.New("--consolidation-min-utxos must be at least 2")
+	}
+	return nil
+}
+
+func validateSetLabelConfig(conf *setLabelConfig) error {
+	if (conf.TxID == "") == (conf.Address == "") {
+		return errors.New("exactly one of --txid and --address must be specified")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/client"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/utils"
+)
+
+func consolidationStatus(conf *consolidationStatusConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+	response, err := daemonClient.GetConsolidationStatus(ctx, &pb.GetConsolidationStatusRequest{})
+	if err != nil {
+		return err
+	}
+
+	if !response.IsEnabled {
+		fmt.Println("UTXO consolidation is disabled. Start the daemon with --consolidate to enable it")
+		return nil
+	}
+
+	fmt.Printf("UTXOs smaller than %s KAS: %d\n", utils.FormatKas(response.SmallUtxoThreshold), response.SmallUtxoCount)
+	fmt.Printf("Consolidated UTXOs:        %d in %d transactions\n",
+		response.ConsolidatedUtxoCount, response.TransactionCount)
+	fmt.Printf("Fees in the last 24h, KAS: %s of %s\n",
+		utils.FormatKas(response.FeesSpent), utils.FormatKas(response.FeeBudget))
+	if response.LastRun != 0 {
+		fmt.Printf("Last run:                  %s (%s)\n",
+			time.UnixMilli(response.LastRun).Format(time.RFC3339), response.LastRunResult)
+		for _, txID := range response.LastRunTxIDs {
+			fmt.Printf("  %s\n", txID)
+		}
+	}
+	fmt.Printf("Next run:                  %s\n", time.UnixMilli(response.NextRun).Format(time.RFC3339))
+	return nil
+}
@@ -6,9 +6,7 @@ import (
 	"os"
 
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/bip32"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/utils"
-	"github.com/pkg/errors"
 
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
 )
@@ -18,36 +16,46 @@ func create(conf *createConfig) error {
 	var signerExtendedPublicKeys []string
 	var err error
 	isMultisig := conf.NumPublicKeys > 1
-	if !conf.Import {
-		encryptedMnemonics, signerExtendedPublicKeys, err = keys.CreateMnemonics(conf.NetParams(), conf.NumPrivateKeys, conf.Password, isMultisig)
-	} else {
-		encryptedMnemonics, signerExtendedPublicKeys, err = keys.ImportMnemonics(conf.NetParams(), conf.NumPrivateKeys, conf.Password, isMultisig)
-	}
-	if err != nil {
-		return err
-	}
+	if !conf.WatchOnly {
+		if !conf.Import {
+			encryptedMnemonics, signerExtendedPublicKeys, err = keys.CreateMnemonics(conf.NetParams(), conf.NumPrivateKeys, conf.Password, isMultisig)
+		} else {
+			encryptedMnemonics, signerExtendedPublicKeys, err = keys.ImportMnemonics(conf.NetParams(), conf.NumPrivateKeys, conf.Password, isMultisig)
+		}
+		if err != nil {
+			return err
+		}
 
-	for i, extendedPublicKey := range signerExtendedPublicKeys {
-		fmt.Printf("Extended public key of mnemonic #%d:\n%s\n\n", i+1, extendedPublicKey)
-	}
+		for i, extendedPublicKey := range signerExtendedPublicKeys {
+			fmt.Printf("Extended public key of mnemonic #%d:\n%s\n\n", i+1, extendedPublicKey)
+		}
 
-	fmt.Printf("Notice the above is neither a secret key to your wallet " +
-		"(use \"kaspawallet dump-unencrypted-data\" to see a secret seed phrase) " +
-		"nor a wallet public address (use \"kaspawallet new-address\" to create and see one)\n\n")
+		fmt.Printf("Notice the above is neither a secret key to your wallet " +
+			"(use \"kaspawallet dump-unencrypted-data\" to see a secret seed phrase) " +
+			"nor a wallet public address (use \"kaspawallet new-address\" to create and see one)\n\n")
+	}
 
 	extendedPublicKeys := make([]string, conf.NumPrivateKeys, conf.NumPublicKeys)
 	copy(extendedPublicKeys, signerExtendedPublicKeys)
+	for _, extendedPublicKey := range conf.ExtendedPublicKeys {
+		err := libkaspawallet.ValidateExtendedPublicKey(conf.NetParams(), extendedPublicKey)
+		if err != nil {
+			return err
+		}
+		extendedPublicKeys = append(extendedPublicKeys, extendedPublicKey)
+	}
+
 	reader := bufio.NewReader(os.Stdin)
-	for i := conf.NumPrivateKeys; i < conf.NumPublicKeys; i++ {
+	for i := uint32(len(extendedPublicKeys)); i < conf.NumPublicKeys; i++ {
 		fmt.Printf("Enter public key #%d here:\n", i+1)
 		extendedPublicKey, err := utils.ReadLine(reader)
 		if err != nil {
 			return err
 		}
 
-		_, err = bip32.DeserializeExtendedKey(string(extendedPublicKey))
+		err = libkaspawallet.ValidateExtendedPublicKey(conf.NetParams(), string(extendedPublicKey))
 		if err != nil {
-			return errors.Wrapf(err, "%s is invalid extended public key", string(extendedPublicKey))
+			return err
 		}
 
 		fmt.Println()
@@ -88,6 +96,10 @@ func create(conf *createConfig) error {
 		return err
 	}
 
+	if conf.WatchOnly {
+		fmt.Printf("Wrote the extended public keys of the watch-only wallet into %s\n", file.Path())
+		return nil
+	}
 	fmt.Printf("Wrote the keys into %s\n", file.Path())
 	return nil
 }
@@ -30,6 +30,11 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 		}
 	}
 
+	coinControl, err := conf.coinControl()
+	if err != nil {
+		return err
+	}
+
 	var feePolicy *pb.FeePolicy
 	if conf.FeeRate > 0 {
 		feePolicy = &pb.FeePolicy{
@@ -54,6 +59,7 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 		IsSendAll:                conf.IsSendAll,
 		UseExistingChangeAddress: conf.UseExistingChangeAddress,
 		FeePolicy:                feePolicy,
+		CoinControl:              coinControl,
 	})
 	if err != nil {
 		return err
@@ -39,6 +39,15 @@ type KaspawalletdClient interface {
 	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
 	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
 	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	SetLabel(ctx context.Context, in *SetLabelRequest, opts ...grpc.CallOption) (*SetLabelResponse, error)
+	// FreezeUTXOs keeps UTXOs from being spent by transactions, including
+	// ones that include them with coin control. Frozen UTXOs stay frozen across
+	// daemon restarts
+	FreezeUTXOs(ctx context.Context, in *FreezeUTXOsRequest, opts ...grpc.CallOption) (*FreezeUTXOsResponse, error)
+	UnfreezeUTXOs(ctx context.Context, in *UnfreezeUTXOsRequest, opts ...grpc.CallOption) (*UnfreezeUTXOsResponse, error)
+	GetFrozenUTXOs(ctx context.Context, in *GetFrozenUTXOsRequest, opts ...grpc.CallOption) (*GetFrozenUTXOsResponse, error)
+	GetConsolidationStatus(ctx context.Context, in *GetConsolidationStatusRequest, opts ...grpc.CallOption) (*GetConsolidationStatusResponse, error)
 }
 
 type kaspawalletdClient struct {
@@ -157,6 +166,60 @@ func (c *kaspawalletdClient) BumpFee(ctx context.Context, in *BumpFeeRequest, op
 	return out, nil
 }
 
+func (c *kaspawalletdClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/kaspawalletd.kaspawalletd/GetTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaspawalletdClient) SetLabel(ctx context.Context, in *SetLabelRequest, opts ...grpc.CallOption) (*SetLabelResponse, error) {
+	out := new(SetLabelResponse)
+	err := c.cc.Invoke(ctx, "/kaspawalletd.kaspawalletd/SetLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaspawalletdClient) FreezeUTXOs(ctx context.Context, in *FreezeUTXOsRequest, opts ...grpc.CallOption) (*FreezeUTXOsResponse, error) {
+	out := new(FreezeUTXOsResponse)
+	err := c.cc.Invoke(ctx, "/kaspawalletd.kaspawalletd/FreezeUTXOs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaspawalletdClient) UnfreezeUTXOs(ctx context.Context, in *UnfreezeUTXOsRequest, opts ...grpc.CallOption) (*UnfreezeUTXOsResponse, error) {
+	out := new(UnfreezeUTXOsResponse)
+	err := c.cc.Invoke(ctx, "/kaspawalletd.kaspawalletd/UnfreezeUTXOs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaspawalletdClient) GetFrozenUTXOs(ctx context.Context, in *GetFrozenUTXOsRequest, opts ...grpc.CallOption) (*GetFrozenUTXOsResponse, error) {
+	out := new(GetFrozenUTXOsResponse)
+	err := c.cc.Invoke(ctx, "/kaspawalletd.kaspawalletd/GetFrozenUTXOs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaspawalletdClient) GetConsolidationStatus(ctx context.Context, in *GetConsolidationStatusRequest, opts ...grpc.CallOption) (*GetConsolidationStatusResponse, error) {
+	out := new(GetConsolidationStatusResponse)
+	err := c.cc.Invoke(ctx, "/kaspawalletd.kaspawalletd/GetConsolidationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KaspawalletdServer is the server API for Kaspawalletd service.
 // All implementations must embed UnimplementedKaspawalletdServer
 // for forward compatibility
@@ -178,6 +241,15 @@ type KaspawalletdServer interface {
 	Sign(context.Context, *SignRequest) (*SignResponse, error)
 	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
 	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	SetLabel(context.Context, *SetLabelRequest) (*SetLabelResponse, error)
+	// FreezeUTXOs keeps UTXOs from being spent by transactions, including
+	// ones that include them with coin control. Frozen UTXOs stay frozen across
+	// daemon restarts
+	FreezeUTXOs(context.Context, *FreezeUTXOsRequest) (*FreezeUTXOsResponse, error)
+	UnfreezeUTXOs(context.Context, *UnfreezeUTXOsRequest) (*UnfreezeUTXOsResponse, error)
+	GetFrozenUTXOs(context.Context, *GetFrozenUTXOsRequest) (*GetFrozenUTXOsResponse, error)
+	GetConsolidationStatus(context.Context, *GetConsolidationStatusRequest) (*GetConsolidationStatusResponse, error)
 	mustEmbedUnimplementedKaspawalletdServer()
 }
 
@@ -221,6 +293,24 @@ func (UnimplementedKaspawalletdServer) GetVersion(context.Context, *GetVersionRe
 func (UnimplementedKaspawalletdServer) BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BumpFee not implemented")
 }
+func (UnimplementedKaspawalletdServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedKaspawalletdServer) SetLabel(context.Context, *SetLabelRequest) (*SetLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLabel not implemented")
+}
+func (UnimplementedKaspawalletdServer) FreezeUTXOs(context.Context, *FreezeUTXOsRequest) (*FreezeUTXOsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeUTXOs not implemented")
+}
+func (UnimplementedKaspawalletdServer) UnfreezeUTXOs(context.Context, *UnfreezeUTXOsRequest) (*UnfreezeUTXOsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeUTXOs not implemented")
+}
+func (UnimplementedKaspawalletdServer) GetFrozenUTXOs(context.Context, *GetFrozenUTXOsRequest) (*GetFrozenUTXOsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrozenUTXOs not implemented")
+}
+func (UnimplementedKaspawalletdServer) GetConsolidationStatus(context.Context, *GetConsolidationStatusRequest) (*GetConsolidationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsolidationStatus not implemented")
+}
 func (UnimplementedKaspawalletdServer) mustEmbedUnimplementedKaspawalletdServer() {}
 
 // UnsafeKaspawalletdServer may be embedded to opt out of forward compatibility for this service.
@@ -450,6 +540,114 @@ func _Kaspawalletd_BumpFee_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Kaspawalletd_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaspawalletdServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kaspawalletd.kaspawalletd/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaspawalletdServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kaspawalletd_SetLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaspawalletdServer).SetLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kaspawalletd.kaspawalletd/SetLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaspawalletdServer).SetLabel(ctx, req.(*SetLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kaspawalletd_FreezeUTXOs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeUTXOsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaspawalletdServer).FreezeUTXOs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kaspawalletd.kaspawalletd/FreezeUTXOs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaspawalletdServer).FreezeUTXOs(ctx, req.(*FreezeUTXOsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kaspawalletd_UnfreezeUTXOs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeUTXOsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaspawalletdServer).UnfreezeUTXOs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kaspawalletd.kaspawalletd/UnfreezeUTXOs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaspawalletdServer).UnfreezeUTXOs(ctx, req.(*UnfreezeUTXOsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kaspawalletd_GetFrozenUTXOs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrozenUTXOsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaspawalletdServer).GetFrozenUTXOs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kaspawalletd.kaspawalletd/GetFrozenUTXOs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaspawalletdServer).GetFrozenUTXOs(ctx, req.(*GetFrozenUTXOsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kaspawalletd_GetConsolidationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsolidationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaspawalletdServer).GetConsolidationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kaspawalletd.kaspawalletd/GetConsolidationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaspawalletdServer).GetConsolidationStatus(ctx, req.(*GetConsolidationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Kaspawalletd_ServiceDesc is the grpc.ServiceDesc for Kaspawalletd service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -505,6 +703,30 @@ var Kaspawalletd_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BumpFee",
 			Handler:    _Kaspawalletd_BumpFee_Handler,
 		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _Kaspawalletd_GetTransactions_Handler,
+		},
+		{
+			MethodName: "SetLabel",
+			Handler:    _Kaspawalletd_SetLabel_Handler,
+		},
+		{
+			MethodName: "FreezeUTXOs",
+			Handler:    _Kaspawalletd_FreezeUTXOs_Handler,
+		},
+		{
+			MethodName: "UnfreezeUTXOs",
+			Handler:    _Kaspawalletd_UnfreezeUTXOs_Handler,
+		},
+		{
+			MethodName: "GetFrozenUTXOs",
+			Handler:    _Kaspawalletd_GetFrozenUTXOs_Handler,
+		},
+		{
+			MethodName: "GetConsolidationStatus",
+			Handler:    _Kaspawalletd_GetConsolidationStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kaspawalletd.proto",
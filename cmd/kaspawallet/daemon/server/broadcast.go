@@ -27,36 +27,152 @@ func (s *server) Broadcast(_ context.Context, request *pb.BroadcastRequest) (*pb
 }
 
 func (s *server) broadcast(transactions [][]byte, isDomain bool) ([]string, error) {
+	sentTransactions, txIDs, err := s.submitTransactions(transactions, isDomain, false)
+	s.recordSentTransactions(sentTransactions)
+	if err != nil {
+		return nil, err
+	}
+	return txIDs, nil
+}
 
-	txIDs := make([]string, len(transactions))
-	var tx *externalapi.DomainTransaction
-	var err error
+// submitTransactions submits the given transactions in order, and stops at the
+// first that fails. It returns the transactions it submitted along with their
+// IDs. If isReplacement is true, the first transaction is submitted as a
+// replacement. It doesn't touch the wallet state, so it may be called without
+// the server lock
+func (s *server) submitTransactions(transactions [][]byte, isDomain bool, isReplacement bool) (
+	sentTransactions []*externalapi.DomainTransaction, txIDs []string, err error) {
 
 	for i, transaction := range transactions {
-
+		var tx *externalapi.DomainTransaction
 		if isDomain {
 			tx, err = serialization.DeserializeDomainTransaction(transaction)
 			if err != nil {
-				return nil, err
+				return sentTransactions, txIDs, err
 			}
-		} else if !isDomain { //default in proto3 is false
+		} else { //default in proto3 is false
 			tx, err = libkaspawallet.ExtractTransaction(transaction, s.keysFile.ECDSA)
 			if err != nil {
-				return nil, err
+				return sentTransactions, txIDs, err
 			}
 		}
 
-		txIDs[i], err = sendTransaction(s.rpcClient, tx)
+		var txID string
+		// Once the first transaction is added to the mempool, the transactions that depend
+		// on the replaced transaction will be removed, so there's no need to submit them
+		// as RBF transactions.
+		if isReplacement && i == 0 {
+			txID, err = sendTransactionRBF(s.rpcClient, tx)
+		} else {
+			txID, err = sendTransaction(s.rpcClient, tx)
+		}
 		if err != nil {
-			return nil, err
+			return sentTransactions, txIDs, err
 		}
+		sentTransactions = append(sentTransactions, tx)
+		txIDs = append(txIDs, txID)
+	}
+	return sentTransactions, txIDs, nil
+}
 
+// recordSentTransactions records the given transactions in the wallet history
+// and marks their outpoints as used. It must be called with the server lock
+func (s *server) recordSentTransactions(sentTransactions []*externalapi.DomainTransaction) {
+	if len(sentTransactions) == 0 {
+		return
+	}
+	for _, tx := range sentTransactions {
+		s.recordSentTransaction(tx)
 		for _, input := range tx.Inputs {
 			s.usedOutpoints[input.PreviousOutpoint] = time.Now()
 		}
 	}
-
 	s.forceSync()
+}
+
+// outpointReservationTimeout bounds the time the outpoints of a transaction
+// are reserved for while it's signed and broadcast without the server lock.
+// It's longer than an external signer may take to sign
+const outpointReservationTimeout = 10 * time.Minute
+
+// outpointReservation maps the outpoints reserved by reserveOutpoints to the
+// time they were used at before, which is zero if they weren't
+type outpointReservation map[externalapi.DomainOutpoint]time.Time
+
+// reserveOutpoints marks the outpoints spent by the given unsigned transactions
+// as used, so that they aren't selected again while the transactions are signed
+// and broadcast without the server lock. It must be called with the server lock
+func (s *server) reserveOutpoints(unsignedTransactions [][]byte) (outpointReservation, error) {
+	reservation := make(outpointReservation)
+	// The reservation is dated in the future, so that it doesn't expire while the transactions are signed
+	reservationTime := time.Now().Add(outpointReservationTimeout)
+	for _, unsignedTransaction := range unsignedTransactions {
+		partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(unsignedTransaction)
+		if err != nil {
+			s.restoreOutpoints(reservation)
+			return nil, err
+		}
+		for _, input := range partiallySignedTransaction.Tx.Inputs {
+			if _, ok := reservation[input.PreviousOutpoint]; ok {
+				continue
+			}
+			reservation[input.PreviousOutpoint] = s.usedOutpoints[input.PreviousOutpoint]
+			s.usedOutpoints[input.PreviousOutpoint] = reservationTime
+		}
+	}
+	return reservation, nil
+}
+
+// restoreOutpoints undoes the reservation of the outpoints of reservation. It
+// must be called with the server lock
+func (s *server) restoreOutpoints(reservation outpointReservation) {
+	for outpoint, previousUseTime := range reservation {
+		if previousUseTime.IsZero() {
+			delete(s.usedOutpoints, outpoint)
+		} else {
+			s.usedOutpoints[outpoint] = previousUseTime
+		}
+	}
+}
+
+// signReserved signs the given unsigned transactions, whose outpoints are
+// reserved by reservation, and releases the reservation if signing fails. It's
+// called without the server lock, since an external signer may take minutes to sign
+func (s *server) signReserved(signer libkaspawallet.Signer, unsignedTransactions [][]byte,
+	reservation outpointReservation) ([][]byte, error) {
+
+	signedTransactions, err := signTransactionsWith(signer, unsignedTransactions)
+	if err != nil {
+		s.lock.Lock()
+		s.restoreOutpoints(reservation)
+		s.lock.Unlock()
+		return nil, err
+	}
+	return signedTransactions, nil
+}
+
+// broadcastReserved broadcasts the given signed transactions, whose outpoints
+// are reserved by reservation, without the server lock, and then records the
+// transactions that were sent with it. The reservation of the outpoints of the
+// transactions that weren't sent is released
+func (s *server) broadcastReserved(signedTransactions [][]byte, isReplacement bool,
+	reservation outpointReservation) ([]string, error) {
+
+	sentTransactions, txIDs, err := s.submitTransactions(signedTransactions, false, isReplacement)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, tx := range sentTransactions {
+		for _, input := range tx.Inputs {
+			delete(reservation, input.PreviousOutpoint)
+		}
+	}
+	s.restoreOutpoints(reservation)
+	s.recordSentTransactions(sentTransactions)
+	if err != nil {
+		return nil, err
+	}
 	return txIDs, nil
 }
 
@@ -2,12 +2,9 @@ package server
 
 import (
 	"context"
-	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
@@ -28,47 +25,11 @@ func (s *server) BroadcastReplacement(_ context.Context, request *pb.BroadcastRe
 
 // broadcastReplacement assumes that all transactions depend on the first one
 func (s *server) broadcastReplacement(transactions [][]byte, isDomain bool) ([]string, error) {
-
-	txIDs := make([]string, len(transactions))
-	var tx *externalapi.DomainTransaction
-	var err error
-
-	for i, transaction := range transactions {
-
-		if isDomain {
-			tx, err = serialization.DeserializeDomainTransaction(transaction)
-			if err != nil {
-				return nil, err
-			}
-		} else if !isDomain { //default in proto3 is false
-			tx, err = libkaspawallet.ExtractTransaction(transaction, s.keysFile.ECDSA)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		// Once the first transaction is added to the mempool, the transactions that depend
-		// on the replaced transaction will be removed, so there's no need to submit them
-		// as RBF transactions.
-		if i == 0 {
-			txIDs[i], err = sendTransactionRBF(s.rpcClient, tx)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			txIDs[i], err = sendTransaction(s.rpcClient, tx)
-			if err != nil {
-				return nil, err
-			}
-
-		}
-
-		for _, input := range tx.Inputs {
-			s.usedOutpoints[input.PreviousOutpoint] = time.Now()
-		}
+	sentTransactions, txIDs, err := s.submitTransactions(transactions, isDomain, true)
+	s.recordSentTransactions(sentTransactions)
+	if err != nil {
+		return nil, err
 	}
-
-	s.forceSync()
 	return txIDs, nil
 }
 
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+)
+
+func TestReserveOutpoints(t *testing.T) {
+	previouslyUsed := externalapi.DomainOutpoint{Index: 0}
+	unused := externalapi.DomainOutpoint{Index: 1}
+	previousUseTime := time.Now().Add(-time.Hour)
+	serverInstance := &server{
+		usedOutpoints: map[externalapi.DomainOutpoint]time.Time{previouslyUsed: previousUseTime},
+	}
+
+	unsignedTransaction, err := serialization.SerializePartiallySignedTransaction(&serialization.PartiallySignedTransaction{
+		Tx: &externalapi.DomainTransaction{
+			Inputs: []*externalapi.DomainTransactionInput{
+				{PreviousOutpoint: previouslyUsed},
+				{PreviousOutpoint: unused},
+			},
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+		},
+	})
+	if err != nil {
+		t.Fatalf("SerializePartiallySignedTransaction: %+v", err)
+	}
+
+	reservation, err := serverInstance.reserveOutpoints([][]byte{unsignedTransaction})
+	if err != nil {
+		t.Fatalf("reserveOutpoints: %+v", err)
+	}
+	// The reservation must not expire while the transaction is signed
+	for _, outpoint := range []externalapi.DomainOutpoint{previouslyUsed, unused} {
+		reservationTime, ok := serverInstance.usedOutpoints[outpoint]
+		if !ok || !reservationTime.After(time.Now()) {
+			t.Fatalf("expected outpoint %s to be reserved, got %s", outpoint, reservationTime)
+		}
+	}
+
+	// Releasing the reservation restores the outpoints that were used
+	// before, and frees the rest
+	serverInstance.restoreOutpoints(reservation)
+	if useTime := serverInstance.usedOutpoints[previouslyUsed]; !useTime.Equal(previousUseTime) {
+		t.Fatalf("expected outpoint %s to be used at %s, got %s", previouslyUsed, previousUseTime, useTime)
+	}
+	if _, ok := serverInstance.usedOutpoints[unused]; ok {
+		t.Fatalf("expected outpoint %s not to be used", unused)
+	}
+}
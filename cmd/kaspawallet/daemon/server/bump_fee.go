@@ -12,17 +12,51 @@ import (
 )
 
 func (s *server) BumpFee(_ context.Context, request *pb.BumpFeeRequest) (*pb.BumpFeeResponse, error) {
+	unsignedTransactions, signer, reservation, err := s.createReplacementTransactions(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Password == "" {
+		return &pb.BumpFeeResponse{
+			Transactions: unsignedTransactions,
+		}, nil
+	}
+
+	signedTransactions, err := s.signReserved(signer, unsignedTransactions, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs, err := s.broadcastReserved(signedTransactions, true, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BumpFeeResponse{
+		TxIDs:        txIDs,
+		Transactions: signedTransactions,
+	}, nil
+}
+
+// createReplacementTransactions creates the transactions that replace the
+// transaction of the given request with a higher fee rate. If the request has
+// a password, it also returns a signer and reserves the outpoints of the
+// transactions, so that they may be signed and broadcast without the server lock
+func (s *server) createReplacementTransactions(request *pb.BumpFeeRequest) (
+	unsignedTransactions [][]byte, signer libkaspawallet.Signer, reservation outpointReservation, err error) {
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	entry, err := s.rpcClient.GetMempoolEntry(request.TxID, false, false)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	domainTx, err := appmessage.RPCTransactionToDomainTransaction(entry.Entry.Transaction)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	outpointsToInputs := make(map[externalapi.DomainOutpoint]*externalapi.DomainTransactionInput)
@@ -56,29 +90,29 @@ func (s *server) BumpFee(_ context.Context, request *pb.BumpFeeRequest) (*pb.Bum
 	}
 
 	if maxUTXO == nil {
-		return nil, errors.Errorf("no UTXOs were found for transaction %s. This probably means the transaction is already accepted", request.TxID)
+		return nil, nil, nil, errors.Errorf("no UTXOs were found for transaction %s. This probably means the transaction is already accepted", request.TxID)
 	}
 
 	mass := s.txMassCalculator.CalculateTransactionOverallMass(domainTx)
 	feeRate := float64(entry.Entry.Fee) / float64(mass)
 	newFeeRate, maxFee, err := s.calculateFeeLimits(request.FeePolicy)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	if feeRate >= newFeeRate {
-		return nil, errors.Errorf("new fee rate (%f) is not higher than the current fee rate (%f)", newFeeRate, feeRate)
+		return nil, nil, nil, errors.Errorf("new fee rate (%f) is not higher than the current fee rate (%f)", newFeeRate, feeRate)
 	}
 
 	if len(domainTx.Outputs) == 0 || len(domainTx.Outputs) > 2 {
-		return nil, errors.Errorf("kaspawallet supports only transactions with 1 or 2 outputs in transaction %s, but this transaction got %d", request.TxID, len(domainTx.Outputs))
+		return nil, nil, nil, errors.Errorf("kaspawallet supports only transactions with 1 or 2 outputs in transaction %s, but this transaction got %d", request.TxID, len(domainTx.Outputs))
 	}
 
 	var fromAddresses []*walletAddress
 	for _, from := range request.From {
 		fromAddress, exists := s.addressSet[from]
 		if !exists {
-			return nil, errors.Errorf("specified from address %s does not exists", from)
+			return nil, nil, nil, errors.Errorf("specified from address %s does not exists", from)
 		}
 		fromAddresses = append(fromAddresses, fromAddress)
 	}
@@ -87,23 +121,23 @@ func (s *server) BumpFee(_ context.Context, request *pb.BumpFeeRequest) (*pb.Bum
 	for outpoint := range outpointsToInputs {
 		allowUsed[outpoint] = struct{}{}
 	}
-	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOsWithPreselected([]*walletUTXO{maxUTXO}, allowUsed, domainTx.Outputs[0].Value, false, newFeeRate, maxFee, fromAddresses)
+	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOsWithPreselected([]*walletUTXO{maxUTXO}, allowUsed, domainTx.Outputs[0].Value, false, newFeeRate, maxFee, fromAddresses, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	_, toAddress, err := txscript.ExtractScriptPubKeyAddress(domainTx.Outputs[0].ScriptPublicKey, s.params)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	changeAddress, changeWalletAddress, err := s.changeAddress(request.UseExistingChangeAddress, fromAddresses)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	if len(selectedUTXOs) == 0 {
-		return nil, errors.Errorf("couldn't find funds to spend")
+		return nil, nil, nil, errors.Errorf("couldn't find funds to spend")
 	}
 
 	payments := []*libkaspawallet.Payment{{
@@ -113,7 +147,7 @@ func (s *server) BumpFee(_ context.Context, request *pb.BumpFeeRequest) (*pb.Bum
 	if changeSompi > 0 {
 		changeAddress, _, err := s.changeAddress(request.UseExistingChangeAddress, fromAddresses)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 		payments = append(payments, &libkaspawallet.Payment{
@@ -125,32 +159,24 @@ func (s *server) BumpFee(_ context.Context, request *pb.BumpFeeRequest) (*pb.Bum
 		s.keysFile.MinimumSignatures,
 		payments, selectedUTXOs)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	unsignedTransactions, err := s.maybeAutoCompoundTransaction(unsignedTransaction, toAddress, changeAddress, changeWalletAddress, newFeeRate, maxFee)
+	unsignedTransactions, err = s.maybeAutoCompoundTransaction(unsignedTransaction, toAddress, changeAddress, changeWalletAddress, newFeeRate, maxFee, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-
 	if request.Password == "" {
-		return &pb.BumpFeeResponse{
-			Transactions: unsignedTransactions,
-		}, nil
+		return unsignedTransactions, nil, nil, nil
 	}
 
-	signedTransactions, err := s.signTransactions(unsignedTransactions, request.Password)
+	signer, err = s.transactionSigner(request.Password)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-
-	txIDs, err := s.broadcastReplacement(signedTransactions, false)
+	reservation, err = s.reserveOutpoints(unsignedTransactions)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-
-	return &pb.BumpFeeResponse{
-		TxIDs:        txIDs,
-		Transactions: signedTransactions,
-	}, nil
+	return unsignedTransactions, signer, reservation, nil
 }
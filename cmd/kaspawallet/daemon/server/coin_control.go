@@ -0,0 +1,301 @@
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/walletdb"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// coinControl is the parsed pb.CoinControl of a request. A nil coinControl
+// selects UTXOs the default way
+type coinControl struct {
+	include  []*externalapi.DomainOutpoint
+	exclude  map[externalapi.DomainOutpoint]struct{}
+	strategy pb.UtxoSelectionStrategy
+}
+
+func parseCoinControl(requestCoinControl *pb.CoinControl) (*coinControl, error) {
+	if requestCoinControl == nil {
+		return nil, nil
+	}
+	if _, ok := pb.UtxoSelectionStrategy_name[int32(requestCoinControl.Strategy)]; !ok {
+		return nil, errors.Errorf("unknown UTXO selection strategy %d", requestCoinControl.Strategy)
+	}
+
+	parsed := &coinControl{
+		exclude:  make(map[externalapi.DomainOutpoint]struct{}, len(requestCoinControl.Exclude)),
+		strategy: requestCoinControl.Strategy,
+	}
+	for _, requestOutpoint := range requestCoinControl.Exclude {
+		outpoint, err := outpointFromPB(requestOutpoint)
+		if err != nil {
+			return nil, err
+		}
+		parsed.exclude[*outpoint] = struct{}{}
+	}
+	included := make(map[externalapi.DomainOutpoint]struct{}, len(requestCoinControl.Include))
+	for _, requestOutpoint := range requestCoinControl.Include {
+		outpoint, err := outpointFromPB(requestOutpoint)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := parsed.exclude[*outpoint]; ok {
+			return nil, errors.Errorf("outpoint %s is both included and excluded", outpoint)
+		}
+		if _, ok := included[*outpoint]; ok {
+			continue
+		}
+		included[*outpoint] = struct{}{}
+		parsed.include = append(parsed.include, outpoint)
+	}
+	return parsed, nil
+}
+
+func (cc *coinControl) isExcluded(outpoint *externalapi.DomainOutpoint) bool {
+	if cc == nil {
+		return false
+	}
+	_, ok := cc.exclude[*outpoint]
+	return ok
+}
+
+func (cc *coinControl) selectionStrategy() pb.UtxoSelectionStrategy {
+	if cc == nil {
+		return pb.UtxoSelectionStrategy_LARGEST_FIRST
+	}
+	return cc.strategy
+}
+
+// includedUTXOs returns the wallet UTXOs of the outpoints coin control
+// includes. Unlike the UTXOs the wallet selects by itself, it's an error for
+// an included one not to be spendable
+func (s *server) includedUTXOs(cc *coinControl, fromAddresses []*walletAddress, virtualDAAScore uint64) ([]*walletUTXO, error) {
+	if cc == nil || len(cc.include) == 0 {
+		return nil, nil
+	}
+
+	utxosByOutpoint := make(map[externalapi.DomainOutpoint]*walletUTXO, len(s.utxosSortedByAmount))
+	for _, utxo := range s.utxosSortedByAmount {
+		utxosByOutpoint[*utxo.Outpoint] = utxo
+	}
+	included := make([]*walletUTXO, len(cc.include))
+	for i, outpoint := range cc.include {
+		utxo, ok := utxosByOutpoint[*outpoint]
+		if !ok {
+			if _, ok := s.mempoolExcludedUTXOs[*outpoint]; ok {
+				return nil, errors.Errorf("included outpoint %s is already spent by a transaction in the mempool", outpoint)
+			}
+			return nil, errors.Errorf("included outpoint %s is not a UTXO of the wallet", outpoint)
+		}
+		if fromAddresses != nil && !walletAddressesContain(fromAddresses, utxo.address) {
+			return nil, errors.Errorf("included outpoint %s is not of any of the from addresses", outpoint)
+		}
+		if !s.isUTXOSpendable(utxo, virtualDAAScore) {
+			return nil, errors.Errorf("included outpoint %s is an immature coinbase output", outpoint)
+		}
+		included[i] = utxo
+	}
+	return included, nil
+}
+
+// orderUTXOs returns the candidate UTXOs, which are sorted from the largest
+// to the smallest, in the order the given strategy selects them
+func orderUTXOs(candidates []*walletUTXO, strategy pb.UtxoSelectionStrategy, spendAmount uint64) []*walletUTXO {
+	switch strategy {
+	case pb.UtxoSelectionStrategy_SMALLEST_FIRST:
+		ordered := make([]*walletUTXO, len(candidates))
+		for i, utxo := range candidates {
+			ordered[len(candidates)-1-i] = utxo
+		}
+		return ordered
+	case pb.UtxoSelectionStrategy_PRIVACY:
+		return orderUTXOsByAddress(candidates, spendAmount)
+	default:
+		return candidates
+	}
+}
+
+// orderUTXOsByAddress groups the candidate UTXOs by their addresses. The
+// address with the smallest balance that pays spendAmount by itself comes
+// first, and the rest follow from the largest balance to the smallest, so
+// that a transaction spends from as few addresses as possible
+func orderUTXOsByAddress(candidates []*walletUTXO, spendAmount uint64) []*walletUTXO {
+	utxosByAddress := make(map[walletAddress][]*walletUTXO)
+	balances := make(map[walletAddress]uint64)
+	var addresses []walletAddress
+	for _, utxo := range candidates {
+		if _, ok := utxosByAddress[*utxo.address]; !ok {
+			addresses = append(addresses, *utxo.address)
+		}
+		utxosByAddress[*utxo.address] = append(utxosByAddress[*utxo.address], utxo)
+		balances[*utxo.address] += utxo.UTXOEntry.Amount()
+	}
+
+	sort.SliceStable(addresses, func(i, j int) bool {
+		return balances[addresses[i]] > balances[addresses[j]]
+	})
+	for i := len(addresses) - 1; i >= 0; i-- {
+		if balances[addresses[i]] >= spendAmount {
+			sufficient := addresses[i]
+			copy(addresses[1:i+1], addresses[:i])
+			addresses[0] = sufficient
+			break
+		}
+	}
+
+	ordered := make([]*walletUTXO, 0, len(candidates))
+	for _, address := range addresses {
+		ordered = append(ordered, utxosByAddress[address]...)
+	}
+	return ordered
+}
+
+// maxBranchAndBoundTries bounds the search of branchAndBound, which is
+// exponential in the number of values
+const maxBranchAndBoundTries = 100_000
+
+// branchAndBound looks for a subset of values, which are sorted from the
+// largest to the smallest, whose sum plus initialSum is between lower and
+// upper, and that accept accepts. It returns the indexes of the subset, and
+// whether it found one
+func branchAndBound(values []uint64, initialSum uint64, lower uint64, upper uint64,
+	accept func(selected []int) (bool, error)) ([]int, bool, error) {
+
+	remaining := make([]uint64, len(values)+1)
+	for i := len(values) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + values[i]
+	}
+
+	var selected []int
+	tries := 0
+	var search func(index int, sum uint64) (bool, error)
+	search = func(index int, sum uint64) (bool, error) {
+		if sum > upper {
+			return false, nil
+		}
+		if sum >= lower {
+			// Adding values would only take the sum further from lower
+			return accept(selected)
+		}
+		if index == len(values) || sum+remaining[index] < lower || tries >= maxBranchAndBoundTries {
+			return false, nil
+		}
+		tries++
+
+		selected = append(selected, index)
+		found, err := search(index+1, sum+values[index])
+		if err != nil || found {
+			return found, err
+		}
+		selected = selected[:len(selected)-1]
+		return search(index+1, sum)
+	}
+
+	found, err := search(0, initialSum)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return selected, true, nil
+}
+
+func (s *server) FreezeUTXOs(_ context.Context, request *pb.FreezeUTXOsRequest) (*pb.FreezeUTXOsResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	outpoints, err := outpointsFromPB(request.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+	err = s.walletDB.FreezeUTXOs(walletDBOutpoints(outpoints))
+	if err != nil {
+		return nil, err
+	}
+	for _, outpoint := range outpoints {
+		s.frozenUTXOs[*outpoint] = struct{}{}
+	}
+	return &pb.FreezeUTXOsResponse{}, nil
+}
+
+func (s *server) UnfreezeUTXOs(_ context.Context, request *pb.UnfreezeUTXOsRequest) (*pb.UnfreezeUTXOsResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	outpoints, err := outpointsFromPB(request.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+	err = s.walletDB.UnfreezeUTXOs(walletDBOutpoints(outpoints))
+	if err != nil {
+		return nil, err
+	}
+	for _, outpoint := range outpoints {
+		delete(s.frozenUTXOs, *outpoint)
+	}
+	return &pb.UnfreezeUTXOsResponse{}, nil
+}
+
+func (s *server) GetFrozenUTXOs(_ context.Context, _ *pb.GetFrozenUTXOsRequest) (*pb.GetFrozenUTXOsResponse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	outpoints := make([]*pb.Outpoint, 0, len(s.frozenUTXOs))
+	for outpoint := range s.frozenUTXOs {
+		outpoints = append(outpoints, &pb.Outpoint{TransactionId: outpoint.TransactionID.String(), Index: outpoint.Index})
+	}
+	sort.Slice(outpoints, func(i, j int) bool {
+		if outpoints[i].TransactionId != outpoints[j].TransactionId {
+			return outpoints[i].TransactionId < outpoints[j].TransactionId
+		}
+		return outpoints[i].Index < outpoints[j].Index
+	})
+	return &pb.GetFrozenUTXOsResponse{Outpoints: outpoints}, nil
+}
+
+// loadFrozenUTXOs reads the frozen UTXOs from the wallet database
+func loadFrozenUTXOs(walletDB *walletdb.DB) (map[externalapi.DomainOutpoint]struct{}, error) {
+	outpoints, err := walletDB.FrozenUTXOs()
+	if err != nil {
+		return nil, err
+	}
+	frozenUTXOs := make(map[externalapi.DomainOutpoint]struct{}, len(outpoints))
+	for _, outpoint := range outpoints {
+		transactionID, err := externalapi.NewDomainTransactionIDFromString(outpoint.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		frozenUTXOs[externalapi.DomainOutpoint{TransactionID: *transactionID, Index: outpoint.Index}] = struct{}{}
+	}
+	return frozenUTXOs, nil
+}
+
+func outpointFromPB(outpoint *pb.Outpoint) (*externalapi.DomainOutpoint, error) {
+	transactionID, err := externalapi.NewDomainTransactionIDFromString(outpoint.TransactionId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid transaction ID %s", outpoint.TransactionId)
+	}
+	return &externalapi.DomainOutpoint{TransactionID: *transactionID, Index: outpoint.Index}, nil
+}
+
+func outpointsFromPB(pbOutpoints []*pb.Outpoint) ([]*externalapi.DomainOutpoint, error) {
+	outpoints := make([]*externalapi.DomainOutpoint, len(pbOutpoints))
+	for i, pbOutpoint := range pbOutpoints {
+		var err error
+		outpoints[i], err = outpointFromPB(pbOutpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return outpoints, nil
+}
+
+func walletDBOutpoints(outpoints []*externalapi.DomainOutpoint) []*walletdb.Outpoint {
+	walletDBOutpoints := make([]*walletdb.Outpoint, len(outpoints))
+	for i, outpoint := range outpoints {
+		walletDBOutpoints[i] = &walletdb.Outpoint{TransactionID: outpoint.TransactionID.String(), Index: outpoint.Index}
+	}
+	return walletDBOutpoints
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+)
+
+func testUTXO(index uint32, amount uint64, address *walletAddress) *walletUTXO {
+	return &walletUTXO{
+		Outpoint:  &externalapi.DomainOutpoint{Index: index},
+		UTXOEntry: utxo.NewUTXOEntry(amount, &externalapi.ScriptPublicKey{}, false, 0),
+		address:   address,
+	}
+}
+
+func utxoIndexes(utxos []*walletUTXO) []uint32 {
+	indexes := make([]uint32, len(utxos))
+	for i, utxo := range utxos {
+		indexes[i] = utxo.Outpoint.Index
+	}
+	return indexes
+}
+
+func TestOrderUTXOs(t *testing.T) {
+	first, second, third := &walletAddress{index: 1}, &walletAddress{index: 2}, &walletAddress{index: 3}
+	// Sorted from the largest to the smallest, like utxosSortedByAmount
+	candidates := []*walletUTXO{
+		testUTXO(0, 500, first),
+		testUTXO(1, 400, second),
+		testUTXO(2, 300, third),
+		testUTXO(3, 200, second),
+		testUTXO(4, 100, first),
+	}
+
+	tests := []struct {
+		strategy    pb.UtxoSelectionStrategy
+		spendAmount uint64
+		expected    []uint32
+	}{
+		{strategy: pb.UtxoSelectionStrategy_LARGEST_FIRST, spendAmount: 100, expected: []uint32{0, 1, 2, 3, 4}},
+		{strategy: pb.UtxoSelectionStrategy_SMALLEST_FIRST, spendAmount: 100, expected: []uint32{4, 3, 2, 1, 0}},
+		// The third address is the smallest to pay 250 by itself
+		{strategy: pb.UtxoSelectionStrategy_PRIVACY, spendAmount: 250, expected: []uint32{2, 0, 4, 1, 3}},
+		// No address pays 1000 by itself, so the largest ones come first
+		{strategy: pb.UtxoSelectionStrategy_PRIVACY, spendAmount: 1000, expected: []uint32{0, 4, 1, 3, 2}},
+	}
+	for _, test := range tests {
+		actual := utxoIndexes(orderUTXOs(candidates, test.strategy, test.spendAmount))
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s with spend amount %d: expected the order %v but got %v",
+				test.strategy, test.spendAmount, test.expected, actual)
+		}
+	}
+}
+
+func TestBranchAndBound(t *testing.T) {
+	values := []uint64{50, 30, 20, 10, 5}
+	acceptAll := func([]int) (bool, error) { return true, nil }
+
+	selected, found, err := branchAndBound(values, 0, 35, 36, acceptAll)
+	if err != nil {
+		t.Fatalf("branchAndBound: %+v", err)
+	}
+	if !found || !reflect.DeepEqual(selected, []int{1, 4}) {
+		t.Fatalf("expected to find the values 30 and 5, got %v (found: %t)", selected, found)
+	}
+
+	_, found, err = branchAndBound(values, 0, 116, 200, acceptAll)
+	if err != nil {
+		t.Fatalf("branchAndBound: %+v", err)
+	}
+	if found {
+		t.Fatalf("expected no subset to sum over the sum of all the values")
+	}
+
+	// The initial sum of the preselected values counts towards the bounds, and
+	// subsets that accept rejects are skipped
+	rejectFirst := func(selected []int) (bool, error) { return len(selected) > 0 && selected[0] != 0, nil }
+	selected, found, err = branchAndBound(values, 40, 60, 60, rejectFirst)
+	if err != nil {
+		t.Fatalf("branchAndBound: %+v", err)
+	}
+	if !found || !reflect.DeepEqual(selected, []int{2}) {
+		t.Fatalf("expected to find the value 20, got %v (found: %t)", selected, found)
+	}
+}
+
+func TestParseCoinControl(t *testing.T) {
+	outpoint := &pb.Outpoint{TransactionId: "0000000000000000000000000000000000000000000000000000000000000001", Index: 2}
+
+	_, err := parseCoinControl(&pb.CoinControl{Include: []*pb.Outpoint{outpoint}, Exclude: []*pb.Outpoint{outpoint}})
+	if err == nil {
+		t.Fatalf("expected an outpoint that's both included and excluded to be rejected")
+	}
+
+	parsed, err := parseCoinControl(&pb.CoinControl{Include: []*pb.Outpoint{outpoint, outpoint}})
+	if err != nil {
+		t.Fatalf("parseCoinControl: %+v", err)
+	}
+	if len(parsed.include) != 1 || parsed.include[0].Index != 2 {
+		t.Fatalf("expected the included outpoint once, got %v", parsed.include)
+	}
+
+	_, err = parseCoinControl(&pb.CoinControl{Strategy: 42})
+	if err == nil {
+		t.Fatalf("expected an unknown strategy to be rejected")
+	}
+
+	var noCoinControl *coinControl
+	if noCoinControl.isExcluded(&externalapi.DomainOutpoint{}) ||
+		noCoinControl.selectionStrategy() != pb.UtxoSelectionStrategy_LARGEST_FIRST {
+		t.Fatalf("expected a nil coin control to select UTXOs the default way")
+	}
+}
+
+func TestCheckUTXOAvailable(t *testing.T) {
+	address := &walletAddress{}
+	available, frozen, consolidating := testUTXO(0, 100, address), testUTXO(1, 100, address), testUTXO(2, 100, address)
+	used, expired := testUTXO(3, 100, address), testUTXO(4, 100, address)
+	now := time.Now()
+	serverInstance := &server{
+		frozenUTXOs: map[externalapi.DomainOutpoint]struct{}{*frozen.Outpoint: {}},
+		consolidation: &consolidationState{
+			consolidatingOutpoints: map[externalapi.DomainOutpoint]struct{}{*consolidating.Outpoint: {}},
+		},
+		usedOutpoints: map[externalapi.DomainOutpoint]time.Time{
+			*used.Outpoint:    now,
+			*expired.Outpoint: now.Add(-2 * time.Minute),
+		},
+		startTimeOfLastCompletedRefresh: now,
+	}
+	noneAllowed := map[externalapi.DomainOutpoint]struct{}{}
+
+	tests := []struct {
+		name        string
+		utxo        *walletUTXO
+		allowUsed   map[externalapi.DomainOutpoint]struct{}
+		expectedErr string
+	}{
+		{name: "available", utxo: available, allowUsed: noneAllowed},
+		{name: "frozen", utxo: frozen, allowUsed: noneAllowed, expectedErr: "is frozen"},
+		{name: "being consolidated", utxo: consolidating, allowUsed: noneAllowed,
+			expectedErr: "is being spent by a consolidation transaction"},
+		{name: "recently used", utxo: used, allowUsed: noneAllowed,
+			expectedErr: "is spent by a recently broadcast transaction"},
+		{name: "recently used and allowed", utxo: used,
+			allowUsed: map[externalapi.DomainOutpoint]struct{}{*used.Outpoint: {}}},
+		{name: "used long ago", utxo: expired, allowUsed: noneAllowed},
+	}
+	for _, test := range tests {
+		err := serverInstance.checkUTXOAvailable(test.utxo, test.allowUsed)
+		if test.expectedErr == "" {
+			if err != nil {
+				t.Errorf("%s: expected the UTXO to be available, got: %s", test.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.expectedErr) ||
+			!strings.Contains(err.Error(), test.utxo.Outpoint.String()) {
+			t.Errorf("%s: expected an error naming the outpoint that %s, got: %v", test.name, test.expectedErr, err)
+		}
+	}
+
+	if _, ok := serverInstance.usedOutpoints[*expired.Outpoint]; ok {
+		t.Errorf("expected the expired used outpoint to be forgotten")
+	}
+}
@@ -0,0 +1,507 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/walletdb"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/pkg/errors"
+)
+
+// ConsolidationConfig configures the background job that merges the small
+// UTXOs of the wallet into larger ones, so that sends don't need more inputs
+// than a transaction can have
+type ConsolidationConfig struct {
+	// Password decrypts the mnemonics that sign the consolidation transactions.
+	// It's ignored when the wallet has an external signer
+	Password string
+
+	// Interval is the time between consolidation runs
+	Interval time.Duration
+
+	// MaxFeeRate is the highest fee rate estimate of the node, in Sompi/gram,
+	// that consolidation runs at
+	MaxFeeRate float64
+
+	// FeeBudget is the maximum total fee, in Sompi, of the consolidation
+	// transactions of any 24 hours
+	FeeBudget uint64
+
+	// SmallUTXOThreshold is the amount, in Sompi, under which UTXOs are
+	// consolidated
+	SmallUTXOThreshold uint64
+
+	// MinUTXOs is the number of small UTXOs under which a run doesn't
+	// consolidate them
+	MinUTXOs int
+}
+
+const consolidationBudgetPeriod = 24 * time.Hour
+
+// maxConsolidationTransactionsPerRun keeps a run from flooding the mempool
+// when the wallet has a very large number of small UTXOs. The rest are left to
+// the next runs
+const maxConsolidationTransactionsPerRun = 10
+
+// consolidationState is the state of the consolidation job, which is guarded
+// by the server lock
+type consolidationState struct {
+	config *ConsolidationConfig
+
+	// spends are the fees of the consolidation transactions of the current
+	// budget period. They're kept in the wallet database as well, so that
+	// restarts don't reset the budget
+	spends []consolidationSpend
+
+	// consolidatingOutpoints are the UTXOs of the consolidation transactions
+	// that are being signed and broadcast, which sends don't select meanwhile
+	consolidatingOutpoints map[externalapi.DomainOutpoint]struct{}
+
+	consolidatedUTXOCount uint64
+	transactionCount      uint64
+	lastRun               time.Time
+	nextRun               time.Time
+	lastRunResult         string
+	lastRunTxIDs          []string
+}
+
+type consolidationSpend struct {
+	time time.Time
+	fee  uint64
+}
+
+func newConsolidationState(config *ConsolidationConfig, spends []*walletdb.ConsolidationSpend) *consolidationState {
+	state := &consolidationState{
+		config:                 config,
+		consolidatingOutpoints: map[externalapi.DomainOutpoint]struct{}{},
+		nextRun:                time.Now().Add(config.Interval),
+		lastRunResult:          "consolidation didn't run yet",
+	}
+	for _, spend := range spends {
+		state.spends = append(state.spends, consolidationSpend{time: time.UnixMilli(spend.Time), fee: spend.Fee})
+	}
+	return state
+}
+
+// loadConsolidationSpends returns the consolidation spends of the budget
+// period that ends now from the wallet database
+func loadConsolidationSpends(walletDB *walletdb.DB) ([]*walletdb.ConsolidationSpend, error) {
+	return walletDB.ConsolidationSpends(time.Now().Add(-consolidationBudgetPeriod).UnixMilli())
+}
+
+// feesSpent returns the total fee of the consolidation transactions of the
+// budget period that ends at now
+func (cs *consolidationState) feesSpent(now time.Time) uint64 {
+	for len(cs.spends) > 0 && now.Sub(cs.spends[0].time) >= consolidationBudgetPeriod {
+		cs.spends = cs.spends[1:]
+	}
+	feesSpent := uint64(0)
+	for _, spend := range cs.spends {
+		feesSpent += spend.fee
+	}
+	return feesSpent
+}
+
+func (cs *consolidationState) recordSpend(now time.Time, fee uint64, consolidatedUTXOCount int) {
+	cs.spends = append(cs.spends, consolidationSpend{time: now, fee: fee})
+	cs.consolidatedUTXOCount += uint64(consolidatedUTXOCount)
+	cs.transactionCount++
+}
+
+// checkConsolidationCanSign returns an error if the daemon would fail to sign
+// the consolidation transactions, so that it fails when it starts rather than
+// at the first consolidation run
+func checkConsolidationCanSign(keysFile *keys.File, externalSigner libkaspawallet.Signer, password string) error {
+	if externalSigner != nil {
+		return nil
+	}
+	if keysFile.IsWatchOnly() {
+		return errors.New("UTXO consolidation of a watch-only wallet requires an external signer")
+	}
+	_, err := keysFile.DecryptMnemonics(password)
+	if err != nil {
+		return errors.Wrap(err, "UTXO consolidation could not decrypt the keys file with the given password")
+	}
+	return nil
+}
+
+func (s *server) consolidationLoop() {
+	ticker := time.NewTicker(s.consolidation.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdown:
+			return
+		case <-ticker.C:
+			s.runConsolidation()
+		}
+	}
+}
+
+func (s *server) runConsolidation() {
+	now := time.Now()
+	txIDs, result, err := s.consolidate(now)
+	if err != nil {
+		result = fmt.Sprintf("failed: %s", err)
+		log.Warnf("Error consolidating UTXOs: %s", err)
+	} else {
+		log.Infof("UTXO consolidation %s", result)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.consolidation.lastRun = now
+	s.consolidation.nextRun = now.Add(s.consolidation.config.Interval)
+	s.consolidation.lastRunResult = result
+	s.consolidation.lastRunTxIDs = txIDs
+}
+
+// consolidationBatch is a consolidation transaction, along with the UTXOs it
+// merges and its fee
+type consolidationBatch struct {
+	utxos               []*walletUTXO
+	unsignedTransaction []byte
+	fee                 uint64
+}
+
+// consolidate sends transactions that merge small UTXOs of the wallet, and
+// returns their IDs along with a description of what it did. The server lock
+// is held while the transactions are created and recorded, but not through
+// the RPCs, the signing and the broadcast
+func (s *server) consolidate(now time.Time) (txIDs []string, result string, err error) {
+	config := s.consolidation.config
+	s.lock.RLock()
+	isSynced := s.isSynced()
+	s.lock.RUnlock()
+	if !isSynced {
+		return nil, "skipped: the wallet is not synced yet", nil
+	}
+
+	estimate, err := s.rpcClient.GetFeeEstimate()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(estimate.Estimate.NormalBuckets) == 0 {
+		return nil, "", errors.New("the node returned no fee estimate")
+	}
+	feeRate := estimate.Estimate.NormalBuckets[0].Feerate
+	if feeRate > config.MaxFeeRate {
+		return nil, fmt.Sprintf("skipped: the fee rate estimate %f is higher than %f", feeRate, config.MaxFeeRate), nil
+	}
+	feeRate = math.Max(feeRate, minFeeRate)
+
+	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		return nil, "", err
+	}
+
+	batches, signer, isAtFeeBudget, skippedResult, err := s.createConsolidationBatches(now, feeRate, dagInfo.VirtualDAAScore)
+	if err != nil || skippedResult != "" {
+		return nil, skippedResult, err
+	}
+
+	sentTransactions, txIDs, sendErr := s.sendConsolidationTransactions(signer, batches)
+	consolidatedUTXOCount := s.recordConsolidation(now, batches, sentTransactions, txIDs)
+	if sendErr != nil {
+		return txIDs, "", sendErr
+	}
+
+	if isAtFeeBudget {
+		return txIDs, fmt.Sprintf("consolidated %d UTXOs in %d transactions, and stopped at the fee budget",
+			consolidatedUTXOCount, len(txIDs)), nil
+	}
+	return txIDs, fmt.Sprintf("consolidated %d UTXOs in %d transactions", consolidatedUTXOCount, len(txIDs)), nil
+}
+
+// createConsolidationBatches creates the consolidation transactions of a run
+// within the fee budget, and the signer to sign them with. Their UTXOs are
+// marked as being consolidated until recordConsolidation is called. If the run
+// has nothing to do, it returns why in skippedResult instead
+func (s *server) createConsolidationBatches(now time.Time, feeRate float64, virtualDAAScore uint64) (
+	batches []*consolidationBatch, signer libkaspawallet.Signer, isAtFeeBudget bool, skippedResult string, err error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	config := s.consolidation.config
+	feePerInput, err := s.estimateFeePerInput(feeRate)
+	if err != nil {
+		return nil, nil, false, "", err
+	}
+	smallUTXOs := s.smallUTXOs(virtualDAAScore, config.SmallUTXOThreshold, feePerInput)
+	if len(smallUTXOs) < config.MinUTXOs {
+		return nil, nil, false, fmt.Sprintf("skipped: there are %d small UTXOs, fewer than %d",
+			len(smallUTXOs), config.MinUTXOs), nil
+	}
+
+	maxInputs, err := s.maxConsolidationInputs(smallUTXOs)
+	if err != nil {
+		return nil, nil, false, "", err
+	}
+
+	feesSpent := s.consolidation.feesSpent(now)
+	for len(batches) < maxConsolidationTransactionsPerRun && len(smallUTXOs) >= config.MinUTXOs {
+		batchUTXOs := smallUTXOs[:min(maxInputs, len(smallUTXOs))]
+		smallUTXOs = smallUTXOs[len(batchUTXOs):]
+
+		unsignedTransaction, fee, err := s.consolidationTransaction(batchUTXOs, feeRate)
+		if err != nil {
+			return nil, nil, false, "", err
+		}
+		if feesSpent+fee > config.FeeBudget {
+			isAtFeeBudget = true
+			break
+		}
+		feesSpent += fee
+		batches = append(batches, &consolidationBatch{
+			utxos:               batchUTXOs,
+			unsignedTransaction: unsignedTransaction,
+			fee:                 fee,
+		})
+	}
+	if len(batches) == 0 {
+		return nil, nil, true, "skipped: the fee budget is spent", nil
+	}
+
+	signer, err = s.transactionSigner(config.Password)
+	if err != nil {
+		return nil, nil, false, "", err
+	}
+	for _, batch := range batches {
+		for _, utxo := range batch.utxos {
+			s.consolidation.consolidatingOutpoints[*utxo.Outpoint] = struct{}{}
+		}
+	}
+	return batches, signer, isAtFeeBudget, "", nil
+}
+
+// sendConsolidationTransactions signs and broadcasts the transactions of
+// batches in order, and stops at the first that fails. It returns the
+// transactions it sent along with their IDs. It's called without the server
+// lock, since an external signer may take minutes to sign
+func (s *server) sendConsolidationTransactions(signer libkaspawallet.Signer, batches []*consolidationBatch) (
+	sentTransactions []*externalapi.DomainTransaction, txIDs []string, err error) {
+
+	for _, batch := range batches {
+		signedTransactions, err := signTransactionsWith(signer, [][]byte{batch.unsignedTransaction})
+		if err != nil {
+			return sentTransactions, txIDs, err
+		}
+		tx, err := libkaspawallet.ExtractTransaction(signedTransactions[0], s.keysFile.ECDSA)
+		if err != nil {
+			return sentTransactions, txIDs, err
+		}
+		txID, err := sendTransaction(s.rpcClient, tx)
+		if err != nil {
+			return sentTransactions, txIDs, err
+		}
+		sentTransactions = append(sentTransactions, tx)
+		txIDs = append(txIDs, txID)
+	}
+	return sentTransactions, txIDs, nil
+}
+
+// recordConsolidation records the consolidation transactions that were sent,
+// which are the first of batches, and releases the UTXOs of all of batches. It
+// returns the number of UTXOs the sent transactions consolidated
+func (s *server) recordConsolidation(now time.Time, batches []*consolidationBatch,
+	sentTransactions []*externalapi.DomainTransaction, txIDs []string) (consolidatedUTXOCount int) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i, tx := range sentTransactions {
+		batch := batches[i]
+		s.recordSentTransaction(tx)
+		for _, input := range tx.Inputs {
+			s.usedOutpoints[input.PreviousOutpoint] = time.Now()
+		}
+
+		// The transaction is already broadcast, so a failure to persist its
+		// fee is only logged
+		err := s.walletDB.AddConsolidationSpend(&walletdb.ConsolidationSpend{
+			TransactionID: txIDs[i],
+			Time:          now.UnixMilli(),
+			Fee:           batch.fee,
+		})
+		if err != nil {
+			log.Warnf("Error recording the fee of consolidation transaction %s: %s", txIDs[i], err)
+		}
+		s.consolidation.recordSpend(now, batch.fee, len(batch.utxos))
+		consolidatedUTXOCount += len(batch.utxos)
+	}
+	for _, batch := range batches {
+		for _, utxo := range batch.utxos {
+			delete(s.consolidation.consolidatingOutpoints, *utxo.Outpoint)
+		}
+	}
+
+	if len(sentTransactions) > 0 {
+		s.forceSync()
+	}
+	return consolidatedUTXOCount
+}
+
+// isBeingConsolidated returns whether the UTXO of the given outpoint is spent
+// by a consolidation transaction that is being signed and broadcast
+func (s *server) isBeingConsolidated(outpoint *externalapi.DomainOutpoint) bool {
+	if s.consolidation == nil {
+		return false
+	}
+	_, ok := s.consolidation.consolidatingOutpoints[*outpoint]
+	return ok
+}
+
+// smallUTXOs returns the spendable UTXOs the wallet may consolidate, from the
+// smallest to the largest. UTXOs that aren't worth more than the fee of
+// spending them are left alone
+func (s *server) smallUTXOs(virtualDAAScore uint64, threshold uint64, feePerInput uint64) []*walletUTXO {
+	var smallUTXOs []*walletUTXO
+	for i := len(s.utxosSortedByAmount) - 1; i >= 0; i-- {
+		utxo := s.utxosSortedByAmount[i]
+		amount := utxo.UTXOEntry.Amount()
+		if amount >= threshold {
+			break
+		}
+		if amount <= feePerInput || !s.isUTXOSpendable(utxo, virtualDAAScore) {
+			continue
+		}
+		if _, ok := s.frozenUTXOs[*utxo.Outpoint]; ok {
+			continue
+		}
+		if broadcastTime, ok := s.usedOutpoints[*utxo.Outpoint]; ok && !s.usedOutpointHasExpired(broadcastTime) {
+			continue
+		}
+		smallUTXOs = append(smallUTXOs, utxo)
+	}
+	return smallUTXOs
+}
+
+// maxConsolidationInputs returns how many inputs a consolidation transaction
+// can have without exceeding the standard transaction mass
+func (s *server) maxConsolidationInputs(smallUTXOs []*walletUTXO) (int, error) {
+	if len(smallUTXOs) < 2 {
+		return len(smallUTXOs), nil
+	}
+	massOfOne, err := s.consolidationTransactionMass(smallUTXOs[:1])
+	if err != nil {
+		return 0, err
+	}
+	massOfTwo, err := s.consolidationTransactionMass(smallUTXOs[:2])
+	if err != nil {
+		return 0, err
+	}
+	massPerInput := massOfTwo - massOfOne
+	if massPerInput == 0 || massOfOne > mempool.MaximumStandardTransactionMass {
+		return 0, errors.New("couldn't estimate the mass of consolidation transactions")
+	}
+
+	// Leave a margin for the estimation error
+	maxInputs := int((mempool.MaximumStandardTransactionMass-massOfOne)/massPerInput) + 1
+	return max(maxInputs*9/10, 2), nil
+}
+
+func (s *server) consolidationTransactionMass(utxos []*walletUTXO) (uint64, error) {
+	unsignedTransaction, _, err := s.createConsolidationTransaction(utxos, 0)
+	if err != nil {
+		return 0, err
+	}
+	return s.estimateMassAfterSignatures(unsignedTransaction)
+}
+
+// consolidationTransaction returns a serialized unsigned transaction that
+// merges the given UTXOs into a single output to the wallet, along with its fee
+func (s *server) consolidationTransaction(utxos []*walletUTXO, feeRate float64) ([]byte, uint64, error) {
+	unsignedTransaction, fee, err := s.createConsolidationTransaction(utxos, feeRate)
+	if err != nil {
+		return nil, 0, err
+	}
+	serialized, err := serialization.SerializePartiallySignedTransaction(unsignedTransaction)
+	if err != nil {
+		return nil, 0, err
+	}
+	return serialized, fee, nil
+}
+
+func (s *server) createConsolidationTransaction(utxos []*walletUTXO, feeRate float64) (
+	*serialization.PartiallySignedTransaction, uint64, error) {
+
+	changeAddress, _, err := s.changeAddress(true, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	selectedUTXOs := make([]*libkaspawallet.UTXO, len(utxos))
+	totalValue := uint64(0)
+	for i, utxo := range utxos {
+		selectedUTXOs[i] = &libkaspawallet.UTXO{
+			Outpoint:       utxo.Outpoint,
+			UTXOEntry:      utxo.UTXOEntry,
+			DerivationPath: s.walletAddressPath(utxo.address),
+		}
+		totalValue += utxo.UTXOEntry.Amount()
+	}
+
+	fee, err := s.estimateFee(selectedUTXOs, feeRate, math.MaxUint64, totalValue)
+	if err != nil {
+		return nil, 0, err
+	}
+	if fee >= totalValue {
+		return nil, 0, errors.Errorf("the fee of consolidating %d UTXOs is higher than their value", len(utxos))
+	}
+
+	payments := []*libkaspawallet.Payment{{Address: changeAddress, Amount: totalValue - fee}}
+	unsignedTransaction, err := libkaspawallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures, payments, selectedUTXOs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return unsignedTransaction, fee, nil
+}
+
+func (s *server) GetConsolidationStatus(_ context.Context, _ *pb.GetConsolidationStatusRequest) (
+	*pb.GetConsolidationStatusResponse, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.consolidation == nil {
+		return &pb.GetConsolidationStatusResponse{IsEnabled: false}, nil
+	}
+
+	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		return nil, err
+	}
+	feePerInput, err := s.estimateFeePerInput(minFeeRate)
+	if err != nil {
+		return nil, err
+	}
+	config := s.consolidation.config
+	response := &pb.GetConsolidationStatusResponse{
+		IsEnabled:             true,
+		SmallUtxoCount:        uint32(len(s.smallUTXOs(dagInfo.VirtualDAAScore, config.SmallUTXOThreshold, feePerInput))),
+		SmallUtxoThreshold:    config.SmallUTXOThreshold,
+		ConsolidatedUtxoCount: s.consolidation.consolidatedUTXOCount,
+		TransactionCount:      s.consolidation.transactionCount,
+		FeesSpent:             s.consolidation.feesSpent(time.Now()),
+		FeeBudget:             config.FeeBudget,
+		NextRun:               s.consolidation.nextRun.UnixMilli(),
+		LastRunResult:         s.consolidation.lastRunResult,
+		LastRunTxIDs:          s.consolidation.lastRunTxIDs,
+	}
+	if !s.consolidation.lastRun.IsZero() {
+		response.LastRun = s.consolidation.lastRun.UnixMilli()
+	}
+	return response, nil
+}
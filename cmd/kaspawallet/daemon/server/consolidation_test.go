@@ -0,0 +1,88 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/walletdb"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+func TestConsolidationFeesSpent(t *testing.T) {
+	state := newConsolidationState(&ConsolidationConfig{Interval: time.Hour}, nil)
+	start := time.Now()
+	state.recordSpend(start, 100, 10)
+	state.recordSpend(start.Add(time.Hour), 200, 20)
+
+	if feesSpent := state.feesSpent(start.Add(2 * time.Hour)); feesSpent != 300 {
+		t.Fatalf("expected 300 Sompi spent, got %d", feesSpent)
+	}
+	// The first spend leaves the budget period after 24 hours
+	if feesSpent := state.feesSpent(start.Add(consolidationBudgetPeriod)); feesSpent != 200 {
+		t.Fatalf("expected 200 Sompi spent, got %d", feesSpent)
+	}
+	if state.consolidatedUTXOCount != 30 || state.transactionCount != 2 {
+		t.Fatalf("expected 30 UTXOs consolidated in 2 transactions, got %d in %d",
+			state.consolidatedUTXOCount, state.transactionCount)
+	}
+}
+
+func TestLoadConsolidationSpends(t *testing.T) {
+	walletDB, err := walletdb.Open(filepath.Join(t.TempDir(), "keys.walletdb"))
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+	defer walletDB.Close()
+
+	now := time.Now()
+	for i, sent := range []time.Time{now.Add(-consolidationBudgetPeriod - time.Hour), now.Add(-time.Hour)} {
+		err = walletDB.AddConsolidationSpend(&walletdb.ConsolidationSpend{
+			TransactionID: fmt.Sprintf("%d", i),
+			Time:          sent.UnixMilli(),
+			Fee:           uint64(i+1) * 100,
+		})
+		if err != nil {
+			t.Fatalf("AddConsolidationSpend: %+v", err)
+		}
+	}
+
+	// Only the spend of the last 24 hours counts toward the budget of a
+	// restarted daemon
+	spends, err := loadConsolidationSpends(walletDB)
+	if err != nil {
+		t.Fatalf("loadConsolidationSpends: %+v", err)
+	}
+	state := newConsolidationState(&ConsolidationConfig{Interval: time.Hour}, spends)
+	if feesSpent := state.feesSpent(now); feesSpent != 200 {
+		t.Fatalf("expected 200 Sompi spent, got %d", feesSpent)
+	}
+}
+
+func TestSmallUTXOs(t *testing.T) {
+	address := &walletAddress{}
+	frozen, used := testUTXO(3, 300, address), testUTXO(4, 400, address)
+	serverInstance := &server{
+		// Sorted from the largest to the smallest
+		utxosSortedByAmount: []*walletUTXO{
+			testUTXO(0, 5000, address),
+			testUTXO(1, 900, address),
+			used,
+			frozen,
+			testUTXO(2, 200, address),
+			testUTXO(5, 10, address),
+		},
+		frozenUTXOs:   map[externalapi.DomainOutpoint]struct{}{*frozen.Outpoint: {}},
+		usedOutpoints: map[externalapi.DomainOutpoint]time.Time{*used.Outpoint: time.Now()},
+	}
+
+	// The UTXO of 10 Sompi isn't worth the fee of spending it, and the one
+	// of 5000 Sompi is above the threshold
+	actual := utxoIndexes(serverInstance.smallUTXOs(0, 1000, 50))
+	expected := []uint32{2, 1}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the small UTXOs %v but got %v", expected, actual)
+	}
+}
@@ -32,7 +32,7 @@ func (s *server) CreateUnsignedTransactions(_ context.Context, request *pb.Creat
 	defer s.lock.Unlock()
 
 	unsignedTransactions, err := s.createUnsignedTransactions(request.Address, request.Amount, request.IsSendAll,
-		request.From, request.UseExistingChangeAddress, request.FeePolicy)
+		request.From, request.UseExistingChangeAddress, request.FeePolicy, request.CoinControl)
 	if err != nil {
 		return nil, err
 	}
@@ -83,11 +83,18 @@ func (s *server) calculateFeeLimits(requestFeePolicy *pb.FeePolicy) (feeRate flo
 	return feeRate, maxFee, nil
 }
 
-func (s *server) createUnsignedTransactions(address string, amount uint64, isSendAll bool, fromAddressesString []string, useExistingChangeAddress bool, requestFeePolicy *pb.FeePolicy) ([][]byte, error) {
+func (s *server) createUnsignedTransactions(address string, amount uint64, isSendAll bool, fromAddressesString []string,
+	useExistingChangeAddress bool, requestFeePolicy *pb.FeePolicy, requestCoinControl *pb.CoinControl) ([][]byte, error) {
+
 	if !s.isSynced() {
 		return nil, errors.Errorf("wallet daemon is not synced yet, %s", s.formatSyncStateReport())
 	}
 
+	coinControl, err := parseCoinControl(requestCoinControl)
+	if err != nil {
+		return nil, err
+	}
+
 	feeRate, maxFee, err := s.calculateFeeLimits(requestFeePolicy)
 	if err != nil {
 		return nil, err
@@ -114,7 +121,7 @@ func (s *server) createUnsignedTransactions(address string, amount uint64, isSen
 		return nil, err
 	}
 
-	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOs(amount, isSendAll, feeRate, maxFee, fromAddresses)
+	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOs(amount, isSendAll, feeRate, maxFee, fromAddresses, coinControl)
 	if err != nil {
 		return nil, err
 	}
@@ -140,19 +147,36 @@ func (s *server) createUnsignedTransactions(address string, amount uint64, isSen
 		return nil, err
 	}
 
-	unsignedTransactions, err := s.maybeAutoCompoundTransaction(unsignedTransaction, toAddress, changeAddress, changeWalletAddress, feeRate, maxFee)
+	unsignedTransactions, err := s.maybeAutoCompoundTransaction(unsignedTransaction, toAddress, changeAddress, changeWalletAddress, feeRate, maxFee, coinControl)
 	if err != nil {
 		return nil, err
 	}
 	return unsignedTransactions, nil
 }
 
-func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feeRate float64, maxFee uint64, fromAddresses []*walletAddress) (
-	selectedUTXOs []*libkaspawallet.UTXO, totalReceived uint64, changeSompi uint64, err error) {
-	return s.selectUTXOsWithPreselected(nil, map[externalapi.DomainOutpoint]struct{}{}, spendAmount, isSendAll, feeRate, maxFee, fromAddresses)
+func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feeRate float64, maxFee uint64, fromAddresses []*walletAddress,
+	coinControl *coinControl) (selectedUTXOs []*libkaspawallet.UTXO, totalReceived uint64, changeSompi uint64, err error) {
+
+	var includedUTXOs []*walletUTXO
+	if coinControl != nil && len(coinControl.include) > 0 {
+		dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		includedUTXOs, err = s.includedUTXOs(coinControl, fromAddresses, dagInfo.VirtualDAAScore)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	return s.selectUTXOsWithPreselected(includedUTXOs, map[externalapi.DomainOutpoint]struct{}{}, spendAmount, isSendAll,
+		feeRate, maxFee, fromAddresses, coinControl)
 }
 
-func (s *server) selectUTXOsWithPreselected(preSelectedUTXOs []*walletUTXO, allowUsed map[externalapi.DomainOutpoint]struct{}, spendAmount uint64, isSendAll bool, feeRate float64, maxFee uint64, fromAddresses []*walletAddress) (
+// selectUTXOsWithPreselected selects all the preselected UTXOs, and
+// adds UTXOs of the wallet to them, in the order of the coin control strategy,
+// until they pay spendAmount and the fee
+func (s *server) selectUTXOsWithPreselected(preSelectedUTXOs []*walletUTXO, allowUsed map[externalapi.DomainOutpoint]struct{},
+	spendAmount uint64, isSendAll bool, feeRate float64, maxFee uint64, fromAddresses []*walletAddress, coinControl *coinControl) (
 	selectedUTXOs []*libkaspawallet.UTXO, totalReceived uint64, changeSompi uint64, err error) {
 
 	preSelectedSet := make(map[externalapi.DomainOutpoint]struct{})
@@ -166,29 +190,13 @@ func (s *server) selectUTXOsWithPreselected(preSelectedUTXOs []*walletUTXO, allo
 		return nil, 0, 0, err
 	}
 
-	var fee uint64
-	iteration := func(utxo *walletUTXO, avoidPreselected bool) (bool, error) {
-		if (fromAddresses != nil && !walletAddressesContain(fromAddresses, utxo.address)) ||
-			!s.isUTXOSpendable(utxo, dagInfo.VirtualDAAScore) {
-			return true, nil
-		}
-
-		if broadcastTime, ok := s.usedOutpoints[*utxo.Outpoint]; ok {
-			if _, ok := allowUsed[*utxo.Outpoint]; !ok {
-				if s.usedOutpointHasExpired(broadcastTime) {
-					delete(s.usedOutpoints, *utxo.Outpoint)
-				} else {
-					return true, nil
-				}
-			}
-		}
-
-		if avoidPreselected {
-			if _, ok := preSelectedSet[*utxo.Outpoint]; ok {
-				return true, nil
-			}
-		}
+	isSelectable := func(utxo *walletUTXO) bool {
+		return (fromAddresses == nil || walletAddressesContain(fromAddresses, utxo.address)) &&
+			s.isUTXOSpendable(utxo, dagInfo.VirtualDAAScore) && s.checkUTXOAvailable(utxo, allowUsed) == nil
+	}
 
+	var fee uint64
+	iteration := func(utxo *walletUTXO) (bool, error) {
 		selectedUTXOs = append(selectedUTXOs, &libkaspawallet.UTXO{
 			Outpoint:       utxo.Outpoint,
 			UTXOEntry:      utxo.UTXOEntry,
@@ -219,28 +227,64 @@ func (s *server) selectUTXOsWithPreselected(preSelectedUTXOs []*walletUTXO, allo
 		return true, nil
 	}
 
+	// All the preselected UTXOs are spent, even if fewer would do
 	shouldContinue := true
 	for _, utxo := range preSelectedUTXOs {
-		shouldContinue, err = iteration(utxo, false)
+		err := s.checkUTXOAvailable(utxo, allowUsed)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if !isSelectable(utxo) {
+			return nil, 0, 0, errors.Errorf("outpoint %s is not a spendable UTXO of the from addresses", utxo.Outpoint)
+		}
+		shouldContinue, err = iteration(utxo)
 		if err != nil {
 			return nil, 0, 0, err
 		}
+	}
+
+	var candidates []*walletUTXO
+	for _, utxo := range s.utxosSortedByAmount {
+		if _, ok := preSelectedSet[*utxo.Outpoint]; ok {
+			continue
+		}
+		if coinControl.isExcluded(utxo.Outpoint) {
+			continue
+		}
+		if isSelectable(utxo) {
+			candidates = append(candidates, utxo)
+		}
+	}
 
-		if !shouldContinue {
-			break
+	strategy := coinControl.selectionStrategy()
+	if shouldContinue && !isSendAll && strategy == pb.UtxoSelectionStrategy_BRANCH_AND_BOUND {
+		withoutChange, found, err := s.selectUTXOsWithoutChange(preSelectedUTXOs, candidates, spendAmount, feeRate, maxFee)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if found {
+			return withoutChange, spendAmount, 0, nil
 		}
 	}
 
 	if shouldContinue {
-		for _, utxo := range s.utxosSortedByAmount {
-			shouldContinue, err := iteration(utxo, true)
-			if err != nil {
-				return nil, 0, 0, err
+		var lastSelectedAddress *walletAddress
+		for _, utxo := range orderUTXOs(candidates, strategy, spendAmount) {
+			// The privacy strategy spends all the UTXOs of the addresses it
+			// spends from, since leaving some of them would link the address
+			// to the addresses of a later transaction
+			keepsAddressWhole := strategy == pb.UtxoSelectionStrategy_PRIVACY &&
+				lastSelectedAddress != nil && *utxo.address == *lastSelectedAddress
+			if !shouldContinue && !keepsAddressWhole {
+				break
 			}
 
-			if !shouldContinue {
-				break
+			iterationShouldContinue, err := iteration(utxo)
+			if err != nil {
+				return nil, 0, 0, err
 			}
+			shouldContinue = shouldContinue && iterationShouldContinue
+			lastSelectedAddress = utxo.address
 		}
 	}
 
@@ -260,6 +304,123 @@ func (s *server) selectUTXOsWithPreselected(preSelectedUTXOs []*walletUTXO, allo
 	return selectedUTXOs, totalReceived, totalValue - totalSpend, nil
 }
 
+// checkUTXOAvailable returns an error naming the given UTXO if the wallet may not
+// spend it, even though it's spendable: when it's frozen, spent by a running
+// consolidation, or spent by a recently broadcast transaction that isn't in
+// allowUsed. The wallet skips such UTXOs when it selects UTXOs by itself, but
+// the UTXOs it's told to spend aren't skipped silently
+func (s *server) checkUTXOAvailable(utxo *walletUTXO, allowUsed map[externalapi.DomainOutpoint]struct{}) error {
+	if _, ok := s.frozenUTXOs[*utxo.Outpoint]; ok {
+		return errors.Errorf("outpoint %s is frozen", utxo.Outpoint)
+	}
+	if s.isBeingConsolidated(utxo.Outpoint) {
+		return errors.Errorf("outpoint %s is being spent by a consolidation transaction", utxo.Outpoint)
+	}
+
+	if broadcastTime, ok := s.usedOutpoints[*utxo.Outpoint]; ok {
+		if _, ok := allowUsed[*utxo.Outpoint]; !ok {
+			if !s.usedOutpointHasExpired(broadcastTime) {
+				return errors.Errorf("outpoint %s is spent by a recently broadcast transaction", utxo.Outpoint)
+			}
+			delete(s.usedOutpoints, *utxo.Outpoint)
+		}
+	}
+	return nil
+}
+
+// selectUTXOsWithoutChange looks, with branch and bound, for candidate UTXOs
+// that together with the preselected ones pay spendAmount and the fee with
+// less left over than a change output would cost. The leftover goes to the fee
+func (s *server) selectUTXOsWithoutChange(preSelectedUTXOs []*walletUTXO, candidates []*walletUTXO, spendAmount uint64,
+	feeRate float64, maxFee uint64) ([]*libkaspawallet.UTXO, bool, error) {
+
+	if len(preSelectedUTXOs) == 0 && len(candidates) == 0 {
+		return nil, false, nil
+	}
+	feePerInput, err := s.estimateFeePerInput(feeRate)
+	if err != nil {
+		return nil, false, err
+	}
+	// A change output costs about an input to create, and another one to spend later
+	costOfChange := 2 * feePerInput
+
+	toLibkaspawalletUTXO := func(utxo *walletUTXO) *libkaspawallet.UTXO {
+		return &libkaspawallet.UTXO{
+			Outpoint:       utxo.Outpoint,
+			UTXOEntry:      utxo.UTXOEntry,
+			DerivationPath: s.walletAddressPath(utxo.address),
+		}
+	}
+
+	// The search is over the values of the UTXOs minus the fees of spending
+	// them, and the fee of the rest of the transaction is estimated with the
+	// first UTXO. Each set the search finds is checked with the exact fee
+	var firstUTXO *walletUTXO
+	if len(preSelectedUTXOs) > 0 {
+		firstUTXO = preSelectedUTXOs[0]
+	} else {
+		firstUTXO = candidates[0]
+	}
+	feeWithFirstUTXO, err := s.estimateFee([]*libkaspawallet.UTXO{toLibkaspawalletUTXO(firstUTXO)}, feeRate, maxFee,
+		firstUTXO.UTXOEntry.Amount())
+	if err != nil {
+		return nil, false, err
+	}
+	lower := spendAmount
+	if feeWithFirstUTXO > feePerInput {
+		lower += feeWithFirstUTXO - feePerInput
+	}
+	upper := lower + costOfChange
+
+	effectiveValue := func(utxo *walletUTXO) uint64 {
+		if utxo.UTXOEntry.Amount() <= feePerInput {
+			return 0
+		}
+		return utxo.UTXOEntry.Amount() - feePerInput
+	}
+	initialSum := uint64(0)
+	for _, utxo := range preSelectedUTXOs {
+		initialSum += effectiveValue(utxo)
+	}
+	var values []uint64
+	var valuedCandidates []*walletUTXO
+	for _, utxo := range candidates {
+		if value := effectiveValue(utxo); value > 0 {
+			values = append(values, value)
+			valuedCandidates = append(valuedCandidates, utxo)
+		}
+	}
+
+	var selectedUTXOs []*libkaspawallet.UTXO
+	accept := func(selected []int) (bool, error) {
+		selectedUTXOs = make([]*libkaspawallet.UTXO, 0, len(preSelectedUTXOs)+len(selected))
+		totalValue := uint64(0)
+		for _, utxo := range preSelectedUTXOs {
+			selectedUTXOs = append(selectedUTXOs, toLibkaspawalletUTXO(utxo))
+			totalValue += utxo.UTXOEntry.Amount()
+		}
+		for _, index := range selected {
+			selectedUTXOs = append(selectedUTXOs, toLibkaspawalletUTXO(valuedCandidates[index]))
+			totalValue += valuedCandidates[index].UTXOEntry.Amount()
+		}
+		if len(selectedUTXOs) == 0 {
+			return false, nil
+		}
+
+		fee, err := s.estimateFee(selectedUTXOs, feeRate, maxFee, totalValue)
+		if err != nil {
+			return false, err
+		}
+		return totalValue >= spendAmount+fee && totalValue-spendAmount <= min(fee+costOfChange, maxFee), nil
+	}
+
+	_, found, err := branchAndBound(values, initialSum, lower, upper, accept)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return selectedUTXOs, true, nil
+}
+
 func (s *server) estimateFee(selectedUTXOs []*libkaspawallet.UTXO, feeRate float64, maxFee uint64, recipientValue uint64) (uint64, error) {
 	fakePubKey := [util.PublicKeySizeECDSA]byte{}
 	fakeAddr, err := util.NewAddressPublicKeyECDSA(fakePubKey[:], s.params.Prefix) // We assume the worst case where the recipient address is ECDSA. In this case the scriptPubKey will be the longest.
@@ -0,0 +1,283 @@
+package server
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/walletdb"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+)
+
+// recordSentTransaction adds a transaction the wallet broadcast to its history,
+// as pending until it's accepted. Transactions that spend no wallet UTXOs, such
+// as sweeps, are recorded as received once their outputs arrive instead.
+//
+// The transaction is already broadcast by the time it's recorded, so failures are
+// only logged. The caller must hold s.lock
+func (s *server) recordSentTransaction(tx *externalapi.DomainTransaction) {
+	err := s.addSentTransactionToHistory(tx)
+	if err != nil {
+		log.Warnf("Error recording sent transaction %s in the wallet history: %s", consensushashing.TransactionID(tx), err)
+	}
+}
+
+func (s *server) addSentTransactionToHistory(tx *externalapi.DomainTransaction) error {
+	walletUTXOs := make(map[externalapi.DomainOutpoint]*walletUTXO, len(s.utxosSortedByAmount)+len(s.mempoolExcludedUTXOs))
+	for _, utxo := range s.utxosSortedByAmount {
+		walletUTXOs[*utxo.Outpoint] = utxo
+	}
+	for outpoint, utxo := range s.mempoolExcludedUTXOs {
+		walletUTXOs[outpoint] = utxo
+	}
+
+	sentTransaction := &walletdb.Transaction{
+		ID:        consensushashing.TransactionID(tx).String(),
+		FirstSeen: time.Now().UnixMilli(),
+	}
+	inputsAmount := uint64(0)
+	areAllInputAmountsKnown := true
+	for _, input := range tx.Inputs {
+		utxo, isWalletUTXO := walletUTXOs[input.PreviousOutpoint]
+		if isWalletUTXO {
+			sentTransaction.Inputs = append(sentTransaction.Inputs, &walletdb.Outpoint{
+				TransactionID: input.PreviousOutpoint.TransactionID.String(),
+				Index:         input.PreviousOutpoint.Index,
+			})
+		}
+		switch {
+		case input.UTXOEntry != nil:
+			inputsAmount += input.UTXOEntry.Amount()
+		case isWalletUTXO:
+			inputsAmount += utxo.UTXOEntry.Amount()
+		default:
+			areAllInputAmountsKnown = false
+		}
+	}
+	if !sentTransaction.IsSent() {
+		return nil
+	}
+
+	outputsAmount := uint64(0)
+	for _, output := range tx.Outputs {
+		outputsAmount += output.Value
+		address := ""
+		_, extractedAddress, err := txscript.ExtractScriptPubKeyAddress(output.ScriptPublicKey, s.params)
+		if err == nil {
+			address = extractedAddress.String()
+		}
+		_, isWalletAddress := s.addressSet[address]
+		if !isWalletAddress {
+			sentTransaction.SentAmount += output.Value
+		}
+		sentTransaction.Outputs = append(sentTransaction.Outputs, &walletdb.Output{
+			Address:         address,
+			Amount:          output.Value,
+			IsWalletAddress: isWalletAddress,
+		})
+	}
+	if areAllInputAmountsKnown && inputsAmount >= outputsAmount {
+		sentTransaction.Fee = inputsAmount - outputsAmount
+	}
+
+	// Transactions that spent the same inputs before were replaced by this one
+	pendingSpends, err := s.walletDB.PendingSpends()
+	if err != nil {
+		return err
+	}
+	for _, input := range sentTransaction.Inputs {
+		spendingTransactionID, ok := pendingSpends[*input]
+		if !ok || spendingTransactionID == sentTransaction.ID {
+			continue
+		}
+		err = s.walletDB.UpdateTransaction(spendingTransactionID,
+			func(replacedTransaction *walletdb.Transaction, found bool) (*walletdb.Transaction, error) {
+				if !found || replacedTransaction.IsAccepted {
+					return nil, nil
+				}
+				replacedTransaction.ReplacedBy = sentTransaction.ID
+				return replacedTransaction, nil
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The change of the transaction may have been received, and recorded, before
+	// the transaction itself
+	err = s.walletDB.UpdateTransaction(sentTransaction.ID,
+		func(existingTransaction *walletdb.Transaction, found bool) (*walletdb.Transaction, error) {
+			if found {
+				sentTransaction.FirstSeen = existingTransaction.FirstSeen
+				sentTransaction.IsAccepted = existingTransaction.IsAccepted
+				sentTransaction.AcceptingDAAScore = existingTransaction.AcceptingDAAScore
+				sentTransaction.AcceptingBlockHash = existingTransaction.AcceptingBlockHash
+				sentTransaction.Label = existingTransaction.Label
+			}
+			return sentTransaction, nil
+		})
+	if err != nil {
+		return err
+	}
+	if sentTransaction.IsAccepted {
+		return nil
+	}
+	return s.walletDB.AddPendingSpends(sentTransaction)
+}
+
+// recordUTXOsChangedHistory records the transactions whose outputs were added to
+// the wallet UTXO set by the given notifications, and accepts the pending
+// transactions they reveal were accepted
+func (s *server) recordUTXOsChangedHistory(utxosChangedNotifications []*appmessage.UTXOsChangedNotificationMessage) error {
+	for _, notification := range utxosChangedNotifications {
+		err := s.recordReceivedUTXOs(notification.Added, true)
+		if err != nil {
+			return err
+		}
+	}
+	return s.acceptSpentTransactions()
+}
+
+// recordReceivedUTXOs records the transactions that created the given UTXOs
+// as accepted. Transactions the wallet didn't know are recorded as received.
+// The accepting blocks of the transactions are resolved only if requested,
+// since it takes a query per transaction
+func (s *server) recordReceivedUTXOs(entries []*appmessage.UTXOsByAddressesEntry, resolveAcceptingBlocks bool) error {
+	var transactionIDs []string
+	entriesByTransactionID := make(map[string][]*appmessage.UTXOsByAddressesEntry)
+	for _, entry := range entries {
+		transactionID := entry.Outpoint.TransactionID
+		if _, ok := entriesByTransactionID[transactionID]; !ok {
+			transactionIDs = append(transactionIDs, transactionID)
+		}
+		entriesByTransactionID[transactionID] = append(entriesByTransactionID[transactionID], entry)
+	}
+
+	for _, transactionID := range transactionIDs {
+		transactionEntries := entriesByTransactionID[transactionID]
+		acceptingDAAScore := transactionEntries[0].UTXOEntry.BlockDAAScore
+		isNewlyAccepted := false
+		err := s.walletDB.UpdateTransaction(transactionID,
+			func(transaction *walletdb.Transaction, found bool) (*walletdb.Transaction, error) {
+				if found {
+					if transaction.IsAccepted {
+						return nil, nil
+					}
+					isNewlyAccepted = true
+					return acceptTransaction(transaction, acceptingDAAScore), nil
+				}
+
+				isNewlyAccepted = true
+				transaction = &walletdb.Transaction{
+					ID:        transactionID,
+					FirstSeen: time.Now().UnixMilli(),
+				}
+				for _, entry := range transactionEntries {
+					transaction.ReceivedAmount += entry.UTXOEntry.Amount
+					transaction.Outputs = append(transaction.Outputs, &walletdb.Output{
+						Address:         entry.Address,
+						Amount:          entry.UTXOEntry.Amount,
+						IsWalletAddress: true,
+					})
+				}
+				return acceptTransaction(transaction, acceptingDAAScore), nil
+			})
+		if err != nil {
+			return err
+		}
+		if !isNewlyAccepted {
+			continue
+		}
+
+		err = s.onTransactionAccepted(transactionID, resolveAcceptingBlocks)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func acceptTransaction(transaction *walletdb.Transaction, acceptingDAAScore uint64) *walletdb.Transaction {
+	transaction.IsAccepted = true
+	transaction.AcceptingDAAScore = acceptingDAAScore
+	transaction.ReplacedBy = ""
+	return transaction
+}
+
+// acceptSpentTransactions accepts the pending transactions sent by the wallet
+// whose inputs left the wallet UTXO set, which happens only once a spending
+// transaction is accepted. This covers the transactions that have no change.
+//
+// The wallet UTXO set doesn't tell the DAA score these transactions were
+// accepted at, so it's left for the node to resolve
+func (s *server) acceptSpentTransactions() error {
+	pendingSpends, err := s.walletDB.PendingSpends()
+	if err != nil {
+		return err
+	}
+
+	acceptedTransactionIDs := make(map[string]struct{})
+	for outpoint, transactionID := range pendingSpends {
+		transactionIDHash, err := externalapi.NewDomainTransactionIDFromString(outpoint.TransactionID)
+		if err != nil {
+			return err
+		}
+		if _, ok := s.utxos[externalapi.DomainOutpoint{TransactionID: *transactionIDHash, Index: outpoint.Index}]; ok {
+			continue
+		}
+		acceptedTransactionIDs[transactionID] = struct{}{}
+	}
+
+	for transactionID := range acceptedTransactionIDs {
+		err := s.walletDB.UpdateTransaction(transactionID,
+			func(transaction *walletdb.Transaction, found bool) (*walletdb.Transaction, error) {
+				if !found || transaction.IsAccepted {
+					return nil, nil
+				}
+				return acceptTransaction(transaction, 0), nil
+			})
+		if err != nil {
+			return err
+		}
+
+		err = s.onTransactionAccepted(transactionID, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onTransactionAccepted releases the inputs of an accepted transaction, and
+// resolves its accepting block if requested
+func (s *server) onTransactionAccepted(transactionID string, resolveAcceptingBlock bool) error {
+	transaction, found, err := s.walletDB.Transaction(transactionID)
+	if err != nil || !found {
+		return err
+	}
+	err = s.walletDB.RemovePendingSpends(transaction)
+	if err != nil {
+		return err
+	}
+	if !resolveAcceptingBlock {
+		return nil
+	}
+
+	// Nodes resolve the accepting blocks of transactions only when they
+	// run with a transaction index, so failing to do it isn't an error
+	getTransactionResponse, err := s.backgroundRPCClient.GetTransaction(transactionID)
+	if err != nil {
+		log.Debugf("Could not resolve the accepting block of transaction %s: %s", transactionID, err)
+		return nil
+	}
+	return s.walletDB.UpdateTransaction(transactionID,
+		func(transaction *walletdb.Transaction, found bool) (*walletdb.Transaction, error) {
+			if !found || getTransactionResponse.AcceptingBlockHash == "" {
+				return nil, nil
+			}
+			transaction.AcceptingBlockHash = getTransactionResponse.AcceptingBlockHash
+			transaction.AcceptingDAAScore = getTransactionResponse.AcceptingBlockDAAScore
+			return transaction, nil
+		})
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/walletdb"
+)
+
+func TestRecordReceivedUTXOs(t *testing.T) {
+	walletDB, err := walletdb.Open(filepath.Join(t.TempDir(), "keys.walletdb"))
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+	defer walletDB.Close()
+	serverInstance := &server{walletDB: walletDB}
+
+	entry := func(transactionID uint64, index uint32, amount uint64) *appmessage.UTXOsByAddressesEntry {
+		return &appmessage.UTXOsByAddressesEntry{
+			Address: fmt.Sprintf("kaspa:address%d", index),
+			Outpoint: &appmessage.RPCOutpoint{
+				TransactionID: fmt.Sprintf("%064x", transactionID),
+				Index:         index,
+			},
+			UTXOEntry: &appmessage.RPCUTXOEntry{Amount: amount, BlockDAAScore: 100 + transactionID},
+		}
+	}
+
+	// The change of a sent transaction accepts it rather than being recorded as received
+	sentTransactionID := fmt.Sprintf("%064x", 2)
+	sentTransaction := &walletdb.Transaction{
+		ID:         sentTransactionID,
+		SentAmount: 7,
+		Inputs:     []*walletdb.Outpoint{{TransactionID: fmt.Sprintf("%064x", 9), Index: 0}},
+	}
+	err = walletDB.UpdateTransaction(sentTransactionID, func(_ *walletdb.Transaction, _ bool) (*walletdb.Transaction, error) {
+		return sentTransaction, nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateTransaction: %+v", err)
+	}
+	err = walletDB.AddPendingSpends(sentTransaction)
+	if err != nil {
+		t.Fatalf("AddPendingSpends: %+v", err)
+	}
+
+	err = serverInstance.recordReceivedUTXOs([]*appmessage.UTXOsByAddressesEntry{entry(1, 0, 10), entry(1, 1, 20), entry(2, 1, 3)}, false)
+	if err != nil {
+		t.Fatalf("recordReceivedUTXOs: %+v", err)
+	}
+
+	transactions, err := walletDB.Transactions()
+	if err != nil {
+		t.Fatalf("Transactions: %+v", err)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("expected 2 transactions but got %d", len(transactions))
+	}
+	received := transactions[0]
+	if !received.IsAccepted || received.AcceptingDAAScore != 101 || received.ReceivedAmount != 30 || len(received.Outputs) != 2 {
+		t.Fatalf("unexpected received transaction %+v", received)
+	}
+	sent := transactions[1]
+	if !sent.IsAccepted || sent.AcceptingDAAScore != 102 || sent.ReceivedAmount != 0 || sent.SentAmount != 7 {
+		t.Fatalf("unexpected sent transaction %+v", sent)
+	}
+	pendingSpends, err := walletDB.PendingSpends()
+	if err != nil {
+		t.Fatalf("PendingSpends: %+v", err)
+	}
+	if len(pendingSpends) != 0 {
+		t.Fatalf("expected the inputs of the accepted transaction to be released")
+	}
+}
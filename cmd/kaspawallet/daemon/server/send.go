@@ -4,29 +4,52 @@ import (
 	"context"
 
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
 	"github.com/pkg/errors"
 )
 
 func (s *server) Send(_ context.Context, request *pb.SendRequest) (*pb.SendResponse, error) {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-
-	unsignedTransactions, err := s.createUnsignedTransactions(request.ToAddress, request.Amount, request.IsSendAll,
-		request.From, request.UseExistingChangeAddress, request.FeePolicy)
-
+	unsignedTransactions, signer, reservation, err := s.createTransactionsToSend(request)
 	if err != nil {
 		return nil, err
 	}
 
-	signedTransactions, err := s.signTransactions(unsignedTransactions, request.Password)
+	signedTransactions, err := s.signReserved(signer, unsignedTransactions, reservation)
 	if err != nil {
 		return nil, err
 	}
 
-	txIDs, err := s.broadcast(signedTransactions, false)
+	txIDs, err := s.broadcastReserved(signedTransactions, false, reservation)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error broadcasting transactions %s", EncodeTransactionsToHex(signedTransactions))
 	}
 
 	return &pb.SendResponse{TxIDs: txIDs, SignedTransactions: signedTransactions}, nil
 }
+
+// createTransactionsToSend creates the transactions of the given request and
+// reserves their outpoints, so that they may be signed and broadcast without
+// the server lock
+func (s *server) createTransactionsToSend(request *pb.SendRequest) (
+	unsignedTransactions [][]byte, signer libkaspawallet.Signer, reservation outpointReservation, err error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	unsignedTransactions, err = s.createUnsignedTransactions(request.ToAddress, request.Amount, request.IsSendAll,
+		request.From, request.UseExistingChangeAddress, request.FeePolicy, request.CoinControl)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	signer, err = s.transactionSigner(request.Password)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reservation, err = s.reserveOutpoints(unsignedTransactions)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return unsignedTransactions, signer, reservation, nil
+}
@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/version"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
@@ -18,6 +19,9 @@ import (
 
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/externalsigner"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/walletdb"
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
 	"github.com/kaspanet/kaspad/infrastructure/os/signal"
@@ -38,16 +42,31 @@ type server struct {
 	lock                            sync.RWMutex
 	utxosSortedByAmount             []*walletUTXO
 	mempoolExcludedUTXOs            map[externalapi.DomainOutpoint]*walletUTXO
-	nextSyncStartIndex              uint32
+	nextSyncStartIndex              uint32 // The first index whose addresses aren't watched yet
 	keysFile                        *keys.File
+	externalSigner                  libkaspawallet.Signer // Signs instead of the keys file mnemonics when set
+	walletDB                        *walletdb.DB
 	shutdown                        chan struct{}
 	forceSyncChan                   chan struct{}
+	reconnectedChan                 chan struct{}
+	fullRefreshChan                 chan struct{}
+	utxosChangedChan                chan struct{}
 	startTimeOfLastCompletedRefresh time.Time
 	addressSet                      walletAddressSet
 	txMassCalculator                *txmass.Calculator
 	usedOutpoints                   map[externalapi.DomainOutpoint]time.Time
+	frozenUTXOs                     map[externalapi.DomainOutpoint]struct{} // Never spent until they are unfrozen
+	consolidation                   *consolidationState                     // nil unless UTXO consolidation is enabled
 	firstSyncDone                   atomic.Bool
 
+	// utxos and mempoolSpentOutpoints are owned by syncLoop, which publishes
+	// them to utxosSortedByAmount and mempoolExcludedUTXOs
+	utxos                 map[externalapi.DomainOutpoint]*walletUTXO
+	mempoolSpentOutpoints map[externalapi.DomainOutpoint]struct{}
+
+	pendingUTXOsChanged     []*appmessage.UTXOsChangedNotificationMessage
+	pendingUTXOsChangedLock sync.Mutex
+
 	isLogFinalProgressLineShown bool
 	maxUsedAddressesForLog      uint32
 	maxProcessedAddressesForLog uint32
@@ -58,7 +77,8 @@ type server struct {
 const MaxDaemonSendMsgSize = 100_000_000
 
 // Start starts the kaspawalletd server
-func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath string, profile string, timeout uint32) error {
+func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath string, profile string, timeout uint32,
+	signerAddress string, consolidationConfig *ConsolidationConfig) error {
 	initLog(defaultLogFile, defaultErrLogFile)
 
 	defer panics.HandlePanic(log, "MAIN", nil)
@@ -96,6 +116,37 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		return err
 	}
 
+	walletDB, err := walletdb.Open(walletdb.PathForKeysFile(keysFile.Path()))
+	if err != nil {
+		return err
+	}
+	frozenUTXOs, err := loadFrozenUTXOs(walletDB)
+	if err != nil {
+		return err
+	}
+
+	var externalSigner libkaspawallet.Signer
+	if signerAddress != "" {
+		externalSigner, err = externalsigner.New(signerAddress, params.Name)
+		if err != nil {
+			return err
+		}
+		log.Infof("Signing transactions with the external signer %s", signerAddress)
+	}
+
+	var consolidation *consolidationState
+	if consolidationConfig != nil {
+		err := checkConsolidationCanSign(keysFile, externalSigner, consolidationConfig.Password)
+		if err != nil {
+			return err
+		}
+		consolidationSpends, err := loadConsolidationSpends(walletDB)
+		if err != nil {
+			return err
+		}
+		consolidation = newConsolidationState(consolidationConfig, consolidationSpends)
+	}
+
 	// Post-Crescendo coinbase maturity
 	coinbaseMaturity := uint64(1000)
 
@@ -108,11 +159,20 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		mempoolExcludedUTXOs:        map[externalapi.DomainOutpoint]*walletUTXO{},
 		nextSyncStartIndex:          0,
 		keysFile:                    keysFile,
+		externalSigner:              externalSigner,
+		walletDB:                    walletDB,
 		shutdown:                    make(chan struct{}),
-		forceSyncChan:               make(chan struct{}),
+		forceSyncChan:               make(chan struct{}, 1),
+		reconnectedChan:             make(chan struct{}, 1),
+		fullRefreshChan:             make(chan struct{}, 1),
+		utxosChangedChan:            make(chan struct{}, 1),
 		addressSet:                  make(walletAddressSet),
 		txMassCalculator:            txmass.NewCalculator(params.MassPerTxByte, params.MassPerScriptPubKeyByte, params.MassPerSigOp),
 		usedOutpoints:               map[externalapi.DomainOutpoint]time.Time{},
+		frozenUTXOs:                 frozenUTXOs,
+		consolidation:               consolidation,
+		utxos:                       map[externalapi.DomainOutpoint]*walletUTXO{},
+		mempoolSpentOutpoints:       map[externalapi.DomainOutpoint]struct{}{},
 		isLogFinalProgressLineShown: false,
 		maxUsedAddressesForLog:      0,
 		maxProcessedAddressesForLog: 0,
@@ -126,6 +186,12 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		}
 	})
 
+	if consolidation != nil {
+		log.Infof("Consolidating UTXOs smaller than %d Sompi every %s", consolidationConfig.SmallUTXOThreshold,
+			consolidationConfig.Interval)
+		spawn("serverInstance.consolidationLoop", serverInstance.consolidationLoop)
+	}
+
 	grpcServer := grpc.NewServer(grpc.MaxSendMsgSize(MaxDaemonSendMsgSize))
 	pb.RegisterKaspawalletdServer(grpcServer, serverInstance)
 
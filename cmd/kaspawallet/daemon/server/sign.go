@@ -3,30 +3,33 @@ package server
 import (
 	"context"
 
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
 
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
 )
 
 func (s *server) Sign(_ context.Context, request *pb.SignRequest) (*pb.SignResponse, error) {
+	// Signing doesn't touch the wallet state, and an external signer may take
+	// up to signTimeout, so the lock is held only while the signer is created
 	s.lock.Lock()
-	defer s.lock.Unlock()
-
-	signedTransactions, err := s.signTransactions(request.UnsignedTransactions, request.Password)
+	signer, err := s.transactionSigner(request.Password)
+	s.lock.Unlock()
 	if err != nil {
 		return nil, err
 	}
-	return &pb.SignResponse{SignedTransactions: signedTransactions}, nil
-}
 
-func (s *server) signTransactions(unsignedTransactions [][]byte, password string) ([][]byte, error) {
-	mnemonics, err := s.keysFile.DecryptMnemonics(password)
+	signedTransactions, err := signTransactionsWith(signer, request.UnsignedTransactions)
 	if err != nil {
 		return nil, err
 	}
+	return &pb.SignResponse{SignedTransactions: signedTransactions}, nil
+}
+
+func signTransactionsWith(signer libkaspawallet.Signer, unsignedTransactions [][]byte) ([][]byte, error) {
 	signedTransactions := make([][]byte, len(unsignedTransactions))
 	for i, unsignedTransaction := range unsignedTransactions {
-		signedTransaction, err := libkaspawallet.Sign(s.params, mnemonics, unsignedTransaction, s.keysFile.ECDSA)
+		signedTransaction, err := signer.Sign(unsignedTransaction)
 		if err != nil {
 			return nil, err
 		}
@@ -34,3 +37,21 @@ func (s *server) signTransactions(unsignedTransactions [][]byte, password string
 	}
 	return signedTransactions, nil
 }
+
+// transactionSigner returns the external signer of the wallet if it has one.
+// Otherwise, it returns a signer of the keys file mnemonics, decrypted with
+// the given password, or keys.ErrWatchOnly if the wallet has no mnemonics
+func (s *server) transactionSigner(password string) (libkaspawallet.Signer, error) {
+	if s.externalSigner != nil {
+		return s.externalSigner, nil
+	}
+	if s.keysFile.IsWatchOnly() {
+		return nil, keys.ErrWatchOnly
+	}
+
+	mnemonics, err := s.keysFile.DecryptMnemonics(password)
+	if err != nil {
+		return nil, err
+	}
+	return libkaspawallet.NewMnemonicSigner(s.params, mnemonics, s.keysFile.ECDSA), nil
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
+	"github.com/pkg/errors"
+)
+
+// recordingSigner is a mock external signer that marks the transactions it
+// signs by appending a byte to them
+type recordingSigner struct {
+	signedTransactions [][]byte
+}
+
+func (rs *recordingSigner) Sign(serializedPSTx []byte) ([]byte, error) {
+	rs.signedTransactions = append(rs.signedTransactions, serializedPSTx)
+	return append(append([]byte{}, serializedPSTx...), 0xff), nil
+}
+
+func TestSignWithExternalSigner(t *testing.T) {
+	signer := &recordingSigner{}
+	// The wallet has no keys file: with an external signer, it's never read
+	serverInstance := &server{externalSigner: signer}
+
+	unsignedTransactions := [][]byte{{1, 2}, {3}}
+	response, err := serverInstance.Sign(context.Background(), &pb.SignRequest{
+		UnsignedTransactions: unsignedTransactions,
+		Password:             "ignored",
+	})
+	if err != nil {
+		t.Fatalf("Sign: %+v", err)
+	}
+
+	if len(signer.signedTransactions) != len(unsignedTransactions) {
+		t.Fatalf("expected the external signer to sign %d transactions but it signed %d",
+			len(unsignedTransactions), len(signer.signedTransactions))
+	}
+	for i, signedTransaction := range response.SignedTransactions {
+		expected := append(append([]byte{}, unsignedTransactions[i]...), 0xff)
+		if !bytes.Equal(signedTransaction, expected) {
+			t.Fatalf("expected signed transaction %d to be %x but got %x", i, expected, signedTransaction)
+		}
+	}
+}
+
+func TestSignWithWatchOnlyWallet(t *testing.T) {
+	serverInstance := &server{keysFile: &keys.File{
+		ExtendedPublicKeys: []string{"xpub"},
+		MinimumSignatures:  1,
+	}}
+
+	_, err := serverInstance.Sign(context.Background(), &pb.SignRequest{UnsignedTransactions: [][]byte{{1}}})
+	if !errors.Is(err, keys.ErrWatchOnly) {
+		t.Fatalf("expected a watch-only wallet to refuse to sign, got: %v", err)
+	}
+
+	// An external signer signs for the watch-only wallet
+	signer := &recordingSigner{}
+	serverInstance.externalSigner = signer
+	_, err = serverInstance.Sign(context.Background(), &pb.SignRequest{UnsignedTransactions: [][]byte{{1}}})
+	if err != nil {
+		t.Fatalf("Sign: %+v", err)
+	}
+	if len(signer.signedTransactions) != 1 {
+		t.Fatalf("expected the external signer to sign the transaction")
+	}
+}
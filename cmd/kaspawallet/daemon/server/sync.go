@@ -24,15 +24,28 @@ func (was walletAddressSet) strings() []string {
 	return addresses
 }
 
+// syncLoop keeps the wallet UTXO set in sync with the node.
+//
+// After scanning for the used addresses, it subscribes once to UTXOs changed
+// notifications for every derived address and loads the UTXO set in full.
+// From then on the UTXO set is only updated by the notifications, and it's loaded
+// in full again only after reconnecting to the node or after the node's pruning
+// point UTXO set is overridden.
 func (s *server) syncLoop() error {
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
+	s.backgroundRPCClient.SetOnReconnectedHandler(func() {
+		signalSyncLoop(s.reconnectedChan)
+	})
 
 	err := s.collectRecentAddresses()
 	if err != nil {
 		return err
 	}
 
+	err = s.registerForNotifications()
+	if err != nil {
+		return err
+	}
+
 	err = s.refreshUTXOs()
 	if err != nil {
 		return err
@@ -41,35 +54,55 @@ func (s *server) syncLoop() error {
 	s.firstSyncDone.Store(true)
 	log.Infof("Wallet is synced and ready for operation")
 
+	// The ticker only checks whether addresses were derived locally, and
+	// doesn't query the node unless they were
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
 	for {
 		select {
-		case <-ticker.C:
+		case <-s.utxosChangedChan:
+			err = s.applyPendingUTXOsChanged()
+		case <-s.reconnectedChan:
+			log.Infof("Reconnected to the node, reloading the wallet UTXO set")
+			err = s.registerForNotifications()
+			if err == nil {
+				err = s.refreshUTXOs()
+			}
+		case <-s.fullRefreshChan:
+			log.Infof("The pruning point UTXO set of the node was overridden, reloading the wallet UTXO set")
+			err = s.refreshUTXOs()
 		case <-s.forceSyncChan:
+			err = s.refreshMempoolSpentOutpoints()
+		case <-ticker.C:
+		}
+		if err != nil {
+			return err
 		}
 
-		err := s.sync()
+		err = s.watchNewAddresses()
 		if err != nil {
 			return err
 		}
 	}
 }
 
-func (s *server) sync() error {
-	err := s.collectFarAddresses()
-	if err != nil {
-		return err
+// signalSyncLoop wakes the sync loop up through the given channel,
+// unless it was already signalled through it
+func signalSyncLoop(channel chan struct{}) {
+	select {
+	case channel <- struct{}{}:
+	default:
 	}
-
-	err = s.collectRecentAddresses()
-	if err != nil {
-		return err
-	}
-
-	return s.refreshUTXOs()
 }
 
 const (
-	numIndexesToQueryForFarAddresses    = 100
+	// numIndexesToLookAhead is the number of indexes past the last
+	// used one whose addresses are watched for incoming UTXOs
+	numIndexesToLookAhead = 1000
+
+	// numIndexesToQueryForRecentAddresses is the number of indexes whose
+	// addresses are scanned together while looking for the used addresses
 	numIndexesToQueryForRecentAddresses = 1000
 )
 
@@ -99,20 +132,6 @@ func (s *server) addressesToQuery(start, end uint32) (walletAddressSet, error) {
 	return addresses, nil
 }
 
-// collectFarAddresses collects numIndexesToQueryForFarAddresses addresses
-// from the last point it stopped in the previous call.
-func (s *server) collectFarAddresses() error {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-	err := s.collectAddresses(s.nextSyncStartIndex, s.nextSyncStartIndex+numIndexesToQueryForFarAddresses)
-	if err != nil {
-		return err
-	}
-
-	s.nextSyncStartIndex += numIndexesToQueryForFarAddresses
-	return nil
-}
-
 func (s *server) maxUsedIndexWithLock() uint32 {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -184,9 +203,14 @@ func (s *server) collectAddresses(start, end uint32) error {
 
 func (s *server) updateAddressesAndLastUsedIndexes(requestedAddressSet walletAddressSet,
 	getBalancesByAddressesResponse *appmessage.GetBalancesByAddressesResponseMessage) error {
-	lastUsedExternalIndex := s.keysFile.LastUsedExternalIndex()
-	lastUsedInternalIndex := s.keysFile.LastUsedInternalIndex()
 
+	for address, walletAddress := range requestedAddressSet {
+		if _, ok := s.addressSet[address]; !ok {
+			s.addressSet[address] = walletAddress
+		}
+	}
+
+	usedAddresses := make([]*walletAddress, 0, len(getBalancesByAddressesResponse.Entries))
 	for _, entry := range getBalancesByAddressesResponse.Entries {
 		walletAddress, ok := requestedAddressSet[entry.Address]
 		if !ok {
@@ -196,9 +220,19 @@ func (s *server) updateAddressesAndLastUsedIndexes(requestedAddressSet walletAdd
 		if entry.Balance == 0 {
 			continue
 		}
+		usedAddresses = append(usedAddresses, walletAddress)
+	}
 
-		s.addressSet[entry.Address] = walletAddress
+	return s.updateLastUsedIndexes(usedAddresses)
+}
 
+// updateLastUsedIndexes raises the last used indexes of the key chains to
+// the ones of the given addresses, if they're higher
+func (s *server) updateLastUsedIndexes(usedAddresses []*walletAddress) error {
+	lastUsedExternalIndex := s.keysFile.LastUsedExternalIndex()
+	lastUsedInternalIndex := s.keysFile.LastUsedInternalIndex()
+
+	for _, walletAddress := range usedAddresses {
 		if walletAddress.keyChain == libkaspawallet.ExternalKeychain {
 			if walletAddress.index > lastUsedExternalIndex {
 				lastUsedExternalIndex = walletAddress.index
@@ -219,67 +253,216 @@ func (s *server) updateAddressesAndLastUsedIndexes(requestedAddressSet walletAdd
 	return s.keysFile.SetLastUsedInternalIndex(lastUsedInternalIndex)
 }
 
-func (s *server) usedOutpointHasExpired(outpointBroadcastTime time.Time) bool {
-	// If the node returns a UTXO we previously attempted to spend and enough time has passed, we assume
-	// that the network rejected or lost the previous transaction and allow a reuse. We set this time
-	// interval to a minute.
-	// We also verify that a full refresh UTXO operation started after this time point and has already
-	// completed, in order to make sure that indeed this state reflects a state obtained following the required wait time.
-	return s.startTimeOfLastCompletedRefresh.After(outpointBroadcastTime.Add(time.Minute))
+// registerForNotifications subscribes to UTXOs changed notifications for all
+// the collected addresses, and to pruning point UTXO set override notifications.
+// Registrations don't survive reconnections, so it's called again after every one.
+// Reconnecting stops the listeners of the previous registration, so the handlers
+// are never active more than once
+func (s *server) registerForNotifications() error {
+	s.lock.RLock()
+	addresses := s.addressSet.strings()
+	s.lock.RUnlock()
+
+	err := s.backgroundRPCClient.RegisterForUTXOsChangedNotifications(addresses, s.onUTXOsChanged)
+	if err != nil {
+		return err
+	}
+
+	return s.backgroundRPCClient.RegisterPruningPointUTXOSetNotifications(func() {
+		signalSyncLoop(s.fullRefreshChan)
+	})
 }
 
-// updateUTXOSet clears the current UTXO set, and re-fills it with the given entries
-func (s *server) updateUTXOSet(entries []*appmessage.UTXOsByAddressesEntry, mempoolEntries []*appmessage.MempoolEntryByAddress, refreshStart time.Time) error {
-	utxos := make([]*walletUTXO, 0, len(entries))
+// onUTXOsChanged queues the given notification for the sync loop. It must not block,
+// or else it would stall the notifications route of the RPC client
+func (s *server) onUTXOsChanged(notification *appmessage.UTXOsChangedNotificationMessage) {
+	s.pendingUTXOsChangedLock.Lock()
+	s.pendingUTXOsChanged = append(s.pendingUTXOsChanged, notification)
+	s.pendingUTXOsChangedLock.Unlock()
 
-	exclude := make(map[appmessage.RPCOutpoint]struct{})
-	for _, entriesByAddress := range mempoolEntries {
-		for _, entry := range entriesByAddress.Sending {
-			for _, input := range entry.Transaction.Inputs {
-				exclude[*input.PreviousOutpoint] = struct{}{}
+	signalSyncLoop(s.utxosChangedChan)
+}
+
+func (s *server) takePendingUTXOsChanged() []*appmessage.UTXOsChangedNotificationMessage {
+	s.pendingUTXOsChangedLock.Lock()
+	defer s.pendingUTXOsChangedLock.Unlock()
+
+	pendingUTXOsChanged := s.pendingUTXOsChanged
+	s.pendingUTXOsChanged = nil
+	return pendingUTXOsChanged
+}
+
+// applyPendingUTXOsChanged applies all the queued UTXOs changed notifications
+// to the wallet UTXO set
+func (s *server) applyPendingUTXOsChanged() error {
+	applyStart := time.Now()
+	pendingUTXOsChanged := s.takePendingUTXOsChanged()
+	if len(pendingUTXOsChanged) == 0 {
+		return nil
+	}
+
+	err := s.applyUTXOsChanged(pendingUTXOsChanged)
+	if err != nil {
+		return err
+	}
+	err = s.recordUTXOsChangedHistory(pendingUTXOsChanged)
+	if err != nil {
+		return err
+	}
+
+	// Transactions that spend wallet UTXOs leave the mempool either by being
+	// accepted, which the notifications already reflect, or by being evicted,
+	// which only the mempool itself tells. Either way, it's enough to query the
+	// mempool only while such transactions are known
+	if len(s.mempoolSpentOutpoints) > 0 {
+		err = s.loadMempoolSpentOutpoints()
+		if err != nil {
+			return err
+		}
+	}
+
+	s.updateUTXOSet(applyStart)
+	return nil
+}
+
+// applyUTXOsChanged applies the given notifications, in the order they were
+// received, to the wallet UTXO set. Applying a notification whose changes are
+// already in the set has no effect
+func (s *server) applyUTXOsChanged(utxosChangedNotifications []*appmessage.UTXOsChangedNotificationMessage) error {
+	for _, notification := range utxosChangedNotifications {
+		for _, entry := range notification.Removed {
+			outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
+			if err != nil {
+				return err
 			}
+			delete(s.utxos, *outpoint)
+		}
+
+		err := s.addUTXOs(notification.Added)
+		if err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	mempoolExcludedUTXOs := make(map[externalapi.DomainOutpoint]*walletUTXO)
+// addUTXOs adds the given entries to the wallet UTXO set, and marks their
+// addresses as used
+func (s *server) addUTXOs(entries []*appmessage.UTXOsByAddressesEntry) error {
+	usedAddresses := make([]*walletAddress, 0, len(entries))
+	s.lock.RLock()
 	for _, entry := range entries {
 		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
 		if err != nil {
+			s.lock.RUnlock()
 			return err
 		}
 
 		utxoEntry, err := appmessage.RPCUTXOEntryToUTXOEntry(entry.UTXOEntry)
 		if err != nil {
+			s.lock.RUnlock()
 			return err
 		}
 
-		// No need to lock for reading since the only writer of this set is on `syncLoop` on the same goroutine.
 		address, ok := s.addressSet[entry.Address]
 		if !ok {
+			s.lock.RUnlock()
 			return errors.Errorf("Got result from address %s even though it wasn't requested", entry.Address)
 		}
 
-		utxo := &walletUTXO{
+		s.utxos[*outpoint] = &walletUTXO{
 			Outpoint:  outpoint,
 			UTXOEntry: utxoEntry,
 			address:   address,
 		}
+		usedAddresses = append(usedAddresses, address)
+	}
+	s.lock.RUnlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.updateLastUsedIndexes(usedAddresses)
+}
+
+// watchNewAddresses makes sure the addresses of the numIndexesToLookAhead indexes
+// following the last used one are watched. The last used indexes grow when the
+// wallet derives new addresses, and when UTXOs are received in addresses close
+// to the end of the watched ones
+func (s *server) watchNewAddresses() error {
+	for {
+		s.lock.RLock()
+		start := s.nextSyncStartIndex
+		end := s.maxUsedIndex() + numIndexesToLookAhead
+		s.lock.RUnlock()
+		if start >= end {
+			return nil
+		}
+
+		addressSet, err := s.addressesToQuery(start, end)
+		if err != nil {
+			return err
+		}
+		addresses := addressSet.strings()
 
-		if _, ok := exclude[*entry.Outpoint]; ok {
-			mempoolExcludedUTXOs[*outpoint] = utxo
-		} else {
-			utxos = append(utxos, &walletUTXO{
-				Outpoint:  outpoint,
-				UTXOEntry: utxoEntry,
-				address:   address,
-			})
+		s.lock.Lock()
+		for address, walletAddress := range addressSet {
+			s.addressSet[address] = walletAddress
 		}
+		s.nextSyncStartIndex = end
+		s.lock.Unlock()
+
+		// Registering before querying the UTXOs makes sure that no change
+		// that happens in between is missed
+		err = s.backgroundRPCClient.RegisterAddressesForUTXOsChangedNotifications(addresses)
+		if err != nil {
+			return err
+		}
+		queryStart := time.Now()
+		getUTXOsByAddressesResponse, err := s.backgroundRPCClient.GetUTXOsByAddresses(addresses)
+		if err != nil {
+			return err
+		}
+
+		err = s.addUTXOs(getUTXOsByAddressesResponse.Entries)
+		if err != nil {
+			return err
+		}
+		err = s.recordReceivedUTXOs(getUTXOsByAddressesResponse.Entries, false)
+		if err != nil {
+			return err
+		}
+		s.updateUTXOSet(queryStart)
+	}
+}
+
+func (s *server) usedOutpointHasExpired(outpointBroadcastTime time.Time) bool {
+	// If the node returns a UTXO we previously attempted to spend and enough time has passed, we assume
+	// that the network rejected or lost the previous transaction and allow a reuse. We set this time
+	// interval to a minute.
+	// We also verify that the UTXO set was brought up to date after this time point, in order
+	// to make sure that indeed this state reflects a state obtained following the required wait time.
+	return s.startTimeOfLastCompletedRefresh.After(outpointBroadcastTime.Add(time.Minute))
+}
+
+// updateUTXOSet publishes the wallet UTXO set, excluding the UTXOs spent by
+// transactions in the mempool, to the wallet operations. updateStart is the
+// time the UTXO set was known to be up to date at
+func (s *server) updateUTXOSet(updateStart time.Time) {
+	utxos := make([]*walletUTXO, 0, len(s.utxos))
+	mempoolExcludedUTXOs := make(map[externalapi.DomainOutpoint]*walletUTXO)
+	for outpoint, utxo := range s.utxos {
+		if _, ok := s.mempoolSpentOutpoints[outpoint]; ok {
+			mempoolExcludedUTXOs[outpoint] = utxo
+			continue
+		}
+		utxos = append(utxos, utxo)
 	}
 
 	sort.Slice(utxos, func(i, j int) bool { return utxos[i].UTXOEntry.Amount() > utxos[j].UTXOEntry.Amount() })
 
 	s.lock.Lock()
-	s.startTimeOfLastCompletedRefresh = refreshStart
+	s.startTimeOfLastCompletedRefresh = updateStart
 	s.utxosSortedByAmount = utxos
 	s.mempoolExcludedUTXOs = mempoolExcludedUTXOs
 
@@ -290,41 +473,101 @@ func (s *server) updateUTXOSet(entries []*appmessage.UTXOsByAddressesEntry, memp
 		}
 	}
 	s.lock.Unlock()
+}
 
+// refreshMempoolSpentOutpoints reloads the outpoints spent by the transactions
+// of the wallet addresses in the mempool
+func (s *server) refreshMempoolSpentOutpoints() error {
+	refreshStart := time.Now()
+	err := s.loadMempoolSpentOutpoints()
+	if err != nil {
+		return err
+	}
+	s.updateUTXOSet(refreshStart)
+	return nil
+}
+
+func (s *server) loadMempoolSpentOutpoints() error {
+	s.lock.RLock()
+	addresses := s.addressSet.strings()
+	s.lock.RUnlock()
+
+	mempoolEntriesByAddresses, err := s.backgroundRPCClient.GetMempoolEntriesByAddresses(addresses, true, true)
+	if err != nil {
+		return err
+	}
+
+	mempoolSpentOutpoints := make(map[externalapi.DomainOutpoint]struct{})
+	for _, entriesByAddress := range mempoolEntriesByAddresses.Entries {
+		for _, entry := range entriesByAddress.Sending {
+			for _, input := range entry.Transaction.Inputs {
+				outpoint, err := appmessage.RPCOutpointToDomainOutpoint(input.PreviousOutpoint)
+				if err != nil {
+					return err
+				}
+				mempoolSpentOutpoints[*outpoint] = struct{}{}
+			}
+		}
+	}
+	s.mempoolSpentOutpoints = mempoolSpentOutpoints
 	return nil
 }
 
+// refreshUTXOs loads the wallet UTXO set in full
 func (s *server) refreshUTXOs() error {
 	refreshStart := time.Now()
 
-	// No need to lock for reading since the only writer of this set is on `syncLoop` on the same goroutine.
-	addresses := s.addressSet.strings()
 	// It's important to check the mempool before calling `GetUTXOsByAddresses`:
 	// If we would do it the other way around an output can be spent in the mempool
 	// and not in consensus, and between the calls its spending transaction will be
 	// added to consensus and removed from the mempool, so `getUTXOsByAddressesResponse`
 	// will include an obsolete output.
-	mempoolEntriesByAddresses, err := s.backgroundRPCClient.GetMempoolEntriesByAddresses(addresses, true, true)
+	err := s.loadMempoolSpentOutpoints()
 	if err != nil {
 		return err
 	}
 
+	s.lock.RLock()
+	addresses := s.addressSet.strings()
+	s.lock.RUnlock()
+
+	// The changes of the notifications received so far are all included in the
+	// loaded UTXO set. The ones of notifications received while it's loading might
+	// not be, so they're applied on top of it
+	s.takePendingUTXOsChanged()
 	getUTXOsByAddressesResponse, err := s.backgroundRPCClient.GetUTXOsByAddresses(addresses)
 	if err != nil {
 		return err
 	}
 
-	return s.updateUTXOSet(getUTXOsByAddressesResponse.Entries, mempoolEntriesByAddresses.Entries, refreshStart)
+	s.utxos = make(map[externalapi.DomainOutpoint]*walletUTXO, len(getUTXOsByAddressesResponse.Entries))
+	err = s.addUTXOs(getUTXOsByAddressesResponse.Entries)
+	if err != nil {
+		return err
+	}
+	pendingUTXOsChanged := s.takePendingUTXOsChanged()
+	err = s.applyUTXOsChanged(pendingUTXOsChanged)
+	if err != nil {
+		return err
+	}
+
+	// The loaded UTXO set includes the UTXOs received while the daemon wasn't
+	// running or connected, so their transactions are added to the history too
+	err = s.recordReceivedUTXOs(getUTXOsByAddressesResponse.Entries, false)
+	if err != nil {
+		return err
+	}
+	err = s.recordUTXOsChangedHistory(pendingUTXOsChanged)
+	if err != nil {
+		return err
+	}
+
+	s.updateUTXOSet(refreshStart)
+	return nil
 }
 
 func (s *server) forceSync() {
-	// Technically if two callers check the `if` simultaneously they will both spawn a
-	// goroutine, but we don't care about the small redundancy in such a rare case.
-	if len(s.forceSyncChan) == 0 {
-		go func() {
-			s.forceSyncChan <- struct{}{}
-		}()
-	}
+	signalSyncLoop(s.forceSyncChan)
 }
 
 func (s *server) isSynced() bool {
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+func TestApplyUTXOsChanged(t *testing.T) {
+	address := &walletAddress{}
+	serverInstance := &server{
+		keysFile:              &keys.File{},
+		addressSet:            walletAddressSet{"kaspa:address": address},
+		utxos:                 map[externalapi.DomainOutpoint]*walletUTXO{},
+		mempoolSpentOutpoints: map[externalapi.DomainOutpoint]struct{}{},
+	}
+
+	entry := func(index uint32, amount uint64) *appmessage.UTXOsByAddressesEntry {
+		return &appmessage.UTXOsByAddressesEntry{
+			Address: "kaspa:address",
+			Outpoint: &appmessage.RPCOutpoint{
+				TransactionID: fmt.Sprintf("%064x", 1),
+				Index:         index,
+			},
+			UTXOEntry: &appmessage.RPCUTXOEntry{
+				Amount:          amount,
+				ScriptPublicKey: &appmessage.RPCScriptPublicKey{Script: "51"},
+			},
+		}
+	}
+	checkAmounts := func(expectedAmounts ...uint64) {
+		if len(serverInstance.utxosSortedByAmount) != len(expectedAmounts) {
+			t.Fatalf("expected %d UTXOs but got %d", len(expectedAmounts), len(serverInstance.utxosSortedByAmount))
+		}
+		for i, utxo := range serverInstance.utxosSortedByAmount {
+			if utxo.UTXOEntry.Amount() != expectedAmounts[i] {
+				t.Fatalf("expected UTXO %d to have amount %d but got %d", i, expectedAmounts[i], utxo.UTXOEntry.Amount())
+			}
+			if utxo.address != address {
+				t.Fatalf("expected UTXO %d to belong to the wallet address", i)
+			}
+		}
+	}
+
+	added := &appmessage.UTXOsChangedNotificationMessage{Added: []*appmessage.UTXOsByAddressesEntry{entry(0, 10), entry(1, 30)}}
+	spent := &appmessage.UTXOsChangedNotificationMessage{
+		Added:   []*appmessage.UTXOsByAddressesEntry{entry(2, 20)},
+		Removed: []*appmessage.UTXOsByAddressesEntry{entry(0, 10)},
+	}
+	err := serverInstance.applyUTXOsChanged([]*appmessage.UTXOsChangedNotificationMessage{added, spent})
+	if err != nil {
+		t.Fatalf("applyUTXOsChanged: %+v", err)
+	}
+	serverInstance.updateUTXOSet(time.Now())
+	checkAmounts(30, 20)
+
+	// Notifications whose changes are already in the UTXO set change nothing
+	err = serverInstance.applyUTXOsChanged([]*appmessage.UTXOsChangedNotificationMessage{spent})
+	if err != nil {
+		t.Fatalf("applyUTXOsChanged: %+v", err)
+	}
+	serverInstance.updateUTXOSet(time.Now())
+	checkAmounts(30, 20)
+
+	// UTXOs spent in the mempool aren't offered to the wallet operations
+	mempoolSpentOutpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry(1, 30).Outpoint)
+	if err != nil {
+		t.Fatalf("RPCOutpointToDomainOutpoint: %+v", err)
+	}
+	serverInstance.mempoolSpentOutpoints[*mempoolSpentOutpoint] = struct{}{}
+	serverInstance.updateUTXOSet(time.Now())
+	checkAmounts(20)
+	if _, ok := serverInstance.mempoolExcludedUTXOs[*mempoolSpentOutpoint]; !ok {
+		t.Fatalf("expected the UTXO spent in the mempool to be excluded")
+	}
+
+	unknownAddressEntry := entry(3, 1)
+	unknownAddressEntry.Address = "kaspa:unknown"
+	err = serverInstance.applyUTXOsChanged([]*appmessage.UTXOsChangedNotificationMessage{
+		{Added: []*appmessage.UTXOsByAddressesEntry{unknownAddressEntry}},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for a UTXO of an address that isn't watched")
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/pkg/errors"
+)
+
+func (s *server) GetTransactions(_ context.Context, _ *pb.GetTransactionsRequest) (*pb.GetTransactionsResponse, error) {
+	transactions, err := s.walletDB.Transactions()
+	if err != nil {
+		return nil, err
+	}
+	addressLabels, err := s.walletDB.AddressLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	walletTransactions := make([]*pb.WalletTransaction, len(transactions))
+	for i, transaction := range transactions {
+		outputs := make([]*pb.WalletTransactionOutput, len(transaction.Outputs))
+		for j, output := range transaction.Outputs {
+			outputs[j] = &pb.WalletTransactionOutput{
+				Address:         output.Address,
+				Amount:          output.Amount,
+				IsWalletAddress: output.IsWalletAddress,
+				AddressLabel:    addressLabels[output.Address],
+			}
+		}
+		walletTransactions[i] = &pb.WalletTransaction{
+			TxID:               transaction.ID,
+			ReceivedAmount:     transaction.ReceivedAmount,
+			SentAmount:         transaction.SentAmount,
+			Fee:                transaction.Fee,
+			Outputs:            outputs,
+			IsAccepted:         transaction.IsAccepted,
+			AcceptingDaaScore:  transaction.AcceptingDAAScore,
+			AcceptingBlockHash: transaction.AcceptingBlockHash,
+			ReplacedBy:         transaction.ReplacedBy,
+			FirstSeen:          transaction.FirstSeen,
+			Label:              transaction.Label,
+		}
+	}
+
+	return &pb.GetTransactionsResponse{Transactions: walletTransactions}, nil
+}
+
+func (s *server) SetLabel(_ context.Context, request *pb.SetLabelRequest) (*pb.SetLabelResponse, error) {
+	switch target := request.Target.(type) {
+	case *pb.SetLabelRequest_TxID:
+		err := s.walletDB.SetTransactionLabel(target.TxID, request.Label)
+		if err != nil {
+			return nil, err
+		}
+	case *pb.SetLabelRequest_Address:
+		s.lock.RLock()
+		_, isWalletAddress := s.addressSet[target.Address]
+		s.lock.RUnlock()
+		if !isWalletAddress {
+			return nil, errors.Errorf("address %s doesn't belong to the wallet", target.Address)
+		}
+		err := s.walletDB.SetAddressLabel(target.Address, request.Label)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("either a transaction ID or an address must be given")
+	}
+
+	return &pb.SetLabelResponse{}, nil
+}
@@ -3,6 +3,8 @@ package server
 import (
 	"encoding/hex"
 	"strings"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/pskt"
 )
 
 // hexTransactionsSeparator is used to mark the end of one transaction and the beginning of the next one.
@@ -17,7 +19,42 @@ func EncodeTransactionsToHex(transactions [][]byte) string {
 	return strings.Join(transactionsInHex, hexTransactionsSeparator)
 }
 
+// DecodeTransactionsFromHex decodes the partially signed transactions encoded by EncodeTransactionsToHex.
+// PSKTs among them are converted to the kaspawallet partially signed transaction format
 func DecodeTransactionsFromHex(transactionsHex string) ([][]byte, error) {
+	transactions, err := decodeHexTransactions(transactionsHex)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, transaction := range transactions {
+		transactions[i], err = pskt.ToSerializedPartiallySignedTransaction(transaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transactions, nil
+}
+
+// DecodePSKTsFromHex decodes the partially signed transactions encoded by EncodeTransactionsToHex,
+// either as PSKTs or in the kaspawallet partially signed transaction format, to PSKTs
+func DecodePSKTsFromHex(transactionsHex string) ([]*pskt.PSKT, error) {
+	transactions, err := decodeHexTransactions(transactionsHex)
+	if err != nil {
+		return nil, err
+	}
+
+	pskts := make([]*pskt.PSKT, len(transactions))
+	for i, transaction := range transactions {
+		pskts[i], err = pskt.Parse(transaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pskts, nil
+}
+
+func decodeHexTransactions(transactionsHex string) ([][]byte, error) {
 	splitTransactionsHexes := strings.Split(transactionsHex, hexTransactionsSeparator)
 	transactions := make([][]byte, len(splitTransactionsHexes))
 
@@ -25,7 +25,8 @@ func dumpUnencryptedData(conf *dumpUnencryptedDataConfig) error {
 		return err
 	}
 
-	if len(conf.Password) == 0 {
+	// A watch-only wallet has nothing encrypted, so there's no password to ask for
+	if len(conf.Password) == 0 && !keysFile.IsWatchOnly() {
 		conf.Password = keys.GetPassword("Password:")
 	}
 	mnemonics, err := keysFile.DecryptMnemonics(conf.Password)
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
+	"github.com/pkg/errors"
+)
+
+func finalize(conf *finalizeConfig) error {
+	transactionsHex, err := readTransactionsHex(conf.Transaction, conf.TransactionFile)
+	if err != nil {
+		return err
+	}
+	pskts, err := server.DecodePSKTsFromHex(transactionsHex)
+	if err != nil {
+		return err
+	}
+
+	finalizedTransactions := make([][]byte, len(pskts))
+	for i, p := range pskts {
+		err = p.Finalize()
+		if err != nil {
+			return errors.Wrapf(err, "Could not finalize transaction #%d", i+1)
+		}
+		finalizedTransactions[i] = p.Serialize()
+	}
+
+	fmt.Fprintln(os.Stderr, "The transaction is finalized and ready to broadcast")
+	fmt.Println(server.EncodeTransactionsToHex(finalizedTransactions))
+	return nil
+}
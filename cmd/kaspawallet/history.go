@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/client"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/utils"
+)
+
+func history(conf *historyConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+	response, err := daemonClient.GetTransactions(ctx, &pb.GetTransactionsRequest{})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Transactions (%d):\n", len(response.Transactions))
+	for _, transaction := range response.Transactions {
+		fmt.Printf("\n%s %s\n", transaction.TxID, transactionStatus(transaction))
+		fmt.Printf("  First seen:      %s\n", time.UnixMilli(transaction.FirstSeen).Format(time.RFC3339))
+		if transaction.Label != "" {
+			fmt.Printf("  Label:           %s\n", transaction.Label)
+		}
+		if transaction.ReceivedAmount > 0 {
+			fmt.Printf("  Received, KAS:   %s\n", utils.FormatKas(transaction.ReceivedAmount))
+		}
+		if transaction.SentAmount > 0 {
+			fmt.Printf("  Sent, KAS:       %s\n", utils.FormatKas(transaction.SentAmount))
+		}
+		if transaction.Fee > 0 {
+			fmt.Printf("  Fee, KAS:        %s\n", utils.FormatKas(transaction.Fee))
+		}
+		if transaction.AcceptingBlockHash != "" {
+			fmt.Printf("  Accepting block: %s\n", transaction.AcceptingBlockHash)
+		}
+
+		if !conf.Verbose {
+			continue
+		}
+		for _, output := range transaction.Outputs {
+			ownership := "external"
+			if output.IsWalletAddress {
+				ownership = "wallet"
+			}
+			addressLabel := ""
+			if output.AddressLabel != "" {
+				addressLabel = fmt.Sprintf(" (%s)", output.AddressLabel)
+			}
+			fmt.Printf("  -> %s %s %s%s\n", output.Address, utils.FormatKas(output.Amount), ownership, addressLabel)
+		}
+	}
+
+	return nil
+}
+
+func transactionStatus(transaction *pb.WalletTransaction) string {
+	switch {
+	case transaction.IsAccepted && transaction.AcceptingDaaScore > 0:
+		return fmt.Sprintf("accepted at DAA score %d", transaction.AcceptingDaaScore)
+	case transaction.IsAccepted:
+		return "accepted"
+	case transaction.ReplacedBy != "":
+		return fmt.Sprintf("replaced by %s", transaction.ReplacedBy)
+	default:
+		return "pending"
+	}
+}
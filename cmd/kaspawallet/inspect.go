@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/pkg/errors"
+)
+
+func inspect(conf *inspectConfig) error {
+	transactionsHex, err := readTransactionsHex(conf.Transaction, conf.TransactionFile)
+	if err != nil {
+		return err
+	}
+	pskts, err := server.DecodePSKTsFromHex(transactionsHex)
+	if err != nil {
+		return err
+	}
+
+	switch conf.Format {
+	case inspectFormatJSON:
+		jsonView, err := json.MarshalIndent(pskts, "", "  ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		fmt.Println(string(jsonView))
+	case inspectFormatPSKT:
+		transactions := make([][]byte, len(pskts))
+		for i, p := range pskts {
+			transactions[i] = p.Serialize()
+		}
+		fmt.Println(server.EncodeTransactionsToHex(transactions))
+	case inspectFormatLegacy:
+		transactions := make([][]byte, len(pskts))
+		for i, p := range pskts {
+			partiallySignedTransaction, err := p.ToPartiallySignedTransaction()
+			if err != nil {
+				return err
+			}
+			transactions[i], err = serialization.SerializePartiallySignedTransaction(partiallySignedTransaction)
+			if err != nil {
+				return err
+			}
+		}
+		fmt.Println(server.EncodeTransactionsToHex(transactions))
+	default:
+		return errors.Errorf("Unknown format %s", conf.Format)
+	}
+	return nil
+}
@@ -28,6 +28,11 @@ var (
 // LastVersion is the most up to date file format version
 const LastVersion = 1
 
+// ErrWatchOnly is returned when a watch-only wallet is asked to sign
+var ErrWatchOnly = errors.New("this is a watch-only wallet, which has no private keys to sign with. " +
+	"Start the daemon with --signer to sign with an external signer, or use 'create-unsigned-transaction', " +
+	"have the transaction signed by the holders of the keys and send it with 'broadcast'")
+
 func defaultKeysFile(netParams *dagconfig.Params) string {
 	return filepath.Join(defaultAppDir, netParams.Name, "keys.json")
 }
@@ -205,6 +210,12 @@ func (d *File) LastUsedInternalIndex() uint32 {
 	return d.lastUsedInternalIndex
 }
 
+// IsWatchOnly returns whether the wallet has only extended public keys, and
+// no mnemonics to sign with
+func (d *File) IsWatchOnly() bool {
+	return len(d.EncryptedMnemonics) == 0
+}
+
 // DecryptMnemonics asks the user to enter the password for the private keys and
 // returns the decrypted private keys.
 func (d *File) DecryptMnemonics(password string) ([]string, error) {
@@ -80,3 +80,39 @@ func versionFromParams(params *dagconfig.Params) ([4]byte, error) {
 
 	return [4]byte{}, errors.Errorf("unknown network %s", params.Name)
 }
+
+// ValidateExtendedPublicKey returns an error if extendedPublicKey isn't an
+// extended public key of the network of params
+func ValidateExtendedPublicKey(params *dagconfig.Params, extendedPublicKey string) error {
+	extendedKey, err := bip32.DeserializeExtendedKey(extendedPublicKey)
+	if err != nil {
+		return errors.Wrapf(err, "%s is not a valid extended public key", extendedPublicKey)
+	}
+	if extendedKey.IsPrivate() {
+		return errors.Errorf("%s is an extended private key rather than an extended public key", extendedPublicKey)
+	}
+
+	version, err := publicVersionFromParams(params)
+	if err != nil {
+		return err
+	}
+	if extendedKey.Version != version {
+		return errors.Errorf("%s is not an extended public key of %s", extendedPublicKey, params.Name)
+	}
+	return nil
+}
+
+func publicVersionFromParams(params *dagconfig.Params) ([4]byte, error) {
+	switch params.Name {
+	case dagconfig.MainnetParams.Name:
+		return bip32.KaspaMainnetPublic, nil
+	case dagconfig.TestnetParams.Name:
+		return bip32.KaspaTestnetPublic, nil
+	case dagconfig.DevnetParams.Name:
+		return bip32.KaspaDevnetPublic, nil
+	case dagconfig.SimnetParams.Name:
+		return bip32.KaspaSimnetPublic, nil
+	}
+
+	return [4]byte{}, errors.Errorf("unknown network %s", params.Name)
+}
@@ -0,0 +1,43 @@
+package libkaspawallet
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestValidateExtendedPublicKey(t *testing.T) {
+	mnemonic, err := CreateMnemonic()
+	if err != nil {
+		t.Fatalf("CreateMnemonic: %+v", err)
+	}
+	extendedPublicKey, err := MasterPublicKeyFromMnemonic(&dagconfig.SimnetParams, mnemonic, true)
+	if err != nil {
+		t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+	}
+	extendedPrivateKey, err := extendedKeyFromMnemonicAndPath(mnemonic, defaultPath(true), &dagconfig.SimnetParams)
+	if err != nil {
+		t.Fatalf("extendedKeyFromMnemonicAndPath: %+v", err)
+	}
+
+	err = ValidateExtendedPublicKey(&dagconfig.SimnetParams, extendedPublicKey)
+	if err != nil {
+		t.Fatalf("ValidateExtendedPublicKey: %+v", err)
+	}
+
+	tests := []struct {
+		name              string
+		params            *dagconfig.Params
+		extendedPublicKey string
+	}{
+		{name: "other network", params: &dagconfig.MainnetParams, extendedPublicKey: extendedPublicKey},
+		{name: "private key", params: &dagconfig.SimnetParams, extendedPublicKey: extendedPrivateKey.String()},
+		{name: "malformed", params: &dagconfig.SimnetParams, extendedPublicKey: extendedPublicKey[1:]},
+	}
+	for _, test := range tests {
+		err := ValidateExtendedPublicKey(test.params, test.extendedPublicKey)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
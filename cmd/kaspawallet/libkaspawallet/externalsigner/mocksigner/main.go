@@ -0,0 +1,86 @@
+// mocksigner is a reference external signer for kaspawallet. It keeps its
+// mnemonics in a plain text file, so it's meant for tests and as an example of
+// the protocol, not to guard real funds.
+//
+// Run by the wallet with --signer="exec:mocksigner --mnemonics-file=<file>", it
+// answers the requests of the wallet on its stdin and stdout. With --listen, it
+// listens on a Unix socket the wallet reaches with --signer=unix:<path> instead.
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/externalsigner"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+type configFlags struct {
+	MnemonicsFile string `long:"mnemonics-file" description:"File with the mnemonics to sign with, one per line" required:"true"`
+	ECDSA         bool   `long:"ecdsa" description:"Sign with ECDSA signatures instead of Schnorr signatures"`
+	Listen        string `long:"listen" description:"Path of a Unix socket to listen on, instead of serving on stdin and stdout"`
+	config.NetworkFlags
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mocksigner: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg := &configFlags{}
+	parser := flags.NewParser(cfg, flags.HelpFlag)
+	_, err := parser.Parse()
+	if err != nil {
+		return err
+	}
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return err
+	}
+
+	mnemonicsBytes, err := os.ReadFile(cfg.MnemonicsFile)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	var mnemonics []string
+	for _, line := range strings.Split(string(mnemonicsBytes), "\n") {
+		mnemonic := strings.TrimSpace(line)
+		if mnemonic != "" {
+			mnemonics = append(mnemonics, mnemonic)
+		}
+	}
+
+	params := cfg.NetParams()
+	signer := libkaspawallet.NewMnemonicSigner(params, mnemonics, cfg.ECDSA)
+	if cfg.Listen == "" {
+		return externalsigner.Serve(os.Stdin, os.Stdout, params.Name, signer)
+	}
+
+	listener, err := net.Listen("unix", cfg.Listen)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer listener.Close()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		go func() {
+			defer conn.Close()
+			err := externalsigner.Serve(conn, conn, params.Name, signer)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "mocksigner: %s\n", err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Package externalsigner lets kaspawallet sign transactions with keys that live
+outside of the wallet process, for example in a hardware wallet or in a signing
+service on an offline machine.
+
+The wallet and the external signer exchange JSON objects, one per line. For
+every transaction, the wallet sends a request:
+
+	{"version": 1, "network": "kaspa-mainnet", "transaction": "<hex>"}
+
+where transaction is the hex encoded partially signed transaction, as
+serialized by serialization.SerializePartiallySignedTransaction. The signer
+adds the signatures of its keys to the transaction and responds with it:
+
+	{"transaction": "<hex>"}
+
+or with the reason it didn't sign it:
+
+	{"error": "<reason>"}
+
+The wallet reaches the signer either by running it and talking to it over its
+stdin and stdout, or by connecting to the Unix socket it listens on.
+*/
+package externalsigner
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ProtocolVersion is the version of the protocol between the wallet and external signers
+const ProtocolVersion = 1
+
+// Request is a request to sign a transaction
+type Request struct {
+	Version     uint32 `json:"version"`
+	Network     string `json:"network"`
+	Transaction string `json:"transaction"`
+}
+
+// Response is the response of an external signer to a Request
+type Response struct {
+	Transaction string `json:"transaction,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func newRequest(network string, serializedPSTx []byte) *Request {
+	return &Request{
+		Version:     ProtocolVersion,
+		Network:     network,
+		Transaction: hex.EncodeToString(serializedPSTx),
+	}
+}
+
+func writeMessage(writer io.Writer, message interface{}) error {
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = writer.Write(append(messageBytes, '\n'))
+	return errors.WithStack(err)
+}
+
+// readMessage reads the next message from the given reader. It returns
+// io.EOF if the reader ended before the message began
+func readMessage(reader *bufio.Reader, message interface{}) error {
+	line, err := reader.ReadBytes('\n')
+	if errors.Is(err, io.EOF) && len(line) == 0 {
+		return io.EOF
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(json.Unmarshal(line, message))
+}
@@ -0,0 +1,52 @@
+package externalsigner
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/pkg/errors"
+)
+
+// Serve answers the sign requests read from reader with the given signer,
+// writing the responses to writer, until reader ends. External signers
+// written in Go can use it to implement the protocol
+func Serve(reader io.Reader, writer io.Writer, network string, signer libkaspawallet.Signer) error {
+	bufferedReader := bufio.NewReader(reader)
+	for {
+		request := &Request{}
+		err := readMessage(bufferedReader, request)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = writeMessage(writer, handleRequest(request, network, signer))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func handleRequest(request *Request, network string, signer libkaspawallet.Signer) *Response {
+	if request.Version != ProtocolVersion {
+		return &Response{Error: errors.Errorf("unsupported protocol version %d", request.Version).Error()}
+	}
+	if request.Network != network {
+		return &Response{Error: errors.Errorf("the signer is on %s and can't sign %s transactions",
+			network, request.Network).Error()}
+	}
+
+	serializedPSTx, err := hex.DecodeString(request.Transaction)
+	if err != nil {
+		return &Response{Error: "the transaction is not hex encoded"}
+	}
+	signedPSTx, err := signer.Sign(serializedPSTx)
+	if err != nil {
+		return &Response{Error: err.Error()}
+	}
+	return &Response{Transaction: hex.EncodeToString(signedPSTx)}
+}
@@ -0,0 +1,180 @@
+package externalsigner
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+// signTimeout bounds the time an external signer takes to sign a transaction.
+// It is long, since hardware wallets wait for their user to confirm
+const signTimeout = 5 * time.Minute
+
+const (
+	execAddressPrefix = "exec:"
+	unixAddressPrefix = "unix:"
+)
+
+type externalSigner struct {
+	network string
+
+	// connect opens a connection to the signer, for a single request
+	connect func(ctx context.Context) (io.ReadWriteCloser, error)
+}
+
+// New returns a signer that forwards the transactions to the external signer
+// at the given address, which is either exec:<command line>, to run the signer
+// and talk to it over its stdin and stdout, or unix:<path>, to connect to the
+// Unix socket the signer listens on
+func New(address string, network string) (libkaspawallet.Signer, error) {
+	signer := &externalSigner{network: network}
+	switch {
+	case strings.HasPrefix(address, execAddressPrefix):
+		commandLine := strings.Fields(strings.TrimPrefix(address, execAddressPrefix))
+		if len(commandLine) == 0 {
+			return nil, errors.Errorf("the external signer address %s has no command", address)
+		}
+		signer.connect = func(ctx context.Context) (io.ReadWriteCloser, error) {
+			return startProcess(ctx, commandLine)
+		}
+	case strings.HasPrefix(address, unixAddressPrefix):
+		path := strings.TrimPrefix(address, unixAddressPrefix)
+		signer.connect = func(ctx context.Context) (io.ReadWriteCloser, error) {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "unix", path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error connecting to the external signer at %s", path)
+			}
+			deadline, _ := ctx.Deadline()
+			return conn, errors.WithStack(conn.SetDeadline(deadline))
+		}
+	default:
+		return nil, errors.Errorf("the external signer address %s should start with %s or %s",
+			address, execAddressPrefix, unixAddressPrefix)
+	}
+	return signer, nil
+}
+
+func (es *externalSigner) Sign(serializedPSTx []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	conn, err := es.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	err = writeMessage(conn, newRequest(es.network, serializedPSTx))
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending the transaction to the external signer")
+	}
+	response := &Response{}
+	err = readMessage(bufio.NewReader(conn), response)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the response of the external signer")
+	}
+	if response.Error != "" {
+		return nil, errors.Errorf("the external signer didn't sign the transaction: %s", response.Error)
+	}
+
+	signedPSTx, err := hex.DecodeString(response.Transaction)
+	if err != nil {
+		return nil, errors.Wrap(err, "the external signer responded with a transaction that is not hex encoded")
+	}
+	err = checkOnlySignaturesAdded(serializedPSTx, signedPSTx)
+	if err != nil {
+		return nil, err
+	}
+	return signedPSTx, nil
+}
+
+// checkOnlySignaturesAdded makes sure the external signer returned the
+// transaction it was given, and didn't change anything but the signatures
+func checkOnlySignaturesAdded(serializedPSTx []byte, signedSerializedPSTx []byte) error {
+	partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(serializedPSTx)
+	if err != nil {
+		return err
+	}
+	signedPartiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(signedSerializedPSTx)
+	if err != nil {
+		return errors.Wrap(err, "the external signer responded with a malformed transaction")
+	}
+
+	if !consensushashing.TransactionID(partiallySignedTransaction.Tx).Equal(
+		consensushashing.TransactionID(signedPartiallySignedTransaction.Tx)) {
+		return errors.New("the external signer responded with a different transaction")
+	}
+	if len(partiallySignedTransaction.PartiallySignedInputs) != len(signedPartiallySignedTransaction.PartiallySignedInputs) {
+		return errors.New("the external signer changed the inputs of the transaction")
+	}
+	for i, input := range partiallySignedTransaction.PartiallySignedInputs {
+		signedInput := signedPartiallySignedTransaction.PartiallySignedInputs[i]
+		if !input.PrevOutput.Equal(signedInput.PrevOutput) ||
+			input.MinimumSignatures != signedInput.MinimumSignatures ||
+			input.DerivationPath != signedInput.DerivationPath ||
+			len(input.PubKeySignaturePairs) != len(signedInput.PubKeySignaturePairs) {
+			return errors.Errorf("the external signer changed input %d of the transaction", i)
+		}
+		for j, pair := range input.PubKeySignaturePairs {
+			if pair.ExtendedPublicKey != signedInput.PubKeySignaturePairs[j].ExtendedPublicKey {
+				return errors.Errorf("the external signer changed the public keys of input %d of the transaction", i)
+			}
+		}
+	}
+	return nil
+}
+
+// process is a running external signer, whose stdin and stdout make its connection
+type process struct {
+	command *exec.Cmd
+	stdin   io.WriteCloser
+	stdout  io.ReadCloser
+}
+
+func startProcess(ctx context.Context, commandLine []string) (*process, error) {
+	command := exec.CommandContext(ctx, commandLine[0], commandLine[1:]...)
+	command.Stderr = os.Stderr
+	stdin, err := command.StdinPipe()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	stdout, err := command.StdoutPipe()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = command.Start()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error running the external signer %s", commandLine[0])
+	}
+	return &process{command: command, stdin: stdin, stdout: stdout}, nil
+}
+
+func (p *process) Read(data []byte) (int, error) {
+	return p.stdout.Read(data)
+}
+
+func (p *process) Write(data []byte) (int, error) {
+	return p.stdin.Write(data)
+}
+
+// Close closes the stdin of the signer, which tells it there are no more
+// requests, and waits for it to exit
+func (p *process) Close() error {
+	err := p.stdin.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(p.command.Wait())
+}
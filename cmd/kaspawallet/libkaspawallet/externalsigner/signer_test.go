@@ -0,0 +1,195 @@
+package externalsigner
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+// mockSignerMnemonicEnv makes the test binary act as a mock external signer
+// that signs with the mnemonic in it, so the tests can run it as a process
+const mockSignerMnemonicEnv = "EXTERNALSIGNER_TEST_MNEMONIC"
+
+var testParams = &dagconfig.SimnetParams
+
+func TestMain(m *testing.M) {
+	mnemonic := os.Getenv(mockSignerMnemonicEnv)
+	if mnemonic != "" {
+		signer := libkaspawallet.NewMnemonicSigner(testParams, []string{mnemonic}, false)
+		err := Serve(os.Stdin, os.Stdout, testParams.Name, signer)
+		if err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// createUnsignedTransaction creates a transaction that spends a UTXO of the
+// wallet of the given mnemonic
+func createUnsignedTransaction(t *testing.T, mnemonic string) []byte {
+	publicKey, err := libkaspawallet.MasterPublicKeyFromMnemonic(testParams, mnemonic, false)
+	if err != nil {
+		t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+	}
+	const path = "m/0/1"
+	address, err := libkaspawallet.Address(testParams, []string{publicKey}, 1, path, false)
+	if err != nil {
+		t.Fatalf("Address: %+v", err)
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %+v", err)
+	}
+
+	selectedUTXOs := []*libkaspawallet.UTXO{{
+		Outpoint:       &externalapi.DomainOutpoint{Index: 0},
+		UTXOEntry:      utxo.NewUTXOEntry(100_000, scriptPublicKey, false, 0),
+		DerivationPath: path,
+	}}
+	payments := []*libkaspawallet.Payment{{Address: address, Amount: 90_000}}
+	partiallySignedTransaction, err := libkaspawallet.CreateUnsignedTransaction([]string{publicKey}, 1, payments, selectedUTXOs)
+	if err != nil {
+		t.Fatalf("CreateUnsignedTransaction: %+v", err)
+	}
+	serializedPSTx, err := serialization.SerializePartiallySignedTransaction(partiallySignedTransaction)
+	if err != nil {
+		t.Fatalf("SerializePartiallySignedTransaction: %+v", err)
+	}
+	return serializedPSTx
+}
+
+func createMnemonic(t *testing.T) string {
+	mnemonic, err := libkaspawallet.CreateMnemonic()
+	if err != nil {
+		t.Fatalf("CreateMnemonic: %+v", err)
+	}
+	return mnemonic
+}
+
+func expectFullySigned(t *testing.T, signer libkaspawallet.Signer, unsignedTransaction []byte) {
+	signedTransaction, err := signer.Sign(unsignedTransaction)
+	if err != nil {
+		t.Fatalf("Sign: %+v", err)
+	}
+	isFullySigned, err := libkaspawallet.IsTransactionFullySigned(signedTransaction)
+	if err != nil {
+		t.Fatalf("IsTransactionFullySigned: %+v", err)
+	}
+	if !isFullySigned {
+		t.Fatalf("expected the transaction to be fully signed")
+	}
+	_, err = libkaspawallet.ExtractTransaction(signedTransaction, false)
+	if err != nil {
+		t.Fatalf("ExtractTransaction: %+v", err)
+	}
+}
+
+// listen serves the given signer on a Unix socket, and returns its address
+func listen(t *testing.T, network string, signer libkaspawallet.Signer) string {
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Listen: %+v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = Serve(conn, conn, network, signer)
+			}()
+		}
+	}()
+	return unixAddressPrefix + path
+}
+
+func TestExecSigner(t *testing.T) {
+	mnemonic := createMnemonic(t)
+	t.Setenv(mockSignerMnemonicEnv, mnemonic)
+
+	signer, err := New(execAddressPrefix+os.Args[0], testParams.Name)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	expectFullySigned(t, signer, createUnsignedTransaction(t, mnemonic))
+
+	// The mock signer doesn't hold the keys of other wallets
+	_, err = signer.Sign(createUnsignedTransaction(t, createMnemonic(t)))
+	if err == nil || !strings.Contains(err.Error(), "didn't sign") {
+		t.Fatalf("expected the signer to refuse a transaction of another wallet, got: %v", err)
+	}
+}
+
+func TestUnixSocketSigner(t *testing.T) {
+	mnemonic := createMnemonic(t)
+	address := listen(t, testParams.Name, libkaspawallet.NewMnemonicSigner(testParams, []string{mnemonic}, false))
+
+	signer, err := New(address, testParams.Name)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	expectFullySigned(t, signer, createUnsignedTransaction(t, mnemonic))
+
+	otherNetworkSigner, err := New(address, dagconfig.MainnetParams.Name)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	_, err = otherNetworkSigner.Sign(createUnsignedTransaction(t, mnemonic))
+	if err == nil || !strings.Contains(err.Error(), "can't sign") {
+		t.Fatalf("expected the signer to refuse a transaction of another network, got: %v", err)
+	}
+}
+
+// tamperingSigner signs, then redirects the first output of the transaction
+type tamperingSigner struct {
+	signer libkaspawallet.Signer
+}
+
+func (ts *tamperingSigner) Sign(serializedPSTx []byte) ([]byte, error) {
+	signedPSTx, err := ts.signer.Sign(serializedPSTx)
+	if err != nil {
+		return nil, err
+	}
+	partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(signedPSTx)
+	if err != nil {
+		return nil, err
+	}
+	partiallySignedTransaction.Tx.Outputs[0].Value++
+	return serialization.SerializePartiallySignedTransaction(partiallySignedTransaction)
+}
+
+func TestTamperingSigner(t *testing.T) {
+	mnemonic := createMnemonic(t)
+	signer := &tamperingSigner{signer: libkaspawallet.NewMnemonicSigner(testParams, []string{mnemonic}, false)}
+	externalSigner, err := New(listen(t, testParams.Name, signer), testParams.Name)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	_, err = externalSigner.Sign(createUnsignedTransaction(t, mnemonic))
+	if err == nil || !strings.Contains(err.Error(), "different transaction") {
+		t.Fatalf("expected the tampered transaction to be rejected, got: %v", err)
+	}
+}
+
+func TestNewInvalidAddress(t *testing.T) {
+	for _, address := range []string{"exec:", "tcp:localhost:1234", "/tmp/signer.sock"} {
+		_, err := New(address, testParams.Name)
+		if err == nil {
+			t.Errorf("expected an error for the address %s", address)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package pskt
+
+import (
+	"bytes"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/pkg/errors"
+)
+
+// TransactionID returns the ID of the transaction of the PSKT, which its
+// signatures don't change
+func (p *PSKT) TransactionID() *externalapi.DomainTransactionID {
+	return consensushashing.TransactionID(p.Transaction())
+}
+
+// Clone returns a deep copy of the PSKT
+func (p *PSKT) Clone() *PSKT {
+	clone, err := Deserialize(p.Serialize())
+	if err != nil {
+		panic(errors.Wrap(err, "a serialized PSKT should always deserialize"))
+	}
+	return clone
+}
+
+// Combine merges PSKTs of the same transaction that different cosigners
+// signed into a PSKT with all of their signatures
+func Combine(pskts ...*PSKT) (*PSKT, error) {
+	if len(pskts) == 0 {
+		return nil, errors.New("no PSKTs to combine")
+	}
+
+	combined := pskts[0].Clone()
+	transactionID := combined.TransactionID()
+	for i, p := range pskts[1:] {
+		if !p.TransactionID().Equal(transactionID) {
+			return nil, errors.Errorf("PSKT %d is of transaction %s rather than of transaction %s",
+				i+2, p.TransactionID(), transactionID)
+		}
+		for j, input := range p.Inputs {
+			err := combineInput(combined.Inputs[j], input)
+			if err != nil {
+				return nil, errors.Wrapf(err, "PSKT %d: input %d", i+2, j)
+			}
+		}
+		combined.Unknown = combineUnknown(combined.Unknown, p.Unknown)
+		for j, output := range p.Outputs {
+			combined.Outputs[j].Unknown = combineUnknown(combined.Outputs[j].Unknown, output.Unknown)
+		}
+	}
+	return combined, nil
+}
+
+func combineInput(combined *Input, input *Input) error {
+	if len(input.Cosigners) != len(combined.Cosigners) || input.MinimumSignatures != combined.MinimumSignatures {
+		return errors.New("the cosigners of the input differ")
+	}
+	if combined.UTXOScriptPublicKey == nil {
+		combined.UTXOAmount = input.UTXOAmount
+		combined.UTXOScriptPublicKey = input.UTXOScriptPublicKey
+	} else if input.UTXOScriptPublicKey != nil &&
+		(input.UTXOAmount != combined.UTXOAmount || !input.UTXOScriptPublicKey.Equal(combined.UTXOScriptPublicKey)) {
+		return errors.New("the spent UTXO differs")
+	}
+	if combined.DerivationPath == "" {
+		combined.DerivationPath = input.DerivationPath
+	}
+
+	for i, cosigner := range input.Cosigners {
+		if cosigner.ExtendedPublicKey != combined.Cosigners[i].ExtendedPublicKey {
+			return errors.New("the cosigners of the input differ")
+		}
+		if combined.Cosigners[i].Signature == nil {
+			combined.Cosigners[i].Signature = cosigner.Signature
+		}
+	}
+	if combined.FinalSignatureScript == nil {
+		combined.FinalSignatureScript = input.FinalSignatureScript
+	}
+	combined.Unknown = combineUnknown(combined.Unknown, input.Unknown)
+	return nil
+}
+
+// combineUnknown adds the entries of unknown types whose keys aren't in
+// combined yet. Like with signatures, the first PSKT with a key wins
+func combineUnknown(combined []*KeyValue, unknown []*KeyValue) []*KeyValue {
+	for _, keyValue := range unknown {
+		found := false
+		for _, combinedKeyValue := range combined {
+			if bytes.Equal(combinedKeyValue.Key, keyValue.Key) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			combined = append(combined, keyValue)
+		}
+	}
+	return combined
+}
+
+// Finalize sets the final signature scripts of the inputs, which requires all
+// of them to have enough signatures. PSKTs don't record whether the keys are
+// Schnorr or ECDSA keys, which the redeem scripts of multisig inputs depend on,
+// so Finalize uses the kind that matches the UTXOs the inputs spend
+func (p *PSKT) Finalize() error {
+	for _, ecdsa := range []bool{false, true} {
+		partiallySignedTransaction, err := p.ToPartiallySignedTransaction()
+		if err != nil {
+			return err
+		}
+		tx, err := libkaspawallet.ExtractTransactionDeserialized(partiallySignedTransaction, ecdsa)
+		if err != nil {
+			return err
+		}
+
+		matches, err := p.redeemScriptsMatch(tx)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+		for i, input := range p.Inputs {
+			input.FinalSignatureScript = tx.Inputs[i].SignatureScript
+		}
+		return nil
+	}
+	return errors.New("the cosigners of the multisig inputs don't match the UTXOs they spend")
+}
+
+// redeemScriptsMatch returns whether the redeem scripts in the signature
+// scripts of the multisig inputs of tx hash to the UTXOs they spend
+func (p *PSKT) redeemScriptsMatch(tx *externalapi.DomainTransaction) (bool, error) {
+	for i, input := range p.Inputs {
+		if len(input.Cosigners) <= 1 {
+			continue
+		}
+		pushes, err := txscript.PushedData(tx.Inputs[i].SignatureScript)
+		if err != nil {
+			return false, err
+		}
+		scriptHashScript, err := txscript.PayToScriptHashScript(pushes[len(pushes)-1])
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(scriptHashScript, input.UTXOScriptPublicKey.Script) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Extract returns the transaction of a finalized PSKT, ready to broadcast
+func (p *PSKT) Extract() (*externalapi.DomainTransaction, error) {
+	if !p.IsFinalized() {
+		return nil, errors.New("the PSKT is not finalized")
+	}
+	return p.Transaction(), nil
+}
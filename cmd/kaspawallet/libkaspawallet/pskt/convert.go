@@ -0,0 +1,148 @@
+package pskt
+
+import (
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// FromPartiallySignedTransaction converts a partially signed transaction of
+// the kaspawallet protobuf format to a PSKT
+func FromPartiallySignedTransaction(partiallySignedTransaction *serialization.PartiallySignedTransaction) (*PSKT, error) {
+	tx := partiallySignedTransaction.Tx
+	if len(tx.Inputs) != len(partiallySignedTransaction.PartiallySignedInputs) {
+		return nil, errors.Errorf("the transaction has %d inputs but %d partially signed inputs",
+			len(tx.Inputs), len(partiallySignedTransaction.PartiallySignedInputs))
+	}
+
+	p := &PSKT{
+		Version:      Version,
+		TxVersion:    tx.Version,
+		LockTime:     tx.LockTime,
+		SubnetworkID: tx.SubnetworkID,
+		Gas:          tx.Gas,
+		Payload:      tx.Payload,
+		Inputs:       make([]*Input, len(tx.Inputs)),
+		Outputs:      make([]*Output, len(tx.Outputs)),
+	}
+	for i, txInput := range tx.Inputs {
+		partiallySignedInput := partiallySignedTransaction.PartiallySignedInputs[i]
+		input := &Input{
+			PreviousOutpoint:  txInput.PreviousOutpoint,
+			Sequence:          txInput.Sequence,
+			SigOpCount:        txInput.SigOpCount,
+			MinimumSignatures: partiallySignedInput.MinimumSignatures,
+			DerivationPath:    partiallySignedInput.DerivationPath,
+			Cosigners:         make([]*Cosigner, len(partiallySignedInput.PubKeySignaturePairs)),
+		}
+		if len(txInput.SignatureScript) > 0 {
+			input.FinalSignatureScript = txInput.SignatureScript
+		}
+		if partiallySignedInput.PrevOutput != nil {
+			input.UTXOAmount = partiallySignedInput.PrevOutput.Value
+			input.UTXOScriptPublicKey = partiallySignedInput.PrevOutput.ScriptPublicKey
+		}
+		for j, pair := range partiallySignedInput.PubKeySignaturePairs {
+			input.Cosigners[j] = &Cosigner{ExtendedPublicKey: pair.ExtendedPublicKey, Signature: pair.Signature}
+		}
+		p.Inputs[i] = input
+	}
+	for i, txOutput := range tx.Outputs {
+		p.Outputs[i] = &Output{Amount: txOutput.Value, ScriptPublicKey: txOutput.ScriptPublicKey}
+	}
+	return p, nil
+}
+
+// ToPartiallySignedTransaction converts the PSKT to a partially signed
+// transaction of the kaspawallet protobuf format. Entries of unknown types
+// have no place in that format, and are dropped
+func (p *PSKT) ToPartiallySignedTransaction() (*serialization.PartiallySignedTransaction, error) {
+	partiallySignedInputs := make([]*serialization.PartiallySignedInput, len(p.Inputs))
+	for i, input := range p.Inputs {
+		if input.UTXOScriptPublicKey == nil {
+			return nil, errors.Errorf("input %d is missing the UTXO it spends", i)
+		}
+		pairs := make([]*serialization.PubKeySignaturePair, len(input.Cosigners))
+		for j, cosigner := range input.Cosigners {
+			pairs[j] = &serialization.PubKeySignaturePair{
+				ExtendedPublicKey: cosigner.ExtendedPublicKey,
+				Signature:         cosigner.Signature,
+			}
+		}
+		partiallySignedInputs[i] = &serialization.PartiallySignedInput{
+			PrevOutput: &externalapi.DomainTransactionOutput{
+				Value:           input.UTXOAmount,
+				ScriptPublicKey: input.UTXOScriptPublicKey,
+			},
+			MinimumSignatures:    input.MinimumSignatures,
+			PubKeySignaturePairs: pairs,
+			DerivationPath:       input.DerivationPath,
+		}
+	}
+
+	return &serialization.PartiallySignedTransaction{
+		Tx:                    p.Transaction(),
+		PartiallySignedInputs: partiallySignedInputs,
+	}, nil
+}
+
+// Transaction returns the transaction of the PSKT, with the signature scripts
+// of the finalized inputs
+func (p *PSKT) Transaction() *externalapi.DomainTransaction {
+	inputs := make([]*externalapi.DomainTransactionInput, len(p.Inputs))
+	for i, input := range p.Inputs {
+		inputs[i] = &externalapi.DomainTransactionInput{
+			PreviousOutpoint: input.PreviousOutpoint,
+			SignatureScript:  input.FinalSignatureScript,
+			Sequence:         input.Sequence,
+			SigOpCount:       input.SigOpCount,
+		}
+	}
+	outputs := make([]*externalapi.DomainTransactionOutput, len(p.Outputs))
+	for i, output := range p.Outputs {
+		outputs[i] = &externalapi.DomainTransactionOutput{
+			Value:           output.Amount,
+			ScriptPublicKey: output.ScriptPublicKey,
+		}
+	}
+	return &externalapi.DomainTransaction{
+		Version:      p.TxVersion,
+		Inputs:       inputs,
+		Outputs:      outputs,
+		LockTime:     p.LockTime,
+		SubnetworkID: p.SubnetworkID,
+		Gas:          p.Gas,
+		Payload:      p.Payload,
+	}
+}
+
+// Parse decodes a PSKT, or a partially signed transaction of the kaspawallet
+// protobuf format, which it converts to a PSKT
+func Parse(serialized []byte) (*PSKT, error) {
+	if HasMagic(serialized) {
+		return Deserialize(serialized)
+	}
+	partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(serialized)
+	if err != nil {
+		return nil, errors.Wrap(err, "the data is neither a PSKT nor a kaspawallet partially signed transaction")
+	}
+	return FromPartiallySignedTransaction(partiallySignedTransaction)
+}
+
+// ToSerializedPartiallySignedTransaction converts a serialized PSKT to a
+// serialized partially signed transaction of the kaspawallet protobuf format.
+// Data that is already in that format is returned as it is
+func ToSerializedPartiallySignedTransaction(serialized []byte) ([]byte, error) {
+	if !HasMagic(serialized) {
+		return serialized, nil
+	}
+	p, err := Deserialize(serialized)
+	if err != nil {
+		return nil, err
+	}
+	partiallySignedTransaction, err := p.ToPartiallySignedTransaction()
+	if err != nil {
+		return nil, err
+	}
+	return serialization.SerializePartiallySignedTransaction(partiallySignedTransaction)
+}
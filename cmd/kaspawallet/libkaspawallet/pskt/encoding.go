@@ -0,0 +1,449 @@
+package pskt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// magic starts every serialized PSKT
+var magic = []byte{'p', 's', 'k', 't', 0xff}
+
+// maxValueLength bounds the lengths of keys and values, so that malformed
+// PSKTs can't make Deserialize allocate without bounds
+const maxValueLength = 1_000_000
+
+const (
+	globalTxVersion    = 0x00
+	globalLockTime     = 0x01
+	globalSubnetworkID = 0x02
+	globalGas          = 0x03
+	globalPayload      = 0x04
+	globalInputCount   = 0x05
+	globalOutputCount  = 0x06
+	globalVersion      = 0xfb
+)
+
+const (
+	inputPreviousOutpoint     = 0x00
+	inputSequence             = 0x01
+	inputSigOpCount           = 0x02
+	inputUTXO                 = 0x03
+	inputMinimumSignatures    = 0x04
+	inputDerivationPath       = 0x05
+	inputCosigner             = 0x06
+	inputPartialSignature     = 0x07
+	inputFinalSignatureScript = 0x08
+)
+
+const (
+	outputAmount          = 0x00
+	outputScriptPublicKey = 0x01
+)
+
+// HasMagic returns whether the given bytes start like a serialized PSKT
+func HasMagic(serialized []byte) bool {
+	return bytes.HasPrefix(serialized, magic)
+}
+
+// Serialize returns the binary encoding of the PSKT
+func (p *PSKT) Serialize() []byte {
+	writer := &mapWriter{}
+	writer.buffer.Write(magic)
+
+	writer.writeUint32(globalVersion, nil, p.Version)
+	writer.writeUint16(globalTxVersion, nil, p.TxVersion)
+	writer.writeUint64(globalLockTime, nil, p.LockTime)
+	writer.write(globalSubnetworkID, nil, p.SubnetworkID[:])
+	writer.writeUint64(globalGas, nil, p.Gas)
+	if len(p.Payload) > 0 {
+		writer.write(globalPayload, nil, p.Payload)
+	}
+	writer.writeUint32(globalInputCount, nil, uint32(len(p.Inputs)))
+	writer.writeUint32(globalOutputCount, nil, uint32(len(p.Outputs)))
+	writer.endMap(p.Unknown)
+
+	for _, input := range p.Inputs {
+		outpoint := append(input.PreviousOutpoint.TransactionID.ByteSlice(), make([]byte, 4)...)
+		binary.LittleEndian.PutUint32(outpoint[externalapi.DomainHashSize:], input.PreviousOutpoint.Index)
+		writer.write(inputPreviousOutpoint, nil, outpoint)
+		writer.writeUint64(inputSequence, nil, input.Sequence)
+		writer.write(inputSigOpCount, nil, []byte{input.SigOpCount})
+		if input.UTXOScriptPublicKey != nil {
+			utxo := binary.LittleEndian.AppendUint64(nil, input.UTXOAmount)
+			writer.write(inputUTXO, nil, append(utxo, serializeScriptPublicKey(input.UTXOScriptPublicKey)...))
+		}
+		writer.writeUint32(inputMinimumSignatures, nil, input.MinimumSignatures)
+		if input.DerivationPath != "" {
+			writer.write(inputDerivationPath, nil, []byte(input.DerivationPath))
+		}
+		for position, cosigner := range input.Cosigners {
+			writer.writeUint32(inputCosigner, []byte(cosigner.ExtendedPublicKey), uint32(position))
+		}
+		for _, cosigner := range input.Cosigners {
+			if cosigner.Signature != nil {
+				writer.write(inputPartialSignature, []byte(cosigner.ExtendedPublicKey), cosigner.Signature)
+			}
+		}
+		if input.FinalSignatureScript != nil {
+			writer.write(inputFinalSignatureScript, nil, input.FinalSignatureScript)
+		}
+		writer.endMap(input.Unknown)
+	}
+
+	for _, output := range p.Outputs {
+		writer.writeUint64(outputAmount, nil, output.Amount)
+		writer.write(outputScriptPublicKey, nil, serializeScriptPublicKey(output.ScriptPublicKey))
+		writer.endMap(output.Unknown)
+	}
+
+	return writer.buffer.Bytes()
+}
+
+func serializeScriptPublicKey(scriptPublicKey *externalapi.ScriptPublicKey) []byte {
+	serialized := binary.LittleEndian.AppendUint16(nil, scriptPublicKey.Version)
+	return append(serialized, scriptPublicKey.Script...)
+}
+
+type mapWriter struct {
+	buffer bytes.Buffer
+}
+
+func (mw *mapWriter) write(keyType byte, keyData []byte, value []byte) {
+	mw.buffer.Write(binary.AppendUvarint(nil, uint64(1+len(keyData))))
+	mw.buffer.WriteByte(keyType)
+	mw.buffer.Write(keyData)
+	mw.buffer.Write(binary.AppendUvarint(nil, uint64(len(value))))
+	mw.buffer.Write(value)
+}
+
+func (mw *mapWriter) writeUint16(keyType byte, keyData []byte, value uint16) {
+	mw.write(keyType, keyData, binary.LittleEndian.AppendUint16(nil, value))
+}
+
+func (mw *mapWriter) writeUint32(keyType byte, keyData []byte, value uint32) {
+	mw.write(keyType, keyData, binary.LittleEndian.AppendUint32(nil, value))
+}
+
+func (mw *mapWriter) writeUint64(keyType byte, keyData []byte, value uint64) {
+	mw.write(keyType, keyData, binary.LittleEndian.AppendUint64(nil, value))
+}
+
+// endMap writes the unknown entries of the map, and then the map separator
+func (mw *mapWriter) endMap(unknown []*KeyValue) {
+	for _, keyValue := range unknown {
+		mw.write(keyValue.Key[0], keyValue.Key[1:], keyValue.Value)
+	}
+	mw.buffer.WriteByte(0)
+}
+
+// entry is a key-value entry of a map, with its key split to its type and data
+type entry struct {
+	keyType byte
+	keyData []byte
+	value   []byte
+}
+
+func (e *entry) toKeyValue() *KeyValue {
+	return &KeyValue{Key: append([]byte{e.keyType}, e.keyData...), Value: e.value}
+}
+
+func (e *entry) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("key type 0x%02x: "+format, append([]interface{}{e.keyType}, args...)...)
+}
+
+func (e *entry) expectNoKeyData() error {
+	if len(e.keyData) != 0 {
+		return e.errorf("unexpected key data")
+	}
+	return nil
+}
+
+func (e *entry) uint16() (uint16, error) {
+	if len(e.value) != 2 || len(e.keyData) != 0 {
+		return 0, e.errorf("expected a 2 bytes value without key data")
+	}
+	return binary.LittleEndian.Uint16(e.value), nil
+}
+
+func (e *entry) uint32() (uint32, error) {
+	if len(e.value) != 4 {
+		return 0, e.errorf("expected a 4 bytes value")
+	}
+	return binary.LittleEndian.Uint32(e.value), nil
+}
+
+func (e *entry) uint64() (uint64, error) {
+	if len(e.value) != 8 || len(e.keyData) != 0 {
+		return 0, e.errorf("expected an 8 bytes value without key data")
+	}
+	return binary.LittleEndian.Uint64(e.value), nil
+}
+
+func (e *entry) scriptPublicKey(value []byte) (*externalapi.ScriptPublicKey, error) {
+	if len(value) < 2 {
+		return nil, e.errorf("the script public key is too short")
+	}
+	return &externalapi.ScriptPublicKey{
+		Version: binary.LittleEndian.Uint16(value),
+		Script:  append([]byte{}, value[2:]...),
+	}, nil
+}
+
+// Deserialize decodes a PSKT from its binary encoding
+func Deserialize(serialized []byte) (*PSKT, error) {
+	if !HasMagic(serialized) {
+		return nil, errors.New("the data is not a PSKT: it doesn't start with the PSKT magic bytes")
+	}
+	reader := bytes.NewReader(serialized[len(magic):])
+
+	globalEntries, err := readMap(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the global map")
+	}
+	p, inputCount, outputCount, err := parseGlobalMap(globalEntries)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the global map")
+	}
+
+	p.Inputs = make([]*Input, inputCount)
+	for i := range p.Inputs {
+		entries, err := readMap(reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading input %d", i)
+		}
+		p.Inputs[i], err = parseInputMap(entries)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading input %d", i)
+		}
+	}
+
+	p.Outputs = make([]*Output, outputCount)
+	for i := range p.Outputs {
+		entries, err := readMap(reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading output %d", i)
+		}
+		p.Outputs[i], err = parseOutputMap(entries)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading output %d", i)
+		}
+	}
+
+	if reader.Len() > 0 {
+		return nil, errors.New("unexpected data after the last output")
+	}
+	return p, nil
+}
+
+// readMap reads the entries of a map up to its separator
+func readMap(reader *bytes.Reader) ([]*entry, error) {
+	var entries []*entry
+	seenKeys := make(map[string]struct{})
+	for {
+		key, err := readLengthPrefixed(reader)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return entries, nil
+		}
+		value, err := readLengthPrefixed(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seenKeys[string(key)]; ok {
+			return nil, errors.Errorf("duplicate key %x", key)
+		}
+		seenKeys[string(key)] = struct{}{}
+		entries = append(entries, &entry{keyType: key[0], keyData: key[1:], value: value})
+	}
+}
+
+func readLengthPrefixed(reader *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, errors.Wrap(unexpectedEOF(err), "error reading a length")
+	}
+	if length > maxValueLength {
+		return nil, errors.Errorf("length %d is above the maximum of %d", length, maxValueLength)
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(reader, data)
+	if err != nil {
+		return nil, errors.Wrap(unexpectedEOF(err), "error reading data")
+	}
+	return data, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func parseGlobalMap(entries []*entry) (p *PSKT, inputCount uint32, outputCount uint32, err error) {
+	p = &PSKT{}
+	hasInputCount, hasOutputCount := false, false
+	for _, entry := range entries {
+		switch entry.keyType {
+		case globalVersion:
+			err = entry.expectNoKeyData()
+			if err == nil {
+				p.Version, err = entry.uint32()
+			}
+			if err == nil && p.Version > Version {
+				err = errors.Errorf("unsupported PSKT version %d", p.Version)
+			}
+		case globalTxVersion:
+			p.TxVersion, err = entry.uint16()
+		case globalLockTime:
+			p.LockTime, err = entry.uint64()
+		case globalSubnetworkID:
+			if len(entry.value) != externalapi.DomainSubnetworkIDSize || len(entry.keyData) != 0 {
+				err = entry.errorf("expected a %d bytes subnetwork ID", externalapi.DomainSubnetworkIDSize)
+			}
+			copy(p.SubnetworkID[:], entry.value)
+		case globalGas:
+			p.Gas, err = entry.uint64()
+		case globalPayload:
+			err = entry.expectNoKeyData()
+			p.Payload = entry.value
+		case globalInputCount:
+			err = entry.expectNoKeyData()
+			if err == nil {
+				inputCount, err = entry.uint32()
+			}
+			hasInputCount = true
+		case globalOutputCount:
+			err = entry.expectNoKeyData()
+			if err == nil {
+				outputCount, err = entry.uint32()
+			}
+			hasOutputCount = true
+		default:
+			p.Unknown = append(p.Unknown, entry.toKeyValue())
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	if !hasInputCount || !hasOutputCount {
+		return nil, 0, 0, errors.New("the number of inputs or outputs is missing")
+	}
+	// Every input and output takes at least its separator byte
+	if inputCount > maxValueLength || outputCount > maxValueLength {
+		return nil, 0, 0, errors.New("too many inputs or outputs")
+	}
+	return p, inputCount, outputCount, nil
+}
+
+func parseInputMap(entries []*entry) (*Input, error) {
+	input := &Input{}
+	hasOutpoint := false
+	cosignerPositions := make(map[string]uint32)
+	signatures := make(map[string][]byte)
+	for _, entry := range entries {
+		var err error
+		switch entry.keyType {
+		case inputPreviousOutpoint:
+			if len(entry.value) != externalapi.DomainHashSize+4 || len(entry.keyData) != 0 {
+				return nil, entry.errorf("expected a %d bytes outpoint", externalapi.DomainHashSize+4)
+			}
+			transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(entry.value[:externalapi.DomainHashSize])
+			if err != nil {
+				return nil, err
+			}
+			input.PreviousOutpoint = externalapi.DomainOutpoint{
+				TransactionID: *transactionID,
+				Index:         binary.LittleEndian.Uint32(entry.value[externalapi.DomainHashSize:]),
+			}
+			hasOutpoint = true
+		case inputSequence:
+			input.Sequence, err = entry.uint64()
+		case inputSigOpCount:
+			if len(entry.value) != 1 || len(entry.keyData) != 0 {
+				return nil, entry.errorf("expected a 1 byte value")
+			}
+			input.SigOpCount = entry.value[0]
+		case inputUTXO:
+			if len(entry.value) < 8 || len(entry.keyData) != 0 {
+				return nil, entry.errorf("the UTXO is too short")
+			}
+			input.UTXOAmount = binary.LittleEndian.Uint64(entry.value)
+			input.UTXOScriptPublicKey, err = entry.scriptPublicKey(entry.value[8:])
+		case inputMinimumSignatures:
+			err = entry.expectNoKeyData()
+			if err == nil {
+				input.MinimumSignatures, err = entry.uint32()
+			}
+		case inputDerivationPath:
+			err = entry.expectNoKeyData()
+			input.DerivationPath = string(entry.value)
+		case inputCosigner:
+			if len(entry.keyData) == 0 {
+				return nil, entry.errorf("the extended public key is missing")
+			}
+			cosignerPositions[string(entry.keyData)], err = entry.uint32()
+		case inputPartialSignature:
+			signatures[string(entry.keyData)] = entry.value
+		case inputFinalSignatureScript:
+			err = entry.expectNoKeyData()
+			input.FinalSignatureScript = entry.value
+		default:
+			input.Unknown = append(input.Unknown, entry.toKeyValue())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !hasOutpoint {
+		return nil, errors.New("the previous outpoint is missing")
+	}
+
+	input.Cosigners = make([]*Cosigner, len(cosignerPositions))
+	for extendedPublicKey, position := range cosignerPositions {
+		if position >= uint32(len(input.Cosigners)) || input.Cosigners[position] != nil {
+			return nil, errors.Errorf("the positions of the cosigners are not 0 to %d", len(input.Cosigners)-1)
+		}
+		input.Cosigners[position] = &Cosigner{ExtendedPublicKey: extendedPublicKey, Signature: signatures[extendedPublicKey]}
+		delete(signatures, extendedPublicKey)
+	}
+	for extendedPublicKey := range signatures {
+		return nil, errors.Errorf("the signature of %s is not of a cosigner", extendedPublicKey)
+	}
+	return input, nil
+}
+
+func parseOutputMap(entries []*entry) (*Output, error) {
+	output := &Output{}
+	for _, entry := range entries {
+		var err error
+		switch entry.keyType {
+		case outputAmount:
+			output.Amount, err = entry.uint64()
+		case outputScriptPublicKey:
+			err = entry.expectNoKeyData()
+			if err == nil {
+				output.ScriptPublicKey, err = entry.scriptPublicKey(entry.value)
+			}
+		default:
+			output.Unknown = append(output.Unknown, entry.toKeyValue())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if output.ScriptPublicKey == nil {
+		return nil, errors.New("the script public key is missing")
+	}
+	return output, nil
+}
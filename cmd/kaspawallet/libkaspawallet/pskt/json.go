@@ -0,0 +1,258 @@
+package pskt
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/pkg/errors"
+)
+
+type jsonPSKT struct {
+	Version       uint32          `json:"version"`
+	TransactionID string          `json:"transactionId"`
+	TxVersion     uint16          `json:"txVersion"`
+	LockTime      uint64          `json:"lockTime"`
+	SubnetworkID  string          `json:"subnetworkId"`
+	Gas           uint64          `json:"gas"`
+	Payload       string          `json:"payload,omitempty"`
+	Inputs        []*jsonInput    `json:"inputs"`
+	Outputs       []*jsonOutput   `json:"outputs"`
+	Unknown       []*jsonKeyValue `json:"unknown,omitempty"`
+}
+
+type jsonInput struct {
+	PreviousOutpoint     *jsonOutpoint   `json:"previousOutpoint"`
+	Sequence             uint64          `json:"sequence"`
+	SigOpCount           byte            `json:"sigOpCount"`
+	UTXO                 *jsonUTXO       `json:"utxo,omitempty"`
+	MinimumSignatures    uint32          `json:"minimumSignatures"`
+	DerivationPath       string          `json:"derivationPath,omitempty"`
+	Cosigners            []*jsonCosigner `json:"cosigners"`
+	FinalSignatureScript string          `json:"finalSignatureScript,omitempty"`
+	Unknown              []*jsonKeyValue `json:"unknown,omitempty"`
+}
+
+type jsonOutpoint struct {
+	TransactionID string `json:"transactionId"`
+	Index         uint32 `json:"index"`
+}
+
+type jsonUTXO struct {
+	Amount          uint64               `json:"amount"`
+	ScriptPublicKey *jsonScriptPublicKey `json:"scriptPublicKey"`
+}
+
+type jsonScriptPublicKey struct {
+	Version uint16 `json:"version"`
+	Script  string `json:"script"`
+}
+
+type jsonCosigner struct {
+	ExtendedPublicKey string `json:"extendedPublicKey"`
+	Signature         string `json:"signature,omitempty"`
+}
+
+type jsonOutput struct {
+	Amount          uint64               `json:"amount"`
+	ScriptPublicKey *jsonScriptPublicKey `json:"scriptPublicKey"`
+	Unknown         []*jsonKeyValue      `json:"unknown,omitempty"`
+}
+
+type jsonKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// MarshalJSON returns the JSON view of the PSKT
+func (p *PSKT) MarshalJSON() ([]byte, error) {
+	view := &jsonPSKT{
+		Version:       p.Version,
+		TransactionID: p.TransactionID().String(),
+		TxVersion:     p.TxVersion,
+		LockTime:      p.LockTime,
+		SubnetworkID:  p.SubnetworkID.String(),
+		Gas:           p.Gas,
+		Payload:       hex.EncodeToString(p.Payload),
+		Inputs:        make([]*jsonInput, len(p.Inputs)),
+		Outputs:       make([]*jsonOutput, len(p.Outputs)),
+		Unknown:       unknownToJSON(p.Unknown),
+	}
+	for i, input := range p.Inputs {
+		jsonInput := &jsonInput{
+			PreviousOutpoint: &jsonOutpoint{
+				TransactionID: input.PreviousOutpoint.TransactionID.String(),
+				Index:         input.PreviousOutpoint.Index,
+			},
+			Sequence:             input.Sequence,
+			SigOpCount:           input.SigOpCount,
+			MinimumSignatures:    input.MinimumSignatures,
+			DerivationPath:       input.DerivationPath,
+			Cosigners:            make([]*jsonCosigner, len(input.Cosigners)),
+			FinalSignatureScript: hex.EncodeToString(input.FinalSignatureScript),
+			Unknown:              unknownToJSON(input.Unknown),
+		}
+		if input.UTXOScriptPublicKey != nil {
+			jsonInput.UTXO = &jsonUTXO{
+				Amount:          input.UTXOAmount,
+				ScriptPublicKey: scriptPublicKeyToJSON(input.UTXOScriptPublicKey),
+			}
+		}
+		for j, cosigner := range input.Cosigners {
+			jsonInput.Cosigners[j] = &jsonCosigner{
+				ExtendedPublicKey: cosigner.ExtendedPublicKey,
+				Signature:         hex.EncodeToString(cosigner.Signature),
+			}
+		}
+		view.Inputs[i] = jsonInput
+	}
+	for i, output := range p.Outputs {
+		view.Outputs[i] = &jsonOutput{
+			Amount:          output.Amount,
+			ScriptPublicKey: scriptPublicKeyToJSON(output.ScriptPublicKey),
+			Unknown:         unknownToJSON(output.Unknown),
+		}
+	}
+	return json.Marshal(view)
+}
+
+func scriptPublicKeyToJSON(scriptPublicKey *externalapi.ScriptPublicKey) *jsonScriptPublicKey {
+	return &jsonScriptPublicKey{Version: scriptPublicKey.Version, Script: hex.EncodeToString(scriptPublicKey.Script)}
+}
+
+func unknownToJSON(unknown []*KeyValue) []*jsonKeyValue {
+	if len(unknown) == 0 {
+		return nil
+	}
+	jsonUnknown := make([]*jsonKeyValue, len(unknown))
+	for i, keyValue := range unknown {
+		jsonUnknown[i] = &jsonKeyValue{Key: hex.EncodeToString(keyValue.Key), Value: hex.EncodeToString(keyValue.Value)}
+	}
+	return jsonUnknown
+}
+
+// UnmarshalJSON decodes the PSKT from its JSON view. The transaction ID in the
+// view is only informative, and is ignored
+func (p *PSKT) UnmarshalJSON(data []byte) error {
+	view := &jsonPSKT{}
+	err := json.Unmarshal(data, view)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if view.Version > Version {
+		return errors.Errorf("unsupported PSKT version %d", view.Version)
+	}
+
+	decoder := &hexDecoder{}
+	subnetworkID, err := subnetworks.FromBytes(decoder.decode(view.SubnetworkID))
+	if decoder.err == nil && err != nil {
+		return err
+	}
+	decoded := &PSKT{
+		Version:   view.Version,
+		TxVersion: view.TxVersion,
+		LockTime:  view.LockTime,
+		Gas:       view.Gas,
+		Payload:   decoder.decodeOptional(view.Payload),
+		Inputs:    make([]*Input, len(view.Inputs)),
+		Outputs:   make([]*Output, len(view.Outputs)),
+		Unknown:   decoder.decodeUnknown(view.Unknown),
+	}
+	if subnetworkID != nil {
+		decoded.SubnetworkID = *subnetworkID
+	}
+
+	for i, jsonInput := range view.Inputs {
+		if jsonInput.PreviousOutpoint == nil {
+			return errors.Errorf("input %d is missing its previous outpoint", i)
+		}
+		transactionID, err := externalapi.NewDomainTransactionIDFromString(jsonInput.PreviousOutpoint.TransactionID)
+		if err != nil {
+			return errors.Wrapf(err, "input %d", i)
+		}
+		input := &Input{
+			PreviousOutpoint: externalapi.DomainOutpoint{
+				TransactionID: *transactionID,
+				Index:         jsonInput.PreviousOutpoint.Index,
+			},
+			Sequence:             jsonInput.Sequence,
+			SigOpCount:           jsonInput.SigOpCount,
+			MinimumSignatures:    jsonInput.MinimumSignatures,
+			DerivationPath:       jsonInput.DerivationPath,
+			Cosigners:            make([]*Cosigner, len(jsonInput.Cosigners)),
+			FinalSignatureScript: decoder.decodeOptional(jsonInput.FinalSignatureScript),
+			Unknown:              decoder.decodeUnknown(jsonInput.Unknown),
+		}
+		if jsonInput.UTXO != nil {
+			if jsonInput.UTXO.ScriptPublicKey == nil {
+				return errors.Errorf("the UTXO of input %d is missing its script public key", i)
+			}
+			input.UTXOAmount = jsonInput.UTXO.Amount
+			input.UTXOScriptPublicKey = decoder.decodeScriptPublicKey(jsonInput.UTXO.ScriptPublicKey)
+		}
+		for j, jsonCosigner := range jsonInput.Cosigners {
+			input.Cosigners[j] = &Cosigner{
+				ExtendedPublicKey: jsonCosigner.ExtendedPublicKey,
+				Signature:         decoder.decodeOptional(jsonCosigner.Signature),
+			}
+		}
+		decoded.Inputs[i] = input
+	}
+
+	for i, jsonOutput := range view.Outputs {
+		if jsonOutput.ScriptPublicKey == nil {
+			return errors.Errorf("output %d is missing its script public key", i)
+		}
+		decoded.Outputs[i] = &Output{
+			Amount:          jsonOutput.Amount,
+			ScriptPublicKey: decoder.decodeScriptPublicKey(jsonOutput.ScriptPublicKey),
+			Unknown:         decoder.decodeUnknown(jsonOutput.Unknown),
+		}
+	}
+
+	if decoder.err != nil {
+		return decoder.err
+	}
+	*p = *decoded
+	return nil
+}
+
+// hexDecoder decodes the hex strings of a JSON view, keeping the first error
+// it encounters
+type hexDecoder struct {
+	err error
+}
+
+func (hd *hexDecoder) decode(hexString string) []byte {
+	decoded, err := hex.DecodeString(hexString)
+	if err != nil && hd.err == nil {
+		hd.err = errors.Wrapf(err, "%q is not a hex string", hexString)
+	}
+	return decoded
+}
+
+// decodeOptional decodes a hex string that the JSON view omits when it's
+// empty, and returns nil for the empty string
+func (hd *hexDecoder) decodeOptional(hexString string) []byte {
+	if hexString == "" {
+		return nil
+	}
+	return hd.decode(hexString)
+}
+
+func (hd *hexDecoder) decodeScriptPublicKey(jsonScriptPublicKey *jsonScriptPublicKey) *externalapi.ScriptPublicKey {
+	return &externalapi.ScriptPublicKey{Version: jsonScriptPublicKey.Version, Script: hd.decode(jsonScriptPublicKey.Script)}
+}
+
+func (hd *hexDecoder) decodeUnknown(jsonUnknown []*jsonKeyValue) []*KeyValue {
+	var unknown []*KeyValue
+	for _, jsonKeyValue := range jsonUnknown {
+		key := hd.decode(jsonKeyValue.Key)
+		if len(key) == 0 && hd.err == nil {
+			hd.err = errors.New("an entry of an unknown type has an empty key")
+		}
+		unknown = append(unknown, &KeyValue{Key: key, Value: hd.decode(jsonKeyValue.Value)})
+	}
+	return unknown
+}
@@ -0,0 +1,130 @@
+/*
+Package pskt implements PSKT (partially signed Kaspa transaction), a portable
+format for transactions that are passed between cosigners until they're signed,
+modeled after Bitcoin's BIP-174 PSBT.
+
+# Binary format
+
+A PSKT starts with the magic bytes 0x70 0x73 0x6b 0x74 0xff ("pskt" followed by
+0xff), followed by a global map, a map per input and a map per output, in the
+order of the inputs and outputs of the transaction. A map is a sequence of
+key-value entries ended by a 0x00 byte:
+
+	<key length> <key> <value length> <value>
+
+where the lengths are unsigned varints (LEB128, as encoding/binary encodes
+them) and the key is a type byte followed by key data, which most types leave
+empty. Integers in values are little-endian. Every key appears at most once in
+its map. Entries of unknown types are kept as they are, so that tools that
+don't know them pass them on.
+
+Global map types:
+
+	0x00 transaction version      uint16
+	0x01 lock time                uint64
+	0x02 subnetwork ID            20 bytes
+	0x03 gas                      uint64
+	0x04 payload                  bytes
+	0x05 number of inputs         uint32
+	0x06 number of outputs        uint32
+	0xfb PSKT version             uint32, currently 0
+
+Input map types:
+
+	0x00 previous outpoint        32 bytes transaction ID, uint32 index
+	0x01 sequence                 uint64
+	0x02 signature operations     uint8
+	0x03 spent UTXO               uint64 amount, uint16 script version, script
+	0x04 minimum signatures       uint32
+	0x05 derivation path          UTF-8, e.g. m/0/1
+	0x06 cosigner                 key data: extended public key, value: uint32
+	                              position of the key in the input's keys
+	0x07 partial signature        key data: extended public key, value: signature
+	0x08 final signature script   bytes
+
+Output map types:
+
+	0x00 amount                   uint64
+	0x01 script public key        uint16 script version, script
+
+# JSON view
+
+A PSKT also has a JSON view, which is made of the same fields, with byte
+strings encoded in hex. It's meant for people to read and tools to inspect, but
+converts back to the same PSKT.
+*/
+package pskt
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// Version is the version of the PSKT format this package writes
+const Version = 0
+
+// PSKT is a partially signed Kaspa transaction
+type PSKT struct {
+	Version uint32
+
+	TxVersion    uint16
+	LockTime     uint64
+	SubnetworkID externalapi.DomainSubnetworkID
+	Gas          uint64
+	Payload      []byte
+
+	Inputs  []*Input
+	Outputs []*Output
+
+	Unknown []*KeyValue
+}
+
+// Input is an input of a PSKT, with what its cosigners need to sign it
+type Input struct {
+	PreviousOutpoint externalapi.DomainOutpoint
+	Sequence         uint64
+	SigOpCount       byte
+
+	UTXOAmount          uint64
+	UTXOScriptPublicKey *externalapi.ScriptPublicKey
+
+	MinimumSignatures uint32
+	DerivationPath    string
+
+	// Cosigners are ordered by their position in the keys of the input
+	Cosigners []*Cosigner
+
+	// FinalSignatureScript is set once the input is finalized
+	FinalSignatureScript []byte
+
+	Unknown []*KeyValue
+}
+
+// Cosigner is a key that may sign an input, with its signature once it did
+type Cosigner struct {
+	ExtendedPublicKey string
+	Signature         []byte
+}
+
+// Output is an output of a PSKT
+type Output struct {
+	Amount          uint64
+	ScriptPublicKey *externalapi.ScriptPublicKey
+
+	Unknown []*KeyValue
+}
+
+// KeyValue is an entry of a type this package doesn't know
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+// IsFinalized returns whether all the inputs of the PSKT are finalized
+func (p *PSKT) IsFinalized() bool {
+	for _, input := range p.Inputs {
+		if input.FinalSignatureScript == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package pskt
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+var testParams = &dagconfig.SimnetParams
+
+// createMultisigTransaction creates a 2-of-3 multisig transaction with two
+// inputs, and returns it along with the mnemonics of its cosigners
+func createMultisigTransaction(t *testing.T, ecdsa bool) ([]byte, []string) {
+	const numKeys, minimumSignatures = 3, 2
+	mnemonics := make([]string, numKeys)
+	publicKeys := make([]string, numKeys)
+	for i := range mnemonics {
+		var err error
+		mnemonics[i], err = libkaspawallet.CreateMnemonic()
+		if err != nil {
+			t.Fatalf("CreateMnemonic: %+v", err)
+		}
+		publicKeys[i], err = libkaspawallet.MasterPublicKeyFromMnemonic(testParams, mnemonics[i], true)
+		if err != nil {
+			t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+		}
+	}
+
+	const path = "m/0/4"
+	address, err := libkaspawallet.Address(testParams, publicKeys, minimumSignatures, path, ecdsa)
+	if err != nil {
+		t.Fatalf("Address: %+v", err)
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %+v", err)
+	}
+
+	selectedUTXOs := make([]*libkaspawallet.UTXO, 2)
+	for i := range selectedUTXOs {
+		selectedUTXOs[i] = &libkaspawallet.UTXO{
+			Outpoint:       &externalapi.DomainOutpoint{Index: uint32(i)},
+			UTXOEntry:      utxo.NewUTXOEntry(uint64(i+1)*100_000, scriptPublicKey, false, 0),
+			DerivationPath: path,
+		}
+	}
+	payments := []*libkaspawallet.Payment{{Address: address, Amount: 250_000}}
+	partiallySignedTransaction, err := libkaspawallet.CreateUnsignedTransaction(publicKeys, minimumSignatures, payments, selectedUTXOs)
+	if err != nil {
+		t.Fatalf("CreateUnsignedTransaction: %+v", err)
+	}
+	serializedPSTx, err := serialization.SerializePartiallySignedTransaction(partiallySignedTransaction)
+	if err != nil {
+		t.Fatalf("SerializePartiallySignedTransaction: %+v", err)
+	}
+	return serializedPSTx, mnemonics
+}
+
+func signAsPSKT(t *testing.T, serializedPSTx []byte, mnemonic string, ecdsa bool) *PSKT {
+	signed, err := libkaspawallet.Sign(testParams, []string{mnemonic}, serializedPSTx, ecdsa)
+	if err != nil {
+		t.Fatalf("Sign: %+v", err)
+	}
+	p, err := Parse(signed)
+	if err != nil {
+		t.Fatalf("Parse: %+v", err)
+	}
+	return p
+}
+
+func TestRoundTrips(t *testing.T) {
+	serializedPSTx, mnemonics := createMultisigTransaction(t, false)
+	signedPSTx, err := libkaspawallet.Sign(testParams, mnemonics[:1], serializedPSTx, false)
+	if err != nil {
+		t.Fatalf("Sign: %+v", err)
+	}
+	p, err := Parse(signedPSTx)
+	if err != nil {
+		t.Fatalf("Parse: %+v", err)
+	}
+	p.Unknown = []*KeyValue{{Key: []byte{0x42, 1}, Value: []byte{2}}}
+	p.Inputs[1].Unknown = []*KeyValue{{Key: []byte{0x43}, Value: nil}}
+
+	deserialized, err := Deserialize(p.Serialize())
+	if err != nil {
+		t.Fatalf("Deserialize: %+v", err)
+	}
+	if !bytes.Equal(deserialized.Serialize(), p.Serialize()) {
+		t.Fatalf("the PSKT changed in a binary round trip")
+	}
+
+	jsonView, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal: %+v", err)
+	}
+	fromJSON := &PSKT{}
+	err = json.Unmarshal(jsonView, fromJSON)
+	if err != nil {
+		t.Fatalf("Unmarshal: %+v", err)
+	}
+	if !bytes.Equal(fromJSON.Serialize(), p.Serialize()) {
+		t.Fatalf("the PSKT changed in a JSON round trip")
+	}
+
+	// The protobuf format has no place for entries of unknown types
+	p.Unknown, p.Inputs[1].Unknown = nil, nil
+	legacy, err := ToSerializedPartiallySignedTransaction(p.Serialize())
+	if err != nil {
+		t.Fatalf("ToSerializedPartiallySignedTransaction: %+v", err)
+	}
+	expected, err := serialization.DeserializePartiallySignedTransaction(signedPSTx)
+	if err != nil {
+		t.Fatalf("DeserializePartiallySignedTransaction: %+v", err)
+	}
+	actual, err := serialization.DeserializePartiallySignedTransaction(legacy)
+	if err != nil {
+		t.Fatalf("DeserializePartiallySignedTransaction: %+v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("the partially signed transaction changed in a round trip through a PSKT")
+	}
+}
+
+func countSignatures(input *Input) int {
+	signatures := 0
+	for _, cosigner := range input.Cosigners {
+		if cosigner.Signature != nil {
+			signatures++
+		}
+	}
+	return signatures
+}
+
+func TestCombineAndFinalize(t *testing.T) {
+	for _, ecdsa := range []bool{false, true} {
+		serializedPSTx, mnemonics := createMultisigTransaction(t, ecdsa)
+		first := signAsPSKT(t, serializedPSTx, mnemonics[0], ecdsa)
+		third := signAsPSKT(t, serializedPSTx, mnemonics[2], ecdsa)
+
+		err := first.Clone().Finalize()
+		if err == nil || !strings.Contains(err.Error(), "missing") {
+			t.Fatalf("expected a PSKT with a single signature not to finalize, got: %v", err)
+		}
+
+		combined, err := Combine(first, third)
+		if err != nil {
+			t.Fatalf("Combine: %+v", err)
+		}
+		for _, input := range combined.Inputs {
+			if countSignatures(input) != 2 {
+				t.Fatalf("expected the combined PSKT to have the signatures of both cosigners")
+			}
+		}
+		if countSignatures(first.Inputs[0]) != 1 {
+			t.Fatalf("Combine modified the PSKTs it combined")
+		}
+
+		_, err = combined.Extract()
+		if err == nil {
+			t.Fatalf("expected a PSKT that isn't finalized not to be extracted")
+		}
+		err = combined.Finalize()
+		if err != nil {
+			t.Fatalf("Finalize: %+v", err)
+		}
+		tx, err := combined.Extract()
+		if err != nil {
+			t.Fatalf("Extract: %+v", err)
+		}
+
+		partiallySignedTransaction, err := combined.ToPartiallySignedTransaction()
+		if err != nil {
+			t.Fatalf("ToPartiallySignedTransaction: %+v", err)
+		}
+		expectedTx, err := libkaspawallet.ExtractTransactionDeserialized(partiallySignedTransaction, ecdsa)
+		if err != nil {
+			t.Fatalf("ExtractTransactionDeserialized: %+v", err)
+		}
+		for i, input := range tx.Inputs {
+			if !bytes.Equal(input.SignatureScript, expectedTx.Inputs[i].SignatureScript) {
+				t.Fatalf("ecdsa=%t: unexpected signature script of input %d", ecdsa, i)
+			}
+		}
+	}
+}
+
+func TestCombineDifferentTransactions(t *testing.T) {
+	serializedPSTx, mnemonics := createMultisigTransaction(t, false)
+	otherPSTx, otherMnemonics := createMultisigTransaction(t, false)
+	_, err := Combine(signAsPSKT(t, serializedPSTx, mnemonics[0], false), signAsPSKT(t, otherPSTx, otherMnemonics[1], false))
+	if err == nil || !strings.Contains(err.Error(), "rather than of transaction") {
+		t.Fatalf("expected PSKTs of different transactions not to combine, got: %v", err)
+	}
+}
+
+func TestDeserializeMalformed(t *testing.T) {
+	serializedPSTx, mnemonics := createMultisigTransaction(t, false)
+	serialized := signAsPSKT(t, serializedPSTx, mnemonics[0], false).Serialize()
+
+	tests := []struct {
+		name       string
+		serialized []byte
+	}{
+		{name: "no magic", serialized: serialized[1:]},
+		{name: "truncated", serialized: serialized[:len(serialized)-1]},
+		{name: "trailing data", serialized: append(append([]byte{}, serialized...), 0)},
+		{name: "duplicate key", serialized: append(append([]byte{}, magic...), 1, 0x04, 0, 1, 0x04, 0, 0)},
+		{name: "missing counts", serialized: append(append([]byte{}, magic...), 0)},
+	}
+	for _, test := range tests {
+		_, err := Deserialize(test.serialized)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
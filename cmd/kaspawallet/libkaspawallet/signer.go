@@ -0,0 +1,34 @@
+package libkaspawallet
+
+import (
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+// Signer adds the signatures of the keys it holds to partially signed
+// transactions. Implementations may hold the keys in the wallet process, or
+// forward the transactions to wherever the keys are kept
+type Signer interface {
+	// Sign returns the given serialized partially signed transaction, with
+	// the signatures of the keys of the signer added to it
+	Sign(serializedPSTx []byte) ([]byte, error)
+}
+
+type mnemonicSigner struct {
+	params    *dagconfig.Params
+	mnemonics []string
+	ecdsa     bool
+}
+
+// NewMnemonicSigner returns a signer that signs with the keys derived from the
+// given mnemonics
+func NewMnemonicSigner(params *dagconfig.Params, mnemonics []string, ecdsa bool) Signer {
+	return &mnemonicSigner{
+		params:    params,
+		mnemonics: mnemonics,
+		ecdsa:     ecdsa,
+	}
+}
+
+func (ms *mnemonicSigner) Sign(serializedPSTx []byte) ([]byte, error) {
+	return Sign(ms.params, ms.mnemonics, serializedPSTx, ms.ecdsa)
+}
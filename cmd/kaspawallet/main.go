@@ -42,6 +42,24 @@ func main() {
 		err = bumpFee(config.(*bumpFeeConfig))
 	case bumpFeeUnsignedSubCmd:
 		err = bumpFeeUnsigned(config.(*bumpFeeUnsignedConfig))
+	case historySubCmd:
+		err = history(config.(*historyConfig))
+	case setLabelSubCmd:
+		err = setLabel(config.(*setLabelConfig))
+	case consolidationStatusSubCmd:
+		err = consolidationStatus(config.(*consolidationStatusConfig))
+	case freezeUTXOsSubCmd:
+		err = freezeUTXOs(config.(*freezeUTXOsConfig))
+	case unfreezeUTXOsSubCmd:
+		err = unfreezeUTXOs(config.(*unfreezeUTXOsConfig))
+	case frozenUTXOsSubCmd:
+		err = frozenUTXOs(config.(*frozenUTXOsConfig))
+	case combineSubCmd:
+		err = combine(config.(*combineConfig))
+	case finalizeSubCmd:
+		err = finalize(config.(*finalizeConfig))
+	case inspectSubCmd:
+		err = inspect(config.(*inspectConfig))
 	default:
 		err = errors.Errorf("Unknown sub-command '%s'\n", subCmd)
 	}
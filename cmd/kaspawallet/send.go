@@ -11,6 +11,7 @@ import (
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/utils"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
 	"github.com/pkg/errors"
 )
 
@@ -20,7 +21,7 @@ func send(conf *sendConfig) error {
 		return err
 	}
 
-	if len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
+	if !keysFile.IsWatchOnly() && len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
 		return errors.Errorf("Cannot use 'send' command for multisig wallet without all of the keys")
 	}
 
@@ -42,6 +43,11 @@ func send(conf *sendConfig) error {
 		}
 	}
 
+	coinControl, err := conf.coinControl()
+	if err != nil {
+		return err
+	}
+
 	var feePolicy *pb.FeePolicy
 	if conf.FeeRate > 0 {
 		feePolicy = &pb.FeePolicy{
@@ -67,32 +73,18 @@ func send(conf *sendConfig) error {
 			IsSendAll:                conf.IsSendAll,
 			UseExistingChangeAddress: conf.UseExistingChangeAddress,
 			FeePolicy:                feePolicy,
+			CoinControl:              coinControl,
 		})
 	if err != nil {
 		return err
 	}
 
-	if len(conf.Password) == 0 {
-		conf.Password = keys.GetPassword("Password:")
-	}
-	mnemonics, err := keysFile.DecryptMnemonics(conf.Password)
+	signedTransactions, err := signTransactions(daemonClient, keysFile, conf.NetParams(), &conf.Password,
+		createUnsignedTransactionsResponse.UnsignedTransactions)
 	if err != nil {
-		if strings.Contains(err.Error(), "message authentication failed") {
-			fmt.Fprintf(os.Stderr, "Password decryption failed. Sometimes this is a result of not "+
-				"specifying the same keys file used by the wallet daemon process.\n")
-		}
 		return err
 	}
 
-	signedTransactions := make([][]byte, len(createUnsignedTransactionsResponse.UnsignedTransactions))
-	for i, unsignedTransaction := range createUnsignedTransactionsResponse.UnsignedTransactions {
-		signedTransaction, err := libkaspawallet.Sign(conf.NetParams(), mnemonics, unsignedTransaction, keysFile.ECDSA)
-		if err != nil {
-			return err
-		}
-		signedTransactions[i] = signedTransaction
-	}
-
 	fmt.Printf("Broadcasting %d transaction(s)\n", len(signedTransactions))
 	// Since we waited for user input when getting the password, which could take unbound amount of time -
 	// create a new context for broadcast, to reset the timeout.
@@ -127,3 +119,43 @@ func send(conf *sendConfig) error {
 
 	return nil
 }
+
+// signTransactions signs the given unsigned transactions with the mnemonics of
+// the keys file. A watch-only wallet has no mnemonics, so its transactions are
+// signed by the daemon instead, with the external signer it was started with
+func signTransactions(daemonClient pb.KaspawalletdClient, keysFile *keys.File, netParams *dagconfig.Params,
+	password *string, unsignedTransactions [][]byte) ([][]byte, error) {
+
+	if keysFile.IsWatchOnly() {
+		// The external signer may wait for its user to confirm every transaction, and the
+		// daemon already bounds the time it waits for each of them
+		response, err := daemonClient.Sign(context.Background(),
+			&pb.SignRequest{UnsignedTransactions: unsignedTransactions})
+		if err != nil {
+			return nil, err
+		}
+		return response.SignedTransactions, nil
+	}
+
+	if len(*password) == 0 {
+		*password = keys.GetPassword("Password:")
+	}
+	mnemonics, err := keysFile.DecryptMnemonics(*password)
+	if err != nil {
+		if strings.Contains(err.Error(), "message authentication failed") {
+			fmt.Fprintf(os.Stderr, "Password decryption failed. Sometimes this is a result of not "+
+				"specifying the same keys file used by the wallet daemon process.\n")
+		}
+		return nil, err
+	}
+
+	signedTransactions := make([][]byte, len(unsignedTransactions))
+	for i, unsignedTransaction := range unsignedTransactions {
+		signedTransaction, err := libkaspawallet.Sign(netParams, mnemonics, unsignedTransaction, keysFile.ECDSA)
+		if err != nil {
+			return nil, err
+		}
+		signedTransactions[i] = signedTransaction
+	}
+	return signedTransactions, nil
+}
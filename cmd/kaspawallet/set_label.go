@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/client"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/pb"
+)
+
+func setLabel(conf *setLabelConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	request := &pb.SetLabelRequest{Label: conf.Label}
+	if conf.TxID != "" {
+		request.Target = &pb.SetLabelRequest_TxID{TxID: conf.TxID}
+	} else {
+		request.Target = &pb.SetLabelRequest_Address{Address: conf.Address}
+	}
+	_, err = daemonClient.SetLabel(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Label set")
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/externalsigner"
 	"github.com/pkg/errors"
 )
 
@@ -20,15 +21,7 @@ func sign(conf *signConfig) error {
 		return errors.Errorf("Both --transaction and --transaction-file cannot be passed at the same time")
 	}
 
-	keysFile, err := keys.ReadKeysFile(conf.NetParams(), conf.KeysFile)
-	if err != nil {
-		return err
-	}
-
-	if len(conf.Password) == 0 {
-		conf.Password = keys.GetPassword("Password:")
-	}
-	privateKeys, err := keysFile.DecryptMnemonics(conf.Password)
+	signer, err := transactionSigner(conf)
 	if err != nil {
 		return err
 	}
@@ -48,8 +41,7 @@ func sign(conf *signConfig) error {
 
 	updatedPartiallySignedTransactions := make([][]byte, len(partiallySignedTransactions))
 	for i, partiallySignedTransaction := range partiallySignedTransactions {
-		updatedPartiallySignedTransactions[i], err =
-			libkaspawallet.Sign(conf.NetParams(), privateKeys, partiallySignedTransaction, keysFile.ECDSA)
+		updatedPartiallySignedTransactions[i], err = signer.Sign(partiallySignedTransaction)
 		if err != nil {
 			return err
 		}
@@ -76,3 +68,28 @@ func sign(conf *signConfig) error {
 	fmt.Println(server.EncodeTransactionsToHex(updatedPartiallySignedTransactions))
 	return nil
 }
+
+// transactionSigner returns the external signer given with --signer, or a
+// signer of the mnemonics of the keys file otherwise
+func transactionSigner(conf *signConfig) (libkaspawallet.Signer, error) {
+	if conf.Signer != "" {
+		return externalsigner.New(conf.Signer, conf.NetParams().Name)
+	}
+
+	keysFile, err := keys.ReadKeysFile(conf.NetParams(), conf.KeysFile)
+	if err != nil {
+		return nil, err
+	}
+	if keysFile.IsWatchOnly() {
+		return nil, keys.ErrWatchOnly
+	}
+
+	if len(conf.Password) == 0 {
+		conf.Password = keys.GetPassword("Password:")
+	}
+	privateKeys, err := keysFile.DecryptMnemonics(conf.Password)
+	if err != nil {
+		return nil, err
+	}
+	return libkaspawallet.NewMnemonicSigner(conf.NetParams(), privateKeys, keysFile.ECDSA), nil
+}
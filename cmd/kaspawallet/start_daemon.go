@@ -1,7 +1,49 @@
 package main
 
-import "github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
+import (
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/daemon/server"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/keys"
+	"github.com/kaspanet/kaspad/cmd/kaspawallet/utils"
+	"github.com/pkg/errors"
+)
 
 func startDaemon(conf *startDaemonConfig) error {
-	return server.Start(conf.NetParams(), conf.Listen, conf.RPCServer, conf.KeysFile, conf.Profile, conf.Timeout)
+	consolidationConfig, err := consolidationConfig(conf)
+	if err != nil {
+		return err
+	}
+	return server.Start(conf.NetParams(), conf.Listen, conf.RPCServer, conf.KeysFile, conf.Profile, conf.Timeout, conf.Signer,
+		consolidationConfig)
+}
+
+// consolidationConfig returns the configuration of UTXO consolidation, or nil
+// if it's not enabled
+func consolidationConfig(conf *startDaemonConfig) (*server.ConsolidationConfig, error) {
+	if !conf.Consolidate {
+		return nil, nil
+	}
+
+	feeBudget, err := utils.KasToSompi(conf.ConsolidationFeeBudget)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid --consolidation-fee-budget")
+	}
+	threshold, err := utils.KasToSompi(conf.ConsolidationThreshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid --consolidation-threshold")
+	}
+
+	// The daemon signs the consolidation transactions by itself, so it needs
+	// the password unless an external signer signs them
+	if conf.Password == "" && conf.Signer == "" {
+		conf.Password = keys.GetPassword("Password:")
+	}
+
+	return &server.ConsolidationConfig{
+		Password:           conf.Password,
+		Interval:           conf.ConsolidationInterval,
+		MaxFeeRate:         conf.ConsolidationMaxFeeRate,
+		FeeBudget:          feeBudget,
+		SmallUTXOThreshold: threshold,
+		MinUTXOs:           int(conf.ConsolidationMinUTXOs),
+	}, nil
 }
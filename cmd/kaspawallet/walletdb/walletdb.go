@@ -0,0 +1,430 @@
+package walletdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+// The wallet database is small, so it doesn't need the cache kaspad gives its own database
+const cacheSizeMiB = 8
+
+var (
+	transactionsBucket  = database.MakeBucket([]byte("transactions"))
+	pendingSpendsBucket = database.MakeBucket([]byte("pending-spends"))
+	addressLabelsBucket = database.MakeBucket([]byte("address-labels"))
+	frozenUTXOsBucket   = database.MakeBucket([]byte("frozen-utxos"))
+
+	consolidationSpendsBucket = database.MakeBucket([]byte("consolidation-spends"))
+)
+
+// Output is an output of a wallet transaction
+type Output struct {
+	Address         string `json:"address"`
+	Amount          uint64 `json:"amount"`
+	IsWalletAddress bool   `json:"isWalletAddress"`
+}
+
+// Outpoint is an outpoint spent by a transaction sent by the wallet, or a
+// frozen UTXO
+type Outpoint struct {
+	TransactionID string `json:"transactionId"`
+	Index         uint32 `json:"index"`
+}
+
+// Transaction is a transaction that either sent funds from the wallet or
+// received funds to it
+type Transaction struct {
+	ID string `json:"id"`
+
+	// ReceivedAmount is the amount received by the wallet from other wallets,
+	// and SentAmount is the amount sent by the wallet to other wallets. Fee
+	// is set only for the transactions the wallet sent
+	ReceivedAmount uint64 `json:"receivedAmount"`
+	SentAmount     uint64 `json:"sentAmount"`
+	Fee            uint64 `json:"fee"`
+
+	// Outputs are all the outputs of the transactions the wallet sent, and only
+	// the outputs to the wallet addresses of the transactions it received
+	Outputs []*Output   `json:"outputs"`
+	Inputs  []*Outpoint `json:"inputs,omitempty"`
+
+	IsAccepted         bool   `json:"isAccepted"`
+	AcceptingDAAScore  uint64 `json:"acceptingDaaScore"`
+	AcceptingBlockHash string `json:"acceptingBlockHash,omitempty"`
+
+	// ReplacedBy is the ID of the transaction that replaced this one in the mempool
+	ReplacedBy string `json:"replacedBy,omitempty"`
+
+	// FirstSeen is the time, in unix milliseconds, the wallet first learned of the transaction
+	FirstSeen int64  `json:"firstSeen"`
+	Label     string `json:"label,omitempty"`
+}
+
+// IsSent returns whether the transaction was sent by the wallet
+func (tx *Transaction) IsSent() bool {
+	return len(tx.Inputs) > 0
+}
+
+// ConsolidationSpend is the fee of a transaction sent by UTXO consolidation,
+// which counts toward the consolidation fee budget
+type ConsolidationSpend struct {
+	TransactionID string `json:"transactionId"`
+
+	// Time is the time, in unix milliseconds, the transaction was sent at
+	Time int64  `json:"time"`
+	Fee  uint64 `json:"fee"`
+}
+
+// DB is the wallet database, which keeps the history, the labels, the frozen
+// UTXOs and the consolidation spends of a wallet
+type DB struct {
+	db database.Database
+
+	// lock makes read-modify-write updates of transactions atomic
+	lock sync.Mutex
+}
+
+// PathForKeysFile returns the path of the wallet database that belongs to the
+// given keys file. It's kept next to the keys file, so wallets that share a
+// directory each get a database of their own
+func PathForKeysFile(keysFilePath string) string {
+	fileName := filepath.Base(keysFilePath)
+	return filepath.Join(filepath.Dir(keysFilePath), strings.TrimSuffix(fileName, filepath.Ext(fileName))+".walletdb")
+}
+
+// Open opens the wallet database in the given path, creating it if it doesn't exist
+func Open(path string) (*DB, error) {
+	db, err := ldb.NewLevelDB(path, cacheSizeMiB)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening the wallet database %s", path)
+	}
+	return &DB{db: db}, nil
+}
+
+// Close closes the wallet database
+func (wdb *DB) Close() error {
+	return wdb.db.Close()
+}
+
+// Transaction returns the transaction with the given ID, and whether it's known at all
+func (wdb *DB) Transaction(transactionID string) (*Transaction, bool, error) {
+	transactionBytes, err := wdb.db.Get(transactionsBucket.Key([]byte(transactionID)))
+	if database.IsNotFoundError(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	transaction := &Transaction{}
+	err = json.Unmarshal(transactionBytes, transaction)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "error deserializing transaction %s", transactionID)
+	}
+	return transaction, true, nil
+}
+
+func (wdb *DB) putTransaction(transaction *Transaction) error {
+	transactionBytes, err := json.Marshal(transaction)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return wdb.db.Put(transactionsBucket.Key([]byte(transaction.ID)), transactionBytes)
+}
+
+// UpdateTransaction replaces the transaction with the given ID by the one the
+// given function returns for it. If the function returns nil, nothing is updated
+func (wdb *DB) UpdateTransaction(transactionID string,
+	update func(transaction *Transaction, found bool) (*Transaction, error)) error {
+
+	wdb.lock.Lock()
+	defer wdb.lock.Unlock()
+
+	transaction, found, err := wdb.Transaction(transactionID)
+	if err != nil {
+		return err
+	}
+	updatedTransaction, err := update(transaction, found)
+	if err != nil {
+		return err
+	}
+	if updatedTransaction == nil {
+		return nil
+	}
+	return wdb.putTransaction(updatedTransaction)
+}
+
+// Transactions returns all the wallet transactions. The accepted ones come
+// first, in the order of their acceptance, followed by the pending ones in the
+// order they were first seen
+func (wdb *DB) Transactions() ([]*Transaction, error) {
+	cursor, err := wdb.db.Cursor(transactionsBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var transactions []*Transaction
+	for cursor.Next() {
+		transactionBytes, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		transaction := &Transaction{}
+		err = json.Unmarshal(transactionBytes, transaction)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].IsAccepted != transactions[j].IsAccepted {
+			return transactions[i].IsAccepted
+		}
+		if transactions[i].AcceptingDAAScore != transactions[j].AcceptingDAAScore {
+			return transactions[i].AcceptingDAAScore < transactions[j].AcceptingDAAScore
+		}
+		if transactions[i].FirstSeen != transactions[j].FirstSeen {
+			return transactions[i].FirstSeen < transactions[j].FirstSeen
+		}
+		return transactions[i].ID < transactions[j].ID
+	})
+	return transactions, nil
+}
+
+// AddPendingSpends remembers that the inputs of the given transaction are
+// spent by it, until it's accepted. A later transaction that spends the same
+// inputs, such as a replacement, takes them over
+func (wdb *DB) AddPendingSpends(transaction *Transaction) error {
+	for _, input := range transaction.Inputs {
+		err := wdb.db.Put(outpointKey(pendingSpendsBucket, input), []byte(transaction.ID))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PendingSpends returns the IDs of the pending transactions sent by the
+// wallet, by the outpoints they spend
+func (wdb *DB) PendingSpends() (map[Outpoint]string, error) {
+	cursor, err := wdb.db.Cursor(pendingSpendsBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	pendingSpends := make(map[Outpoint]string)
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		transactionID, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		outpoint, err := parseOutpointKeySuffix(key.Suffix())
+		if err != nil {
+			return nil, err
+		}
+		pendingSpends[*outpoint] = string(transactionID)
+	}
+	return pendingSpends, nil
+}
+
+// RemovePendingSpends forgets that the inputs of the given transaction are
+// spent by it, once it's either accepted or replaced
+func (wdb *DB) RemovePendingSpends(transaction *Transaction) error {
+	for _, input := range transaction.Inputs {
+		key := outpointKey(pendingSpendsBucket, input)
+		spendingTransactionID, err := wdb.db.Get(key)
+		if database.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// A replacement of the transaction may already spend the input
+		if string(spendingTransactionID) != transaction.ID {
+			continue
+		}
+		err = wdb.db.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func outpointKey(bucket *database.Bucket, outpoint *Outpoint) *database.Key {
+	return bucket.Key([]byte(fmt.Sprintf("%s:%d", outpoint.TransactionID, outpoint.Index)))
+}
+
+func parseOutpointKeySuffix(suffix []byte) (*Outpoint, error) {
+	separatorIndex := strings.LastIndexByte(string(suffix), ':')
+	if separatorIndex < 0 {
+		return nil, errors.Errorf("malformed outpoint key %s", suffix)
+	}
+	index, err := strconv.ParseUint(string(suffix[separatorIndex+1:]), 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "malformed outpoint key %s", suffix)
+	}
+	return &Outpoint{TransactionID: string(suffix[:separatorIndex]), Index: uint32(index)}, nil
+}
+
+// SetTransactionLabel sets the label of the transaction with the given ID.
+// An empty label removes it
+func (wdb *DB) SetTransactionLabel(transactionID string, label string) error {
+	return wdb.UpdateTransaction(transactionID, func(transaction *Transaction, found bool) (*Transaction, error) {
+		if !found {
+			return nil, errors.Errorf("transaction %s is not in the wallet history", transactionID)
+		}
+		transaction.Label = label
+		return transaction, nil
+	})
+}
+
+// SetAddressLabel sets the label of the given address. An empty label removes it
+func (wdb *DB) SetAddressLabel(address string, label string) error {
+	key := addressLabelsBucket.Key([]byte(address))
+	if label == "" {
+		return wdb.db.Delete(key)
+	}
+	return wdb.db.Put(key, []byte(label))
+}
+
+// AddressLabels returns the labels of all the labeled addresses
+func (wdb *DB) AddressLabels() (map[string]string, error) {
+	cursor, err := wdb.db.Cursor(addressLabelsBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	addressLabels := make(map[string]string)
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		label, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		addressLabels[string(key.Suffix())] = string(label)
+	}
+	return addressLabels, nil
+}
+
+// FreezeUTXOs freezes the UTXOs of the given outpoints
+func (wdb *DB) FreezeUTXOs(outpoints []*Outpoint) error {
+	for _, outpoint := range outpoints {
+		err := wdb.db.Put(outpointKey(frozenUTXOsBucket, outpoint), []byte{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnfreezeUTXOs unfreezes the UTXOs of the given outpoints. Outpoints that
+// aren't frozen are ignored
+func (wdb *DB) UnfreezeUTXOs(outpoints []*Outpoint) error {
+	for _, outpoint := range outpoints {
+		err := wdb.db.Delete(outpointKey(frozenUTXOsBucket, outpoint))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FrozenUTXOs returns the outpoints of all the frozen UTXOs
+func (wdb *DB) FrozenUTXOs() ([]*Outpoint, error) {
+	cursor, err := wdb.db.Cursor(frozenUTXOsBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var outpoints []*Outpoint
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		outpoint, err := parseOutpointKeySuffix(key.Suffix())
+		if err != nil {
+			return nil, err
+		}
+		outpoints = append(outpoints, outpoint)
+	}
+	return outpoints, nil
+}
+
+// AddConsolidationSpend records the fee of a transaction sent by UTXO consolidation
+func (wdb *DB) AddConsolidationSpend(spend *ConsolidationSpend) error {
+	spendBytes, err := json.Marshal(spend)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return wdb.db.Put(consolidationSpendKey(spend), spendBytes)
+}
+
+// ConsolidationSpends returns the consolidation spends from the given time, in
+// unix milliseconds, on, in the order they were sent. Earlier spends no longer
+// count toward the budget, so they're removed
+func (wdb *DB) ConsolidationSpends(since int64) ([]*ConsolidationSpend, error) {
+	cursor, err := wdb.db.Cursor(consolidationSpendsBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var spends []*ConsolidationSpend
+	var expiredKeys []*database.Key
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		spendBytes, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		spend := &ConsolidationSpend{}
+		err = json.Unmarshal(spendBytes, spend)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if spend.Time < since {
+			expiredKeys = append(expiredKeys, key)
+			continue
+		}
+		spends = append(spends, spend)
+	}
+
+	for _, key := range expiredKeys {
+		err := wdb.db.Delete(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return spends, nil
+}
+
+// consolidationSpendKey orders the consolidation spends by the time they were sent
+func consolidationSpendKey(spend *ConsolidationSpend) *database.Key {
+	return consolidationSpendsBucket.Key([]byte(fmt.Sprintf("%020d:%s", spend.Time, spend.TransactionID)))
+}
@@ -0,0 +1,201 @@
+package walletdb
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTransactions(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "keys.walletdb"))
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+	defer db.Close()
+
+	put := func(transaction *Transaction) {
+		err := db.UpdateTransaction(transaction.ID, func(_ *Transaction, _ bool) (*Transaction, error) {
+			return transaction, nil
+		})
+		if err != nil {
+			t.Fatalf("UpdateTransaction: %+v", err)
+		}
+	}
+	put(&Transaction{ID: "pending", FirstSeen: 1, Inputs: []*Outpoint{{TransactionID: "received", Index: 0}}})
+	put(&Transaction{ID: "late", IsAccepted: true, AcceptingDAAScore: 20})
+	put(&Transaction{ID: "received", IsAccepted: true, AcceptingDAAScore: 10, ReceivedAmount: 5})
+
+	transactions, err := db.Transactions()
+	if err != nil {
+		t.Fatalf("Transactions: %+v", err)
+	}
+	if len(transactions) != 3 || transactions[0].ID != "received" || transactions[1].ID != "late" || transactions[2].ID != "pending" {
+		t.Fatalf("expected the accepted transactions by DAA score followed by the pending ones, got %+v", transactions)
+	}
+
+	err = db.SetTransactionLabel("received", "salary")
+	if err != nil {
+		t.Fatalf("SetTransactionLabel: %+v", err)
+	}
+	received, found, err := db.Transaction("received")
+	if err != nil || !found || received.Label != "salary" || received.ReceivedAmount != 5 {
+		t.Fatalf("unexpected labeled transaction %+v, found: %t, err: %+v", received, found, err)
+	}
+	err = db.SetTransactionLabel("unknown", "salary")
+	if err == nil {
+		t.Fatalf("expected an error labeling an unknown transaction")
+	}
+}
+
+func TestPendingSpends(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "keys.walletdb"))
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+	defer db.Close()
+
+	outpoint := &Outpoint{TransactionID: "funding", Index: 12}
+	original := &Transaction{ID: "original", Inputs: []*Outpoint{outpoint}}
+	replacement := &Transaction{ID: "replacement", Inputs: []*Outpoint{outpoint}}
+	for _, transaction := range []*Transaction{original, replacement} {
+		err = db.AddPendingSpends(transaction)
+		if err != nil {
+			t.Fatalf("AddPendingSpends: %+v", err)
+		}
+	}
+
+	pendingSpends, err := db.PendingSpends()
+	if err != nil {
+		t.Fatalf("PendingSpends: %+v", err)
+	}
+	if len(pendingSpends) != 1 || pendingSpends[*outpoint] != "replacement" {
+		t.Fatalf("expected the replacement to take the outpoint over, got %+v", pendingSpends)
+	}
+
+	// Only the transaction that currently spends an outpoint releases it
+	err = db.RemovePendingSpends(original)
+	if err != nil {
+		t.Fatalf("RemovePendingSpends: %+v", err)
+	}
+	pendingSpends, err = db.PendingSpends()
+	if err != nil {
+		t.Fatalf("PendingSpends: %+v", err)
+	}
+	if len(pendingSpends) != 1 {
+		t.Fatalf("expected the outpoint to remain spent by the replacement")
+	}
+	err = db.RemovePendingSpends(replacement)
+	if err != nil {
+		t.Fatalf("RemovePendingSpends: %+v", err)
+	}
+	pendingSpends, err = db.PendingSpends()
+	if err != nil {
+		t.Fatalf("PendingSpends: %+v", err)
+	}
+	if len(pendingSpends) != 0 {
+		t.Fatalf("expected no pending spends, got %+v", pendingSpends)
+	}
+}
+
+func TestAddressLabels(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "keys.walletdb"))
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+	defer db.Close()
+
+	for _, label := range []string{"cold storage", "", "savings"} {
+		err = db.SetAddressLabel("kaspa:address", label)
+		if err != nil {
+			t.Fatalf("SetAddressLabel: %+v", err)
+		}
+	}
+	addressLabels, err := db.AddressLabels()
+	if err != nil {
+		t.Fatalf("AddressLabels: %+v", err)
+	}
+	if len(addressLabels) != 1 || addressLabels["kaspa:address"] != "savings" {
+		t.Fatalf("unexpected address labels %+v", addressLabels)
+	}
+}
+
+func TestFrozenUTXOs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.walletdb")
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+
+	first := &Outpoint{TransactionID: "aa", Index: 0}
+	second := &Outpoint{TransactionID: "aa", Index: 1}
+	err = db.FreezeUTXOs([]*Outpoint{first, second})
+	if err != nil {
+		t.Fatalf("FreezeUTXOs: %+v", err)
+	}
+	err = db.UnfreezeUTXOs([]*Outpoint{first, {TransactionID: "bb", Index: 2}})
+	if err != nil {
+		t.Fatalf("UnfreezeUTXOs: %+v", err)
+	}
+
+	// Frozen UTXOs stay frozen after the database is reopened
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	db, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+	defer db.Close()
+
+	frozenUTXOs, err := db.FrozenUTXOs()
+	if err != nil {
+		t.Fatalf("FrozenUTXOs: %+v", err)
+	}
+	if len(frozenUTXOs) != 1 || *frozenUTXOs[0] != *second {
+		t.Fatalf("unexpected frozen UTXOs %+v", frozenUTXOs)
+	}
+}
+
+func TestConsolidationSpends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.walletdb")
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+
+	expired := &ConsolidationSpend{TransactionID: "aa", Time: 1000, Fee: 100}
+	late := &ConsolidationSpend{TransactionID: "bb", Time: 30000, Fee: 300}
+	early := &ConsolidationSpend{TransactionID: "cc", Time: 2000, Fee: 200}
+	for _, spend := range []*ConsolidationSpend{expired, late, early} {
+		err = db.AddConsolidationSpend(spend)
+		if err != nil {
+			t.Fatalf("AddConsolidationSpend: %+v", err)
+		}
+	}
+
+	// Consolidation spends are kept after the database is reopened
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	db, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open: %+v", err)
+	}
+	defer db.Close()
+
+	spends, err := db.ConsolidationSpends(2000)
+	if err != nil {
+		t.Fatalf("ConsolidationSpends: %+v", err)
+	}
+	if len(spends) != 2 || *spends[0] != *early || *spends[1] != *late {
+		t.Fatalf("expected the spends since 2000 by the time they were sent, got %+v", spends)
+	}
+	spends, err = db.ConsolidationSpends(0)
+	if err != nil {
+		t.Fatalf("ConsolidationSpends: %+v", err)
+	}
+	if len(spends) != 2 {
+		t.Fatalf("expected the expired spend to be removed, got %+v", spends)
+	}
+}
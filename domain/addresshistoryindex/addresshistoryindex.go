@@ -0,0 +1,155 @@
+package addresshistoryindex
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/chainindex"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// AddressHistoryIndex maintains an index between scriptPublicKeys and every
+// credit and debit made to them by transactions accepted by the virtual
+// selected parent chain
+type AddressHistoryIndex struct {
+	store    *addressHistoryStore
+	follower *chainindex.Follower
+
+	mutex sync.Mutex
+}
+
+// New creates a new address history index, and brings it up to date with
+// the virtual selected parent chain.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func New(domain domain.Domain, database database.Database) (*AddressHistoryIndex, error) {
+	store := newAddressHistoryStore(database)
+	addressHistoryIndex := &AddressHistoryIndex{
+		store:    store,
+		follower: newFollower(domain, database, store),
+	}
+
+	addressHistoryIndex.mutex.Lock()
+	defer addressHistoryIndex.mutex.Unlock()
+
+	err := addressHistoryIndex.follower.Sync()
+	if err != nil {
+		return nil, err
+	}
+
+	return addressHistoryIndex, nil
+}
+
+func newFollower(domain domain.Domain, database database.Database, store *addressHistoryStore) *chainindex.Follower {
+	return chainindex.NewFollower("address history index", log, domain, database,
+		&chainBlockIndexer{store: store}, selectedTipKey, addressHistoryBuckets)
+}
+
+// Reset deletes the whole address history index and re-indexes the selected chain
+// from the pruning point.
+func (ahi *AddressHistoryIndex) Reset() error {
+	ahi.mutex.Lock()
+	defer ahi.mutex.Unlock()
+
+	return ahi.follower.Reset()
+}
+
+// Update updates the address history index with the given DAG selected parent chain changes
+func (ahi *AddressHistoryIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddressHistoryIndex.Update")
+	defer onEnd()
+
+	if virtualChangeSet.VirtualSelectedParentChainChanges == nil {
+		return nil
+	}
+
+	ahi.mutex.Lock()
+	defer ahi.mutex.Unlock()
+
+	return ahi.follower.ApplyChainPath(virtualChangeSet.VirtualSelectedParentChainChanges)
+}
+
+// chainBlockIndexer indexes the entries made by the transactions accepted by chain blocks
+// in the address history store
+type chainBlockIndexer struct {
+	store *addressHistoryStore
+}
+
+func (cbi *chainBlockIndexer) AddChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash,
+	chainBlockHeader externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	entries := chainBlockEntries(chainBlock, chainBlockHeader.DAAScore(), acceptanceData)
+	log.Tracef("Adding the entries of %d scriptPublicKeys of chain block %s to the address history index",
+		len(entries), chainBlock)
+	return cbi.store.addChainBlock(dbTransaction, chainBlock, entries)
+}
+
+func (cbi *chainBlockIndexer) RemoveChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash) error {
+	return cbi.store.removeChainBlock(dbTransaction, chainBlock)
+}
+
+func (cbi *chainBlockIndexer) VerifyChainBlock(dataAccessor database.DataAccessor, chainBlock *externalapi.DomainHash,
+	chainBlockHeader externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) ([]string, error) {
+
+	entries := chainBlockEntries(chainBlock, chainBlockHeader.DAAScore(), acceptanceData)
+	return cbi.store.verifyChainBlock(dataAccessor, chainBlock, entries)
+}
+
+func (cbi *chainBlockIndexer) ChainBlocksBucket() *database.Bucket {
+	return chainBlockEntriesBucket
+}
+
+// chainBlockEntries returns the entries made by the transactions accepted by the given chain
+// block, mapped by their serialized scriptPublicKey
+func chainBlockEntries(chainBlock *externalapi.DomainHash, daaScore uint64,
+	acceptanceData externalapi.AcceptanceData) map[string][]*HistoryEntry {
+
+	entries := make(map[string][]*HistoryEntry)
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			transactionID := consensushashing.TransactionID(transactionAcceptanceData.Transaction)
+
+			debits := make(map[string]uint64)
+			for _, utxoEntry := range transactionAcceptanceData.TransactionInputUTXOEntries {
+				debits[string(serializeScriptPublicKey(utxoEntry.ScriptPublicKey()))] += utxoEntry.Amount()
+			}
+			credits := make(map[string]uint64)
+			for _, output := range transactionAcceptanceData.Transaction.Outputs {
+				credits[string(serializeScriptPublicKey(output.ScriptPublicKey))] += output.Value
+			}
+
+			for isDebit, amounts := range map[bool]map[string]uint64{true: debits, false: credits} {
+				for serializedScriptPublicKey, amount := range amounts {
+					entries[serializedScriptPublicKey] = append(entries[serializedScriptPublicKey], &HistoryEntry{
+						TransactionID:          transactionID,
+						AcceptingBlockHash:     chainBlock,
+						AcceptingBlockDAAScore: daaScore,
+						Amount:                 amount,
+						IsDebit:                isDebit,
+					})
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// History returns up to limit entries of the given scriptPublicKey, ordered by the DAA score
+// of their accepting blocks, skipping the first offset ones
+func (ahi *AddressHistoryIndex) History(scriptPublicKey *externalapi.ScriptPublicKey, offset uint64, limit uint64) (
+	[]*HistoryEntry, error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddressHistoryIndex.History")
+	defer onEnd()
+
+	ahi.mutex.Lock()
+	defer ahi.mutex.Unlock()
+
+	return ahi.store.getEntries(scriptPublicKey, offset, limit)
+}
@@ -0,0 +1,166 @@
+package addresshistoryindex
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func TestChainBlockEntries(t *testing.T) {
+	walletScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0}
+	otherScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{4, 5, 6}, Version: 0}
+
+	// The wallet spends two of its outputs, pays the other scriptPublicKey and takes change
+	transaction := &externalapi.DomainTransaction{
+		Inputs: []*externalapi.DomainTransactionInput{
+			{PreviousOutpoint: externalapi.DomainOutpoint{Index: 0}},
+			{PreviousOutpoint: externalapi.DomainOutpoint{Index: 1}},
+		},
+		Outputs: []*externalapi.DomainTransactionOutput{
+			{Value: 700, ScriptPublicKey: otherScriptPublicKey},
+			{Value: 250, ScriptPublicKey: walletScriptPublicKey},
+		},
+		SubnetworkID: subnetworks.SubnetworkIDNative,
+	}
+	rejectedTransaction := &externalapi.DomainTransaction{
+		Outputs: []*externalapi.DomainTransactionOutput{
+			{Value: 1000, ScriptPublicKey: walletScriptPublicKey},
+		},
+		SubnetworkID: subnetworks.SubnetworkIDNative,
+	}
+	chainBlock := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	acceptanceData := externalapi.AcceptanceData{{
+		BlockHash: chainBlock,
+		TransactionAcceptanceData: []*externalapi.TransactionAcceptanceData{
+			{
+				Transaction: transaction,
+				IsAccepted:  true,
+				TransactionInputUTXOEntries: []externalapi.UTXOEntry{
+					utxo.NewUTXOEntry(600, walletScriptPublicKey, false, 0),
+					utxo.NewUTXOEntry(400, walletScriptPublicKey, false, 0),
+				},
+			},
+			{
+				Transaction: rejectedTransaction,
+				IsAccepted:  false,
+			},
+		},
+	}}
+
+	entries := chainBlockEntries(chainBlock, 10, acceptanceData)
+
+	walletEntries := entries[string(serializeScriptPublicKey(walletScriptPublicKey))]
+	if len(walletEntries) != 2 {
+		t.Fatalf("expected a debit and a credit for the wallet, got %d entries", len(walletEntries))
+	}
+	for _, entry := range walletEntries {
+		if !entry.TransactionID.Equal(consensushashing.TransactionID(transaction)) {
+			t.Fatalf("unexpected transaction ID %s", entry.TransactionID)
+		}
+		if entry.AcceptingBlockDAAScore != 10 || !entry.AcceptingBlockHash.Equal(chainBlock) {
+			t.Fatalf("unexpected accepting block %s at DAA score %d", entry.AcceptingBlockHash,
+				entry.AcceptingBlockDAAScore)
+		}
+		if entry.IsDebit && entry.Amount != 1000 {
+			t.Fatalf("expected the wallet to be debited 1000, got %d", entry.Amount)
+		}
+		if !entry.IsDebit && entry.Amount != 250 {
+			t.Fatalf("expected the wallet to be credited 250, got %d", entry.Amount)
+		}
+	}
+
+	otherEntries := entries[string(serializeScriptPublicKey(otherScriptPublicKey))]
+	if len(otherEntries) != 1 || otherEntries[0].IsDebit || otherEntries[0].Amount != 700 {
+		t.Fatalf("expected a single credit of 700 to the other scriptPublicKey, got %+v", otherEntries)
+	}
+}
+
+func TestStoreAddAndRemoveChainBlock(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "TestStoreAddAndRemoveChainBlock")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir: %+v", err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	db, err := ldb.NewLevelDB(dataDir, 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %+v", err)
+	}
+	defer db.Close()
+
+	store := newAddressHistoryStore(db)
+	scriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0}
+	serializedScriptPublicKey := string(serializeScriptPublicKey(scriptPublicKey))
+
+	addChainBlock := func(chainBlock *externalapi.DomainHash, daaScore uint64) {
+		entries := make([]*HistoryEntry, 3)
+		for i := range entries {
+			entries[i] = &HistoryEntry{
+				TransactionID: externalapi.NewDomainTransactionIDFromByteArray(
+					&[externalapi.DomainHashSize]byte{byte(daaScore), byte(i)}),
+				AcceptingBlockHash:     chainBlock,
+				AcceptingBlockDAAScore: daaScore,
+				Amount:                 uint64(i),
+			}
+		}
+
+		dbTransaction, err := db.Begin()
+		if err != nil {
+			t.Fatalf("Begin: %+v", err)
+		}
+		err = store.addChainBlock(dbTransaction, chainBlock, map[string][]*HistoryEntry{serializedScriptPublicKey: entries})
+		if err != nil {
+			t.Fatalf("addChainBlock: %+v", err)
+		}
+		err = dbTransaction.Commit()
+		if err != nil {
+			t.Fatalf("Commit: %+v", err)
+		}
+	}
+
+	// Chain blocks are added in reverse order to make sure entries are ordered by DAA score
+	chainBlockA := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	chainBlockB := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2})
+	addChainBlock(chainBlockB, 20)
+	addChainBlock(chainBlockA, 10)
+
+	entries, err := store.getEntries(scriptPublicKey, 2, 2)
+	if err != nil {
+		t.Fatalf("getEntries: %+v", err)
+	}
+	if len(entries) != 2 || entries[0].AcceptingBlockDAAScore != 10 || entries[1].AcceptingBlockDAAScore != 20 {
+		t.Fatalf("unexpected page of entries: %+v", entries)
+	}
+
+	dbTransaction, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %+v", err)
+	}
+	err = store.removeChainBlock(dbTransaction, chainBlockB)
+	if err != nil {
+		t.Fatalf("removeChainBlock: %+v", err)
+	}
+	err = dbTransaction.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %+v", err)
+	}
+
+	entries, err = store.getEntries(scriptPublicKey, 0, 100)
+	if err != nil {
+		t.Fatalf("getEntries: %+v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries after removing a chain block, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if !entry.AcceptingBlockHash.Equal(chainBlockA) {
+			t.Fatalf("found an entry of the removed chain block")
+		}
+	}
+}
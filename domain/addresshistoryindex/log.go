@@ -0,0 +1,7 @@
+package addresshistoryindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("ADHI")
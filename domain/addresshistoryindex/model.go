@@ -0,0 +1,22 @@
+package addresshistoryindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// HistoryEntry is a credit to or a debit from a scriptPublicKey, made by a
+// transaction accepted by the virtual selected parent chain.
+//
+// A transaction that both spends from and pays to the same scriptPublicKey
+// results in two entries: a debit and a credit
+type HistoryEntry struct {
+	TransactionID          *externalapi.DomainTransactionID
+	AcceptingBlockHash     *externalapi.DomainHash
+	AcceptingBlockDAAScore uint64
+
+	// Amount is the sum of the outputs paid to the scriptPublicKey if IsDebit
+	// is false, or the sum of the outputs of the scriptPublicKey spent by the
+	// transaction otherwise
+	Amount  uint64
+	IsDebit bool
+}
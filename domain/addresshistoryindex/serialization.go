@@ -0,0 +1,109 @@
+package addresshistoryindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// An entry key is made of the accepting block DAA score, the transaction ID and the direction.
+// The DAA score is big-endian so that the entries of a scriptPublicKey are ordered by it
+const entryKeySize = 8 + externalapi.DomainHashSize + 1
+
+// An entry value is made of the accepting block hash and the amount
+const entryValueSize = externalapi.DomainHashSize + 8
+
+func serializeScriptPublicKey(scriptPublicKey *externalapi.ScriptPublicKey) []byte {
+	serialized := make([]byte, 2+len(scriptPublicKey.Script))
+	binary.LittleEndian.PutUint16(serialized[:2], scriptPublicKey.Version)
+	copy(serialized[2:], scriptPublicKey.Script)
+	return serialized
+}
+
+func serializeEntryKey(entry *HistoryEntry) []byte {
+	serialized := make([]byte, entryKeySize)
+	binary.BigEndian.PutUint64(serialized[:8], entry.AcceptingBlockDAAScore)
+	copy(serialized[8:8+externalapi.DomainHashSize], entry.TransactionID.ByteSlice())
+	if entry.IsDebit {
+		serialized[entryKeySize-1] = 1
+	}
+	return serialized
+}
+
+func serializeEntryValue(entry *HistoryEntry) []byte {
+	serialized := make([]byte, entryValueSize)
+	copy(serialized[:externalapi.DomainHashSize], entry.AcceptingBlockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(serialized[externalapi.DomainHashSize:], entry.Amount)
+	return serialized
+}
+
+func deserializeEntry(serializedKey []byte, serializedValue []byte) (*HistoryEntry, error) {
+	if len(serializedKey) != entryKeySize || len(serializedValue) != entryValueSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected key length %d or value length %d "+
+			"while deserializing an address history entry", len(serializedKey), len(serializedValue))
+	}
+
+	transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(serializedKey[8 : 8+externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(serializedValue[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+
+	return &HistoryEntry{
+		TransactionID:          transactionID,
+		AcceptingBlockHash:     acceptingBlockHash,
+		AcceptingBlockDAAScore: binary.BigEndian.Uint64(serializedKey[:8]),
+		Amount:                 binary.LittleEndian.Uint64(serializedValue[externalapi.DomainHashSize:]),
+		IsDebit:                serializedKey[entryKeySize-1] == 1,
+	}, nil
+}
+
+// entryLocation is the serialized scriptPublicKey and entry key of a single entry
+type entryLocation struct {
+	serializedScriptPublicKey []byte
+	entryKey                  []byte
+}
+
+const entryLocationLengthSize = 4
+
+func serializeEntryLocations(entryLocations []*entryLocation) []byte {
+	size := 0
+	for _, location := range entryLocations {
+		size += entryLocationLengthSize + len(location.serializedScriptPublicKey) + entryKeySize
+	}
+
+	serialized := make([]byte, 0, size)
+	for _, location := range entryLocations {
+		var length [entryLocationLengthSize]byte
+		binary.LittleEndian.PutUint32(length[:], uint32(len(location.serializedScriptPublicKey)))
+		serialized = append(serialized, length[:]...)
+		serialized = append(serialized, location.serializedScriptPublicKey...)
+		serialized = append(serialized, location.entryKey...)
+	}
+	return serialized
+}
+
+func deserializeEntryLocations(serialized []byte) ([]*entryLocation, error) {
+	var entryLocations []*entryLocation
+	for len(serialized) > 0 {
+		if len(serialized) < entryLocationLengthSize {
+			return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing entry locations")
+		}
+		length := int(binary.LittleEndian.Uint32(serialized[:entryLocationLengthSize]))
+		serialized = serialized[entryLocationLengthSize:]
+		if len(serialized) < length+entryKeySize {
+			return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing entry locations")
+		}
+		entryLocations = append(entryLocations, &entryLocation{
+			serializedScriptPublicKey: serialized[:length],
+			entryKey:                  serialized[length : length+entryKeySize],
+		})
+		serialized = serialized[length+entryKeySize:]
+	}
+	return entryLocations, nil
+}
@@ -0,0 +1,169 @@
+package addresshistoryindex
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var historyBucket = database.MakeBucket([]byte("address-history"))
+var chainBlockEntriesBucket = database.MakeBucket([]byte("address-history-chain-block-entries"))
+var selectedTipKey = database.MakeBucket([]byte("")).Key([]byte("address-history-selected-tip"))
+
+// addressHistoryBuckets are all the buckets of the address history index, besides its selected tip
+var addressHistoryBuckets = []*database.Bucket{historyBucket, chainBlockEntriesBucket}
+
+type addressHistoryStore struct {
+	database database.Database
+}
+
+func newAddressHistoryStore(database database.Database) *addressHistoryStore {
+	return &addressHistoryStore{
+		database: database,
+	}
+}
+
+func (ahs *addressHistoryStore) bucketForScriptPublicKey(serializedScriptPublicKey []byte) *database.Bucket {
+	return historyBucket.Bucket(serializedScriptPublicKey)
+}
+
+// addChainBlock adds the given entries, all made by transactions accepted by the given chain block
+func (ahs *addressHistoryStore) addChainBlock(dbTransaction database.Transaction, chainBlockHash *externalapi.DomainHash,
+	entries map[string][]*HistoryEntry) error {
+
+	var entryLocations []*entryLocation
+	for serializedScriptPublicKey, entriesOfScriptPublicKey := range entries {
+		bucket := ahs.bucketForScriptPublicKey([]byte(serializedScriptPublicKey))
+		for _, entry := range entriesOfScriptPublicKey {
+			entryKey := serializeEntryKey(entry)
+			err := dbTransaction.Put(bucket.Key(entryKey), serializeEntryValue(entry))
+			if err != nil {
+				return err
+			}
+			entryLocations = append(entryLocations, &entryLocation{
+				serializedScriptPublicKey: []byte(serializedScriptPublicKey),
+				entryKey:                  entryKey,
+			})
+		}
+	}
+
+	return dbTransaction.Put(chainBlockEntriesBucket.Key(chainBlockHash.ByteSlice()),
+		serializeEntryLocations(entryLocations))
+}
+
+// removeChainBlock removes all the entries made by transactions accepted by the given chain block
+func (ahs *addressHistoryStore) removeChainBlock(dbTransaction database.Transaction, chainBlockHash *externalapi.DomainHash) error {
+	chainBlockKey := chainBlockEntriesBucket.Key(chainBlockHash.ByteSlice())
+	serializedEntryLocations, err := dbTransaction.Get(chainBlockKey)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	entryLocations, err := deserializeEntryLocations(serializedEntryLocations)
+	if err != nil {
+		return err
+	}
+
+	for _, location := range entryLocations {
+		bucket := ahs.bucketForScriptPublicKey(location.serializedScriptPublicKey)
+		err := dbTransaction.Delete(bucket.Key(location.entryKey))
+		if err != nil {
+			return err
+		}
+	}
+
+	return dbTransaction.Delete(chainBlockKey)
+}
+
+// verifyChainBlock returns the discrepancies between the entries indexed for the given chain block
+// and the given ones
+func (ahs *addressHistoryStore) verifyChainBlock(dataAccessor database.DataAccessor,
+	chainBlockHash *externalapi.DomainHash, entries map[string][]*HistoryEntry) ([]string, error) {
+
+	var discrepancies []string
+	entryCount := 0
+	for _, entriesOfScriptPublicKey := range entries {
+		entryCount += len(entriesOfScriptPublicKey)
+	}
+	serializedEntryLocations, err := dataAccessor.Get(chainBlockEntriesBucket.Key(chainBlockHash.ByteSlice()))
+	if err != nil {
+		if !database.IsNotFoundError(err) {
+			return nil, err
+		}
+		discrepancies = append(discrepancies, fmt.Sprintf("the chain block %s is missing from the address "+
+			"history index", chainBlockHash))
+	} else {
+		entryLocations, err := deserializeEntryLocations(serializedEntryLocations)
+		if err != nil {
+			return nil, err
+		}
+		if len(entryLocations) != entryCount {
+			discrepancies = append(discrepancies, fmt.Sprintf("the address history index holds %d entries of the "+
+				"chain block %s rather than %d", len(entryLocations), chainBlockHash, entryCount))
+		}
+	}
+
+	for serializedScriptPublicKey, entriesOfScriptPublicKey := range entries {
+		bucket := ahs.bucketForScriptPublicKey([]byte(serializedScriptPublicKey))
+		for _, entry := range entriesOfScriptPublicKey {
+			serializedValue, err := dataAccessor.Get(bucket.Key(serializeEntryKey(entry)))
+			if err != nil {
+				if !database.IsNotFoundError(err) {
+					return nil, err
+				}
+				discrepancies = append(discrepancies, fmt.Sprintf("an entry of the transaction %s accepted by the "+
+					"chain block %s is missing from the address history index", entry.TransactionID, chainBlockHash))
+				continue
+			}
+			if !bytes.Equal(serializedValue, serializeEntryValue(entry)) {
+				discrepancies = append(discrepancies, fmt.Sprintf("an entry of the transaction %s in the address "+
+					"history index differs from its acceptance by the chain block %s", entry.TransactionID,
+					chainBlockHash))
+			}
+		}
+	}
+	return discrepancies, nil
+}
+
+// getEntries returns up to limit entries of the given scriptPublicKey, ordered by their accepting
+// block DAA score, skipping the first offset ones
+func (ahs *addressHistoryStore) getEntries(scriptPublicKey *externalapi.ScriptPublicKey, offset uint64, limit uint64) (
+	[]*HistoryEntry, error) {
+
+	cursor, err := ahs.database.Cursor(ahs.bucketForScriptPublicKey(serializeScriptPublicKey(scriptPublicKey)))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var entries []*HistoryEntry
+	for skipped := uint64(0); uint64(len(entries)) < limit && cursor.Next(); {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		// The bucket of a scriptPublicKey is a prefix of the buckets of scriptPublicKeys that
+		// extend it with a separator, so their entries are skipped here
+		if len(key.Suffix()) != entryKeySize {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		serializedValue, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		entry, err := deserializeEntry(key.Suffix(), serializedValue)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
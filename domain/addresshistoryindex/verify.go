@@ -0,0 +1,24 @@
+package addresshistoryindex
+
+import (
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/chainindex"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// Verify compares the address history index in the given database with the
+// virtual selected parent chain, without modifying either of them. Unlike New,
+// it doesn't bring an index that isn't synced up to date.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func Verify(domain domain.Domain, database database.Database) (*chainindex.VerificationReport, error) {
+	return newFollower(domain, database, newAddressHistoryStore(database)).Verify()
+}
+
+// Repair rebuilds the address history index in the given database from the
+// virtual selected parent chain.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func Repair(domain domain.Domain, database database.Database) error {
+	return newFollower(domain, database, newAddressHistoryStore(database)).Reset()
+}
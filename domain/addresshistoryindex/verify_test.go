@@ -0,0 +1,99 @@
+package addresshistoryindex
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
+)
+
+func TestVerifyAndRepair(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+
+	db := memorydb.NewMemoryDB()
+	defer db.Close()
+	domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+
+	blocks := make([]*externalapi.DomainBlock, 5)
+	for i := range blocks {
+		blocks[i], err = domainInstance.Consensus().BuildBlock(&externalapi.DomainCoinbaseData{
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{1}, Version: 0},
+			ExtraData:       nil,
+		}, nil)
+		if err != nil {
+			t.Fatalf("BuildBlock: %+v", err)
+		}
+		err = domainInstance.Consensus().ValidateAndInsertBlock(blocks[i], true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+	}
+
+	verify := func() []string {
+		report, err := Verify(domainInstance, db)
+		if err != nil {
+			t.Fatalf("Verify: %+v", err)
+		}
+		if !report.Exists {
+			t.Fatalf("Expected an existing address history index")
+		}
+		return report.Discrepancies
+	}
+
+	report, err := Verify(domainInstance, db)
+	if err != nil {
+		t.Fatalf("Verify: %+v", err)
+	}
+	if report.Exists {
+		t.Fatalf("Verify found an address history index before one was created")
+	}
+
+	_, err = New(domainInstance, db)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	discrepancies := verify()
+	if len(discrepancies) != 0 {
+		t.Fatalf("Expected no discrepancies, but got %v", discrepancies)
+	}
+
+	// Remove an entry of the last chain block, which accepts the coinbase transaction of its selected parent
+	serializedEntryLocations, err := db.Get(chainBlockEntriesBucket.Key(consensushashing.BlockHash(blocks[len(blocks)-1]).ByteSlice()))
+	if err != nil {
+		t.Fatalf("Get: %+v", err)
+	}
+	entryLocations, err := deserializeEntryLocations(serializedEntryLocations)
+	if err != nil {
+		t.Fatalf("deserializeEntryLocations: %+v", err)
+	}
+	if len(entryLocations) == 0 {
+		t.Fatalf("Expected the last chain block to have entries")
+	}
+	store := newAddressHistoryStore(db)
+	err = db.Delete(store.bucketForScriptPublicKey(entryLocations[0].serializedScriptPublicKey).Key(entryLocations[0].entryKey))
+	if err != nil {
+		t.Fatalf("Delete: %+v", err)
+	}
+	discrepancies = verify()
+	if len(discrepancies) != 1 {
+		t.Fatalf("Expected a single discrepancy, but got %d: %v", len(discrepancies), discrepancies)
+	}
+
+	err = Repair(domainInstance, db)
+	if err != nil {
+		t.Fatalf("Repair: %+v", err)
+	}
+	discrepancies = verify()
+	if len(discrepancies) != 0 {
+		t.Fatalf("Expected no discrepancies after the repair, but got %v", discrepancies)
+	}
+}
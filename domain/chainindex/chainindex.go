@@ -0,0 +1,256 @@
+package chainindex
+
+import (
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// addedChainBlocksChunkSize is the number of added chain blocks whose acceptance data is
+// read and indexed in a single database transaction, so that syncing a long chain doesn't
+// hold the acceptance data of all of it in memory at once
+const addedChainBlocksChunkSize = 1000
+
+// Index is an index of the transactions accepted by the virtual selected parent chain
+type Index interface {
+	// AddChainBlock indexes the transactions accepted by the given chain block
+	AddChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash,
+		chainBlockHeader externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error
+
+	// RemoveChainBlock un-indexes the transactions accepted by the given chain block
+	RemoveChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash) error
+
+	// VerifyChainBlock returns the discrepancies between what the index holds for the given
+	// chain block and the transactions it accepted
+	VerifyChainBlock(dataAccessor database.DataAccessor, chainBlock *externalapi.DomainHash,
+		chainBlockHeader externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) ([]string, error)
+
+	// ChainBlocksBucket returns the bucket keyed by the hashes of the indexed chain blocks
+	ChainBlocksBucket() *database.Bucket
+}
+
+// Follower keeps an Index up to date with the virtual selected parent chain. It stores
+// the selected tip the index is synced to next to the index itself.
+//
+// Follower isn't safe for concurrent use.
+type Follower struct {
+	name           string
+	log            *logger.Logger
+	domain         domain.Domain
+	database       database.Database
+	index          Index
+	selectedTipKey *database.Key
+	buckets        []*database.Bucket
+}
+
+// NewFollower creates a Follower of the given index, whose data is kept in the given
+// buckets. name is the name of the index in log messages
+func NewFollower(name string, log *logger.Logger, domain domain.Domain, database database.Database,
+	index Index, selectedTipKey *database.Key, buckets []*database.Bucket) *Follower {
+
+	return &Follower{
+		name:           name,
+		log:            log,
+		domain:         domain,
+		database:       database,
+		index:          index,
+		selectedTipKey: selectedTipKey,
+		buckets:        buckets,
+	}
+}
+
+// Sync brings the index up to date with the virtual selected parent chain.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func (f *Follower) Sync() error {
+	selectedTip, err := f.selectedTip()
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			f.log.Infof("The %s is empty, indexing the selected chain from the pruning point", f.name)
+			return f.syncFromPruningPoint()
+		}
+		return err
+	}
+
+	chainPath, err := f.domain.Consensus().GetVirtualSelectedParentChainFromBlock(selectedTip)
+	if err != nil {
+		// This happens if the selected tip of the index was pruned while the node was down.
+		// What was indexed below the pruning point can't be reorged, so it's kept.
+		f.log.Infof("Could not build the selected chain from the %s selected tip %s: %s. "+
+			"Re-indexing the selected chain from the pruning point", f.name, selectedTip, err)
+		return f.syncFromPruningPoint()
+	}
+
+	f.log.Infof("Syncing the %s: %d chain blocks removed and %d added since the last run",
+		f.name, len(chainPath.Removed), len(chainPath.Added))
+	return f.ApplyChainPath(chainPath)
+}
+
+// Reset deletes the whole index and re-indexes the selected chain from the pruning point.
+func (f *Follower) Reset() error {
+	f.log.Infof("Starting %s reset", f.name)
+
+	err := f.deleteAll()
+	if err != nil {
+		return err
+	}
+
+	err = f.syncFromPruningPoint()
+	if err != nil {
+		return err
+	}
+
+	f.log.Infof("Finished %s reset", f.name)
+	return nil
+}
+
+func (f *Follower) syncFromPruningPoint() error {
+	pruningPoint, err := f.domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+
+	chainPath, err := f.domain.Consensus().GetVirtualSelectedParentChainFromBlock(pruningPoint)
+	if err != nil {
+		return err
+	}
+
+	return f.ApplyChainPath(chainPath)
+}
+
+// ApplyChainPath indexes the given selected chain changes. The removed chain blocks are
+// un-indexed in a database transaction of their own, which moves the selected tip back to
+// the chain block they were built on. The added chain blocks are then indexed in chunks,
+// each moving the selected tip forward in its own database transaction. This way the index
+// matches its selected tip whenever kaspad stops, even in the middle of a reorg
+func (f *Follower) ApplyChainPath(chainPath *externalapi.SelectedChainPath) error {
+	if len(chainPath.Removed) > 0 {
+		err := f.removeChainBlocks(chainPath.Removed)
+		if err != nil {
+			return err
+		}
+	}
+
+	for position := 0; position < len(chainPath.Added); position += addedChainBlocksChunkSize {
+		end := position + addedChainBlocksChunkSize
+		if end > len(chainPath.Added) {
+			end = len(chainPath.Added)
+		}
+		err := f.addChainBlocks(chainPath.Added[position:end])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeChainBlocks un-indexes the given removed chain blocks, ordered from the highest
+// to the lowest, and sets the selected tip to the selected parent of the lowest
+func (f *Follower) removeChainBlocks(removed []*externalapi.DomainHash) error {
+	lowestRemovedBlockInfo, err := f.domain.Consensus().GetBlockInfo(removed[len(removed)-1])
+	if err != nil {
+		return err
+	}
+
+	dbTransaction, err := f.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, removedChainBlock := range removed {
+		f.log.Tracef("Removing chain block %s from the %s", removedChainBlock, f.name)
+		err := f.index.RemoveChainBlock(dbTransaction, removedChainBlock)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = dbTransaction.Put(f.selectedTipKey, lowestRemovedBlockInfo.SelectedParent.ByteSlice())
+	if err != nil {
+		return err
+	}
+
+	return dbTransaction.Commit()
+}
+
+// addChainBlocks indexes the given added chain blocks and sets the selected tip to the
+// last of them in a single database transaction
+func (f *Follower) addChainBlocks(added []*externalapi.DomainHash) error {
+	chainBlocksAcceptanceData, err := f.domain.Consensus().GetBlocksAcceptanceData(added)
+	if err != nil {
+		return err
+	}
+
+	dbTransaction, err := f.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for i, chainBlock := range added {
+		chainBlockHeader, err := f.domain.Consensus().GetBlockHeader(chainBlock)
+		if err != nil {
+			return err
+		}
+		f.log.Tracef("Adding chain block %s to the %s", chainBlock, f.name)
+		err = f.index.AddChainBlock(dbTransaction, chainBlock, chainBlockHeader, chainBlocksAcceptanceData[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = dbTransaction.Put(f.selectedTipKey, added[len(added)-1].ByteSlice())
+	if err != nil {
+		return err
+	}
+
+	return dbTransaction.Commit()
+}
+
+func (f *Follower) selectedTip() (*externalapi.DomainHash, error) {
+	serializedSelectedTip, err := f.database.Get(f.selectedTipKey)
+	if err != nil {
+		return nil, err
+	}
+	return externalapi.NewDomainHashFromByteSlice(serializedSelectedTip)
+}
+
+func (f *Follower) deleteAll() error {
+	// First we delete the selected tip, so if anything goes wrong, the index will be
+	// marked as "not synced" and will be reset.
+	err := f.database.Delete(f.selectedTipKey)
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range f.buckets {
+		err := f.deleteBucket(bucket)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Follower) deleteBucket(bucket *database.Bucket) error {
+	cursor, err := f.database.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+
+		err = f.database.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package chainindex_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/chainindex"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+var testBucket = database.MakeBucket([]byte("test-index"))
+var testSelectedTipKey = database.MakeBucket([]byte("")).Key([]byte("test-index-selected-tip"))
+
+// testIndex indexes every chain block under its hash, and fails to index failingChainBlock
+type testIndex struct {
+	failingChainBlock *externalapi.DomainHash
+}
+
+func (ti *testIndex) AddChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash,
+	_ externalapi.BlockHeader, _ externalapi.AcceptanceData) error {
+
+	if chainBlock.Equal(ti.failingChainBlock) {
+		return errors.Errorf("failed to index %s", chainBlock)
+	}
+	return dbTransaction.Put(testBucket.Key(chainBlock.ByteSlice()), []byte{})
+}
+
+func (ti *testIndex) RemoveChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash) error {
+	return dbTransaction.Delete(testBucket.Key(chainBlock.ByteSlice()))
+}
+
+func (ti *testIndex) VerifyChainBlock(dataAccessor database.DataAccessor, chainBlock *externalapi.DomainHash,
+	_ externalapi.BlockHeader, _ externalapi.AcceptanceData) ([]string, error) {
+
+	has, err := dataAccessor.Has(testBucket.Key(chainBlock.ByteSlice()))
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return []string{fmt.Sprintf("the chain block %s is missing", chainBlock)}, nil
+	}
+	return nil, nil
+}
+
+func (ti *testIndex) ChainBlocksBucket() *database.Bucket {
+	return testBucket
+}
+
+func TestFollowerApplyChainPath(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		db, err := ldb.NewLevelDB(t.TempDir(), 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		var selectedParent, tip *externalapi.DomainHash
+		for i := 0; i < 3; i++ {
+			block, err := domainInstance.Consensus().BuildBlock(&externalapi.DomainCoinbaseData{
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: nil, Version: 0},
+				ExtraData:       nil,
+			}, nil)
+			if err != nil {
+				t.Fatalf("BuildBlock: %+v", err)
+			}
+			err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+			selectedParent, tip = tip, consensushashing.BlockHash(block)
+		}
+
+		index := &testIndex{}
+		follower := chainindex.NewFollower("test index", logger.RegisterSubSystem("TEST"), domainInstance, db,
+			index, testSelectedTipKey, []*database.Bucket{testBucket})
+		err = follower.Sync()
+		if err != nil {
+			t.Fatalf("Sync: %+v", err)
+		}
+
+		isIndexed := func(chainBlock *externalapi.DomainHash) bool {
+			has, err := db.Has(testBucket.Key(chainBlock.ByteSlice()))
+			if err != nil {
+				t.Fatalf("Has: %+v", err)
+			}
+			return has
+		}
+		checkSelectedTip := func(expected *externalapi.DomainHash) {
+			serializedSelectedTip, err := db.Get(testSelectedTipKey)
+			if err != nil {
+				t.Fatalf("Get: %+v", err)
+			}
+			selectedTip, err := externalapi.NewDomainHashFromByteSlice(serializedSelectedTip)
+			if err != nil {
+				t.Fatalf("NewDomainHashFromByteSlice: %+v", err)
+			}
+			if !selectedTip.Equal(expected) {
+				t.Fatalf("expected the selected tip %s, got %s", expected, selectedTip)
+			}
+		}
+		if !isIndexed(tip) {
+			t.Fatalf("expected the selected chain to be indexed")
+		}
+		checkSelectedTip(tip)
+
+		// The removed chain blocks are un-indexed even if indexing an added one fails, and the
+		// selected tip then records the chain block they were built on, so syncing resumes from it
+		index.failingChainBlock = tip
+		err = follower.ApplyChainPath(&externalapi.SelectedChainPath{
+			Removed: []*externalapi.DomainHash{tip},
+			Added:   []*externalapi.DomainHash{tip},
+		})
+		if err == nil {
+			t.Fatalf("expected ApplyChainPath to fail")
+		}
+		if isIndexed(tip) {
+			t.Fatalf("expected the chain block to be removed")
+		}
+		checkSelectedTip(selectedParent)
+
+		index.failingChainBlock = nil
+		err = follower.Sync()
+		if err != nil {
+			t.Fatalf("Sync: %+v", err)
+		}
+		if !isIndexed(tip) {
+			t.Fatalf("expected Sync to index the chain block again")
+		}
+		checkSelectedTip(tip)
+
+		// Removing chain blocks without adding any moves the selected tip back
+		err = follower.ApplyChainPath(&externalapi.SelectedChainPath{Removed: []*externalapi.DomainHash{tip}})
+		if err != nil {
+			t.Fatalf("ApplyChainPath: %+v", err)
+		}
+		if isIndexed(tip) {
+			t.Fatalf("expected the chain block to be removed")
+		}
+		checkSelectedTip(selectedParent)
+
+		err = follower.Reset()
+		if err != nil {
+			t.Fatalf("Reset: %+v", err)
+		}
+		if !isIndexed(tip) {
+			t.Fatalf("expected Reset to re-index the selected chain")
+		}
+		checkSelectedTip(tip)
+	})
+}
+
+func TestFollowerVerify(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		db, err := ldb.NewLevelDB(t.TempDir(), 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		// Both blocks are built on the same virtual, so only one of them is a chain block
+		var blocks []*externalapi.DomainBlock
+		for i := byte(0); i < 2; i++ {
+			block, err := domainInstance.Consensus().BuildBlock(&externalapi.DomainCoinbaseData{
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: nil, Version: 0},
+				ExtraData:       []byte{i},
+			}, nil)
+			if err != nil {
+				t.Fatalf("BuildBlock: %+v", err)
+			}
+			blocks = append(blocks, block)
+		}
+		for _, block := range blocks {
+			err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+		}
+
+		follower := chainindex.NewFollower("test index", logger.RegisterSubSystem("TEST"), domainInstance, db,
+			&testIndex{}, testSelectedTipKey, []*database.Bucket{testBucket})
+		verify := func() *chainindex.VerificationReport {
+			report, err := follower.Verify()
+			if err != nil {
+				t.Fatalf("Verify: %+v", err)
+			}
+			return report
+		}
+
+		report := verify()
+		if report.Exists {
+			t.Fatalf("expected Verify not to find an index before it's synced")
+		}
+
+		err = follower.Sync()
+		if err != nil {
+			t.Fatalf("Sync: %+v", err)
+		}
+		report = verify()
+		if !report.Exists || report.CheckedChainBlocks != 1 || len(report.Discrepancies) != 0 {
+			t.Fatalf("expected a single chain block to be checked without discrepancies, got %+v", report)
+		}
+
+		// Index the block that isn't in the selected chain
+		virtualSelectedParent, err := domainInstance.Consensus().GetVirtualSelectedParent()
+		if err != nil {
+			t.Fatalf("GetVirtualSelectedParent: %+v", err)
+		}
+		for _, block := range blocks {
+			blockHash := consensushashing.BlockHash(block)
+			if blockHash.Equal(virtualSelectedParent) {
+				continue
+			}
+			err = db.Put(testBucket.Key(blockHash.ByteSlice()), []byte{})
+			if err != nil {
+				t.Fatalf("Put: %+v", err)
+			}
+		}
+		report = verify()
+		if len(report.Discrepancies) != 1 {
+			t.Fatalf("expected the stale chain block to be found, got %v", report.Discrepancies)
+		}
+
+		err = follower.Reset()
+		if err != nil {
+			t.Fatalf("Reset: %+v", err)
+		}
+		report = verify()
+		if len(report.Discrepancies) != 0 {
+			t.Fatalf("expected no discrepancies after Reset, got %v", report.Discrepancies)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package chainindex
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// maxReportedDiscrepancies is the number of discrepancies Verify describes one
+// by one. Any more of them are only counted
+const maxReportedDiscrepancies = 100
+
+// VerificationReport is the result of Verify
+type VerificationReport struct {
+	// Exists is false if the database doesn't have the index at all
+	Exists             bool
+	CheckedChainBlocks uint64
+	Discrepancies      []string
+
+	unreportedDiscrepancyCount uint64
+}
+
+func (f *Follower) addDiscrepancy(report *VerificationReport, format string, args ...interface{}) {
+	if len(report.Discrepancies) >= maxReportedDiscrepancies {
+		report.unreportedDiscrepancyCount++
+		return
+	}
+	discrepancy := fmt.Sprintf(format, args...)
+	f.log.Warnf("Found a %s discrepancy: %s", f.name, discrepancy)
+	report.Discrepancies = append(report.Discrepancies, discrepancy)
+}
+
+// Verify compares the index with the virtual selected parent chain from the pruning
+// point, without modifying either of them. Unlike Sync, it doesn't bring an index
+// that isn't synced up to date. What the index holds below the pruning point isn't
+// checked, since the consensus no longer has the acceptance data of it.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func (f *Follower) Verify() (*VerificationReport, error) {
+	report := &VerificationReport{}
+
+	selectedTip, err := f.selectedTip()
+	hasSelectedTip := true
+	if err != nil {
+		if !database.IsNotFoundError(err) {
+			return nil, err
+		}
+		hasSelectedTip = false
+	}
+	if !hasSelectedTip {
+		isEmpty, err := f.isEmpty()
+		if err != nil {
+			return nil, err
+		}
+		if isEmpty {
+			return report, nil
+		}
+	}
+	report.Exists = true
+
+	f.log.Infof("Verifying the %s", f.name)
+	virtualSelectedParent, err := f.domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	if !hasSelectedTip {
+		f.addDiscrepancy(report, "the %s has no selected tip, so it was never completely built", f.name)
+	} else if !selectedTip.Equal(virtualSelectedParent) {
+		f.addDiscrepancy(report, "the %s is synced to the selected tip %s rather than %s",
+			f.name, selectedTip, virtualSelectedParent)
+	}
+
+	pruningPoint, err := f.domain.Consensus().PruningPoint()
+	if err != nil {
+		return nil, err
+	}
+	chainPath, err := f.domain.Consensus().GetVirtualSelectedParentChainFromBlock(pruningPoint)
+	if err != nil {
+		return nil, err
+	}
+	chainBlocks := make(map[externalapi.DomainHash]struct{}, len(chainPath.Added))
+	for position := 0; position < len(chainPath.Added); position += addedChainBlocksChunkSize {
+		end := position + addedChainBlocksChunkSize
+		if end > len(chainPath.Added) {
+			end = len(chainPath.Added)
+		}
+		err := f.verifyChainBlocks(report, chainPath.Added[position:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, chainBlock := range chainPath.Added[position:end] {
+			chainBlocks[*chainBlock] = struct{}{}
+		}
+	}
+
+	err = f.verifyNoStaleChainBlocks(report, pruningPoint, chainBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.unreportedDiscrepancyCount > 0 {
+		report.Discrepancies = append(report.Discrepancies,
+			fmt.Sprintf("%d more discrepancies were found in the %s", report.unreportedDiscrepancyCount, f.name))
+	}
+	return report, nil
+}
+
+func (f *Follower) verifyChainBlocks(report *VerificationReport, chainBlocks []*externalapi.DomainHash) error {
+	chainBlocksAcceptanceData, err := f.domain.Consensus().GetBlocksAcceptanceData(chainBlocks)
+	if err != nil {
+		return err
+	}
+
+	for i, chainBlock := range chainBlocks {
+		chainBlockHeader, err := f.domain.Consensus().GetBlockHeader(chainBlock)
+		if err != nil {
+			return err
+		}
+		discrepancies, err := f.index.VerifyChainBlock(f.database, chainBlock, chainBlockHeader, chainBlocksAcceptanceData[i])
+		if err != nil {
+			return err
+		}
+		for _, discrepancy := range discrepancies {
+			f.addDiscrepancy(report, "%s", discrepancy)
+		}
+		report.CheckedChainBlocks++
+	}
+	return nil
+}
+
+// verifyNoStaleChainBlocks checks that every indexed chain block above the pruning point
+// is in the virtual selected parent chain. The selected chain below the pruning point
+// can't change, so the chain blocks indexed there are left as is
+func (f *Follower) verifyNoStaleChainBlocks(report *VerificationReport, pruningPoint *externalapi.DomainHash,
+	chainBlocks map[externalapi.DomainHash]struct{}) error {
+
+	pruningPointInfo, err := f.domain.Consensus().GetBlockInfo(pruningPoint)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := f.database.Cursor(f.index.ChainBlocksBucket())
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		indexedChainBlock, err := externalapi.NewDomainHashFromByteSlice(key.Suffix())
+		if err != nil {
+			return err
+		}
+		if _, ok := chainBlocks[*indexedChainBlock]; ok {
+			continue
+		}
+		blockInfo, err := f.domain.Consensus().GetBlockInfo(indexedChainBlock)
+		if err != nil {
+			return err
+		}
+		if blockInfo.Exists && blockInfo.BlueScore > pruningPointInfo.BlueScore {
+			f.addDiscrepancy(report, "the block %s is indexed as a chain block, but it isn't in the "+
+				"selected chain", indexedChainBlock)
+		}
+	}
+	return nil
+}
+
+func (f *Follower) isEmpty() (bool, error) {
+	for _, bucket := range f.buckets {
+		cursor, err := f.database.Cursor(bucket)
+		if err != nil {
+			return false, err
+		}
+		hasKeys := cursor.Next()
+		err = cursor.Close()
+		if err != nil {
+			return false, err
+		}
+		if hasKeys {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/lrucache"
 	"github.com/kaspanet/kaspad/util/staging"
+	"github.com/pkg/errors"
 )
 
 var bucketName = []byte("block-headers")
@@ -190,3 +191,56 @@ func (bhs *blockHeaderStore) serializeHeaderCount(count uint64) ([]byte, error)
 	dbBlockHeaderCount := &serialization.DbBlockHeaderCount{Count: count}
 	return proto.Marshal(dbBlockHeaderCount)
 }
+
+type allBlockHashesIterator struct {
+	cursor   model.DBCursor
+	isClosed bool
+}
+
+func (a *allBlockHashesIterator) First() bool {
+	if a.isClosed {
+		panic("Tried using a closed AllBlockHashesIterator")
+	}
+	return a.cursor.First()
+}
+
+func (a *allBlockHashesIterator) Next() bool {
+	if a.isClosed {
+		panic("Tried using a closed AllBlockHashesIterator")
+	}
+	return a.cursor.Next()
+}
+
+func (a *allBlockHashesIterator) Get() (*externalapi.DomainHash, error) {
+	if a.isClosed {
+		return nil, errors.New("Tried using a closed AllBlockHashesIterator")
+	}
+	key, err := a.cursor.Key()
+	if err != nil {
+		return nil, err
+	}
+	return externalapi.NewDomainHashFromByteSlice(key.Suffix())
+}
+
+func (a *allBlockHashesIterator) Close() error {
+	if a.isClosed {
+		return errors.New("Tried using a closed AllBlockHashesIterator")
+	}
+	a.isClosed = true
+	err := a.cursor.Close()
+	if err != nil {
+		return err
+	}
+	a.cursor = nil
+	return nil
+}
+
+// AllBlockHashesIterator returns an iterator over the hashes of all the
+// headers in the store. Staged headers are not included
+func (bhs *blockHeaderStore) AllBlockHashesIterator(dbContext model.DBReader) (model.BlockIterator, error) {
+	cursor, err := dbContext.Cursor(bhs.bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &allBlockHashesIterator{cursor: cursor}, nil
+}
@@ -0,0 +1,24 @@
+package consensusstatestore
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var (
+	utxoCacheHits = metrics.NewCounter("kaspad_utxo_cache_hits_total",
+		"Virtual UTXO entries that were found in the UTXO set cache")
+	utxoCacheMisses = metrics.NewCounter("kaspad_utxo_cache_misses_total",
+		"Virtual UTXO entries that had to be read from the database")
+)
+
+func init() {
+	metrics.NewGaugeFunc("kaspad_utxo_cache_hit_ratio",
+		"Ratio of the virtual UTXO entry lookups that were served by the UTXO set cache", func() float64 {
+			hits := utxoCacheHits.Value()
+			lookups := hits + utxoCacheMisses.Value()
+			if lookups == 0 {
+				return 0
+			}
+			return hits / lookups
+		})
+}
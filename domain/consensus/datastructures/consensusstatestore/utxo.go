@@ -99,8 +99,10 @@ func (css *consensusStateStore) utxoByOutpointFromStagedVirtualUTXODiff(dbContex
 	}
 
 	if entry, ok := css.virtualUTXOSetCache.Get(outpoint); ok {
+		utxoCacheHits.Inc()
 		return entry, nil
 	}
+	utxoCacheMisses.Inc()
 
 	key, err := css.utxoKey(outpoint)
 	if err != nil {
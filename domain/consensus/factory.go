@@ -1,7 +1,6 @@
 package consensus
 
 import (
-	"io/ioutil"
 	"os"
 	"sync"
 
@@ -56,6 +55,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 	infrastructuredatabase "github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
 )
 
 const (
@@ -80,6 +80,9 @@ type Factory interface {
 	NewConsensus(config *Config, db infrastructuredatabase.Database, dbPrefix *prefix.Prefix,
 		consensusEventsChan chan externalapi.ConsensusEvent) (
 		externalapi.Consensus, bool, error)
+	// NewTestConsensus returns a consensus for tests along with a function that closes it.
+	// The consensus is stored in memory unless SetTestDataDir was called, so teardown's
+	// keepDataDir only has an effect with a data directory: there's nothing to keep otherwise
 	NewTestConsensus(config *Config, testName string) (
 		tc testapi.TestConsensus, teardown func(keepDataDir bool), err error)
 
@@ -567,23 +570,10 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 
 func (f *factory) NewTestConsensus(config *Config, testName string) (
 	tc testapi.TestConsensus, teardown func(keepDataDir bool), err error) {
-	datadir := f.dataDir
-	if datadir == "" {
-		datadir, err = ioutil.TempDir("", testName)
-		if err != nil {
-			return nil, nil, err
-		}
-	}
-	var cacheSizeMiB int
-	if f.cacheSizeMiB != nil {
-		cacheSizeMiB = *f.cacheSizeMiB
-	} else {
-		cacheSizeMiB = defaultTestLeveldbCacheSizeMiB
-	}
 	if f.preallocateCaches == nil {
 		f.SetTestPreAllocateCache(defaultTestPreallocateCaches)
 	}
-	db, err := ldb.NewLevelDB(datadir, cacheSizeMiB)
+	db, err := f.newTestDatabase()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -612,9 +602,10 @@ func (f *factory) NewTestConsensus(config *Config, testName string) (
 		testTransactionValidator: testTransactionValidator,
 	}
 	tstConsensus.testBlockBuilder = blockbuilder.NewTestBlockBuilder(consensusAsImplementation.blockBuilder, tstConsensus)
+	// keepDataDir is meaningless for an in-memory database, which is gone once it's closed
 	teardown = func(keepDataDir bool) {
 		db.Close()
-		if !keepDataDir {
+		if !keepDataDir && f.dataDir != "" {
 			err := os.RemoveAll(f.dataDir)
 			if err != nil {
 				log.Errorf("Error removing data directory for test consensus: %s", err)
@@ -624,6 +615,23 @@ func (f *factory) NewTestConsensus(config *Config, testName string) (
 	return tstConsensus, teardown, nil
 }
 
+// newTestDatabase returns the database of a test consensus: a leveldb
+// database in the data directory if one was set, and an in-memory database
+// otherwise
+func (f *factory) newTestDatabase() (infrastructuredatabase.Database, error) {
+	if f.dataDir == "" {
+		return memorydb.NewMemoryDB(), nil
+	}
+
+	var cacheSizeMiB int
+	if f.cacheSizeMiB != nil {
+		cacheSizeMiB = *f.cacheSizeMiB
+	} else {
+		cacheSizeMiB = defaultTestLeveldbCacheSizeMiB
+	}
+	return ldb.NewLevelDB(f.dataDir, cacheSizeMiB)
+}
+
 func (f *factory) SetTestDataDir(dataDir string) {
 	f.dataDir = dataDir
 }
@@ -0,0 +1,54 @@
+package externalapi
+
+// IntegrityCheck names one of the checks of Consensus.VerifyIntegrity
+type IntegrityCheck string
+
+// The checks of Consensus.VerifyIntegrity
+const (
+	// IntegrityCheckBlockRelations checks that the parents and children of
+	// every block agree with each other and with the reachability data
+	IntegrityCheckBlockRelations IntegrityCheck = "block-relations"
+
+	// IntegrityCheckGHOSTDAG recomputes the GHOSTDAG data of blocks and
+	// compares it with the stored GHOSTDAG data
+	IntegrityCheckGHOSTDAG IntegrityCheck = "ghostdag"
+
+	// IntegrityCheckMultisets checks that the stored multiset of every block
+	// matches the UTXO commitment in its header
+	IntegrityCheckMultisets IntegrityCheck = "multisets"
+
+	// IntegrityCheckVirtualUTXOSet checks that the virtual UTXO set matches
+	// the stored virtual multiset
+	IntegrityCheckVirtualUTXOSet IntegrityCheck = "virtual-utxo-set"
+
+	// IntegrityCheckPruningPointUTXOSet checks that the pruning point UTXO set
+	// matches the UTXO commitment of the pruning point
+	IntegrityCheckPruningPointUTXOSet IntegrityCheck = "pruning-point-utxo-set"
+)
+
+// IntegrityVerificationOptions are the options of Consensus.VerifyIntegrity
+type IntegrityVerificationOptions struct {
+	// GHOSTDAGSampleSize is the number of blocks whose GHOSTDAG data is
+	// recomputed, spread evenly over all the blocks. 0 means all of them
+	GHOSTDAGSampleSize uint64
+}
+
+// IntegrityDiscrepancy is an inconsistency that Consensus.VerifyIntegrity
+// found in the consensus stores
+type IntegrityDiscrepancy struct {
+	Check IntegrityCheck
+
+	// BlockHash is the block the discrepancy was found in, if any
+	BlockHash *DomainHash
+	Details   string
+}
+
+// IntegrityReport is the result of Consensus.VerifyIntegrity
+type IntegrityReport struct {
+	CheckedBlocks          uint64
+	RecomputedGHOSTDAGData uint64
+	CheckedMultisets       uint64
+	VirtualUTXOCount       uint64
+	PruningPointUTXOCount  uint64
+	Discrepancies          []*IntegrityDiscrepancy
+}
@@ -148,6 +148,7 @@ func (bp *blockProcessor) ValidateAndInsertBlock(block *externalapi.DomainBlock,
 	shouldValidateAgainstUTXO bool) (*externalapi.VirtualChangeSet, externalapi.BlockStatus, error) {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "ValidateAndInsertBlock")
 	defer onEnd()
+	defer observeBlockProcessingDuration(block, time.Now())
 
 	stagingArea := model.NewStagingArea()
 	return bp.validateAndInsertBlock(stagingArea, block, false, shouldValidateAgainstUTXO, false)
@@ -165,6 +166,7 @@ func (bp *blockProcessor) ValidateAndInsertBlockWithTrustedData(block *externala
 	shouldValidateAgainstUTXO bool) (*externalapi.VirtualChangeSet, externalapi.BlockStatus, error) {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "ValidateAndInsertBlockWithTrustedData")
 	defer onEnd()
+	defer observeBlockProcessingDuration(block.Block, time.Now())
 
 	stagingArea := model.NewStagingArea()
 
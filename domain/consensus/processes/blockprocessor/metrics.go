@@ -0,0 +1,20 @@
+package blockprocessor
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var blockProcessingDuration = metrics.NewHistogramVec("kaspad_block_processing_duration_seconds",
+	"Time it took to validate and insert a header-only block (header) or a block with a body (block)",
+	metrics.DurationBuckets, "type")
+
+func observeBlockProcessingDuration(block *externalapi.DomainBlock, start time.Time) {
+	blockType := "block"
+	if isHeaderOnlyBlock(block) {
+		blockType = "header"
+	}
+	blockProcessingDuration.With(blockType).ObserveDurationSince(start)
+}
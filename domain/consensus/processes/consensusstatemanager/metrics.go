@@ -0,0 +1,8 @@
+package consensusstatemanager
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var virtualResolveDuration = metrics.NewHistogram("kaspad_virtual_resolve_duration_seconds",
+	"Time it took to resolve a chunk of the virtual", metrics.DurationBuckets)
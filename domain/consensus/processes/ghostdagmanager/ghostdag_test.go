@@ -458,3 +458,7 @@ func (b *blockHeadersStore) Delete(stagingArea *model.StagingArea, blockHash *ex
 func (b *blockHeadersStore) Count(*model.StagingArea) uint64 {
 	return uint64(len(b.dagMap))
 }
+
+func (b *blockHeadersStore) AllBlockHashesIterator(model.DBReader) (model.BlockIterator, error) {
+	panic("unimplemented")
+}
@@ -0,0 +1,424 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/database"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/multiset"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// verifyIntegrityProgressInterval is the number of blocks between every two
+// progress logs of VerifyIntegrity
+const verifyIntegrityProgressInterval = 10_000
+
+// VerifyIntegrity walks the consensus stores and reports the inconsistencies
+// it finds between them. It doesn't modify the stores
+func (s *consensus) VerifyIntegrity(options *externalapi.IntegrityVerificationOptions) (
+	*externalapi.IntegrityReport, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "VerifyIntegrity")
+	defer onEnd()
+
+	report := &externalapi.IntegrityReport{}
+	err := s.verifyBlocksIntegrity(options, report)
+	if err != nil {
+		return nil, err
+	}
+	err = s.verifyVirtualUTXOSetIntegrity(report)
+	if err != nil {
+		return nil, err
+	}
+	err = s.verifyPruningPointUTXOSetIntegrity(report)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func addDiscrepancy(report *externalapi.IntegrityReport, check externalapi.IntegrityCheck,
+	blockHash *externalapi.DomainHash, format string, args ...interface{}) {
+
+	discrepancy := &externalapi.IntegrityDiscrepancy{
+		Check:     check,
+		BlockHash: blockHash,
+		Details:   fmt.Sprintf(format, args...),
+	}
+	if blockHash != nil {
+		log.Warnf("Found a %s discrepancy in block %s: %s", check, blockHash, discrepancy.Details)
+	} else {
+		log.Warnf("Found a %s discrepancy: %s", check, discrepancy.Details)
+	}
+	report.Discrepancies = append(report.Discrepancies, discrepancy)
+}
+
+func (s *consensus) verifyBlocksIntegrity(options *externalapi.IntegrityVerificationOptions,
+	report *externalapi.IntegrityReport) error {
+
+	blockCount := s.blockHeaderStore.Count(model.NewStagingArea())
+	ghostdagSampleInterval := uint64(1)
+	if options.GHOSTDAGSampleSize > 0 && options.GHOSTDAGSampleSize < blockCount {
+		ghostdagSampleInterval = blockCount / options.GHOSTDAGSampleSize
+	}
+	log.Infof("Verifying %d blocks, recomputing the GHOSTDAG data of one in every %d of them",
+		blockCount, ghostdagSampleInterval)
+
+	iterator, err := s.blockHeaderStore.AllBlockHashesIterator(s.databaseContext)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	for ok := iterator.First(); ok; ok = iterator.Next() {
+		blockHash, err := iterator.Get()
+		if err != nil {
+			return err
+		}
+
+		stagingArea := model.NewStagingArea()
+		err = s.verifyBlockRelationsIntegrity(stagingArea, blockHash, report)
+		if err != nil {
+			return err
+		}
+		if report.CheckedBlocks%ghostdagSampleInterval == 0 {
+			err = s.verifyGHOSTDAGDataIntegrity(blockHash, report)
+			if err != nil {
+				return err
+			}
+		}
+		err = s.verifyMultisetIntegrity(stagingArea, blockHash, report)
+		if err != nil {
+			return err
+		}
+
+		report.CheckedBlocks++
+		if report.CheckedBlocks%verifyIntegrityProgressInterval == 0 {
+			log.Infof("Verified %d of %d blocks", report.CheckedBlocks, blockCount)
+		}
+	}
+	return nil
+}
+
+func (s *consensus) verifyBlockRelationsIntegrity(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	report *externalapi.IntegrityReport) error {
+
+	check := externalapi.IntegrityCheckBlockRelations
+	blockRelations, found, err := s.blockRelations(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	if !found {
+		addDiscrepancy(report, check, blockHash, "the block has a header but no block relations")
+		return nil
+	}
+	hasReachabilityData, err := s.reachabilityDataStore.HasReachabilityData(s.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	if !hasReachabilityData {
+		addDiscrepancy(report, check, blockHash, "the block has no reachability data")
+		return nil
+	}
+
+	for _, parent := range blockRelations.Parents {
+		parentRelations, found, err := s.blockRelations(stagingArea, parent)
+		if err != nil {
+			return err
+		}
+		if !found {
+			addDiscrepancy(report, check, blockHash, "the parent %s has no block relations", parent)
+			continue
+		}
+		if !containsHash(parentRelations.Children, blockHash) {
+			addDiscrepancy(report, check, blockHash, "the block is missing from the children of its parent %s", parent)
+		}
+
+		hasReachabilityData, err := s.reachabilityDataStore.HasReachabilityData(s.databaseContext, stagingArea, parent)
+		if err != nil {
+			return err
+		}
+		if !hasReachabilityData {
+			addDiscrepancy(report, check, blockHash, "the parent %s has no reachability data", parent)
+			continue
+		}
+		isParentAncestorOfBlock, err := s.reachabilityManager.IsDAGAncestorOf(stagingArea, parent, blockHash)
+		if err != nil {
+			return err
+		}
+		if !isParentAncestorOfBlock {
+			addDiscrepancy(report, check, blockHash,
+				"the reachability data doesn't have the parent %s as an ancestor of the block", parent)
+		}
+	}
+
+	for _, child := range blockRelations.Children {
+		childRelations, found, err := s.blockRelations(stagingArea, child)
+		if err != nil {
+			return err
+		}
+		if !found {
+			addDiscrepancy(report, check, blockHash, "the child %s has no block relations", child)
+			continue
+		}
+		if !containsHash(childRelations.Parents, blockHash) {
+			addDiscrepancy(report, check, blockHash, "the block is missing from the parents of its child %s", child)
+		}
+	}
+	return nil
+}
+
+func (s *consensus) blockRelations(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
+	blockRelations *model.BlockRelations, found bool, err error) {
+
+	hasBlockRelations, err := s.blockRelationStores[0].Has(s.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if !hasBlockRelations {
+		return nil, false, nil
+	}
+	blockRelations, err = s.blockRelationStores[0].BlockRelation(s.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, false, err
+	}
+	return blockRelations, true, nil
+}
+
+// verifyGHOSTDAGDataIntegrity recomputes the GHOSTDAG data of the given block
+// and compares it with the stored one. Blocks that were inserted with trusted
+// data, and blocks with parents like that, can't be recomputed, so they are
+// skipped
+func (s *consensus) verifyGHOSTDAGDataIntegrity(blockHash *externalapi.DomainHash,
+	report *externalapi.IntegrityReport) error {
+
+	// The recomputed GHOSTDAG data is staged in its own staging area,
+	// which is never committed
+	stagingArea := model.NewStagingArea()
+	check := externalapi.IntegrityCheckGHOSTDAG
+
+	storedGHOSTDAGData, found, err := s.ghostdagData(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	blockRelations, found, err := s.blockRelations(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	for _, parent := range blockRelations.Parents {
+		if parent.Equal(model.VirtualGenesisBlockHash) {
+			return nil
+		}
+		_, found, err := s.ghostdagData(stagingArea, parent)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return nil
+		}
+	}
+
+	err = s.ghostdagManagers[0].GHOSTDAG(stagingArea, blockHash)
+	if err != nil {
+		addDiscrepancy(report, check, blockHash, "recomputing the GHOSTDAG data failed: %s", err)
+		return nil
+	}
+	recomputedGHOSTDAGData, err := s.ghostdagDataStores[0].Get(s.databaseContext, stagingArea, blockHash, false)
+	if err != nil {
+		return err
+	}
+	report.RecomputedGHOSTDAGData++
+
+	if !ghostdagDataEqual(storedGHOSTDAGData, recomputedGHOSTDAGData) {
+		addDiscrepancy(report, check, blockHash, "the stored GHOSTDAG data (blue score %d, selected parent %s) "+
+			"differs from the recomputed one (blue score %d, selected parent %s)",
+			storedGHOSTDAGData.BlueScore(), storedGHOSTDAGData.SelectedParent(),
+			recomputedGHOSTDAGData.BlueScore(), recomputedGHOSTDAGData.SelectedParent())
+	}
+	return nil
+}
+
+func (s *consensus) ghostdagData(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
+	ghostdagData *externalapi.BlockGHOSTDAGData, found bool, err error) {
+
+	ghostdagData, err = s.ghostdagDataStores[0].Get(s.databaseContext, stagingArea, blockHash, false)
+	if database.IsNotFoundError(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return ghostdagData, true, nil
+}
+
+func ghostdagDataEqual(a, b *externalapi.BlockGHOSTDAGData) bool {
+	if a.BlueScore() != b.BlueScore() ||
+		a.BlueWork().Cmp(b.BlueWork()) != 0 ||
+		!a.SelectedParent().Equal(b.SelectedParent()) ||
+		!externalapi.HashesEqual(a.MergeSetBlues(), b.MergeSetBlues()) ||
+		!externalapi.HashesEqual(a.MergeSetReds(), b.MergeSetReds()) {
+		return false
+	}
+
+	aBluesAnticoneSizes, bBluesAnticoneSizes := a.BluesAnticoneSizes(), b.BluesAnticoneSizes()
+	if len(aBluesAnticoneSizes) != len(bBluesAnticoneSizes) {
+		return false
+	}
+	for blockHash, anticoneSize := range aBluesAnticoneSizes {
+		otherAnticoneSize, ok := bBluesAnticoneSizes[blockHash]
+		if !ok || anticoneSize != otherAnticoneSize {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyMultisetIntegrity checks that the multiset of a UTXO valid block
+// matches its UTXO commitment. The genesis is skipped, since its multiset
+// doesn't match its UTXO commitment to begin with
+func (s *consensus) verifyMultisetIntegrity(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	report *externalapi.IntegrityReport) error {
+
+	if blockHash.Equal(s.genesisHash) {
+		return nil
+	}
+	blockStatus, err := s.blockStatusStore.Get(s.databaseContext, stagingArea, blockHash)
+	if database.IsNotFoundError(err) {
+		addDiscrepancy(report, externalapi.IntegrityCheckMultisets, blockHash, "the block has no status")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if blockStatus != externalapi.StatusUTXOValid {
+		return nil
+	}
+
+	blockMultiset, err := s.multisetStore.Get(s.databaseContext, stagingArea, blockHash)
+	if database.IsNotFoundError(err) {
+		addDiscrepancy(report, externalapi.IntegrityCheckMultisets, blockHash, "the UTXO valid block has no multiset")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	header, err := s.blockHeaderStore.BlockHeader(s.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	report.CheckedMultisets++
+
+	if !blockMultiset.Hash().Equal(header.UTXOCommitment()) {
+		addDiscrepancy(report, externalapi.IntegrityCheckMultisets, blockHash,
+			"the multiset hash %s doesn't match the UTXO commitment %s", blockMultiset.Hash(), header.UTXOCommitment())
+	}
+	return nil
+}
+
+func (s *consensus) verifyVirtualUTXOSetIntegrity(report *externalapi.IntegrityReport) error {
+	log.Infof("Verifying the virtual UTXO set")
+	stagingArea := model.NewStagingArea()
+
+	virtualUTXOSetIterator, err := s.consensusStateStore.VirtualUTXOSetIterator(s.databaseContext, stagingArea)
+	if err != nil {
+		return err
+	}
+	defer virtualUTXOSetIterator.Close()
+
+	virtualUTXOSetMultiset, utxoCount, err := utxoSetMultiset(virtualUTXOSetIterator)
+	if err != nil {
+		return err
+	}
+	report.VirtualUTXOCount = utxoCount
+
+	virtualMultiset, err := s.multisetStore.Get(s.databaseContext, stagingArea, model.VirtualBlockHash)
+	if database.IsNotFoundError(err) {
+		addDiscrepancy(report, externalapi.IntegrityCheckVirtualUTXOSet, nil, "there's no virtual multiset")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !virtualUTXOSetMultiset.Hash().Equal(virtualMultiset.Hash()) {
+		addDiscrepancy(report, externalapi.IntegrityCheckVirtualUTXOSet, nil,
+			"the multiset hash %s of the virtual UTXO set doesn't match the virtual multiset hash %s",
+			virtualUTXOSetMultiset.Hash(), virtualMultiset.Hash())
+	}
+	return nil
+}
+
+// verifyPruningPointUTXOSetIntegrity checks the pruning point UTXO set against
+// the UTXO commitment of the pruning point. It's skipped while the pruning
+// point is the genesis, whose UTXO set doesn't match its UTXO commitment
+func (s *consensus) verifyPruningPointUTXOSetIntegrity(report *externalapi.IntegrityReport) error {
+	stagingArea := model.NewStagingArea()
+	pruningPoint, err := s.pruningStore.PruningPoint(s.databaseContext, stagingArea)
+	if err != nil {
+		return err
+	}
+	if pruningPoint.Equal(s.genesisHash) {
+		return nil
+	}
+
+	log.Infof("Verifying the UTXO set of pruning point %s", pruningPoint)
+	pruningPointUTXOIterator, err := s.pruningStore.PruningPointUTXOIterator(s.databaseContext)
+	if err != nil {
+		return err
+	}
+	defer pruningPointUTXOIterator.Close()
+
+	pruningPointUTXOSetMultiset, utxoCount, err := utxoSetMultiset(pruningPointUTXOIterator)
+	if err != nil {
+		return err
+	}
+	report.PruningPointUTXOCount = utxoCount
+
+	header, err := s.blockHeaderStore.BlockHeader(s.databaseContext, stagingArea, pruningPoint)
+	if err != nil {
+		return err
+	}
+	if !pruningPointUTXOSetMultiset.Hash().Equal(header.UTXOCommitment()) {
+		addDiscrepancy(report, externalapi.IntegrityCheckPruningPointUTXOSet, pruningPoint,
+			"the multiset hash %s of the pruning point UTXO set doesn't match the UTXO commitment %s",
+			pruningPointUTXOSetMultiset.Hash(), header.UTXOCommitment())
+	}
+	return nil
+}
+
+func utxoSetMultiset(iterator externalapi.ReadOnlyUTXOSetIterator) (model.Multiset, uint64, error) {
+	utxoSetMultiset := multiset.New()
+	utxoCount := uint64(0)
+	for ok := iterator.First(); ok; ok = iterator.Next() {
+		outpoint, entry, err := iterator.Get()
+		if err != nil {
+			return nil, 0, err
+		}
+		serializedUTXO, err := utxo.SerializeUTXO(entry, outpoint)
+		if err != nil {
+			return nil, 0, err
+		}
+		utxoSetMultiset.Add(serializedUTXO)
+		utxoCount++
+	}
+	return utxoSetMultiset, utxoCount, nil
+}
+
+func containsHash(hashes []*externalapi.DomainHash, hash *externalapi.DomainHash) bool {
+	for _, h := range hashes {
+		if h.Equal(hash) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package consensus_test
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/multiset"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/util/staging"
+)
+
+func TestVerifyIntegrity(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestVerifyIntegrity")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// Build a chain with a side block that is merged back into it
+		chain := []*externalapi.DomainHash{consensusConfig.GenesisHash}
+		for i := 0; i < 10; i++ {
+			blockHash, _, err := tc.AddBlock([]*externalapi.DomainHash{chain[len(chain)-1]}, nil, nil)
+			if err != nil {
+				t.Fatalf("AddBlock: %+v", err)
+			}
+			chain = append(chain, blockHash)
+		}
+		sideBlock, _, err := tc.AddBlock([]*externalapi.DomainHash{chain[5]}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		mergingBlock, _, err := tc.AddBlock([]*externalapi.DomainHash{chain[len(chain)-1], sideBlock}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+
+		report, err := tc.VerifyIntegrity(&externalapi.IntegrityVerificationOptions{})
+		if err != nil {
+			t.Fatalf("VerifyIntegrity: %+v", err)
+		}
+		if len(report.Discrepancies) != 0 {
+			t.Fatalf("Expected no discrepancies, but got %d. The first: %s: %s", len(report.Discrepancies),
+				report.Discrepancies[0].Check, report.Discrepancies[0].Details)
+		}
+		const expectedBlockCount = 13
+		if report.CheckedBlocks != expectedBlockCount {
+			t.Fatalf("Expected %d checked blocks, but got %d", expectedBlockCount, report.CheckedBlocks)
+		}
+		if report.RecomputedGHOSTDAGData == 0 || report.CheckedMultisets == 0 || report.VirtualUTXOCount == 0 {
+			t.Fatalf("Expected the GHOSTDAG data, multisets and virtual UTXO set to be checked, but got %+v", report)
+		}
+
+		sampledReport, err := tc.VerifyIntegrity(&externalapi.IntegrityVerificationOptions{GHOSTDAGSampleSize: 3})
+		if err != nil {
+			t.Fatalf("VerifyIntegrity: %+v", err)
+		}
+		if sampledReport.RecomputedGHOSTDAGData >= report.RecomputedGHOSTDAGData {
+			t.Fatalf("Expected sampling to recompute the GHOSTDAG data of less than %d blocks, but it recomputed %d",
+				report.RecomputedGHOSTDAGData, sampledReport.RecomputedGHOSTDAGData)
+		}
+
+		// Corrupt a multiset, the GHOSTDAG data of the merging block, and the
+		// children of the side block
+		stagingArea := model.NewStagingArea()
+		tc.MultisetStore().Stage(stagingArea, chain[3], multiset.New())
+
+		mergingBlockGHOSTDAGData, err := tc.GHOSTDAGDataStore().Get(tc.DatabaseContext(), stagingArea, mergingBlock, false)
+		if err != nil {
+			t.Fatalf("GHOSTDAGDataStore().Get: %+v", err)
+		}
+		tc.GHOSTDAGDataStore().Stage(stagingArea, mergingBlock, externalapi.NewBlockGHOSTDAGData(
+			mergingBlockGHOSTDAGData.BlueScore()+1,
+			mergingBlockGHOSTDAGData.BlueWork(),
+			mergingBlockGHOSTDAGData.SelectedParent(),
+			mergingBlockGHOSTDAGData.MergeSetBlues(),
+			mergingBlockGHOSTDAGData.MergeSetReds(),
+			mergingBlockGHOSTDAGData.BluesAnticoneSizes(),
+		), false)
+
+		sideBlockRelations, err := tc.BlockRelationStore().BlockRelation(tc.DatabaseContext(), stagingArea, sideBlock)
+		if err != nil {
+			t.Fatalf("BlockRelation: %+v", err)
+		}
+		tc.BlockRelationStore().StageBlockRelation(stagingArea, sideBlock, &model.BlockRelations{
+			Parents:  sideBlockRelations.Parents,
+			Children: nil,
+		})
+
+		err = staging.CommitAllChanges(tc.DatabaseContext(), stagingArea)
+		if err != nil {
+			t.Fatalf("CommitAllChanges: %+v", err)
+		}
+
+		report, err = tc.VerifyIntegrity(&externalapi.IntegrityVerificationOptions{})
+		if err != nil {
+			t.Fatalf("VerifyIntegrity: %+v", err)
+		}
+		expectedDiscrepancies := map[externalapi.IntegrityCheck]*externalapi.DomainHash{
+			externalapi.IntegrityCheckMultisets:      chain[3],
+			externalapi.IntegrityCheckGHOSTDAG:       mergingBlock,
+			externalapi.IntegrityCheckBlockRelations: mergingBlock,
+		}
+		if len(report.Discrepancies) != len(expectedDiscrepancies) {
+			t.Fatalf("Expected %d discrepancies, but got %d", len(expectedDiscrepancies), len(report.Discrepancies))
+		}
+		for _, discrepancy := range report.Discrepancies {
+			expectedBlockHash, ok := expectedDiscrepancies[discrepancy.Check]
+			if !ok || !expectedBlockHash.Equal(discrepancy.BlockHash) {
+				t.Fatalf("Unexpected %s discrepancy in block %s: %s",
+					discrepancy.Check, discrepancy.BlockHash, discrepancy.Details)
+			}
+		}
+	})
+}
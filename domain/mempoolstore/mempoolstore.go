@@ -0,0 +1,261 @@
+package mempoolstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var transactionPoolBucket = database.MakeBucket([]byte("mempool-transactions"))
+var orphanPoolBucket = database.MakeBucket([]byte("mempool-orphans"))
+
+// saveInterval is the interval between periodic saves of the mempool, so that
+// a node that doesn't shut down cleanly still restores most of its mempool
+const saveInterval = 5 * time.Minute
+
+// MempoolStore persists the transaction pool and the orphan pool of the mempool
+// into the node database, and restores them on startup
+type MempoolStore struct {
+	domain   domain.Domain
+	database database.Database
+
+	mutex     sync.Mutex
+	isStarted bool
+	quit      chan struct{}
+	quitDone  chan struct{}
+}
+
+// New creates a new MempoolStore
+func New(domain domain.Domain, database database.Database) *MempoolStore {
+	return &MempoolStore{
+		domain:   domain,
+		database: database,
+		quit:     make(chan struct{}),
+		quitDone: make(chan struct{}),
+	}
+}
+
+// Start launches the periodic saving of the mempool
+func (ms *MempoolStore) Start() {
+	ms.isStarted = true
+	spawn("MempoolStore.saveLoop", ms.saveLoop)
+}
+
+// Stop stops the periodic saving of the mempool and saves it one last time
+func (ms *MempoolStore) Stop() error {
+	if ms.isStarted {
+		close(ms.quit)
+		<-ms.quitDone
+	}
+
+	return ms.Save()
+}
+
+func (ms *MempoolStore) saveLoop() {
+	defer close(ms.quitDone)
+
+	ticker := time.NewTicker(saveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := ms.Save()
+			if err != nil {
+				log.Errorf("Error saving the mempool: %+v", err)
+			}
+		case <-ms.quit:
+			return
+		}
+	}
+}
+
+// Save replaces the stored mempool with the current content of the mempool
+func (ms *MempoolStore) Save() error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	transactionPoolTransactions, orphanPoolTransactions, highPriorityTransactionIDList := ms.domain.MiningManager().Snapshot()
+	highPriorityTransactionIDs := make(map[externalapi.DomainTransactionID]struct{})
+	for _, transactionID := range highPriorityTransactionIDList {
+		highPriorityTransactionIDs[*transactionID] = struct{}{}
+	}
+
+	transactionPoolKeys, err := ms.storedKeys(transactionPoolBucket)
+	if err != nil {
+		return err
+	}
+	orphanPoolKeys, err := ms.storedKeys(orphanPoolBucket)
+	if err != nil {
+		return err
+	}
+
+	dbTx, err := ms.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	for _, key := range append(transactionPoolKeys, orphanPoolKeys...) {
+		err := dbTx.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	err = putTransactions(dbTx, transactionPoolBucket, transactionPoolTransactions, highPriorityTransactionIDs)
+	if err != nil {
+		return err
+	}
+	err = putTransactions(dbTx, orphanPoolBucket, orphanPoolTransactions, highPriorityTransactionIDs)
+	if err != nil {
+		return err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Saved %d transactions and %d orphans of the mempool",
+		len(transactionPoolTransactions), len(orphanPoolTransactions))
+	return nil
+}
+
+func putTransactions(dbTx database.Transaction, bucket *database.Bucket, transactions []*externalapi.DomainTransaction,
+	highPriorityTransactionIDs map[externalapi.DomainTransactionID]struct{}) error {
+
+	for _, transaction := range transactions {
+		transactionID := consensushashing.TransactionID(transaction)
+		_, isHighPriority := highPriorityTransactionIDs[*transactionID]
+		serializedTransaction, err := serializeStoredTransaction(transaction, isHighPriority)
+		if err != nil {
+			return err
+		}
+		err = dbTx.Put(bucket.Key(transactionID.ByteSlice()), serializedTransaction)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ms *MempoolStore) storedKeys(bucket *database.Bucket) ([]*database.Key, error) {
+	cursor, err := ms.database.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var keys []*database.Key
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (ms *MempoolStore) storedTransactions(bucket *database.Bucket) ([]*storedTransaction, error) {
+	cursor, err := ms.database.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var storedTransactions []*storedTransaction
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		serializedTransaction, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		storedTransaction, err := deserializeStoredTransaction(serializedTransaction)
+		if err != nil {
+			return nil, err
+		}
+		storedTransactions = append(storedTransactions, storedTransaction)
+	}
+	return storedTransactions, nil
+}
+
+// Restore revalidates the stored mempool against the current virtual UTXO set and inserts
+// every transaction that is still valid into the mempool. Invalid transactions are dropped,
+// and the reason for dropping them is logged
+func (ms *MempoolStore) Restore() error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	transactionPoolTransactions, err := ms.storedTransactions(transactionPoolBucket)
+	if err != nil {
+		return err
+	}
+	orphanPoolTransactions, err := ms.storedTransactions(orphanPoolBucket)
+	if err != nil {
+		return err
+	}
+
+	restoredCount := 0
+	// Orphans are not allowed when restoring the transaction pool, since a transaction that used to
+	// be in it and is now missing its parents most likely had them double spent while the node was down
+	for _, storedTransaction := range sortByDependencies(transactionPoolTransactions) {
+		if ms.restoreTransaction(storedTransaction, false) {
+			restoredCount++
+		}
+	}
+	for _, storedTransaction := range orphanPoolTransactions {
+		if ms.restoreTransaction(storedTransaction, true) {
+			restoredCount++
+		}
+	}
+
+	log.Infof("Restored %d out of %d stored mempool transactions", restoredCount,
+		len(transactionPoolTransactions)+len(orphanPoolTransactions))
+	return nil
+}
+
+func (ms *MempoolStore) restoreTransaction(storedTransaction *storedTransaction, allowOrphan bool) bool {
+	transactionID := consensushashing.TransactionID(storedTransaction.transaction)
+	_, err := ms.domain.MiningManager().ValidateAndInsertTransaction(
+		storedTransaction.transaction, storedTransaction.isHighPriority, allowOrphan)
+	if err != nil {
+		log.Infof("Dropping stored mempool transaction %s: %s", transactionID, err)
+		return false
+	}
+	return true
+}
+
+// sortByDependencies orders the given transactions so that every transaction comes after
+// all the other given transactions it spends outputs of
+func sortByDependencies(storedTransactions []*storedTransaction) []*storedTransaction {
+	transactionsByID := make(map[externalapi.DomainTransactionID]*storedTransaction, len(storedTransactions))
+	for _, storedTransaction := range storedTransactions {
+		transactionsByID[*consensushashing.TransactionID(storedTransaction.transaction)] = storedTransaction
+	}
+
+	sorted := make([]*storedTransaction, 0, len(storedTransactions))
+	visited := make(map[externalapi.DomainTransactionID]struct{}, len(storedTransactions))
+	var visit func(transactionID externalapi.DomainTransactionID)
+	visit = func(transactionID externalapi.DomainTransactionID) {
+		storedTransaction, ok := transactionsByID[transactionID]
+		if !ok {
+			return
+		}
+		if _, ok := visited[transactionID]; ok {
+			return
+		}
+		visited[transactionID] = struct{}{}
+		for _, input := range storedTransaction.transaction.Inputs {
+			visit(input.PreviousOutpoint.TransactionID)
+		}
+		sorted = append(sorted, storedTransaction)
+	}
+	for _, storedTransaction := range storedTransactions {
+		visit(*consensushashing.TransactionID(storedTransaction.transaction))
+	}
+	return sorted
+}
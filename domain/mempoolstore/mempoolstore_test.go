@@ -0,0 +1,187 @@
+package mempoolstore
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func newTestTransaction(previousOutpoint externalapi.DomainOutpoint, value uint64) *externalapi.DomainTransaction {
+	return &externalapi.DomainTransaction{
+		Version: 0,
+		Inputs: []*externalapi.DomainTransactionInput{{
+			PreviousOutpoint: previousOutpoint,
+			SignatureScript:  []byte{1, 2, 3},
+			Sequence:         1,
+			SigOpCount:       1,
+		}},
+		Outputs: []*externalapi.DomainTransactionOutput{{
+			Value:           value,
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{4, 5, 6}, Version: 0},
+		}},
+		SubnetworkID: subnetworks.SubnetworkIDNative,
+		Payload:      []byte{},
+	}
+}
+
+func TestStoredTransactionSerialization(t *testing.T) {
+	transaction := newTestTransaction(externalapi.DomainOutpoint{
+		TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		Index:         2,
+	}, 1000)
+	transaction.MassCommitment = 1234
+
+	for _, isHighPriority := range []bool{false, true} {
+		serialized, err := serializeStoredTransaction(transaction, isHighPriority)
+		if err != nil {
+			t.Fatalf("serializeStoredTransaction: %+v", err)
+		}
+		deserialized, err := deserializeStoredTransaction(serialized)
+		if err != nil {
+			t.Fatalf("deserializeStoredTransaction: %+v", err)
+		}
+		if !deserialized.transaction.Equal(transaction) {
+			t.Fatalf("expected deserialized transaction %+v to equal %+v", deserialized.transaction, transaction)
+		}
+		if deserialized.isHighPriority != isHighPriority {
+			t.Fatalf("expected isHighPriority to be %t, got %t", isHighPriority, deserialized.isHighPriority)
+		}
+	}
+
+	_, err := deserializeStoredTransaction([]byte{1})
+	if err == nil {
+		t.Fatalf("expected deserializing a truncated transaction to fail")
+	}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	parent := newTestTransaction(externalapi.DomainOutpoint{
+		TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+	}, 3000)
+	child := newTestTransaction(externalapi.DomainOutpoint{TransactionID: *consensushashing.TransactionID(parent)}, 2000)
+	grandchild := newTestTransaction(externalapi.DomainOutpoint{TransactionID: *consensushashing.TransactionID(child)}, 1000)
+
+	sorted := sortByDependencies([]*storedTransaction{
+		{transaction: grandchild},
+		{transaction: child},
+		{transaction: parent},
+	})
+	if len(sorted) != 3 {
+		t.Fatalf("expected 3 sorted transactions, got %d", len(sorted))
+	}
+	for i, expected := range []*externalapi.DomainTransaction{parent, child, grandchild} {
+		if sorted[i].transaction != expected {
+			t.Fatalf("unexpected transaction %s at index %d", consensushashing.TransactionID(sorted[i].transaction), i)
+		}
+	}
+}
+
+func TestSaveAndRestore(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+
+		db, err := ldb.NewLevelDB(t.TempDir(), 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+
+		scriptPublicKey, _ := testutils.OpTrueScript()
+		addBlock := func(transactions []*externalapi.DomainTransaction) *externalapi.DomainBlock {
+			block, err := domainInstance.Consensus().BuildBlock(&externalapi.DomainCoinbaseData{
+				ScriptPublicKey: scriptPublicKey,
+				ExtraData:       nil,
+			}, transactions)
+			if err != nil {
+				t.Fatalf("BuildBlock: %+v", err)
+			}
+			// Blocks arrive without the UTXO entries of their transactions
+			for _, transaction := range block.Transactions {
+				for _, input := range transaction.Inputs {
+					input.UTXOEntry = nil
+				}
+			}
+			err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+			return block
+		}
+		createTransaction := func(txToSpend *externalapi.DomainTransaction, fee uint64) *externalapi.DomainTransaction {
+			transaction, err := testutils.CreateTransaction(txToSpend, fee)
+			if err != nil {
+				t.Fatalf("CreateTransaction: %+v", err)
+			}
+			return transaction
+		}
+
+		// The coinbase transaction of a block pays the blocks it merges, so the coinbase of the
+		// first block pays nothing, and it's spendable once a later chain block accepts it
+		addBlock(nil)
+		fundingBlocks := []*externalapi.DomainBlock{addBlock(nil), addBlock(nil)}
+		addBlock(nil)
+
+		parent := createTransaction(fundingBlocks[0].Transactions[0], 1000)
+		child := createTransaction(parent, 1000)
+		doubleSpent := createTransaction(fundingBlocks[1].Transactions[0], 1000)
+		for _, transaction := range []*externalapi.DomainTransaction{parent, child, doubleSpent} {
+			_, err := domainInstance.MiningManager().ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+			}
+		}
+
+		err = New(domainInstance, db).Save()
+		if err != nil {
+			t.Fatalf("Save: %+v", err)
+		}
+
+		// While the node is down, a block spends the input of doubleSpent
+		doubleSpender := createTransaction(fundingBlocks[1].Transactions[0], 2000)
+		err = domainInstance.Consensus().ValidateTransactionAndPopulateWithConsensusData(doubleSpender)
+		if err != nil {
+			t.Fatalf("ValidateTransactionAndPopulateWithConsensusData: %+v", err)
+		}
+		addBlock([]*externalapi.DomainTransaction{doubleSpender})
+
+		// Restart the node
+		domainInstance, err = domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		err = New(domainInstance, db).Restore()
+		if err != nil {
+			t.Fatalf("Restore: %+v", err)
+		}
+
+		transactionPool, orphanPool := domainInstance.MiningManager().AllTransactions(true, true)
+		if len(orphanPool) != 0 {
+			t.Fatalf("expected no orphans, got %d", len(orphanPool))
+		}
+		if len(transactionPool) != 2 {
+			t.Fatalf("expected the parent and the child to be restored, got %d transactions", len(transactionPool))
+		}
+		for _, expected := range []*externalapi.DomainTransaction{parent, child} {
+			_, _, found := domainInstance.MiningManager().GetTransaction(consensushashing.TransactionID(expected), true, false)
+			if !found {
+				t.Fatalf("expected transaction %s to be restored", consensushashing.TransactionID(expected))
+			}
+		}
+		_, _, found := domainInstance.MiningManager().GetTransaction(consensushashing.TransactionID(doubleSpent), true, true)
+		if found {
+			t.Fatalf("expected the double spent transaction to be dropped")
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package mempoolstore
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kaspanet/kaspad/domain/consensus/database/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// storedTransactionHeaderLength is the length of the fields preceding the serialized
+// transaction: a one byte high-priority flag followed by the uint64 mass commitment,
+// which DbTransaction doesn't carry
+const storedTransactionHeaderLength = 1 + 8
+
+type storedTransaction struct {
+	transaction    *externalapi.DomainTransaction
+	isHighPriority bool
+}
+
+func serializeStoredTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool) ([]byte, error) {
+	serializedTransaction, err := proto.Marshal(serialization.DomainTransactionToDbTransaction(transaction))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	serialized := make([]byte, storedTransactionHeaderLength, storedTransactionHeaderLength+len(serializedTransaction))
+	if isHighPriority {
+		serialized[0] = 1
+	}
+	binary.LittleEndian.PutUint64(serialized[1:storedTransactionHeaderLength], transaction.MassCommitment)
+	return append(serialized, serializedTransaction...), nil
+}
+
+func deserializeStoredTransaction(serialized []byte) (*storedTransaction, error) {
+	if len(serialized) < storedTransactionHeaderLength {
+		return nil, errors.Errorf("stored mempool transaction is %d bytes long, while at least %d are "+
+			"expected", len(serialized), storedTransactionHeaderLength)
+	}
+
+	dbTransaction := &serialization.DbTransaction{}
+	err := proto.Unmarshal(serialized[storedTransactionHeaderLength:], dbTransaction)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	transaction, err := serialization.DbTransactionToDomainTransaction(dbTransaction)
+	if err != nil {
+		return nil, err
+	}
+	transaction.MassCommitment = binary.LittleEndian.Uint64(serialized[1:storedTransactionHeaderLength])
+
+	return &storedTransaction{
+		transaction:    transaction,
+		isHighPriority: serialized[0] == 1,
+	}, nil
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensusreference"
 	"github.com/kaspanet/kaspad/util/mstime"
 	"math"
-	"sort"
 
 	"github.com/kaspanet/kaspad/util/difficulty"
 
@@ -118,9 +117,12 @@ func New(consensusReference consensusreference.ConsensusReference, mempool minin
 func (btb *blockTemplateBuilder) BuildBlockTemplate(
 	coinbaseData *consensusexternalapi.DomainCoinbaseData) (*consensusexternalapi.DomainBlockTemplate, error) {
 
+	// The candidates are read from the fee rate index of the mempool, from the highest
+	// package fee rate down, and are already bounded to a few blocks worth of mass
 	mempoolTransactions := btb.mempool.BlockCandidateTransactions()
 	candidateTxs := make([]*candidateTx, 0, len(mempoolTransactions))
-	for _, tx := range mempoolTransactions {
+	for _, mempoolTransaction := range mempoolTransactions {
+		tx := mempoolTransaction.Transaction
 		// Calculate the tx value
 		gasLimit := uint64(0)
 		if !subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
@@ -128,16 +130,11 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 		}
 		candidateTxs = append(candidateTxs, &candidateTx{
 			DomainTransaction: tx,
-			txValue:           btb.calcTxValue(tx),
+			txValue:           btb.calcTxValue(tx, mempoolTransaction.Feerate),
 			gasLimit:          gasLimit,
 		})
 	}
 
-	// Sort the candidate txs by subnetworkID.
-	sort.Slice(candidateTxs, func(i, j int) bool {
-		return subnetworks.Less(candidateTxs[i].SubnetworkID, candidateTxs[j].SubnetworkID)
-	})
-
 	log.Debugf("Considering %d transactions for inclusion to new block",
 		len(candidateTxs))
 
@@ -203,16 +200,18 @@ func (btb *blockTemplateBuilder) ModifyBlockTemplate(newCoinbaseData *consensuse
 
 // calcTxValue calculates a value to be used in transaction selection.
 // The higher the number the more likely it is that the transaction will be
-// included in the block.
-func (btb *blockTemplateBuilder) calcTxValue(tx *consensusexternalapi.DomainTransaction) float64 {
+// included in the block. The fee is taken at the feerate the mempool selected
+// the transaction by, so that a transaction is valued by the package that
+// pays for it.
+func (btb *blockTemplateBuilder) calcTxValue(tx *consensusexternalapi.DomainTransaction, feerate float64) float64 {
 	massLimit := btb.policy.BlockMaxMass
 
 	mass := tx.Mass
-	fee := tx.Fee
+	fee := feerate * float64(mass)
 	if subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
-		return float64(fee) / (float64(mass) / float64(massLimit))
+		return fee / (float64(mass) / float64(massLimit))
 	}
 	// TODO: Replace with real gas once implemented
 	gasLimit := uint64(math.MaxUint64)
-	return float64(fee) / (float64(mass)/float64(massLimit) + float64(tx.Gas)/float64(gasLimit))
+	return fee / (float64(mass)/float64(massLimit) + float64(tx.Gas)/float64(gasLimit))
 }
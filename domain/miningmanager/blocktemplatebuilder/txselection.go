@@ -52,9 +52,9 @@ type selectedTransactions struct {
 //   rebalanceThreshold percent of the sum of probabilities of all transactions,
 //   rebalance.
 
-// selectTransactions loops over the candidate transactions
-// and appends the ones that will be included in the next block into
-// txsForBlockTemplates.
+// selectTransactions loops over the candidate transactions, which are ordered
+// by fee rate from the highest, and appends the ones that will be included in
+// the next block into txsForBlockTemplates.
 // See selectTxs for further details.
 func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx) selectedTransactions {
 	txsForBlockTemplate := selectedTransactions{
@@ -64,6 +64,12 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 		totalMass:   0,
 		totalFees:   0,
 	}
+
+	// When all the candidates fit in the block there's nothing to draw
+	if btb.candidatesFitInBlock(candidateTxs) {
+		return btb.selectedTransactions(txsForBlockTemplate, candidateTxs)
+	}
+
 	usedCount, usedP := 0, 0.0
 	candidateTxs, totalP := rebalanceCandidates(candidateTxs, true)
 	gasUsageMap := make(map[consensusexternalapi.DomainSubnetworkID]uint64)
@@ -75,6 +81,7 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 	}
 
 	selectedTxs := make([]*candidateTx, 0)
+	selectedMass := uint64(0)
 	for len(candidateTxs)-usedCount > 0 {
 		// Rebalance the candidates if it's required
 		if usedP >= rebalanceThreshold*totalP {
@@ -101,8 +108,8 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 
 		// Enforce maximum transaction mass per block. Also check
 		// for overflow.
-		if txsForBlockTemplate.totalMass+selectedTx.Mass < txsForBlockTemplate.totalMass ||
-			txsForBlockTemplate.totalMass+selectedTx.Mass > btb.policy.BlockMaxMass {
+		if selectedMass+selectedTx.Mass < selectedMass ||
+			selectedMass+selectedTx.Mass > btb.policy.BlockMaxMass {
 			log.Tracef("Tx %s would exceed the max block mass. "+
 				"As such, stopping.", consensushashing.TransactionID(tx))
 			break
@@ -123,14 +130,10 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 					"subnetwork %s. Removing all remaining txs from this "+
 					"subnetwork.",
 					consensushashing.TransactionID(tx), subnetworkID)
+				// candidateTxs are ordered by fee rate rather than by subnetwork,
+				// so all of them are scanned.
 				for _, candidateTx := range candidateTxs {
-					// candidateTxs are ordered by subnetwork, so we can safely assume
-					// that transactions after subnetworkID will not be relevant.
-					if subnetworks.Less(subnetworkID, candidateTx.SubnetworkID) {
-						break
-					}
-
-					if candidateTx.SubnetworkID == subnetworkID {
+					if candidateTx.SubnetworkID == subnetworkID && !candidateTx.isMarkedForDeletion {
 						markCandidateTxForDeletion(candidateTx)
 					}
 				}
@@ -143,8 +146,7 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 		// save the masses, fees, and signature operation counts to the
 		// result.
 		selectedTxs = append(selectedTxs, selectedTx)
-		txsForBlockTemplate.totalMass += selectedTx.Mass
-		txsForBlockTemplate.totalFees += selectedTx.Fee
+		selectedMass += selectedTx.Mass
 
 		log.Tracef("Adding tx %s (feePerMegaGram %d)",
 			consensushashing.TransactionID(tx), selectedTx.Fee*1e6/selectedTx.Mass)
@@ -152,13 +154,40 @@ func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx)
 		markCandidateTxForDeletion(selectedTx)
 	}
 
-	sort.Slice(selectedTxs, func(i, j int) bool {
+	return btb.selectedTransactions(txsForBlockTemplate, selectedTxs)
+}
+
+// candidatesFitInBlock returns whether all of candidateTxs can be included in
+// a single block
+func (btb *blockTemplateBuilder) candidatesFitInBlock(candidateTxs []*candidateTx) bool {
+	totalMass := uint64(0)
+	for _, candidateTx := range candidateTxs {
+		if !subnetworks.IsBuiltInOrNative(candidateTx.SubnetworkID) {
+			return false
+		}
+		if totalMass+candidateTx.Mass < totalMass || totalMass+candidateTx.Mass > btb.policy.BlockMaxMass {
+			return false
+		}
+		totalMass += candidateTx.Mass
+	}
+	return true
+}
+
+// selectedTransactions adds selectedTxs to txsForBlockTemplate, ordered by
+// subnetwork as blocks require. Transactions of the same subnetwork keep their
+// order
+func (btb *blockTemplateBuilder) selectedTransactions(txsForBlockTemplate selectedTransactions,
+	selectedTxs []*candidateTx) selectedTransactions {
+
+	sort.SliceStable(selectedTxs, func(i, j int) bool {
 		return subnetworks.Less(selectedTxs[i].SubnetworkID, selectedTxs[j].SubnetworkID)
 	})
 	for _, selectedTx := range selectedTxs {
 		txsForBlockTemplate.selectedTxs = append(txsForBlockTemplate.selectedTxs, selectedTx.DomainTransaction)
 		txsForBlockTemplate.txMasses = append(txsForBlockTemplate.txMasses, selectedTx.Mass)
 		txsForBlockTemplate.txFees = append(txsForBlockTemplate.txFees, selectedTx.Fee)
+		txsForBlockTemplate.totalMass += selectedTx.Mass
+		txsForBlockTemplate.totalFees += selectedTx.Fee
 	}
 	return txsForBlockTemplate
 }
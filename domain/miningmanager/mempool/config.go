@@ -12,6 +12,13 @@ import (
 
 const (
 	defaultMaximumTransactionCount = 1_000_000
+	// defaultMaximumTransactionsMass bounds the total mass of all transactions in the transaction pool.
+	// Once it is exceeded, the transactions with the lowest package fee rate are evicted.
+	defaultMaximumTransactionsMass = 1_000_000_000
+
+	// blockCandidatesMassFactor is the number of blocks worth of mass read from the top of the fee rate
+	// index when collecting candidates for a block template
+	blockCandidatesMassFactor = 10
 
 	defaultTransactionExpireIntervalSeconds     uint64 = 60
 	defaultTransactionExpireScanIntervalSeconds uint64 = 10
@@ -38,6 +45,7 @@ const (
 // Config represents a mempool configuration
 type Config struct {
 	MaximumTransactionCount               uint64
+	MaximumTransactionsMass               uint64
 	TransactionExpireIntervalDAAScore     uint64
 	TransactionExpireScanIntervalDAAScore uint64
 	TransactionExpireScanIntervalSeconds  uint64
@@ -47,6 +55,7 @@ type Config struct {
 	MaximumOrphanTransactionCount         uint64
 	AcceptNonStandard                     bool
 	MaximumMassPerBlock                   uint64
+	TargetBlocksPerSecond                 float64
 	MinimumRelayTransactionFee            util.Amount
 	MinimumStandardTransactionVersion     uint16
 	MaximumStandardTransactionVersion     uint16
@@ -58,6 +67,7 @@ func DefaultConfig(dagParams *dagconfig.Params) *Config {
 
 	return &Config{
 		MaximumTransactionCount:               defaultMaximumTransactionCount,
+		MaximumTransactionsMass:               defaultMaximumTransactionsMass,
 		TransactionExpireIntervalDAAScore:     uint64(float64(defaultTransactionExpireIntervalSeconds) / targetBlocksPerSecond),
 		TransactionExpireScanIntervalDAAScore: uint64(float64(defaultTransactionExpireScanIntervalSeconds) / targetBlocksPerSecond),
 		TransactionExpireScanIntervalSeconds:  defaultTransactionExpireScanIntervalSeconds,
@@ -67,6 +77,7 @@ func DefaultConfig(dagParams *dagconfig.Params) *Config {
 		MaximumOrphanTransactionCount:         defaultMaximumOrphanTransactionCount,
 		AcceptNonStandard:                     dagParams.RelayNonStdTxs,
 		MaximumMassPerBlock:                   dagParams.MaxBlockMass,
+		TargetBlocksPerSecond:                 targetBlocksPerSecond,
 		MinimumRelayTransactionFee:            defaultMinimumRelayTransactionFee,
 		MinimumStandardTransactionVersion:     defaultMinimumStandardTransactionVersion,
 		MaximumStandardTransactionVersion:     defaultMaximumStandardTransactionVersion,
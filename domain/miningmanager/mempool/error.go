@@ -52,6 +52,8 @@ const (
 	RejectImmatureSpend   RejectCode = 0x45
 	RejectBadOrphan       RejectCode = 0x64
 	RejectSpamTx          RejectCode = 0x65
+	RejectNoDoubleSpend   RejectCode = 0x66
+	RejectManyConflicts   RejectCode = 0x67
 )
 
 // Map of reject codes back strings for pretty printing.
@@ -68,6 +70,8 @@ var rejectCodeStrings = map[RejectCode]string{
 	RejectNotRequested:    "REJECT_NOT_REQUESTED",
 	RejectImmatureSpend:   "REJECT_IMMATURE_SPEND",
 	RejectBadOrphan:       "REJECT_BAD_ORPHAN",
+	RejectNoDoubleSpend:   "REJECT_NO_DOUBLE_SPEND",
+	RejectManyConflicts:   "REJECT_MANY_CONFLICTS",
 }
 
 // String returns the RejectCode in human-readable form.
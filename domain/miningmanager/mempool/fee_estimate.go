@@ -0,0 +1,118 @@
+package mempool
+
+import (
+	"math"
+
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+)
+
+var (
+	// normalBucketsTargetSeconds are the inclusion times sampled for the normal buckets.
+	// The first one is guaranteed to be returned, and provides a sub-minute estimate.
+	normalBucketsTargetSeconds = []float64{60, 180, 600}
+
+	// lowBucketsTargetSeconds are the inclusion times sampled for the low buckets.
+	// The first one is guaranteed to be returned, and provides a sub-hour estimate.
+	lowBucketsTargetSeconds = []float64{3600, 6 * 3600}
+)
+
+// feerateEntry is the feerate and mass of a single transaction in the transaction pool
+type feerateEntry struct {
+	feerate float64
+	mass    uint64
+}
+
+// feeEstimator estimates inclusion times by assuming that every block is filled
+// with the highest-feerate transactions currently in the transaction pool
+type feeEstimator struct {
+	// entries are ordered by feerate, highest first
+	entries        []feerateEntry
+	massPerBlock   float64
+	blockInterval  float64
+	minimumFeerate float64
+}
+
+func (mp *mempool) FeeEstimate() *miningmanagermodel.FeeEstimate {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	estimator := &feeEstimator{
+		entries:        mp.transactionsPool.feerateEntries(),
+		massPerBlock:   float64(mp.config.MaximumMassPerBlock),
+		blockInterval:  1 / mp.config.TargetBlocksPerSecond,
+		minimumFeerate: float64(mp.config.MinimumRelayTransactionFee) / 1000,
+	}
+	return estimator.estimate()
+}
+
+func (fe *feeEstimator) estimate() *miningmanagermodel.FeeEstimate {
+	priorityFeerate := fe.feerateForSeconds(fe.blockInterval)
+	normalBuckets := fe.buckets(normalBucketsTargetSeconds, priorityFeerate)
+	lowBuckets := fe.buckets(lowBucketsTargetSeconds, normalBuckets[len(normalBuckets)-1].Feerate)
+
+	lowestFeerate := lowBuckets[len(lowBuckets)-1].Feerate
+	if fe.minimumFeerate < lowestFeerate {
+		lowBuckets = append(lowBuckets, fe.bucket(fe.minimumFeerate))
+	}
+
+	return &miningmanagermodel.FeeEstimate{
+		PriorityBucket: fe.bucket(priorityFeerate),
+		NormalBuckets:  normalBuckets,
+		LowBuckets:     lowBuckets,
+	}
+}
+
+// buckets samples a bucket for every one of the given target times. The first bucket
+// is always returned, the rest only if their feerate is strictly lower than that of
+// the previous bucket
+func (fe *feeEstimator) buckets(targetSeconds []float64, previousFeerate float64) []miningmanagermodel.FeerateBucket {
+	buckets := make([]miningmanagermodel.FeerateBucket, 0, len(targetSeconds))
+	for i, seconds := range targetSeconds {
+		feerate := math.Min(fe.feerateForSeconds(seconds), previousFeerate)
+		if i > 0 && feerate >= previousFeerate {
+			continue
+		}
+		buckets = append(buckets, fe.bucket(feerate))
+		previousFeerate = feerate
+	}
+	return buckets
+}
+
+func (fe *feeEstimator) bucket(feerate float64) miningmanagermodel.FeerateBucket {
+	return miningmanagermodel.FeerateBucket{
+		Feerate:          feerate,
+		EstimatedSeconds: fe.estimatedSeconds(feerate),
+	}
+}
+
+// estimatedSeconds returns the expected time until a transaction with the given feerate
+// is included in a block, given all the mass in the pool with a strictly higher feerate
+// is included before it
+func (fe *feeEstimator) estimatedSeconds(feerate float64) float64 {
+	massAhead := uint64(0)
+	for _, entry := range fe.entries {
+		if entry.feerate <= feerate {
+			break
+		}
+		massAhead += entry.mass
+	}
+	blocksAhead := math.Floor(float64(massAhead) / fe.massPerBlock)
+	return fe.blockInterval * (blocksAhead + 1)
+}
+
+// feerateForSeconds returns the lowest feerate that is still expected to be included
+// within the given amount of seconds. It never returns less than the minimum relay feerate
+func (fe *feeEstimator) feerateForSeconds(seconds float64) float64 {
+	allowedMassAhead := math.Max(math.Floor(seconds/fe.blockInterval), 1) * fe.massPerBlock
+	massAhead := uint64(0)
+	for _, entry := range fe.entries {
+		if entry.feerate <= fe.minimumFeerate {
+			break
+		}
+		if float64(massAhead+entry.mass) >= allowedMassAhead {
+			return entry.feerate
+		}
+		massAhead += entry.mass
+	}
+	return fe.minimumFeerate
+}
@@ -0,0 +1,73 @@
+package mempool
+
+import (
+	"testing"
+)
+
+func TestFeeEstimateEmptyPool(t *testing.T) {
+	estimator := &feeEstimator{
+		massPerBlock:   500_000,
+		blockInterval:  1,
+		minimumFeerate: 1,
+	}
+	estimate := estimator.estimate()
+
+	if estimate.PriorityBucket.Feerate != 1 || estimate.PriorityBucket.EstimatedSeconds != 1 {
+		t.Fatalf("unexpected priority bucket for an empty pool: %+v", estimate.PriorityBucket)
+	}
+	if len(estimate.NormalBuckets) != 1 || len(estimate.LowBuckets) != 1 {
+		t.Fatalf("expected exactly one normal and one low bucket for an empty pool, got %d and %d",
+			len(estimate.NormalBuckets), len(estimate.LowBuckets))
+	}
+	if estimate.NormalBuckets[0].Feerate != 1 || estimate.LowBuckets[0].Feerate != 1 {
+		t.Fatalf("expected all buckets to use the minimum feerate, got %+v", estimate)
+	}
+}
+
+func TestFeeEstimateFullPool(t *testing.T) {
+	const massPerBlock = 100_000
+
+	// 10,000 transactions of mass 1,000 take 100 blocks to clear. Feerates range from 10,000 down to 1.
+	entries := make([]feerateEntry, 10_000)
+	for i := range entries {
+		entries[i] = feerateEntry{
+			feerate: float64(len(entries) - i),
+			mass:    1_000,
+		}
+	}
+	estimator := &feeEstimator{
+		entries:        entries,
+		massPerBlock:   massPerBlock,
+		blockInterval:  1,
+		minimumFeerate: 1,
+	}
+	estimate := estimator.estimate()
+
+	// The first block fits 100 transactions, so paying the feerate of the 100th transaction
+	// gets a transaction into the next block
+	if estimate.PriorityBucket.Feerate != 9_901 {
+		t.Fatalf("unexpected priority feerate: %f", estimate.PriorityBucket.Feerate)
+	}
+	if estimate.PriorityBucket.EstimatedSeconds != 1 {
+		t.Fatalf("unexpected priority estimated seconds: %f", estimate.PriorityBucket.EstimatedSeconds)
+	}
+	if estimate.NormalBuckets[0].EstimatedSeconds > 60 {
+		t.Fatalf("expected the first normal bucket to be sub-minute, got %f", estimate.NormalBuckets[0].EstimatedSeconds)
+	}
+
+	previous := estimate.PriorityBucket
+	for _, bucket := range append(estimate.NormalBuckets, estimate.LowBuckets...) {
+		if bucket.Feerate > previous.Feerate {
+			t.Fatalf("feerates are expected to decrease, got %f after %f", bucket.Feerate, previous.Feerate)
+		}
+		if bucket.EstimatedSeconds < previous.EstimatedSeconds {
+			t.Fatalf("estimated seconds are expected to increase, got %f after %f",
+				bucket.EstimatedSeconds, previous.EstimatedSeconds)
+		}
+		previous = bucket
+	}
+
+	if previous.Feerate != 1 {
+		t.Fatalf("expected the last low bucket to use the minimum feerate, got %f", previous.Feerate)
+	}
+}
@@ -46,7 +46,7 @@ func (mp *mempool) handleNewBlockTransactions(blockTransactions []*externalapi.D
 		return nil, err
 	}
 
-	return acceptedOrphans, nil
+	return mp.transactionsPool.transactionsInPool(acceptedOrphans), nil
 }
 
 func (mp *mempool) removeDoubleSpends(transaction *externalapi.DomainTransaction) error {
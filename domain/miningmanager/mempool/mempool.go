@@ -44,8 +44,20 @@ func (mp *mempool) ValidateAndInsertTransaction(transaction *externalapi.DomainT
 
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
+	defer mp.updateMetrics()
 
-	return mp.validateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
+	acceptedTransactions, _, err = mp.validateAndInsertTransaction(transaction, isHighPriority, allowOrphan, rbfPolicyForbidden)
+	return acceptedTransactions, err
+}
+
+func (mp *mempool) ValidateAndInsertTransactionReplacement(transaction *externalapi.DomainTransaction, isHighPriority bool) (
+	acceptedTransactions []*externalapi.DomainTransaction, replacedTransaction *externalapi.DomainTransaction, err error) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	defer mp.updateMetrics()
+
+	return mp.validateAndInsertTransaction(transaction, isHighPriority, false, rbfPolicyMandatory)
 }
 
 func (mp *mempool) GetTransaction(transactionID *externalapi.DomainTransactionID,
@@ -117,6 +129,30 @@ func (mp *mempool) AllTransactions(includeTransactionPool bool, includeOrphanPoo
 	return transactionPoolTransactions, orphanPoolTransactions
 }
 
+// Snapshot returns all the transactions in the transaction pool and the orphan pool, together with the
+// IDs of the high-priority ones, all read under a single lock so that they're consistent with each other
+func (mp *mempool) Snapshot() (
+	transactionPoolTransactions []*externalapi.DomainTransaction,
+	orphanPoolTransactions []*externalapi.DomainTransaction,
+	highPriorityTransactionIDs []*externalapi.DomainTransactionID) {
+
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	transactionPoolTransactions = mp.transactionsPool.getAllTransactions()
+	orphanPoolTransactions = mp.orphansPool.getAllOrphanTransactions()
+	highPriorityTransactionIDs = make([]*externalapi.DomainTransactionID, 0, len(mp.transactionsPool.highPriorityTransactions))
+	for _, transaction := range mp.transactionsPool.highPriorityTransactions {
+		highPriorityTransactionIDs = append(highPriorityTransactionIDs, transaction.TransactionID())
+	}
+	for _, orphan := range mp.orphansPool.allOrphans {
+		if orphan.IsHighPriority() {
+			highPriorityTransactionIDs = append(highPriorityTransactionIDs, orphan.TransactionID())
+		}
+	}
+	return transactionPoolTransactions, orphanPoolTransactions, highPriorityTransactionIDs
+}
+
 func (mp *mempool) TransactionCount(includeTransactionPool bool, includeOrphanPool bool) int {
 	mp.mtx.RLock()
 	defer mp.mtx.RUnlock()
@@ -138,19 +174,26 @@ func (mp *mempool) HandleNewBlockTransactions(transactions []*externalapi.Domain
 
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
+	defer mp.updateMetrics()
 
 	return mp.handleNewBlockTransactions(transactions)
 }
 
-func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction {
+// BlockCandidateTransactions returns the transactions to be considered for the next block template.
+// The fee rate index is read from the top, and reading stops once the candidates would fill
+// blockCandidatesMassFactor blocks, so the cost of building a template doesn't grow with the size of the mempool.
+// Transactions with parents in the mempool aren't candidates, but their packages bring in their ancestors
+func (mp *mempool) BlockCandidateTransactions() []*miningmanagermodel.BlockCandidateTransaction {
 	mp.mtx.RLock()
 	defer mp.mtx.RUnlock()
 
-	readyTxs := mp.transactionsPool.allReadyTransactions()
-	var candidateTxs []*externalapi.DomainTransaction
-	var spamTx *externalapi.DomainTransaction
+	candidatesMassLimit := mp.config.MaximumMassPerBlock * blockCandidatesMassFactor
+	candidatesMass := uint64(0)
+	var candidateTxs []*miningmanagermodel.BlockCandidateTransaction
+	var spamTx *miningmanagermodel.BlockCandidateTransaction
 	var spamTxNewestUTXODaaScore uint64
-	for _, tx := range readyTxs {
+	mp.transactionsPool.readyTransactionsByFeeRate(func(tx *externalapi.DomainTransaction, feeRate float64) bool {
+		candidate := &miningmanagermodel.BlockCandidateTransaction{Transaction: tx, Feerate: feeRate}
 		if len(tx.Outputs) > len(tx.Inputs) {
 			hasCoinbaseInput := false
 			for _, input := range tx.Inputs {
@@ -163,11 +206,12 @@ func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction
 			numExtraOuts := len(tx.Outputs) - len(tx.Inputs)
 			if !hasCoinbaseInput && numExtraOuts > 2 && tx.Fee < uint64(numExtraOuts)*constants.SompiPerKaspa {
 				log.Debugf("Filtered spam tx %s", consensushashing.TransactionID(tx))
-				continue
+				return true
 			}
 
 			if hasCoinbaseInput || tx.Fee > uint64(numExtraOuts)*constants.SompiPerKaspa {
-				candidateTxs = append(candidateTxs, tx)
+				candidateTxs = append(candidateTxs, candidate)
+				candidatesMass += tx.Mass
 			} else {
 				txNewestUTXODaaScore := tx.Inputs[0].UTXOEntry.BlockDAAScore()
 				for _, input := range tx.Inputs {
@@ -178,21 +222,23 @@ func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction
 
 				if spamTx != nil {
 					if txNewestUTXODaaScore < spamTxNewestUTXODaaScore {
-						spamTx = tx
+						spamTx = candidate
 						spamTxNewestUTXODaaScore = txNewestUTXODaaScore
 					}
 				} else {
-					spamTx = tx
+					spamTx = candidate
 					spamTxNewestUTXODaaScore = txNewestUTXODaaScore
 				}
 			}
 		} else {
-			candidateTxs = append(candidateTxs, tx)
+			candidateTxs = append(candidateTxs, candidate)
+			candidatesMass += tx.Mass
 		}
-	}
+		return candidatesMass < candidatesMassLimit
+	})
 
 	if spamTx != nil {
-		log.Debugf("Adding spam tx candidate %s", consensushashing.TransactionID(spamTx))
+		log.Debugf("Adding spam tx candidate %s", consensushashing.TransactionID(spamTx.Transaction))
 		candidateTxs = append(candidateTxs, spamTx)
 	}
 
@@ -202,6 +248,7 @@ func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction
 func (mp *mempool) RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error) {
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
+	defer mp.updateMetrics()
 
 	return mp.revalidateHighPriorityTransactions()
 }
@@ -209,6 +256,7 @@ func (mp *mempool) RevalidateHighPriorityTransactions() (validTransactions []*ex
 func (mp *mempool) RemoveInvalidTransactions(err *ruleerrors.ErrInvalidTransactionsInNewBlock) error {
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
+	defer mp.updateMetrics()
 
 	for _, tx := range err.InvalidTransactions {
 		removeRedeemers := !errors.As(tx.Error, &ruleerrors.ErrMissingTxOut{})
@@ -224,6 +272,7 @@ func (mp *mempool) RemoveInvalidTransactions(err *ruleerrors.ErrInvalidTransacti
 func (mp *mempool) RemoveTransaction(transactionID *externalapi.DomainTransactionID, removeRedeemers bool) error {
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
+	defer mp.updateMetrics()
 
 	return mp.removeTransaction(transactionID, removeRedeemers)
 }
@@ -80,3 +80,22 @@ func (mpus *mempoolUTXOSet) checkDoubleSpends(transaction *externalapi.DomainTra
 
 	return nil
 }
+
+// getConflictingTransactions returns the distinct transactions in the mempool that spend
+// any of the outpoints spent by the given transaction
+func (mpus *mempoolUTXOSet) getConflictingTransactions(transaction *externalapi.DomainTransaction) []*model.MempoolTransaction {
+	conflictingTransactions := []*model.MempoolTransaction{}
+	seen := model.IDToTransactionMap{}
+	for _, input := range transaction.Inputs {
+		existingTransaction, exists := mpus.transactionByPreviousOutpoint[input.PreviousOutpoint]
+		if !exists {
+			continue
+		}
+		if _, ok := seen[*existingTransaction.TransactionID()]; ok {
+			continue
+		}
+		seen[*existingTransaction.TransactionID()] = existingTransaction
+		conflictingTransactions = append(conflictingTransactions, existingTransaction)
+	}
+	return conflictingTransactions
+}
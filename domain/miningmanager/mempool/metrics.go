@@ -0,0 +1,22 @@
+package mempool
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var (
+	transactionCountGauge = metrics.NewGauge("kaspad_mempool_transactions",
+		"Transactions in the mempool, excluding orphans")
+	transactionsMassGauge = metrics.NewGauge("kaspad_mempool_mass",
+		"Total mass of the transactions in the mempool, excluding orphans")
+	orphanCountGauge = metrics.NewGauge("kaspad_mempool_orphans",
+		"Orphan transactions in the mempool")
+)
+
+// updateMetrics sets the mempool gauges. It's called with the mempool
+// lock held, after every change to the pools
+func (mp *mempool) updateMetrics() {
+	transactionCountGauge.Set(float64(mp.transactionsPool.transactionCount()))
+	transactionsMassGauge.Set(float64(mp.transactionsPool.totalMass))
+	orphanCountGauge.Set(float64(mp.orphansPool.orphanTransactionCount()))
+}
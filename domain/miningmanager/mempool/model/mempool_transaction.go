@@ -11,6 +11,8 @@ type MempoolTransaction struct {
 	parentTransactionsInPool IDToTransactionMap
 	isHighPriority           bool
 	addedAtDAAScore          uint64
+	ancestorFee              uint64
+	ancestorMass             uint64
 }
 
 // NewMempoolTransaction constructs a new MempoolTransaction
@@ -25,6 +27,8 @@ func NewMempoolTransaction(
 		parentTransactionsInPool: parentTransactionsInPool,
 		isHighPriority:           isHighPriority,
 		addedAtDAAScore:          addedAtDAAScore,
+		ancestorFee:              transaction.Fee,
+		ancestorMass:             transaction.Mass,
 	}
 }
 
@@ -57,3 +61,27 @@ func (mt *MempoolTransaction) IsHighPriority() bool {
 func (mt *MempoolTransaction) AddedAtDAAScore() uint64 {
 	return mt.addedAtDAAScore
 }
+
+// AncestorFee returns the total fee of this MempoolTransaction and all its ancestors in the pool
+func (mt *MempoolTransaction) AncestorFee() uint64 {
+	return mt.ancestorFee
+}
+
+// AncestorMass returns the total mass of this MempoolTransaction and all its ancestors in the pool
+func (mt *MempoolTransaction) AncestorMass() uint64 {
+	return mt.ancestorMass
+}
+
+// SetAncestorTotals sets the total fee and mass of this MempoolTransaction and all its ancestors in the pool.
+// Note that this changes the transaction's place in TransactionsOrderedByFeeRate, so it must not be called
+// while the transaction is in one
+func (mt *MempoolTransaction) SetAncestorTotals(ancestorFee uint64, ancestorMass uint64) {
+	mt.ancestorFee = ancestorFee
+	mt.ancestorMass = ancestorMass
+}
+
+// PackageFeeRate returns the fee / mass rate of this MempoolTransaction together with all its ancestors
+// in the pool, as these must all be mined for this transaction to be mined
+func (mt *MempoolTransaction) PackageFeeRate() float64 {
+	return float64(mt.ancestorFee) / float64(mt.ancestorMass)
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/pkg/errors"
 )
 
-// TransactionsOrderedByFeeRate represents a set of MempoolTransactions ordered by their fee / mass rate
+// TransactionsOrderedByFeeRate represents a set of MempoolTransactions ordered by their package fee / mass rate,
+// lowest first. See MempoolTransaction.PackageFeeRate
 type TransactionsOrderedByFeeRate struct {
 	slice []*MempoolTransaction
 }
@@ -16,6 +17,11 @@ func (tobf *TransactionsOrderedByFeeRate) GetByIndex(index int) *MempoolTransact
 	return tobf.slice[index]
 }
 
+// Len returns the number of transactions in the set
+func (tobf *TransactionsOrderedByFeeRate) Len() int {
+	return len(tobf.slice)
+}
+
 // Push inserts a transaction into the set, placing it in the correct place to preserve order
 func (tobf *TransactionsOrderedByFeeRate) Push(transaction *MempoolTransaction) error {
 	index, _, err := tobf.findTransactionIndex(transaction)
@@ -68,11 +74,11 @@ func (tobf *TransactionsOrderedByFeeRate) findTransactionIndex(transaction *Memp
 			"populated fee and mass")
 	}
 	txID := transaction.TransactionID()
-	txFeeRate := float64(transaction.Transaction().Fee) / float64(transaction.Transaction().Mass)
+	txFeeRate := transaction.PackageFeeRate()
 
 	index = sort.Search(len(tobf.slice), func(i int) bool {
 		iElement := tobf.slice[i]
-		elementFeeRate := float64(iElement.Transaction().Fee) / float64(iElement.Transaction().Mass)
+		elementFeeRate := iElement.PackageFeeRate()
 		if elementFeeRate > txFeeRate {
 			return true
 		}
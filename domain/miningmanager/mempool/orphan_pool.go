@@ -216,15 +216,21 @@ func (op *orphansPool) unorphanTransaction(transaction *model.OrphanTransaction)
 	mempoolTransaction := model.NewMempoolTransaction(
 		transaction.Transaction(),
 		op.mempool.transactionsPool.getParentTransactionsInPool(transaction.Transaction()),
-		false,
+		transaction.IsHighPriority(),
 		virtualDAAScore,
 	)
+	mempoolTransaction.SetAncestorTotals(op.mempool.transactionsPool.calculateAncestorTotals(mempoolTransaction))
+	if op.mempool.transactionsPool.wouldBeEvicted(mempoolTransaction, nil) {
+		str := fmt.Sprintf("Orphan transaction %s would be evicted right away because the mempool is full and its "+
+			"package fee rate is too low", mempoolTransaction.TransactionID())
+		return transactionRuleError(RejectInsufficientFee, str)
+	}
 	err = op.mempool.transactionsPool.addMempoolTransaction(mempoolTransaction)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return op.mempool.transactionsPool.limitTransactionPoolSize()
 }
 
 func (op *orphansPool) removeOrphan(orphanTransactionID *externalapi.DomainTransactionID, removeRedeemers bool) error {
@@ -37,6 +37,12 @@ func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactio
 		if err != nil {
 			return err
 		}
+	} else {
+		// The removed transaction is no longer an ancestor of its redeemers, so their package fee rates change
+		err := mp.transactionsPool.updateAncestorTotals(redeemers)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,105 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+)
+
+// replaceByFeePolicy defines how a transaction double spending transactions already in the
+// mempool is handled
+type replaceByFeePolicy uint8
+
+const (
+	// rbfPolicyForbidden rejects any transaction that double spends a transaction in the mempool
+	rbfPolicyForbidden replaceByFeePolicy = iota
+
+	// rbfPolicyMandatory requires the transaction to double spend exactly one transaction in the
+	// mempool, to pay a strictly higher feerate than it, and to pay at least the total fee of it and
+	// all its redeemers plus the minimum relay fee for its own mass. The double spent transaction and
+	// all its redeemers are then evicted from the mempool
+	rbfPolicyMandatory
+)
+
+func (mp *mempool) validateReplaceByFeePreUTXOEntry(transaction *externalapi.DomainTransaction,
+	rbfPolicy replaceByFeePolicy) (conflictingTransaction *model.MempoolTransaction, err error) {
+
+	if rbfPolicy == rbfPolicyForbidden {
+		return nil, mp.mempoolUTXOSet.checkDoubleSpends(transaction)
+	}
+
+	transactionID := consensushashing.TransactionID(transaction)
+	conflictingTransactions := mp.mempoolUTXOSet.getConflictingTransactions(transaction)
+	if len(conflictingTransactions) == 0 {
+		str := fmt.Sprintf("transaction %s is not a replacement: it doesn't double spend any transaction "+
+			"in the mempool", transactionID)
+		return nil, transactionRuleError(RejectNoDoubleSpend, str)
+	}
+	if len(conflictingTransactions) > 1 {
+		str := fmt.Sprintf("transaction %s double spends %d transactions in the mempool, while a replacement "+
+			"may double spend only one", transactionID, len(conflictingTransactions))
+		return nil, transactionRuleError(RejectManyConflicts, str)
+	}
+
+	return conflictingTransactions[0], nil
+}
+
+// validateReplaceByFeeInContext checks that transaction may replace conflictingTransaction.
+// It expects the fee and mass of transaction to already be populated
+func (mp *mempool) validateReplaceByFeeInContext(transaction *externalapi.DomainTransaction,
+	conflictingTransaction *model.MempoolTransaction, parentsInPool model.IDToTransactionMap) error {
+
+	transactionID := consensushashing.TransactionID(transaction)
+
+	evictedTransactions := append([]*model.MempoolTransaction{conflictingTransaction},
+		mp.transactionsPool.getRedeemers(conflictingTransaction)...)
+	evictedFee := uint64(0)
+	for _, evictedTransaction := range evictedTransactions {
+		if _, ok := parentsInPool[*evictedTransaction.TransactionID()]; ok {
+			str := fmt.Sprintf("transaction %s spends an output of transaction %s, which it would evict "+
+				"from the mempool", transactionID, evictedTransaction.TransactionID())
+			return transactionRuleError(RejectInvalid, str)
+		}
+		evictedFee += evictedTransaction.Transaction().Fee
+	}
+
+	conflictingFeeRate := feeRate(conflictingTransaction.Transaction())
+	transactionFeeRate := feeRate(transaction)
+	if transactionFeeRate <= conflictingFeeRate {
+		str := fmt.Sprintf("transaction %s has a feerate of %f sompi/gram, which is not higher than "+
+			"the feerate of %f sompi/gram of the transaction %s it replaces", transactionID,
+			transactionFeeRate, conflictingFeeRate, conflictingTransaction.TransactionID())
+		return transactionRuleError(RejectInsufficientFee, str)
+	}
+
+	// The replacement pays for the whole package it evicts, so that evicting a large package with a
+	// small transaction of a slightly higher feerate isn't cheap, and for its own relay
+	requiredFee := evictedFee + mp.minimumRequiredTransactionRelayFee(transaction.Mass)
+	if transaction.Fee < requiredFee {
+		str := fmt.Sprintf("transaction %s pays a fee of %d sompi, while replacing transaction %s and its "+
+			"%d redeemers requires a fee of at least %d sompi", transactionID, transaction.Fee,
+			conflictingTransaction.TransactionID(), len(evictedTransactions)-1, requiredFee)
+		return transactionRuleError(RejectInsufficientFee, str)
+	}
+
+	return nil
+}
+
+// replaceTransaction evicts conflictingTransaction and all its redeemers from the mempool,
+// returning a copy of the evicted conflictingTransaction
+func (mp *mempool) replaceTransaction(conflictingTransaction *model.MempoolTransaction) (
+	*externalapi.DomainTransaction, error) {
+
+	log.Debugf("Replacing transaction %s and its redeemers", conflictingTransaction.TransactionID())
+	err := mp.removeTransaction(conflictingTransaction.TransactionID(), true)
+	if err != nil {
+		return nil, err
+	}
+	return conflictingTransaction.Transaction().Clone(), nil //this pointer leaves the mempool, hence we clone.
+}
+
+func feeRate(transaction *externalapi.DomainTransaction) float64 {
+	return float64(transaction.Fee) / float64(transaction.Mass)
+}
@@ -16,6 +16,7 @@ type transactionsPool struct {
 	highPriorityTransactions      model.IDToTransactionMap
 	chainedTransactionsByParentID model.IDToTransactionsSliceMap
 	transactionsOrderedByFeeRate  model.TransactionsOrderedByFeeRate
+	totalMass                     uint64
 	lastExpireScanDAAScore        uint64
 	lastExpireScanTime            time.Time
 }
@@ -27,30 +28,12 @@ func newTransactionsPool(mp *mempool) *transactionsPool {
 		highPriorityTransactions:      model.IDToTransactionMap{},
 		chainedTransactionsByParentID: model.IDToTransactionsSliceMap{},
 		transactionsOrderedByFeeRate:  model.TransactionsOrderedByFeeRate{},
+		totalMass:                     0,
 		lastExpireScanDAAScore:        0,
 		lastExpireScanTime:            time.Now(),
 	}
 }
 
-func (tp *transactionsPool) addTransaction(transaction *externalapi.DomainTransaction,
-	parentTransactionsInPool model.IDToTransactionMap, isHighPriority bool) (*model.MempoolTransaction, error) {
-
-	virtualDAAScore, err := tp.mempool.consensusReference.Consensus().GetVirtualDAAScore()
-	if err != nil {
-		return nil, err
-	}
-
-	mempoolTransaction := model.NewMempoolTransaction(
-		transaction, parentTransactionsInPool, isHighPriority, virtualDAAScore)
-
-	err = tp.addMempoolTransaction(mempoolTransaction)
-	if err != nil {
-		return nil, err
-	}
-
-	return mempoolTransaction, nil
-}
-
 func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTransaction) error {
 	tp.allTransactions[*transaction.TransactionID()] = transaction
 
@@ -65,10 +48,13 @@ func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTran
 
 	tp.mempool.mempoolUTXOSet.addTransaction(transaction)
 
+	ancestorFee, ancestorMass := tp.calculateAncestorTotals(transaction)
+	transaction.SetAncestorTotals(ancestorFee, ancestorMass)
 	err := tp.transactionsOrderedByFeeRate.Push(transaction)
 	if err != nil {
 		return err
 	}
+	tp.totalMass += transaction.Transaction().Mass
 
 	if transaction.IsHighPriority() {
 		tp.highPriorityTransactions[*transaction.TransactionID()] = transaction
@@ -78,7 +64,10 @@ func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTran
 }
 
 func (tp *transactionsPool) removeTransaction(transaction *model.MempoolTransaction) error {
-	delete(tp.allTransactions, *transaction.TransactionID())
+	if _, ok := tp.allTransactions[*transaction.TransactionID()]; ok {
+		delete(tp.allTransactions, *transaction.TransactionID())
+		tp.totalMass -= transaction.Transaction().Mass
+	}
 
 	err := tp.transactionsOrderedByFeeRate.Remove(transaction)
 	if err != nil {
@@ -131,16 +120,73 @@ func (tp *transactionsPool) expireOldTransactions() error {
 	return nil
 }
 
-func (tp *transactionsPool) allReadyTransactions() []*externalapi.DomainTransaction {
-	result := []*externalapi.DomainTransaction{}
+// transactionsInPool returns the transactions out of transactions that are still in the pool.
+// Accepting a transaction may evict others that were accepted right before it
+func (tp *transactionsPool) transactionsInPool(
+	transactions []*externalapi.DomainTransaction) []*externalapi.DomainTransaction {
 
-	for _, mempoolTransaction := range tp.allTransactions {
+	transactionsInPool := make([]*externalapi.DomainTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if _, ok := tp.allTransactions[*consensushashing.TransactionID(transaction)]; ok {
+			transactionsInPool = append(transactionsInPool, transaction)
+		}
+	}
+	return transactionsInPool
+}
+
+// readyTransactionsByFeeRate iterates the packages in the pool, from the highest package fee rate to the
+// lowest, calling shouldContinue with the transactions of each package that have no parents in the pool,
+// together with the package fee rate, until it returns false.
+// A transaction can't be mined in the same block as its parents, so the package of a transaction with
+// parents in the pool brings in its ready ancestors, which is how a child pays for its parents. Every
+// transaction is passed once, with the fee rate of the first package that brought it in.
+// Transactions passed to shouldContinue are clones, since they leave the mempool
+func (tp *transactionsPool) readyTransactionsByFeeRate(
+	shouldContinue func(transaction *externalapi.DomainTransaction, feeRate float64) bool) {
+
+	passedTransactionIDs := make(map[externalapi.DomainTransactionID]struct{})
+	pass := func(mempoolTransaction *model.MempoolTransaction, feeRate float64) bool {
+		if _, ok := passedTransactionIDs[*mempoolTransaction.TransactionID()]; ok {
+			return true
+		}
+		passedTransactionIDs[*mempoolTransaction.TransactionID()] = struct{}{}
+		//this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
+		return shouldContinue(mempoolTransaction.Transaction().Clone(), feeRate)
+	}
+
+	for i := tp.transactionsOrderedByFeeRate.Len() - 1; i >= 0; i-- {
+		mempoolTransaction := tp.transactionsOrderedByFeeRate.GetByIndex(i)
+		feeRate := mempoolTransaction.PackageFeeRate()
 		if len(mempoolTransaction.ParentTransactionsInPool()) == 0 {
-			result = append(result, mempoolTransaction.Transaction().Clone()) //this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
+			if !pass(mempoolTransaction, feeRate) {
+				return
+			}
+			continue
+		}
+		for _, ancestor := range tp.getAncestors(mempoolTransaction) {
+			if len(ancestor.ParentTransactionsInPool()) != 0 {
+				continue
+			}
+			if !pass(ancestor, feeRate) {
+				return
+			}
 		}
 	}
+}
 
-	return result
+// feerateEntries returns the package feerate and mass of every transaction in the pool, ordered
+// by package feerate from highest to lowest
+func (tp *transactionsPool) feerateEntries() []feerateEntry {
+	length := tp.transactionsOrderedByFeeRate.Len()
+	entries := make([]feerateEntry, 0, length)
+	for i := length - 1; i >= 0; i-- {
+		transaction := tp.transactionsOrderedByFeeRate.GetByIndex(i)
+		entries = append(entries, feerateEntry{
+			feerate: transaction.PackageFeeRate(),
+			mass:    transaction.Transaction().Mass,
+		})
+	}
+	return entries
 }
 
 func (tp *transactionsPool) getParentTransactionsInPool(
@@ -160,12 +206,18 @@ func (tp *transactionsPool) getParentTransactionsInPool(
 func (tp *transactionsPool) getRedeemers(transaction *model.MempoolTransaction) []*model.MempoolTransaction {
 	stack := []*model.MempoolTransaction{transaction}
 	redeemers := []*model.MempoolTransaction{}
+	visited := map[externalapi.DomainTransactionID]struct{}{*transaction.TransactionID(): {}}
 	for len(stack) > 0 {
 		var current *model.MempoolTransaction
 		last := len(stack) - 1
 		current, stack = stack[last], stack[:last]
 
 		for _, redeemerTransaction := range tp.chainedTransactionsByParentID[*current.TransactionID()] {
+			// A redeemer spending several transactions in the chain is reachable through each of them
+			if _, ok := visited[*redeemerTransaction.TransactionID()]; ok {
+				continue
+			}
+			visited[*redeemerTransaction.TransactionID()] = struct{}{}
 			stack = append(stack, redeemerTransaction)
 			redeemers = append(redeemers, redeemerTransaction)
 		}
@@ -173,38 +225,156 @@ func (tp *transactionsPool) getRedeemers(transaction *model.MempoolTransaction)
 	return redeemers
 }
 
-func (tp *transactionsPool) limitTransactionCount() error {
-	currentIndex := 0
+// calculateAncestorTotals returns the total fee and mass of the given transaction together
+// with all its ancestors in the pool
+func (tp *transactionsPool) calculateAncestorTotals(transaction *model.MempoolTransaction) (
+	ancestorFee uint64, ancestorMass uint64) {
+
+	ancestorFee = transaction.Transaction().Fee
+	ancestorMass = transaction.Transaction().Mass
+	for _, ancestor := range tp.getAncestors(transaction) {
+		ancestorFee += ancestor.Transaction().Fee
+		ancestorMass += ancestor.Transaction().Mass
+	}
+	return ancestorFee, ancestorMass
+}
+
+// getAncestors returns all the ancestors of the given transaction in the pool
+func (tp *transactionsPool) getAncestors(transaction *model.MempoolTransaction) model.IDToTransactionMap {
+	ancestors := model.IDToTransactionMap{}
+	stack := []*model.MempoolTransaction{transaction}
+	for len(stack) > 0 {
+		var current *model.MempoolTransaction
+		last := len(stack) - 1
+		current, stack = stack[last], stack[:last]
 
-	for uint64(len(tp.allTransactions)) > tp.mempool.config.MaximumTransactionCount {
-		var transactionToRemove *model.MempoolTransaction
-		for {
-			transactionToRemove = tp.transactionsOrderedByFeeRate.GetByIndex(currentIndex)
-			if !transactionToRemove.IsHighPriority() {
-				break
+		for parentID, parent := range current.ParentTransactionsInPool() {
+			if _, ok := ancestors[parentID]; ok {
+				continue
 			}
+			ancestors[parentID] = parent
+			stack = append(stack, parent)
+		}
+	}
+	return ancestors
+}
+
+// updateAncestorTotals recalculates the ancestor totals of the given transactions, and
+// moves them to their new place in tp.transactionsOrderedByFeeRate
+func (tp *transactionsPool) updateAncestorTotals(transactions []*model.MempoolTransaction) error {
+	for _, transaction := range transactions {
+		err := tp.transactionsOrderedByFeeRate.Remove(transaction)
+		if err != nil {
+			return err
+		}
+		ancestorFee, ancestorMass := tp.calculateAncestorTotals(transaction)
+		transaction.SetAncestorTotals(ancestorFee, ancestorMass)
+		err = tp.transactionsOrderedByFeeRate.Push(transaction)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitTransactionPoolSize evicts the transactions with the lowest package fee rate, along with
+// their redeemers, until both the transaction count and the total mass of the pool are within
+// their configured limits. High priority transactions are never evicted.
+func (tp *transactionsPool) limitTransactionPoolSize() error {
+	currentIndex := 0
+
+	for tp.isOverLimits() {
+		if currentIndex >= tp.transactionsOrderedByFeeRate.Len() {
+			log.Warnf("High-priority transactions in mempool (count: %d, mass: %d) exceed the maximum "+
+				"allowed (count: %d, mass: %d)", len(tp.allTransactions), tp.totalMass,
+				tp.mempool.config.MaximumTransactionCount, tp.mempool.config.MaximumTransactionsMass)
+			return nil
+		}
+		transactionToRemove := tp.transactionsOrderedByFeeRate.GetByIndex(currentIndex)
+		if transactionToRemove.IsHighPriority() {
 			currentIndex++
-			if currentIndex >= len(tp.allTransactions) {
-				log.Warnf(
-					"Number of high-priority transactions in mempool (%d) is higher than maximum allowed (%d)",
-					len(tp.allTransactions), tp.mempool.config.MaximumTransactionCount)
-				return nil
-			}
+			continue
 		}
 
-		log.Debugf("Removing transaction %s, because mempoolTransaction count (%d) exceeded the limit (%d)",
-			transactionToRemove.TransactionID(), len(tp.allTransactions), tp.mempool.config.MaximumTransactionCount)
+		log.Debugf("Removing transaction %s with package fee rate %f, because the mempool (count: %d, mass: %d) "+
+			"exceeded its limits (count: %d, mass: %d)", transactionToRemove.TransactionID(),
+			transactionToRemove.PackageFeeRate(), len(tp.allTransactions), tp.totalMass,
+			tp.mempool.config.MaximumTransactionCount, tp.mempool.config.MaximumTransactionsMass)
 		err := tp.mempool.removeTransaction(transactionToRemove.TransactionID(), true)
 		if err != nil {
 			return err
 		}
-		if currentIndex >= len(tp.allTransactions) {
-			break
-		}
 	}
 	return nil
 }
 
+// wouldBeEvicted returns whether limitTransactionPoolSize would evict transaction, which
+// isn't in the pool yet, if it were added to the pool after the transactions in removed were
+// removed from it. It follows the eviction order of limitTransactionPoolSize without changing
+// the pool, and expects the ancestor totals of transaction to be set
+func (tp *transactionsPool) wouldBeEvicted(transaction *model.MempoolTransaction,
+	removed []*model.MempoolTransaction) bool {
+
+	if transaction.IsHighPriority() {
+		return false
+	}
+
+	evicted := model.IDToTransactionMap{}
+	transactionCount := uint64(len(tp.allTransactions)) + 1
+	totalMass := tp.totalMass + transaction.Transaction().Mass
+	evict := func(evictedTransaction *model.MempoolTransaction) {
+		for _, current := range append([]*model.MempoolTransaction{evictedTransaction},
+			tp.getRedeemers(evictedTransaction)...) {
+
+			if _, ok := evicted[*current.TransactionID()]; ok {
+				continue
+			}
+			evicted[*current.TransactionID()] = current
+			transactionCount--
+			totalMass -= current.Transaction().Mass
+		}
+	}
+	for _, removedTransaction := range removed {
+		evict(removedTransaction)
+	}
+
+	ancestors := tp.getAncestors(transaction)
+	for i := 0; transactionCount > tp.mempool.config.MaximumTransactionCount ||
+		totalMass > tp.mempool.config.MaximumTransactionsMass; i++ {
+
+		if i >= tp.transactionsOrderedByFeeRate.Len() ||
+			isOrderedBefore(transaction, tp.transactionsOrderedByFeeRate.GetByIndex(i)) {
+			// transaction is the lowest transaction left that may be evicted
+			return true
+		}
+
+		candidate := tp.transactionsOrderedByFeeRate.GetByIndex(i)
+		if _, ok := evicted[*candidate.TransactionID()]; ok || candidate.IsHighPriority() {
+			continue
+		}
+		// Evicting an ancestor of transaction evicts transaction as one of its redeemers
+		if _, ok := ancestors[*candidate.TransactionID()]; ok {
+			return true
+		}
+		evict(candidate)
+	}
+	return false
+}
+
+// isOrderedBefore returns whether transaction is placed before other in
+// transactionsOrderedByFeeRate
+func isOrderedBefore(transaction *model.MempoolTransaction, other *model.MempoolTransaction) bool {
+	if transaction.PackageFeeRate() != other.PackageFeeRate() {
+		return transaction.PackageFeeRate() < other.PackageFeeRate()
+	}
+	return transaction.TransactionID().LessOrEqual(other.TransactionID())
+}
+
+func (tp *transactionsPool) isOverLimits() bool {
+	return uint64(len(tp.allTransactions)) > tp.mempool.config.MaximumTransactionCount ||
+		tp.totalMass > tp.mempool.config.MaximumTransactionsMass
+}
+
 func (tp *transactionsPool) getTransaction(transactionID *externalapi.DomainTransactionID, clone bool) (*externalapi.DomainTransaction, bool) {
 	if mempoolTransaction, ok := tp.allTransactions[*transactionID]; ok {
 		if clone {
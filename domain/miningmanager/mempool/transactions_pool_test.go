@@ -0,0 +1,121 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/domain/consensusreference"
+)
+
+// TestDiamondRemoval verifies that a redeemer reachable through several of its ancestors is removed
+// only once, so that the total mass of the pool stays consistent
+func TestDiamondRemoval(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestDiamondRemoval")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+
+		for _, replace := range []bool{false, true} {
+			mp := New(DefaultConfig(&consensusConfig.Params), consensusReference).(*mempool)
+
+			// root is spent by both left and right, which are both spent by bottom
+			root, left, right, bottom := createDiamond(t)
+			for _, transaction := range []*externalapi.DomainTransaction{root, left, right, bottom} {
+				_, err = mp.ValidateAndInsertTransaction(transaction, false, false)
+				if err != nil {
+					t.Fatalf("ValidateAndInsertTransaction: %+v", err)
+				}
+			}
+			expectedMass := root.Mass + left.Mass + right.Mass + bottom.Mass
+			if mp.transactionsPool.totalMass != expectedMass {
+				t.Fatalf("Expected a total mass of %d, got %d", expectedMass, mp.transactionsPool.totalMass)
+			}
+
+			expectedMass = 0
+			if replace {
+				replacement := root.Clone()
+				replacement.ID = nil
+				replacement.Fee = 0
+				replacement.Mass = 0
+				// The replacement pays for the whole diamond it evicts
+				replacement.Outputs[0].Value -= 4_000_000
+				_, _, err = mp.ValidateAndInsertTransactionReplacement(replacement, false)
+				if err != nil {
+					t.Fatalf("ValidateAndInsertTransactionReplacement: %+v", err)
+				}
+				expectedMass = replacement.Mass
+			} else {
+				err = mp.RemoveTransaction(consensushashing.TransactionID(root), true)
+				if err != nil {
+					t.Fatalf("RemoveTransaction: %+v", err)
+				}
+			}
+
+			if len(mp.transactionsPool.allTransactions) != mp.transactionsPool.transactionsOrderedByFeeRate.Len() {
+				t.Fatalf("The pool and its fee rate index are out of sync")
+			}
+			if mp.transactionsPool.totalMass != expectedMass {
+				t.Fatalf("Expected a total mass of %d after removing the diamond, got %d",
+					expectedMass, mp.transactionsPool.totalMass)
+			}
+		}
+	})
+}
+
+func createDiamond(t *testing.T) (root, left, right, bottom *externalapi.DomainTransaction) {
+	scriptPublicKey, redeemScript := testutils.OpTrueScript()
+	signatureScript, err := txscript.PayToScriptHashSignatureScript(redeemScript, nil)
+	if err != nil {
+		t.Fatalf("PayToScriptHashSignatureScript: %v", err)
+	}
+
+	createTransaction := func(inputs []*externalapi.DomainTransactionInput, outputValues ...uint64) *externalapi.DomainTransaction {
+		outputs := make([]*externalapi.DomainTransactionOutput, len(outputValues))
+		for i, value := range outputValues {
+			outputs[i] = &externalapi.DomainTransactionOutput{Value: value, ScriptPublicKey: scriptPublicKey}
+		}
+		return &externalapi.DomainTransaction{
+			Version:      constants.MaxTransactionVersion,
+			Inputs:       inputs,
+			Outputs:      outputs,
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+			Payload:      []byte{},
+		}
+	}
+	createInput := func(transaction *externalapi.DomainTransaction, index uint32) *externalapi.DomainTransactionInput {
+		return &externalapi.DomainTransactionInput{
+			PreviousOutpoint: externalapi.DomainOutpoint{
+				TransactionID: *consensushashing.TransactionID(transaction),
+				Index:         index,
+			},
+			SignatureScript: signatureScript,
+			Sequence:        constants.MaxTxInSequenceNum,
+		}
+	}
+
+	rootInput := &externalapi.DomainTransactionInput{
+		PreviousOutpoint: externalapi.DomainOutpoint{TransactionID: externalapi.DomainTransactionID{}, Index: 0},
+		SignatureScript:  signatureScript,
+		Sequence:         constants.MaxTxInSequenceNum,
+		UTXOEntry:        utxo.NewUTXOEntry(100_000_000, scriptPublicKey, false, 0),
+	}
+	root = createTransaction([]*externalapi.DomainTransactionInput{rootInput}, 49_000_000, 49_000_000)
+	left = createTransaction([]*externalapi.DomainTransactionInput{createInput(root, 0)}, 48_000_000)
+	right = createTransaction([]*externalapi.DomainTransactionInput{createInput(root, 1)}, 48_000_000)
+	bottom = createTransaction([]*externalapi.DomainTransactionInput{createInput(left, 0), createInput(right, 0)}, 95_000_000)
+	return root, left, right, bottom
+}
@@ -6,10 +6,12 @@ import (
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
 )
 
 func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool,
-	allowOrphan bool) (acceptedTransactions []*externalapi.DomainTransaction, err error) {
+	allowOrphan bool, rbfPolicy replaceByFeePolicy) (
+	acceptedTransactions []*externalapi.DomainTransaction, replacedTransaction *externalapi.DomainTransaction, err error) {
 
 	onEnd := logger.LogAndMeasureExecutionTime(log,
 		fmt.Sprintf("validateAndInsertTransaction %s", consensushashing.TransactionID(transaction)))
@@ -18,47 +20,79 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 	// Populate mass in the beginning, it will be used in multiple places throughout the validation and insertion.
 	mp.consensusReference.Consensus().PopulateMass(transaction)
 
-	err = mp.validateTransactionPreUTXOEntry(transaction)
+	conflictingTransaction, err := mp.validateTransactionPreUTXOEntry(transaction, rbfPolicy)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	parentsInPool, missingOutpoints, err := mp.fillInputsAndGetMissingParents(transaction)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(missingOutpoints) > 0 {
 		if !allowOrphan {
 			str := fmt.Sprintf("Transaction %s is an orphan, where allowOrphan = false",
 				consensushashing.TransactionID(transaction))
-			return nil, transactionRuleError(RejectBadOrphan, str)
+			return nil, nil, transactionRuleError(RejectBadOrphan, str)
 		}
 
-		return nil, mp.orphansPool.maybeAddOrphan(transaction, isHighPriority)
+		return nil, nil, mp.orphansPool.maybeAddOrphan(transaction, isHighPriority)
 	}
 
 	err = mp.validateTransactionInContext(transaction)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	mempoolTransaction, err := mp.transactionsPool.addTransaction(transaction, parentsInPool, isHighPriority)
+	virtualDAAScore, err := mp.consensusReference.Consensus().GetVirtualDAAScore()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	mempoolTransaction := model.NewMempoolTransaction(transaction, parentsInPool, isHighPriority, virtualDAAScore)
+	mempoolTransaction.SetAncestorTotals(mp.transactionsPool.calculateAncestorTotals(mempoolTransaction))
 
-	acceptedOrphans, err := mp.orphansPool.processOrphansAfterAcceptedTransaction(mempoolTransaction.Transaction())
-	if err != nil {
-		return nil, err
+	// Everything that may reject the transaction is checked before the transaction it replaces
+	// is removed, so that a rejected replacement leaves the mempool as it was
+	var replacedTransactions []*model.MempoolTransaction
+	if conflictingTransaction != nil {
+		err = mp.validateReplaceByFeeInContext(transaction, conflictingTransaction, parentsInPool)
+		if err != nil {
+			return nil, nil, err
+		}
+		replacedTransactions = append([]*model.MempoolTransaction{conflictingTransaction},
+			mp.transactionsPool.getRedeemers(conflictingTransaction)...)
+	}
+	if mp.transactionsPool.wouldBeEvicted(mempoolTransaction, replacedTransactions) {
+		str := fmt.Sprintf("Transaction %s would be evicted right away because the mempool is full and its "+
+			"package fee rate is too low", mempoolTransaction.TransactionID())
+		return nil, nil, transactionRuleError(RejectInsufficientFee, str)
 	}
 
-	acceptedTransactions = append([]*externalapi.DomainTransaction{transaction.Clone()}, acceptedOrphans...) //these pointer leave the mempool, hence we clone.
+	if conflictingTransaction != nil {
+		replacedTransaction, err = mp.replaceTransaction(conflictingTransaction)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-	err = mp.transactionsPool.limitTransactionCount()
+	err = mp.transactionsPool.addMempoolTransaction(mempoolTransaction)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	// The transaction itself isn't evicted, as checked above
+	err = mp.transactionsPool.limitTransactionPoolSize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acceptedOrphans, err := mp.orphansPool.processOrphansAfterAcceptedTransaction(mempoolTransaction.Transaction())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acceptedTransactions = append([]*externalapi.DomainTransaction{transaction.Clone()}, acceptedOrphans...) //these pointer leave the mempool, hence we clone.
 
-	return acceptedTransactions, nil
+	// An orphan accepted into a full mempool may evict transactions accepted before it
+	return mp.transactionsPool.transactionsInPool(acceptedTransactions), replacedTransaction, nil
 }
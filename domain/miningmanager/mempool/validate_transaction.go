@@ -6,18 +6,18 @@ import (
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
 )
 
-func (mp *mempool) validateTransactionPreUTXOEntry(transaction *externalapi.DomainTransaction) error {
-	err := mp.validateTransactionInIsolation(transaction)
+func (mp *mempool) validateTransactionPreUTXOEntry(transaction *externalapi.DomainTransaction,
+	rbfPolicy replaceByFeePolicy) (conflictingTransaction *model.MempoolTransaction, err error) {
+
+	err = mp.validateTransactionInIsolation(transaction)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := mp.mempoolUTXOSet.checkDoubleSpends(transaction); err != nil {
-		return err
-	}
-	return nil
+	return mp.validateReplaceByFeePreUTXOEntry(transaction, rbfPolicy)
 }
 
 func (mp *mempool) validateTransactionInIsolation(transaction *externalapi.DomainTransaction) error {
@@ -29,10 +29,17 @@ type MiningManager interface {
 		transactionPoolTransactions []*externalapi.DomainTransaction,
 		orphanPoolTransactions []*externalapi.DomainTransaction)
 	TransactionCount(includeTransactionPool bool, includeOrphanPool bool) int
+	Snapshot() (
+		transactionPoolTransactions []*externalapi.DomainTransaction,
+		orphanPoolTransactions []*externalapi.DomainTransaction,
+		highPriorityTransactionIDs []*externalapi.DomainTransactionID)
 	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error)
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
+	ValidateAndInsertTransactionReplacement(transaction *externalapi.DomainTransaction, isHighPriority bool) (
+		acceptedTransactions []*externalapi.DomainTransaction, replacedTransaction *externalapi.DomainTransaction, err error)
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
+	FeeEstimate() *miningmanagermodel.FeeEstimate
 }
 
 type miningManager struct {
@@ -117,6 +124,16 @@ func (mm *miningManager) ValidateAndInsertTransaction(transaction *externalapi.D
 	return mm.mempool.ValidateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
 }
 
+// ValidateAndInsertTransactionReplacement validates the given transaction, and adds it to the
+// set of known transactions that have not yet been added to any block, replacing the single
+// transaction in the mempool it double spends. The replaced transaction is returned
+func (mm *miningManager) ValidateAndInsertTransactionReplacement(transaction *externalapi.DomainTransaction,
+	isHighPriority bool) (acceptedTransactions []*externalapi.DomainTransaction,
+	replacedTransaction *externalapi.DomainTransaction, err error) {
+
+	return mm.mempool.ValidateAndInsertTransactionReplacement(transaction, isHighPriority)
+}
+
 func (mm *miningManager) GetTransaction(
 	transactionID *externalapi.DomainTransactionID,
 	includeTransactionPool bool,
@@ -149,8 +166,21 @@ func (mm *miningManager) TransactionCount(includeTransactionPool bool, includeOr
 	return mm.mempool.TransactionCount(includeTransactionPool, includeOrphanPool)
 }
 
+func (mm *miningManager) Snapshot() (
+	transactionPoolTransactions []*externalapi.DomainTransaction,
+	orphanPoolTransactions []*externalapi.DomainTransaction,
+	highPriorityTransactionIDs []*externalapi.DomainTransactionID) {
+
+	return mm.mempool.Snapshot()
+}
+
 func (mm *miningManager) RevalidateHighPriorityTransactions() (
 	validTransactions []*externalapi.DomainTransaction, err error) {
 
 	return mm.mempool.RevalidateHighPriorityTransactions()
 }
+
+// FeeEstimate returns feerate estimations derived from the current transaction pool
+func (mm *miningManager) FeeEstimate() *miningmanagermodel.FeeEstimate {
+	return mm.mempool.FeeEstimate()
+}
@@ -79,6 +79,65 @@ func TestValidateAndInsertTransaction(t *testing.T) {
 	})
 }
 
+// TestMempoolMassLimit verifies that once the total mass of the mempool exceeds its limit, the transactions
+// with the lowest fee rate are evicted, and that a transaction that would be evicted right away is rejected.
+func TestMempoolMassLimit(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestMempoolMassLimit")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+
+		const transactionCount = 10
+		const transactionsToKeep = 5
+		transactions := make([]*externalapi.DomainTransaction, transactionCount)
+		for i := range transactions {
+			transactions[i] = createTransactionWithUTXOEntry(t, i, 0)
+			// The higher the index, the lower the fee
+			transactions[i].Outputs[0].Value += uint64(i) * 1000
+		}
+
+		// Insert from the lowest fee to the highest, so that every insertion beyond the limit evicts
+		// the lowest fee transaction in the mempool
+		for i := transactionCount - 1; i >= 0; i-- {
+			_, err = miningManager.ValidateAndInsertTransaction(transactions[i], false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+			if i == transactionCount-1 {
+				mempoolConfig.MaximumTransactionsMass = transactions[i].Mass * transactionsToKeep
+			}
+		}
+
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		if len(transactionsFromMempool) != transactionsToKeep {
+			t.Fatalf("Expected %d transactions in the mempool, got %d", transactionsToKeep, len(transactionsFromMempool))
+		}
+		for i := 0; i < transactionsToKeep; i++ {
+			if !contains(transactions[i], transactionsFromMempool) {
+				t.Fatalf("Missing high fee transaction %s in the mempool", consensushashing.TransactionID(transactions[i]))
+			}
+		}
+
+		lowFeeTransaction := createTransactionWithUTXOEntry(t, transactionCount, 0)
+		lowFeeTransaction.Outputs[0].Value += transactionCount * 1000
+		_, err = miningManager.ValidateAndInsertTransaction(lowFeeTransaction, false, true)
+		if err == nil || !strings.Contains(err.Error(), "mempool is full") {
+			t.Fatalf("ValidateAndInsertTransaction: expected the low fee transaction to be rejected, got: %v", err)
+		}
+	})
+}
+
 func TestImmatureSpend(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
 		factory := consensus.NewFactory()
@@ -172,6 +231,371 @@ func TestDoubleSpendInMempool(t *testing.T) {
 	})
 }
 
+// TestTransactionReplacement verifies that a transaction double-spending another transaction already
+// in the mempool replaces it only if it pays a higher feerate.
+func TestTransactionReplacement(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestTransactionReplacement")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		transaction, err := createChildAndParentTxsAndAddParentToConsensus(tc)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+
+		_, _, err = miningManager.ValidateAndInsertTransactionReplacement(transaction.Clone(), false)
+		if err == nil || !strings.Contains(err.Error(), "is not a replacement") {
+			t.Fatalf("ValidateAndInsertTransactionReplacement: expected a no double spend error, got: %v", err)
+		}
+
+		_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+
+		createReplacement := func(extraFee uint64) *externalapi.DomainTransaction {
+			replacement := transaction.Clone()
+			replacement.ID = nil
+			replacement.Fee = 0
+			replacement.Mass = 0
+			replacement.Outputs[0].Value -= extraFee
+			return replacement
+		}
+
+		_, _, err = miningManager.ValidateAndInsertTransactionReplacement(createReplacement(2), false)
+		if err == nil || !strings.Contains(err.Error(), "requires a fee of at least") {
+			t.Fatalf("ValidateAndInsertTransactionReplacement: expected an insufficient fee error, got: %v", err)
+		}
+
+		replacement := createReplacement(10_000)
+		_, replacedTransaction, err := miningManager.ValidateAndInsertTransactionReplacement(replacement, false)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransactionReplacement: %v", err)
+		}
+		if !consensushashing.TransactionID(replacedTransaction).Equal(consensushashing.TransactionID(transaction)) {
+			t.Fatalf("Expected the replaced transaction to be %s, got %s",
+				consensushashing.TransactionID(transaction), consensushashing.TransactionID(replacedTransaction))
+		}
+		if _, _, found := miningManager.GetTransaction(consensushashing.TransactionID(transaction), true, true); found {
+			t.Fatalf("Expected the replaced transaction to be removed from the mempool")
+		}
+		if _, _, found := miningManager.GetTransaction(consensushashing.TransactionID(replacement), true, false); !found {
+			t.Fatalf("Expected the replacement to be in the mempool")
+		}
+
+		_, _, err = miningManager.ValidateAndInsertTransactionReplacement(createReplacement(1), false)
+		if err == nil || !strings.Contains(err.Error(), "which is not higher than") {
+			t.Fatalf("ValidateAndInsertTransactionReplacement: expected an insufficient fee error, got: %v", err)
+		}
+	})
+}
+
+// TestPackageTransactionReplacement verifies that replacing a transaction requires paying at least
+// the total fee of the transaction and all the redeemers it evicts
+func TestPackageTransactionReplacement(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestPackageTransactionReplacement")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		parentTransaction, err := createChildAndParentTxsAndAddParentToConsensus(tc)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		const childFee = 1_000_000
+		childTransaction, err := testutils.CreateTransaction(parentTransaction, childFee)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, childTransaction} {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		createReplacement := func(extraFee uint64) *externalapi.DomainTransaction {
+			replacement := parentTransaction.Clone()
+			replacement.ID = nil
+			replacement.Fee = 0
+			replacement.Mass = 0
+			replacement.Outputs[0].Value -= extraFee
+			return replacement
+		}
+
+		// The replacement pays a much higher feerate than the parent, but less than the parent and its
+		// child together
+		lowFeeReplacement := createReplacement(childFee / 2)
+		_, _, err = miningManager.ValidateAndInsertTransactionReplacement(lowFeeReplacement, false)
+		if err == nil || !strings.Contains(err.Error(), "requires a fee of at least") {
+			t.Fatalf("ValidateAndInsertTransactionReplacement: expected an insufficient fee error, got: %v", err)
+		}
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, childTransaction} {
+			if _, _, found := miningManager.GetTransaction(consensushashing.TransactionID(transaction), true, false); !found {
+				t.Fatalf("Expected transaction %s to remain in the mempool", consensushashing.TransactionID(transaction))
+			}
+		}
+
+		replacement := createReplacement(childFee + 10_000)
+		_, _, err = miningManager.ValidateAndInsertTransactionReplacement(replacement, false)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransactionReplacement: %v", err)
+		}
+		for _, transaction := range []*externalapi.DomainTransaction{parentTransaction, childTransaction} {
+			if _, _, found := miningManager.GetTransaction(consensushashing.TransactionID(transaction), true, true); found {
+				t.Fatalf("Expected transaction %s to be evicted from the mempool", consensushashing.TransactionID(transaction))
+			}
+		}
+		if _, _, found := miningManager.GetTransaction(consensushashing.TransactionID(replacement), true, false); !found {
+			t.Fatalf("Expected the replacement to be in the mempool")
+		}
+	})
+}
+
+// TestEvictedTransactionReplacement verifies that a replacement that would be evicted right away
+// is rejected without removing the transaction it replaces
+func TestEvictedTransactionReplacement(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestEvictedTransactionReplacement")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+
+		const lowFee = 10_000
+		lowFeeTransaction := createTransactionWithUTXOEntry(t, 0, 0)
+		lowFeeTransaction.Outputs[0].Value = lowFeeTransaction.Inputs[0].UTXOEntry.Amount() - lowFee
+		highFeeTransactions := []*externalapi.DomainTransaction{
+			createTransactionWithUTXOEntry(t, 1, 0),
+			createTransactionWithUTXOEntry(t, 2, 0),
+		}
+		totalMass := uint64(0)
+		for _, transaction := range append([]*externalapi.DomainTransaction{lowFeeTransaction}, highFeeTransactions...) {
+			// Let the mempool populate the actual fee and mass
+			transaction.Fee = 0
+			transaction.Mass = 0
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+			totalMass += transaction.Mass
+		}
+		mempoolConfig.MaximumTransactionsMass = totalMass
+
+		// The replacement pays a higher fee rate than the transaction it replaces, but it's the lowest
+		// in the mempool, and its extra output puts the mempool over its mass limit
+		const extraOutputValue = 100_000
+		replacement := lowFeeTransaction.Clone()
+		replacement.ID = nil
+		replacement.Fee = 0
+		replacement.Mass = 0
+		replacement.Outputs[0].Value -= extraOutputValue + lowFee
+		replacement.Outputs = append(replacement.Outputs, &externalapi.DomainTransactionOutput{
+			Value:           extraOutputValue,
+			ScriptPublicKey: replacement.Outputs[0].ScriptPublicKey,
+		})
+
+		_, _, err = miningManager.ValidateAndInsertTransactionReplacement(replacement, false)
+		if err == nil || !strings.Contains(err.Error(), "mempool is full") {
+			t.Fatalf("ValidateAndInsertTransactionReplacement: expected the replacement to be evicted, got: %v", err)
+		}
+		if _, _, found := miningManager.GetTransaction(consensushashing.TransactionID(replacement), true, true); found {
+			t.Fatalf("Expected the replacement not to be in the mempool")
+		}
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		if len(transactionsFromMempool) != 1+len(highFeeTransactions) ||
+			!contains(lowFeeTransaction, transactionsFromMempool) {
+			t.Fatalf("Expected the mempool to keep the transaction the evicted replacement would have replaced")
+		}
+	})
+}
+
+// TestEvictedOrphan verifies that an orphan that would be evicted once its parent is accepted into a full
+// mempool is neither added to the mempool nor returned as accepted
+func TestEvictedOrphan(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestEvictedOrphan")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+
+		totalMass := uint64(0)
+		for i := 1; i <= 2; i++ {
+			transaction := createTransactionWithUTXOEntry(t, i, 0)
+			// Let the mempool populate the actual fee and mass
+			transaction.Fee = 0
+			transaction.Mass = 0
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+			totalMass += transaction.Mass
+		}
+		parent := createTransactionWithUTXOEntry(t, 0, 0)
+		parent.Fee = 0
+		parent.Mass = 0
+		tc.PopulateMass(parent)
+		mempoolConfig.MaximumTransactionsMass = totalMass + parent.Mass
+
+		// The orphan pays a low fee, so its package is the lowest in the mempool once its parent is in
+		_, redeemScript := testutils.OpTrueScript()
+		signatureScript, err := txscript.PayToScriptHashSignatureScript(redeemScript, nil)
+		if err != nil {
+			t.Fatalf("PayToScriptHashSignatureScript: %v", err)
+		}
+		orphan := &externalapi.DomainTransaction{
+			Version: constants.MaxTransactionVersion,
+			Inputs: []*externalapi.DomainTransactionInput{{
+				PreviousOutpoint: externalapi.DomainOutpoint{TransactionID: *consensushashing.TransactionID(parent)},
+				SignatureScript:  signatureScript,
+				Sequence:         constants.MaxTxInSequenceNum,
+			}},
+			Outputs: []*externalapi.DomainTransactionOutput{{
+				Value:           parent.Outputs[0].Value - 5000,
+				ScriptPublicKey: parent.Outputs[0].ScriptPublicKey,
+			}},
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(orphan, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+
+		acceptedTransactions, err := miningManager.ValidateAndInsertTransaction(parent, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+		if len(acceptedTransactions) != 1 || !contains(parent, acceptedTransactions) {
+			t.Fatalf("Expected only the parent to be accepted, got %d accepted transactions", len(acceptedTransactions))
+		}
+		if _, _, found := miningManager.GetTransaction(consensushashing.TransactionID(orphan), true, true); found {
+			t.Fatalf("Expected the evicted orphan not to be in the mempool")
+		}
+		transactionsFromMempool, _ := miningManager.AllTransactions(true, false)
+		if len(transactionsFromMempool) != 3 || !contains(parent, transactionsFromMempool) {
+			t.Fatalf("Expected the mempool to keep the parent and the transactions before it")
+		}
+	})
+}
+
+// TestChildPaysForParent verifies that a low-fee parent is left out of the block candidates until a child
+// of it pays for it, and that the template then includes the parent without the child
+func TestChildPaysForParent(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestChildPaysForParent")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+
+		const numOfHighFeeTransactions = 3
+		highFeeTransactions := make([]*externalapi.DomainTransaction, numOfHighFeeTransactions)
+		highFeeTransactionsMass := uint64(0)
+		for i := range highFeeTransactions {
+			chain, err := createTxChain(tc, 1)
+			if err != nil {
+				t.Fatalf("Error creating transaction: %+v", err)
+			}
+			highFeeTransactions[i] = chain[0]
+			highFeeTransactions[i].Outputs[0].Value -= 10_000
+			tc.PopulateMass(highFeeTransactions[i])
+			highFeeTransactionsMass += highFeeTransactions[i].Mass
+		}
+		chain, err := createTxChain(tc, 1)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		parentTransaction := chain[0]
+		childTransaction, err := testutils.CreateTransaction(parentTransaction, 1_000_000)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+
+		// The candidates are bounded to the mass of the high-fee transactions, and all of them fit in a block
+		mempoolConfig.MaximumMassPerBlock = highFeeTransactionsMass / 10
+		for _, transaction := range append(highFeeTransactions, parentTransaction) {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		coinbaseData := &externalapi.DomainCoinbaseData{
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: nil, Version: 0},
+			ExtraData:       nil}
+		blockTemplate, err := miningManager.GetBlockTemplateBuilder().BuildBlockTemplate(coinbaseData)
+		if err != nil {
+			t.Fatalf("BuildBlockTemplate: %v", err)
+		}
+		if contains(parentTransaction, blockTemplate.Block.Transactions) {
+			t.Fatalf("Expected the parent not to be selected while it pays the lowest fee")
+		}
+
+		_, err = miningManager.ValidateAndInsertTransaction(childTransaction, false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+		blockTemplate, err = miningManager.GetBlockTemplateBuilder().BuildBlockTemplate(coinbaseData)
+		if err != nil {
+			t.Fatalf("BuildBlockTemplate: %v", err)
+		}
+		if !contains(parentTransaction, blockTemplate.Block.Transactions) {
+			t.Fatalf("Expected the child to make the parent be selected")
+		}
+		// A transaction can't be in the same block as its parent
+		if contains(childTransaction, blockTemplate.Block.Transactions) {
+			t.Fatalf("Expected the child not to be selected while its parent is in the mempool")
+		}
+	})
+}
+
 // TestHandleNewBlockTransactions verifies that all the transactions in the block were successfully removed from the mempool.
 func TestHandleNewBlockTransactions(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
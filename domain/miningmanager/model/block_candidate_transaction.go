@@ -0,0 +1,13 @@
+package model
+
+import "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+
+// BlockCandidateTransaction is a transaction to be considered for a block template
+type BlockCandidateTransaction struct {
+	Transaction *externalapi.DomainTransaction
+
+	// Feerate is the package feerate the transaction was selected by, in sompi per gram of
+	// transaction mass. It's above the transaction's own feerate when descendants of it in
+	// the mempool pay for it
+	Feerate float64
+}
@@ -0,0 +1,18 @@
+package model
+
+// FeerateBucket is a single point on the feerate-to-inclusion-time curve
+// estimated from the current mempool contents
+type FeerateBucket struct {
+	// Feerate is in sompi per gram of transaction mass
+	Feerate          float64
+	EstimatedSeconds float64
+}
+
+// FeeEstimate holds the feerate buckets derived from the mempool.
+// Feerates decrease and estimated times increase along
+// PriorityBucket -> NormalBuckets -> LowBuckets
+type FeeEstimate struct {
+	PriorityBucket FeerateBucket
+	NormalBuckets  []FeerateBucket
+	LowBuckets     []FeerateBucket
+}
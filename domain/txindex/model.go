@@ -0,0 +1,19 @@
+package txindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// TransactionAcceptance describes where and when a transaction was accepted
+// by the virtual selected parent chain
+type TransactionAcceptance struct {
+	TransactionID *externalapi.DomainTransactionID
+
+	// IncludingBlockHash is the hash of the block that contains the transaction
+	IncludingBlockHash *externalapi.DomainHash
+
+	// AcceptingBlockHash is the hash of the selected chain block that merged
+	// IncludingBlockHash and accepted the transaction
+	AcceptingBlockHash     *externalapi.DomainHash
+	AcceptingBlockDAAScore uint64
+}
@@ -0,0 +1,72 @@
+package txindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const serializedTransactionAcceptanceSize = 2*externalapi.DomainHashSize + 8
+
+func serializeTransactionAcceptance(transactionAcceptance *TransactionAcceptance) []byte {
+	serialized := make([]byte, serializedTransactionAcceptanceSize)
+	copy(serialized[:externalapi.DomainHashSize], transactionAcceptance.IncludingBlockHash.ByteSlice())
+	copy(serialized[externalapi.DomainHashSize:2*externalapi.DomainHashSize],
+		transactionAcceptance.AcceptingBlockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(serialized[2*externalapi.DomainHashSize:], transactionAcceptance.AcceptingBlockDAAScore)
+	return serialized
+}
+
+func deserializeTransactionAcceptance(transactionID *externalapi.DomainTransactionID,
+	serialized []byte) (*TransactionAcceptance, error) {
+
+	if len(serialized) != serializedTransactionAcceptanceSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected length %d while deserializing "+
+			"the acceptance of transaction %s", len(serialized), transactionID)
+	}
+
+	includingBlockHash, err := externalapi.NewDomainHashFromByteSlice(serialized[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(
+		serialized[externalapi.DomainHashSize : 2*externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionAcceptance{
+		TransactionID:          transactionID,
+		IncludingBlockHash:     includingBlockHash,
+		AcceptingBlockHash:     acceptingBlockHash,
+		AcceptingBlockDAAScore: binary.LittleEndian.Uint64(serialized[2*externalapi.DomainHashSize:]),
+	}, nil
+}
+
+func serializeTransactionIDs(transactionIDs []*externalapi.DomainTransactionID) []byte {
+	serialized := make([]byte, externalapi.DomainHashSize*len(transactionIDs))
+	for i, transactionID := range transactionIDs {
+		copy(serialized[externalapi.DomainHashSize*i:], transactionID.ByteSlice())
+	}
+	return serialized
+}
+
+func deserializeTransactionIDs(serialized []byte) ([]*externalapi.DomainTransactionID, error) {
+	if len(serialized)%externalapi.DomainHashSize != 0 {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing transaction IDs")
+	}
+
+	transactionIDs := make([]*externalapi.DomainTransactionID, len(serialized)/externalapi.DomainHashSize)
+	for i := range transactionIDs {
+		start := externalapi.DomainHashSize * i
+		transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(
+			serialized[start : start+externalapi.DomainHashSize])
+		if err != nil {
+			return nil, err
+		}
+		transactionIDs[i] = transactionID
+	}
+	return transactionIDs, nil
+}
@@ -0,0 +1,141 @@
+package txindex
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+var acceptedTransactionsBucket = database.MakeBucket([]byte("tx-index-accepted-transactions"))
+var chainBlockTransactionsBucket = database.MakeBucket([]byte("tx-index-chain-block-transactions"))
+var selectedTipKey = database.MakeBucket([]byte("")).Key([]byte("tx-index-selected-tip"))
+
+// txIndexBuckets are all the buckets of the TX index, besides its selected tip
+var txIndexBuckets = []*database.Bucket{acceptedTransactionsBucket, chainBlockTransactionsBucket}
+
+type txIndexStore struct {
+	database database.Database
+}
+
+func newTXIndexStore(database database.Database) *txIndexStore {
+	return &txIndexStore{
+		database: database,
+	}
+}
+
+// addChainBlock indexes the given transactions as accepted by the given chain block
+func (tis *txIndexStore) addChainBlock(dbTransaction database.Transaction, chainBlockHash *externalapi.DomainHash,
+	transactionAcceptances []*TransactionAcceptance) error {
+
+	transactionIDs := make([]*externalapi.DomainTransactionID, len(transactionAcceptances))
+	for i, transactionAcceptance := range transactionAcceptances {
+		err := dbTransaction.Put(acceptedTransactionsBucket.Key(transactionAcceptance.TransactionID.ByteSlice()),
+			serializeTransactionAcceptance(transactionAcceptance))
+		if err != nil {
+			return err
+		}
+		transactionIDs[i] = transactionAcceptance.TransactionID
+	}
+
+	return dbTransaction.Put(chainBlockTransactionsBucket.Key(chainBlockHash.ByteSlice()),
+		serializeTransactionIDs(transactionIDs))
+}
+
+// removeChainBlock un-indexes all the transactions accepted by the given chain block. Transactions
+// that were meanwhile re-accepted by a different chain block are left untouched
+func (tis *txIndexStore) removeChainBlock(dbTransaction database.Transaction, chainBlockHash *externalapi.DomainHash) error {
+	chainBlockKey := chainBlockTransactionsBucket.Key(chainBlockHash.ByteSlice())
+	serializedTransactionIDs, err := dbTransaction.Get(chainBlockKey)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	transactionIDs, err := deserializeTransactionIDs(serializedTransactionIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, transactionID := range transactionIDs {
+		transactionAcceptance, found, err := tis.getTransactionAcceptance(dbTransaction, transactionID)
+		if err != nil {
+			return err
+		}
+		if !found || !transactionAcceptance.AcceptingBlockHash.Equal(chainBlockHash) {
+			continue
+		}
+		err = dbTransaction.Delete(acceptedTransactionsBucket.Key(transactionID.ByteSlice()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return dbTransaction.Delete(chainBlockKey)
+}
+
+// verifyChainBlock returns the discrepancies between the transactions indexed as accepted by the
+// given chain block and the given acceptances
+func (tis *txIndexStore) verifyChainBlock(dataAccessor database.DataAccessor, chainBlockHash *externalapi.DomainHash,
+	transactionAcceptances []*TransactionAcceptance) ([]string, error) {
+
+	var discrepancies []string
+	serializedTransactionIDs, err := dataAccessor.Get(chainBlockTransactionsBucket.Key(chainBlockHash.ByteSlice()))
+	if err != nil {
+		if !database.IsNotFoundError(err) {
+			return nil, err
+		}
+		discrepancies = append(discrepancies, fmt.Sprintf("the chain block %s is missing from the TX index",
+			chainBlockHash))
+	} else {
+		transactionIDs, err := deserializeTransactionIDs(serializedTransactionIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(transactionIDs) != len(transactionAcceptances) {
+			discrepancies = append(discrepancies, fmt.Sprintf("the TX index holds %d transactions accepted by "+
+				"the chain block %s rather than %d", len(transactionIDs), chainBlockHash, len(transactionAcceptances)))
+		}
+	}
+
+	for _, expectedTransactionAcceptance := range transactionAcceptances {
+		transactionAcceptance, found, err := tis.getTransactionAcceptance(dataAccessor,
+			expectedTransactionAcceptance.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			discrepancies = append(discrepancies, fmt.Sprintf("the transaction %s accepted by the chain block %s "+
+				"is missing from the TX index", expectedTransactionAcceptance.TransactionID, chainBlockHash))
+			continue
+		}
+		if !bytes.Equal(serializeTransactionAcceptance(transactionAcceptance),
+			serializeTransactionAcceptance(expectedTransactionAcceptance)) {
+
+			discrepancies = append(discrepancies, fmt.Sprintf("the acceptance of the transaction %s in the TX index "+
+				"differs from its acceptance by the chain block %s", expectedTransactionAcceptance.TransactionID,
+				chainBlockHash))
+		}
+	}
+	return discrepancies, nil
+}
+
+func (tis *txIndexStore) getTransactionAcceptance(dataAccessor database.DataAccessor,
+	transactionID *externalapi.DomainTransactionID) (*TransactionAcceptance, bool, error) {
+
+	serializedTransactionAcceptance, err := dataAccessor.Get(acceptedTransactionsBucket.Key(transactionID.ByteSlice()))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	transactionAcceptance, err := deserializeTransactionAcceptance(transactionID, serializedTransactionAcceptance)
+	if err != nil {
+		return nil, false, err
+	}
+	return transactionAcceptance, true, nil
+}
@@ -0,0 +1,139 @@
+package txindex
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/chainindex"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// TXIndex maintains an index between transaction IDs and the selected
+// chain blocks that accepted them.
+//
+// Transactions accepted below the pruning point stay in the index after
+// their blocks are pruned.
+type TXIndex struct {
+	store    *txIndexStore
+	follower *chainindex.Follower
+
+	mutex sync.Mutex
+}
+
+// New creates a new TX index, and brings it up to date with the
+// virtual selected parent chain.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func New(domain domain.Domain, database database.Database) (*TXIndex, error) {
+	store := newTXIndexStore(database)
+	txIndex := &TXIndex{
+		store:    store,
+		follower: newFollower(domain, database, store),
+	}
+
+	txIndex.mutex.Lock()
+	defer txIndex.mutex.Unlock()
+
+	err := txIndex.follower.Sync()
+	if err != nil {
+		return nil, err
+	}
+
+	return txIndex, nil
+}
+
+func newFollower(domain domain.Domain, database database.Database, store *txIndexStore) *chainindex.Follower {
+	return chainindex.NewFollower("TX index", log, domain, database, &chainBlockIndexer{store: store},
+		selectedTipKey, txIndexBuckets)
+}
+
+// Reset deletes the whole TX index and re-indexes the selected chain from the pruning point.
+func (ti *TXIndex) Reset() error {
+	ti.mutex.Lock()
+	defer ti.mutex.Unlock()
+
+	return ti.follower.Reset()
+}
+
+// Update updates the TX index with the given DAG selected parent chain changes
+func (ti *TXIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.Update")
+	defer onEnd()
+
+	if virtualChangeSet.VirtualSelectedParentChainChanges == nil {
+		return nil
+	}
+
+	ti.mutex.Lock()
+	defer ti.mutex.Unlock()
+
+	return ti.follower.ApplyChainPath(virtualChangeSet.VirtualSelectedParentChainChanges)
+}
+
+// chainBlockIndexer indexes the transactions accepted by chain blocks in the TX index store
+type chainBlockIndexer struct {
+	store *txIndexStore
+}
+
+func (cbi *chainBlockIndexer) AddChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash,
+	chainBlockHeader externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) error {
+
+	transactionAcceptances := chainBlockTransactionAcceptances(chainBlock, chainBlockHeader.DAAScore(), acceptanceData)
+	log.Tracef("Adding %d transactions accepted by chain block %s to the TX index",
+		len(transactionAcceptances), chainBlock)
+	return cbi.store.addChainBlock(dbTransaction, chainBlock, transactionAcceptances)
+}
+
+func (cbi *chainBlockIndexer) RemoveChainBlock(dbTransaction database.Transaction, chainBlock *externalapi.DomainHash) error {
+	return cbi.store.removeChainBlock(dbTransaction, chainBlock)
+}
+
+func (cbi *chainBlockIndexer) VerifyChainBlock(dataAccessor database.DataAccessor, chainBlock *externalapi.DomainHash,
+	chainBlockHeader externalapi.BlockHeader, acceptanceData externalapi.AcceptanceData) ([]string, error) {
+
+	transactionAcceptances := chainBlockTransactionAcceptances(chainBlock, chainBlockHeader.DAAScore(), acceptanceData)
+	return cbi.store.verifyChainBlock(dataAccessor, chainBlock, transactionAcceptances)
+}
+
+func (cbi *chainBlockIndexer) ChainBlocksBucket() *database.Bucket {
+	return chainBlockTransactionsBucket
+}
+
+// chainBlockTransactionAcceptances returns the acceptances of the transactions accepted by the given chain block
+func chainBlockTransactionAcceptances(chainBlock *externalapi.DomainHash, daaScore uint64,
+	acceptanceData externalapi.AcceptanceData) []*TransactionAcceptance {
+
+	var transactionAcceptances []*TransactionAcceptance
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			transactionAcceptances = append(transactionAcceptances, &TransactionAcceptance{
+				TransactionID:          consensushashing.TransactionID(transactionAcceptanceData.Transaction),
+				IncludingBlockHash:     blockAcceptanceData.BlockHash,
+				AcceptingBlockHash:     chainBlock,
+				AcceptingBlockDAAScore: daaScore,
+			})
+		}
+	}
+	return transactionAcceptances
+}
+
+// TransactionAcceptance returns where and when the given transaction was accepted by the
+// virtual selected parent chain. found is false if the transaction isn't accepted by any
+// block in the selected chain
+func (ti *TXIndex) TransactionAcceptance(transactionID *externalapi.DomainTransactionID) (
+	transactionAcceptance *TransactionAcceptance, found bool, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.TransactionAcceptance")
+	defer onEnd()
+
+	ti.mutex.Lock()
+	defer ti.mutex.Unlock()
+
+	return ti.store.getTransactionAcceptance(ti.store.database, transactionID)
+}
@@ -0,0 +1,121 @@
+package txindex_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/domain/txindex"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func TestTXIndex(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		dataDir, err := ioutil.TempDir("", fmt.Sprintf("TestTXIndex-%s", consensusConfig.Name))
+		if err != nil {
+			t.Fatalf("ioutil.TempDir: %+v", err)
+		}
+		defer os.RemoveAll(dataDir)
+
+		db, err := ldb.NewLevelDB(dataDir, 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+
+		addBlock := func() *externalapi.DomainBlock {
+			block, err := domainInstance.Consensus().BuildBlock(&externalapi.DomainCoinbaseData{
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: nil, Version: 0},
+				ExtraData:       nil,
+			}, nil)
+			if err != nil {
+				t.Fatalf("BuildBlock: %+v", err)
+			}
+			err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+			return block
+		}
+
+		// The coinbase transaction of a block is accepted by the chain block that merges it
+		checkAccepted := func(txIndex *txindex.TXIndex, includingBlock *externalapi.DomainBlock,
+			acceptingBlock *externalapi.DomainBlock) {
+
+			coinbaseTransactionID := consensushashing.TransactionID(includingBlock.Transactions[0])
+			transactionAcceptance, found, err := txIndex.TransactionAcceptance(coinbaseTransactionID)
+			if err != nil {
+				t.Fatalf("TransactionAcceptance: %+v", err)
+			}
+			if !found {
+				t.Fatalf("transaction %s is missing from the TX index", coinbaseTransactionID)
+			}
+			if !transactionAcceptance.IncludingBlockHash.Equal(consensushashing.BlockHash(includingBlock)) {
+				t.Fatalf("unexpected including block %s", transactionAcceptance.IncludingBlockHash)
+			}
+			if !transactionAcceptance.AcceptingBlockHash.Equal(consensushashing.BlockHash(acceptingBlock)) {
+				t.Fatalf("unexpected accepting block %s", transactionAcceptance.AcceptingBlockHash)
+			}
+			if transactionAcceptance.AcceptingBlockDAAScore != acceptingBlock.Header.DAAScore() {
+				t.Fatalf("unexpected accepting block DAA score %d", transactionAcceptance.AcceptingBlockDAAScore)
+			}
+		}
+
+		blocks := []*externalapi.DomainBlock{addBlock(), addBlock(), addBlock()}
+
+		// A new TX index indexes the existing selected chain
+		txIndex, err := txindex.New(domainInstance, db)
+		if err != nil {
+			t.Fatalf("txindex.New: %+v", err)
+		}
+		checkAccepted(txIndex, blocks[0], blocks[1])
+		checkAccepted(txIndex, blocks[1], blocks[2])
+
+		// Drop the events that were already covered by the initial sync
+		for len(domainInstance.ConsensusEventsChannel()) > 0 {
+			<-domainInstance.ConsensusEventsChannel()
+		}
+
+		blocks = append(blocks, addBlock())
+		for len(domainInstance.ConsensusEventsChannel()) > 0 {
+			event := <-domainInstance.ConsensusEventsChannel()
+			virtualChangeSet, ok := event.(*externalapi.VirtualChangeSet)
+			if !ok {
+				continue
+			}
+			err := txIndex.Update(virtualChangeSet)
+			if err != nil {
+				t.Fatalf("Update: %+v", err)
+			}
+		}
+		checkAccepted(txIndex, blocks[2], blocks[3])
+
+		// A restarted TX index keeps what was already indexed
+		txIndex, err = txindex.New(domainInstance, db)
+		if err != nil {
+			t.Fatalf("txindex.New: %+v", err)
+		}
+		checkAccepted(txIndex, blocks[0], blocks[1])
+		checkAccepted(txIndex, blocks[2], blocks[3])
+
+		_, found, err := txIndex.TransactionAcceptance(consensushashing.TransactionID(blocks[3].Transactions[0]))
+		if err != nil {
+			t.Fatalf("TransactionAcceptance: %+v", err)
+		}
+		if found {
+			t.Fatalf("the coinbase transaction of the selected tip isn't expected to be accepted yet")
+		}
+	})
+}
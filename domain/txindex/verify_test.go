@@ -0,0 +1,103 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
+)
+
+func TestVerifyAndRepair(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+
+	db := memorydb.NewMemoryDB()
+	defer db.Close()
+	domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+
+	blocks := make([]*externalapi.DomainBlock, 5)
+	for i := range blocks {
+		blocks[i], err = domainInstance.Consensus().BuildBlock(&externalapi.DomainCoinbaseData{
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{1}, Version: 0},
+			ExtraData:       nil,
+		}, nil)
+		if err != nil {
+			t.Fatalf("BuildBlock: %+v", err)
+		}
+		err = domainInstance.Consensus().ValidateAndInsertBlock(blocks[i], true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+	}
+
+	verify := func() []string {
+		report, err := Verify(domainInstance, db)
+		if err != nil {
+			t.Fatalf("Verify: %+v", err)
+		}
+		if !report.Exists {
+			t.Fatalf("Expected an existing TX index")
+		}
+		return report.Discrepancies
+	}
+
+	report, err := Verify(domainInstance, db)
+	if err != nil {
+		t.Fatalf("Verify: %+v", err)
+	}
+	if report.Exists {
+		t.Fatalf("Verify found a TX index before one was created")
+	}
+
+	_, err = New(domainInstance, db)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	report, err = Verify(domainInstance, db)
+	if err != nil {
+		t.Fatalf("Verify: %+v", err)
+	}
+	if !report.Exists || len(report.Discrepancies) != 0 {
+		t.Fatalf("Expected an existing TX index without discrepancies, but got %+v", report)
+	}
+	if report.CheckedChainBlocks != uint64(len(blocks)) {
+		t.Fatalf("Expected %d chain blocks to be checked, but %d were", len(blocks), report.CheckedChainBlocks)
+	}
+
+	// Remove the acceptance of a coinbase transaction, accepted by the next chain block
+	err = db.Delete(acceptedTransactionsBucket.Key(consensushashing.TransactionID(blocks[0].Transactions[0]).ByteSlice()))
+	if err != nil {
+		t.Fatalf("Delete: %+v", err)
+	}
+	discrepancies := verify()
+	if len(discrepancies) != 1 {
+		t.Fatalf("Expected a single discrepancy, but got %d: %v", len(discrepancies), discrepancies)
+	}
+
+	// Move the selected tip of the index back
+	err = db.Put(selectedTipKey, consensushashing.BlockHash(blocks[0]).ByteSlice())
+	if err != nil {
+		t.Fatalf("Put: %+v", err)
+	}
+	discrepancies = verify()
+	if len(discrepancies) != 2 {
+		t.Fatalf("Expected 2 discrepancies, but got %d: %v", len(discrepancies), discrepancies)
+	}
+
+	err = Repair(domainInstance, db)
+	if err != nil {
+		t.Fatalf("Repair: %+v", err)
+	}
+	discrepancies = verify()
+	if len(discrepancies) != 0 {
+		t.Fatalf("Expected no discrepancies after the repair, but got %v", discrepancies)
+	}
+}
@@ -431,3 +431,39 @@ func (uis *utxoIndexStore) getCirculatingSompiSupply() (uint64, error) {
 	}
 	return binaryserialization.DeserializeUint64(circulatingSupply)
 }
+
+func (uis *utxoIndexStore) getUTXOEntry(scriptPublicKey *externalapi.ScriptPublicKey,
+	outpoint *externalapi.DomainOutpoint) (utxoEntry externalapi.UTXOEntry, found bool, err error) {
+
+	key, err := uis.convertOutpointToKey(uis.bucketForScriptPublicKey(scriptPublicKey), outpoint)
+	if err != nil {
+		return nil, false, err
+	}
+	serializedUTXOEntry, err := uis.database.Get(key)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	utxoEntry, err = deserializeUTXOEntry(serializedUTXOEntry)
+	if err != nil {
+		return nil, false, err
+	}
+	return utxoEntry, true, nil
+}
+
+// count returns the number of UTXOs in the index
+func (uis *utxoIndexStore) count() (uint64, error) {
+	cursor, err := uis.database.Cursor(utxoIndexBucket)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	count := uint64(0)
+	for cursor.Next() {
+		count++
+	}
+	return count, nil
+}
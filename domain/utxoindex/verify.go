@@ -0,0 +1,146 @@
+package utxoindex
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// maxReportedUTXODiscrepancies is the number of missing or mismatched UTXOs
+// Verify describes one by one. Any more of them are only counted
+const maxReportedUTXODiscrepancies = 100
+
+// VerificationReport is the result of Verify
+type VerificationReport struct {
+	// Exists is false if the database doesn't have a UTXO index at all
+	Exists           bool
+	VirtualUTXOCount uint64
+	IndexedUTXOCount uint64
+	Discrepancies    []string
+}
+
+func (report *VerificationReport) addDiscrepancy(format string, args ...interface{}) {
+	discrepancy := fmt.Sprintf(format, args...)
+	log.Warnf("Found a UTXO index discrepancy: %s", discrepancy)
+	report.Discrepancies = append(report.Discrepancies, discrepancy)
+}
+
+// Verify compares the UTXO index in the given database with the virtual UTXO
+// set, without modifying either of them. Unlike New, it doesn't reset an
+// index that isn't synced with the consensus.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func Verify(domain domain.Domain, db database.Database) (*VerificationReport, error) {
+	store := newUTXOIndexStore(db)
+	report := &VerificationReport{}
+
+	indexedUTXOCount, err := store.count()
+	if err != nil {
+		return nil, err
+	}
+	report.IndexedUTXOCount = indexedUTXOCount
+
+	utxoIndexVirtualParents, err := store.getVirtualParents()
+	hasVirtualParents := true
+	if err != nil {
+		if !database.IsNotFoundError(err) {
+			return nil, err
+		}
+		hasVirtualParents = false
+	}
+	if !hasVirtualParents && indexedUTXOCount == 0 {
+		return report, nil
+	}
+	report.Exists = true
+
+	log.Infof("Verifying the UTXO index")
+	virtualInfo, err := domain.Consensus().GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+	if !hasVirtualParents {
+		report.addDiscrepancy("the UTXO index has no virtual parents, so it was never completely built")
+	} else if !externalapi.HashesEqual(utxoIndexVirtualParents, virtualInfo.ParentHashes) {
+		report.addDiscrepancy("the UTXO index is synced to the virtual parents %s rather than %s",
+			utxoIndexVirtualParents, virtualInfo.ParentHashes)
+	}
+
+	missingUTXOCount, mismatchedUTXOCount := uint64(0), uint64(0)
+	virtualSompiSupply := uint64(0)
+	var fromOutpoint *externalapi.DomainOutpoint
+	for {
+		const step = 1000
+		virtualUTXOs, err := domain.Consensus().GetVirtualUTXOs(virtualInfo.ParentHashes, fromOutpoint, step)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pair := range virtualUTXOs {
+			report.VirtualUTXOCount++
+			virtualSompiSupply += pair.UTXOEntry.Amount()
+
+			indexedUTXOEntry, found, err := store.getUTXOEntry(pair.UTXOEntry.ScriptPublicKey(), pair.Outpoint)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				missingUTXOCount++
+				if missingUTXOCount+mismatchedUTXOCount <= maxReportedUTXODiscrepancies {
+					report.addDiscrepancy("the UTXO %s:%d is missing from the UTXO index",
+						pair.Outpoint.TransactionID, pair.Outpoint.Index)
+				}
+				continue
+			}
+			if !indexedUTXOEntry.Equal(pair.UTXOEntry) {
+				mismatchedUTXOCount++
+				if missingUTXOCount+mismatchedUTXOCount <= maxReportedUTXODiscrepancies {
+					report.addDiscrepancy("the UTXO %s:%d in the UTXO index differs from the virtual UTXO set",
+						pair.Outpoint.TransactionID, pair.Outpoint.Index)
+				}
+			}
+		}
+
+		if len(virtualUTXOs) < step {
+			break
+		}
+		fromOutpoint = virtualUTXOs[len(virtualUTXOs)-1].Outpoint
+	}
+	if missingUTXOCount+mismatchedUTXOCount > maxReportedUTXODiscrepancies {
+		report.addDiscrepancy("%d UTXOs are missing from the UTXO index and %d differ from the virtual UTXO set",
+			missingUTXOCount, mismatchedUTXOCount)
+	}
+
+	// Every virtual UTXO that was found in the index accounts for exactly one
+	// indexed UTXO, so any other indexed UTXO isn't in the virtual UTXO set
+	foundUTXOCount := report.VirtualUTXOCount - missingUTXOCount
+	if report.IndexedUTXOCount > foundUTXOCount {
+		report.addDiscrepancy("%d UTXOs in the UTXO index are not in the virtual UTXO set",
+			report.IndexedUTXOCount-foundUTXOCount)
+	}
+
+	circulatingSompiSupply, err := store.getCirculatingSompiSupply()
+	if err != nil {
+		if !database.IsNotFoundError(err) {
+			return nil, err
+		}
+		report.addDiscrepancy("the UTXO index has no circulating supply")
+	} else if circulatingSompiSupply != virtualSompiSupply {
+		report.addDiscrepancy("the circulating supply of the UTXO index is %d sompi, but the virtual UTXO set "+
+			"holds %d sompi", circulatingSompiSupply, virtualSompiSupply)
+	}
+	return report, nil
+}
+
+// Repair rebuilds the UTXO index in the given database from the virtual UTXO
+// set.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func Repair(domain domain.Domain, db database.Database) error {
+	utxoIndex := &UTXOIndex{
+		domain: domain,
+		store:  newUTXOIndexStore(db),
+	}
+	return utxoIndex.Reset()
+}
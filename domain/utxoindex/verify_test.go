@@ -0,0 +1,100 @@
+package utxoindex
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
+)
+
+func TestVerifyAndRepair(t *testing.T) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+
+	db := memorydb.NewMemoryDB()
+	defer db.Close()
+	domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		block, err := domainInstance.Consensus().BuildBlock(&externalapi.DomainCoinbaseData{
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{1}, Version: 0},
+			ExtraData:       nil,
+		}, nil)
+		if err != nil {
+			t.Fatalf("BuildBlock: %+v", err)
+		}
+		err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+	}
+
+	verify := func() *VerificationReport {
+		report, err := Verify(domainInstance, db)
+		if err != nil {
+			t.Fatalf("Verify: %+v", err)
+		}
+		return report
+	}
+
+	report := verify()
+	if report.Exists {
+		t.Fatalf("Verify found a UTXO index before one was created")
+	}
+
+	_, err = New(domainInstance, db)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	report = verify()
+	if !report.Exists || len(report.Discrepancies) != 0 {
+		t.Fatalf("Expected an existing UTXO index without discrepancies, but got %+v", report)
+	}
+	if report.VirtualUTXOCount == 0 || report.IndexedUTXOCount != report.VirtualUTXOCount {
+		t.Fatalf("Expected the UTXO index to hold the %d virtual UTXOs, but it holds %d",
+			report.VirtualUTXOCount, report.IndexedUTXOCount)
+	}
+
+	// Remove one UTXO from the index
+	virtualInfo, err := domainInstance.Consensus().GetVirtualInfo()
+	if err != nil {
+		t.Fatalf("GetVirtualInfo: %+v", err)
+	}
+	virtualUTXOs, err := domainInstance.Consensus().GetVirtualUTXOs(virtualInfo.ParentHashes, nil, 1)
+	if err != nil {
+		t.Fatalf("GetVirtualUTXOs: %+v", err)
+	}
+	store := newUTXOIndexStore(db)
+	key, err := store.convertOutpointToKey(
+		store.bucketForScriptPublicKey(virtualUTXOs[0].UTXOEntry.ScriptPublicKey()), virtualUTXOs[0].Outpoint)
+	if err != nil {
+		t.Fatalf("convertOutpointToKey: %+v", err)
+	}
+	err = db.Delete(key)
+	if err != nil {
+		t.Fatalf("Delete: %+v", err)
+	}
+
+	report = verify()
+	const expectedDiscrepancies = 1
+	if len(report.Discrepancies) != expectedDiscrepancies {
+		t.Fatalf("Expected %d discrepancies, but got %d: %v",
+			expectedDiscrepancies, len(report.Discrepancies), report.Discrepancies)
+	}
+
+	err = Repair(domainInstance, db)
+	if err != nil {
+		t.Fatalf("Repair: %+v", err)
+	}
+	report = verify()
+	if len(report.Discrepancies) != 0 {
+		t.Fatalf("Expected no discrepancies after the repair, but got %v", report.Discrepancies)
+	}
+}
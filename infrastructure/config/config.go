@@ -54,6 +54,27 @@ const (
 	sampleConfigFilename    = "sample-kaspad.conf"
 	defaultMaxUTXOCacheSize = 5_000_000_000
 	defaultProtocolVersion  = 5
+
+	defaultVerifyDatabaseGHOSTDAGSample = 1000
+)
+
+// The offline commands kaspad runs instead of the node
+const (
+	ExportSnapshotCommand = "export-snapshot"
+	ImportSnapshotCommand = "import-snapshot"
+)
+
+// The database backends --dbtype selects
+const (
+	// LevelDBDatabaseType is the default database backend
+	LevelDBDatabaseType = "leveldb"
+
+	// PebbleDatabaseType is the pebble database backend
+	PebbleDatabaseType = "pebble"
+
+	// MemoryDatabaseType is the in-memory database backend, for nodes that
+	// don't need to keep their state
+	MemoryDatabaseType = "memory"
 )
 
 var (
@@ -92,10 +113,15 @@ type Flags struct {
 	BanThreshold                    uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
 	Whitelists                      []string      `long:"whitelist" description:"Add an IP network or IP that will not be banned. (eg. 192.168.1.0/24 or ::1)"`
 	RPCListeners                    []string      `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 16110, testnet: 16210)"`
+	RPCJSONListeners                []string      `long:"rpcjsonlisten" description:"Add an interface/port to listen for JSON-RPC 2.0 requests over HTTP and WebSocket (disabled by default)"`
+	RPCJSONAllowedOrigins           []string      `long:"rpcjsonallowedorigin" description:"Add an origin, such as https://example.com, whose web pages may open JSON-RPC WebSocket connections. WebSocket connections from any other web page are rejected"`
 	RPCCert                         string        `long:"rpccert" description:"File containing the certificate file"`
 	RPCKey                          string        `long:"rpckey" description:"File containing the certificate key"`
-	RPCMaxClients                   int           `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
-	RPCMaxWebsockets                int           `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
+	RPCClientCAFile                 string        `long:"rpcclientca" description:"File containing the CA certificates of RPC client certificates. Enables TLS on the RPC servers with --rpccert and --rpckey"`
+	RPCAuthFile                     string        `long:"rpcauthfile" description:"File mapping RPC bearer tokens and client certificate common names to roles. RPC authorization is disabled if not set. Requires --rpcclientca unless the RPC servers listen only on loopback addresses"`
+	RPCMaxClients                   int           `long:"rpcmaxclients" description:"Max number of RPC clients, counting gRPC, JSON-RPC HTTP and JSON-RPC WebSocket clients together"`
+	RPCMaxWebsockets                int           `long:"rpcmaxwebsockets" description:"Max number of JSON-RPC WebSocket connections, which also count towards --rpcmaxclients"`
+	RPCRateLimits                   []string      `long:"rpcratelimit" description:"Limit the RPC requests of every client, as <class>:<requests per second>:<burst>. The classes are light, heavy and utxo, where utxo requests cost a request per address. May be repeated"`
 	RPCMaxConcurrentReqs            int           `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
 	DisableRPC                      bool          `long:"norpc" description:"Disable built-in RPC server"`
 	SafeRPC                         bool          `long:"saferpc" description:"Disable RPC commands which affect the state of the node"`
@@ -106,12 +132,20 @@ type Flags struct {
 	Proxy                           string        `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser                       string        `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass                       string        `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
-	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG"`
+	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG {leveldb, pebble, memory}. The memory backend loses the DAG on shutdown, and is only available on simnet and devnet"`
+	ConvertDatabase                 bool          `long:"convert-db" description:"Copy the LevelDB database into a new database of the backend given by --dbtype, and exit. The LevelDB database is left as is"`
+	VerifyDatabase                  bool          `long:"verify-db" description:"Verify the integrity of the database, report the discrepancies found, and exit"`
+	VerifyDatabaseGHOSTDAGSample    uint64        `long:"verify-db-ghostdag-sample" description:"The number of blocks whose GHOSTDAG data --verify-db recomputes, spread evenly over the DAG. 0 recomputes all of them"`
+	VerifyDatabaseReport            string        `long:"verify-db-report" description:"Write the --verify-db report to the given file as JSON"`
+	VerifyDatabaseRepair            bool          `long:"verify-db-repair" description:"Rebuild the UTXO, TX and address history indexes that --verify-db finds inconsistent with the consensus"`
+	MigrateDatabaseDryRun           bool          `long:"migrate-db-dry-run" description:"Report the pending database migrations and the changes they would make without applying them, and exit"`
 	Profile                         string        `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	Metrics                         string        `long:"metrics" description:"Export Prometheus metrics over HTTP at /metrics of the given interface/port (eg. 127.0.0.1:9110)"`
 	LogLevel                        string        `short:"d" long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 	Upnp                            bool          `long:"upnp" description:"Use UPnP to map our listening port outside of NAT"`
 	MinRelayTxFee                   float64       `long:"minrelaytxfee" description:"The minimum transaction fee in KAS/kB to be considered a non-zero fee."`
 	MaxOrphanTxs                    uint64        `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
+	NoMempoolPersistence            bool          `long:"nomempoolpersistence" description:"Do not save the mempool into the database on shutdown, and do not restore it on startup"`
 	BlockMaxMass                    uint64        `long:"blockmaxmass" description:"Maximum transaction mass to be used when creating a block"`
 	UserAgentComments               []string      `long:"uacomment" description:"Comment to add to the user agent -- See BIP 14 for more information."`
 	NoPeerBloomFilters              bool          `long:"nopeerbloomfilters" description:"Disable bloom filtering support"`
@@ -122,12 +156,25 @@ type Flags struct {
 	ResetDatabase                   bool          `long:"reset-db" description:"Reset database before starting node. It's needed when switching between subnetworks."`
 	MaxUTXOCacheSize                uint64        `long:"maxutxocachesize" description:"Max size of loaded UTXO into ram from the disk in bytes"`
 	UTXOIndex                       bool          `long:"utxoindex" description:"Enable the UTXO index"`
+	TXIndex                         bool          `long:"txindex" description:"Enable the transaction index, mapping accepted transactions to their accepting blocks"`
+	AddressHistoryIndex             bool          `long:"addresshistoryindex" description:"Enable the address history index, recording every credit and debit made to each address"`
 	IsArchivalNode                  bool          `long:"archival" description:"Run as an archival node: don't delete old block data when moving the pruning point (Warning: heavy disk usage)'"`
 	AllowSubmitBlockWhenNotSynced   bool          `long:"allow-submit-block-when-not-synced" hidden:"true" description:"Allow the node to accept blocks from RPC while not synced (this flag is mainly used for testing)"`
 	EnableSanityCheckPruningUTXOSet bool          `long:"enable-sanity-check-pruning-utxo" hidden:"true" description:"When moving the pruning point - check that the utxo set matches the utxo commitment"`
 	ProtocolVersion                 uint32        `long:"protocol-version" description:"Use non default p2p protocol version"`
 	NetworkFlags
 	ServiceOptions *ServiceOptions
+
+	ExportSnapshot SnapshotCommand `command:"export-snapshot" description:"Write the pruning point state of the node to a snapshot file, and exit"`
+	ImportSnapshot SnapshotCommand `command:"import-snapshot" description:"Bootstrap the empty database of the node from a snapshot file, and exit"`
+}
+
+// SnapshotCommand defines the arguments of the export-snapshot and
+// import-snapshot commands
+type SnapshotCommand struct {
+	Args struct {
+		File string `positional-arg-name:"file" description:"The snapshot file"`
+	} `positional-args:"yes" required:"yes"`
 }
 
 // Config defines the configuration options for kaspad.
@@ -135,6 +182,7 @@ type Flags struct {
 // See loadConfig for details on the configuration load process.
 type Config struct {
 	*Flags
+	Command       string // The offline command to run instead of the node, if any
 	Lookup        func(string) ([]net.IP, error)
 	Dial          func(string, string, time.Duration) (net.Conn, error)
 	MiningAddrs   []util.Address
@@ -166,6 +214,7 @@ func cleanAndExpandPath(path string) string {
 // newConfigParser returns a new command line flags parser.
 func newConfigParser(cfgFlags *Flags, options flags.Options) *flags.Parser {
 	parser := flags.NewParser(cfgFlags, options)
+	parser.SubcommandsOptional = true
 	if runtime.GOOS == "windows" {
 		parser.AddGroup("Service Options", "Service Options", cfgFlags.ServiceOptions)
 	}
@@ -193,6 +242,9 @@ func defaultFlags() *Flags {
 		MaxUTXOCacheSize:     defaultMaxUTXOCacheSize,
 		ServiceOptions:       &ServiceOptions{},
 		ProtocolVersion:      defaultProtocolVersion,
+		DbType:               LevelDBDatabaseType,
+
+		VerifyDatabaseGHOSTDAGSample: defaultVerifyDatabaseGHOSTDAGSample,
 	}
 }
 
@@ -274,6 +326,9 @@ func LoadConfig() (*Config, error) {
 		}
 		return nil, err
 	}
+	if parser.Active != nil {
+		cfg.Command = parser.Active.Name
+	}
 
 	// Create the home directory if it doesn't already exist.
 	funcName := "loadConfig"
@@ -364,6 +419,81 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// Validate the database backend
+	if cfg.DbType != LevelDBDatabaseType && cfg.DbType != PebbleDatabaseType && cfg.DbType != MemoryDatabaseType {
+		str := "%s: The database backend %s is not one of {%s, %s, %s}"
+		err := errors.Errorf(str, funcName, cfg.DbType, LevelDBDatabaseType, PebbleDatabaseType, MemoryDatabaseType)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.DbType == MemoryDatabaseType && !cfg.Simnet && !cfg.Devnet {
+		str := "%s: The %s database backend is only available on simnet and devnet"
+		err := errors.Errorf(str, funcName, MemoryDatabaseType)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.ConvertDatabase && (cfg.DbType == LevelDBDatabaseType || cfg.DbType == MemoryDatabaseType) {
+		str := "%s: --convert-db requires --dbtype to be the on-disk backend to convert the LevelDB database into"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.Command != "" && (cfg.ConvertDatabase || cfg.ResetDatabase || cfg.DbType == MemoryDatabaseType) {
+		str := "%s: %s cannot be used with --convert-db, --reset-db or the %s database backend"
+		err := errors.Errorf(str, funcName, cfg.Command, MemoryDatabaseType)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.ConvertDatabase && cfg.ResetDatabase {
+		str := "%s: --convert-db and --reset-db cannot be used together"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.VerifyDatabase && (cfg.Command != "" || cfg.ConvertDatabase || cfg.ResetDatabase ||
+		cfg.DbType == MemoryDatabaseType) {
+
+		str := "%s: --verify-db cannot be used with a command, --convert-db, --reset-db or the %s database backend"
+		err := errors.Errorf(str, funcName, MemoryDatabaseType)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.MigrateDatabaseDryRun && (cfg.Command != "" || cfg.ConvertDatabase || cfg.ResetDatabase ||
+		cfg.VerifyDatabase || cfg.DbType == MemoryDatabaseType) {
+
+		str := "%s: --migrate-db-dry-run cannot be used with a command, --convert-db, --reset-db, --verify-db " +
+			"or the %s database backend"
+		err := errors.Errorf(str, funcName, MemoryDatabaseType)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if !cfg.VerifyDatabase && (cfg.VerifyDatabaseReport != "" || cfg.VerifyDatabaseRepair) {
+		str := "%s: --verify-db-report and --verify-db-repair require --verify-db"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the metrics listen address
+	if cfg.Metrics != "" {
+		_, _, err := net.SplitHostPort(cfg.Metrics)
+		if err != nil {
+			str := "%s: The metrics listen address %s is invalid: %s"
+			err := errors.Errorf(str, funcName, cfg.Metrics, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Don't allow ban durations that are too short.
 	if cfg.BanDuration < time.Second {
 		str := "%s: The banduration option may not be less than 1s -- parsed [%s]"
@@ -438,6 +568,7 @@ func LoadConfig() (*Config, error) {
 
 	if cfg.DisableRPC {
 		log.Infof("RPC service is disabled")
+		cfg.RPCJSONListeners = nil
 	}
 
 	// Add the default RPC listener if none were specified. The default
@@ -518,6 +649,20 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Bearer tokens are sent in plain text without TLS, so they may only be
+	// sent to RPC servers that listen on loopback addresses
+	if cfg.RPCAuthFile != "" && cfg.RPCClientCAFile == "" {
+		address := firstNonLoopbackAddress(append(cfg.RPCListeners, cfg.RPCJSONListeners...))
+		if address != "" {
+			str := "%s: --rpcauthfile requires TLS, which is enabled with --rpcclientca, " +
+				"unless the RPC servers listen only on loopback addresses -- listening on %s"
+			err := errors.Errorf(str, funcName, address)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Disallow --addpeer and --connect used together
 	if len(cfg.AddPeers) > 0 && len(cfg.ConnectPeers) > 0 {
 		str := "%s: --addpeer and --connect can not be used together"
@@ -595,3 +740,23 @@ func createDefaultConfigFile(destinationPath string) error {
 
 	return err
 }
+
+// firstNonLoopbackAddress returns the first of the given host:port addresses
+// that isn't a loopback address, or an empty string if there isn't one. An
+// address without a host listens on all interfaces, so it isn't a loopback address
+func firstNonLoopbackAddress(addresses []string) string {
+	for _, address := range addresses {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		if host == "localhost" {
+			continue
+		}
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return address
+		}
+	}
+	return ""
+}
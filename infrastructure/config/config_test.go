@@ -70,3 +70,23 @@ func TestConstants(t *testing.T) {
 		t.Errorf("subnetworks.SubnetworkIDRegistry value was changed from 2, therefore you probably need to update the help text for SubnetworkID")
 	}
 }
+
+func TestFirstNonLoopbackAddress(t *testing.T) {
+	tests := []struct {
+		addresses []string
+		expected  string
+	}{
+		{[]string{"127.0.0.1:16110", "[::1]:16110", "localhost:16110"}, ""},
+		{[]string{"127.0.0.1:16110", ":16110"}, ":16110"},
+		{[]string{"0.0.0.0:16110"}, "0.0.0.0:16110"},
+		{[]string{"192.168.1.1:16110", "127.0.0.1:16110"}, "192.168.1.1:16110"},
+		{[]string{"example.com:16110"}, "example.com:16110"},
+		{nil, ""},
+	}
+	for _, test := range tests {
+		actual := firstNonLoopbackAddress(test.addresses)
+		if actual != test.expected {
+			t.Errorf("firstNonLoopbackAddress(%v): expected %q, got %q", test.addresses, test.expected, actual)
+		}
+	}
+}
@@ -6,8 +6,8 @@ Package database provides a database for kaspad.
 This package provides a database layer to store and retrieve data in a simple
 and efficient manner.
 
-The current backend is ffldb, which makes use of leveldb, flat files, and strict
-checksums in key areas to ensure data integrity.
+The backends are ldb, which makes use of leveldb, and pebbledb, which makes use
+of pebble. kaspad selects one with --dbtype.
 
 Implementors of additional backends are required to implement the following interfaces:
 
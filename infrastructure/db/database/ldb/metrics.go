@@ -0,0 +1,49 @@
+package ldb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// RegisterMetrics exports the internal statistics of the leveldb instance
+// as metrics. Only the most recently registered instance is exported
+func (db *LevelDB) RegisterMetrics() {
+	stat := func(value func(stats *leveldb.DBStats) float64) func() float64 {
+		return func() float64 {
+			stats := &leveldb.DBStats{}
+			err := db.ldb.Stats(stats)
+			if err != nil {
+				return 0
+			}
+			return value(stats)
+		}
+	}
+
+	metrics.NewCounterFunc("kaspad_leveldb_read_bytes_total", "Bytes read from disk by LevelDB",
+		stat(func(stats *leveldb.DBStats) float64 { return float64(stats.IORead) }))
+	metrics.NewCounterFunc("kaspad_leveldb_written_bytes_total", "Bytes written to disk by LevelDB",
+		stat(func(stats *leveldb.DBStats) float64 { return float64(stats.IOWrite) }))
+	metrics.NewCounterFunc("kaspad_leveldb_write_delays_total", "Writes LevelDB delayed to let compaction catch up",
+		stat(func(stats *leveldb.DBStats) float64 { return float64(stats.WriteDelayCount) }))
+	metrics.NewCounterFunc("kaspad_leveldb_write_delay_seconds_total", "Time LevelDB writes spent delayed",
+		stat(func(stats *leveldb.DBStats) float64 { return stats.WriteDelayDuration.Seconds() }))
+	metrics.NewGaugeFunc("kaspad_leveldb_write_paused", "Whether LevelDB writes are currently paused",
+		stat(func(stats *leveldb.DBStats) float64 {
+			if stats.WritePaused {
+				return 1
+			}
+			return 0
+		}))
+	metrics.NewCounterFunc("kaspad_leveldb_compactions_total", "Compactions performed by LevelDB",
+		stat(func(stats *leveldb.DBStats) float64 {
+			return float64(stats.MemComp + stats.Level0Comp + stats.NonLevel0Comp + stats.SeekComp)
+		}))
+	metrics.NewGaugeFunc("kaspad_leveldb_size_bytes", "Total size of the LevelDB tables",
+		stat(func(stats *leveldb.DBStats) float64 { return float64(stats.LevelSizes.Sum()) }))
+	metrics.NewGaugeFunc("kaspad_leveldb_block_cache_bytes", "Size of the LevelDB block cache",
+		stat(func(stats *leveldb.DBStats) float64 { return float64(stats.BlockCacheSize) }))
+	metrics.NewGaugeFunc("kaspad_leveldb_open_tables", "Tables LevelDB currently holds open",
+		stat(func(stats *leveldb.DBStats) float64 { return float64(stats.OpenedTablesCount) }))
+	metrics.NewGaugeFunc("kaspad_leveldb_alive_iterators", "Iterators currently open on LevelDB",
+		stat(func(stats *leveldb.DBStats) float64 { return float64(stats.AliveIterators) }))
+}
@@ -0,0 +1,143 @@
+package memorydb
+
+import (
+	"bytes"
+
+	"github.com/google/btree"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// MemoryDBCursor iterates over a snapshot of the entries of a MemoryDB, so
+// that like a leveldb iterator, it doesn't see the changes that were made
+// after it was opened.
+type MemoryDBCursor struct {
+	snapshot *btree.BTreeG[*entry]
+	bucket   *database.Bucket
+
+	// current is the entry the cursor points at, or nil if the cursor is
+	// exhausted or wasn't moved yet
+	current      *entry
+	isPositioned bool
+	isClosed     bool
+}
+
+// Cursor begins a new cursor over the given prefix.
+func (db *MemoryDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	// Cloning marks the nodes of the tree as shared, so it requires the
+	// write lock
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.isClosed {
+		return nil, errors.WithStack(errClosed)
+	}
+	return &MemoryDBCursor{
+		snapshot:     db.entries.Clone(),
+		bucket:       bucket,
+		isPositioned: false,
+		isClosed:     false,
+	}, nil
+}
+
+// seek moves the cursor to the first entry of the bucket whose key is
+// greater than or equal to pivot, or greater than it if skipPivot is true
+func (c *MemoryDBCursor) seek(pivot []byte, skipPivot bool) bool {
+	prefix := c.bucket.Path()
+	if bytes.Compare(pivot, prefix) < 0 {
+		pivot = prefix
+	}
+
+	c.isPositioned = true
+	c.current = nil
+	c.snapshot.AscendGreaterOrEqual(&entry{key: pivot}, func(item *entry) bool {
+		if skipPivot && bytes.Equal(item.key, pivot) {
+			return true
+		}
+		if bytes.HasPrefix(item.key, prefix) {
+			c.current = item
+		}
+		return false
+	})
+	return c.current != nil
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *MemoryDBCursor) Next() bool {
+	if c.isClosed {
+		panic("cannot call next on a closed cursor")
+	}
+	if !c.isPositioned {
+		return c.seek(c.bucket.Path(), false)
+	}
+	if c.current == nil {
+		return false
+	}
+	return c.seek(c.current.key, true)
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *MemoryDBCursor) First() bool {
+	if c.isClosed {
+		panic("cannot call first on a closed cursor")
+	}
+	return c.seek(c.bucket.Path(), false)
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *MemoryDBCursor) Seek(key *database.Key) error {
+	if c.isClosed {
+		return errors.New("cannot seek a closed cursor")
+	}
+
+	keyBytes := key.Bytes()
+	found := c.seek(keyBytes, false)
+	if !found || !bytes.Equal(c.current.key, keyBytes) {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+	return nil
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+// Note that the key is trimmed to not include the prefix the cursor was opened
+// with. The caller should not modify the contents of the returned slice.
+func (c *MemoryDBCursor) Key() (*database.Key, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the key of a closed cursor")
+	}
+	if c.current == nil {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"key of an exhausted cursor")
+	}
+	suffix := bytes.TrimPrefix(c.current.key, c.bucket.Path())
+	return c.bucket.Key(suffix), nil
+}
+
+// Value returns the value of the current key/value pair, or ErrNotFound if done.
+// The caller should not modify the contents of the returned slice.
+func (c *MemoryDBCursor) Value() ([]byte, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the value of a closed cursor")
+	}
+	if c.current == nil {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"value of an exhausted cursor")
+	}
+	return c.current.value, nil
+}
+
+// Close releases associated resources.
+func (c *MemoryDBCursor) Close() error {
+	if c.isClosed {
+		return errors.New("cannot close an already closed cursor")
+	}
+	c.isClosed = true
+	c.snapshot = nil
+	c.current = nil
+	c.bucket = nil
+	return nil
+}
@@ -0,0 +1,126 @@
+package memorydb
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/google/btree"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// btreeDegree is the degree of the tree that holds the entries. Higher
+// degrees make the tree shallower at the cost of copying more on writes
+const btreeDegree = 32
+
+var errClosed = errors.New("the database is closed")
+
+// MemoryDB is a database that keeps its entries in memory. Its entries are
+// lost when it closes.
+type MemoryDB struct {
+	entries  *btree.BTreeG[*entry]
+	lock     sync.RWMutex
+	isClosed bool
+}
+
+type entry struct {
+	key   []byte
+	value []byte
+}
+
+func entryLess(a, b *entry) bool {
+	return bytes.Compare(a.key, b.key) < 0
+}
+
+// NewMemoryDB returns a new empty in-memory database.
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{
+		entries: btree.NewG[*entry](btreeDegree, entryLess),
+	}
+}
+
+// Compact does nothing, since there's nothing to compact in memory.
+func (db *MemoryDB) Compact() error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.isClosed {
+		return errors.WithStack(errClosed)
+	}
+	return nil
+}
+
+// Close closes the database and drops its entries.
+func (db *MemoryDB) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.isClosed {
+		return errors.WithStack(errClosed)
+	}
+	db.isClosed = true
+	db.entries = nil
+	return nil
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *MemoryDB) Put(key *database.Key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.isClosed {
+		return errors.WithStack(errClosed)
+	}
+	db.put(key.Bytes(), append([]byte{}, value...))
+	return nil
+}
+
+// put inserts the given entry. The caller must hold the write lock, and must
+// not modify key and value afterwards
+func (db *MemoryDB) put(key []byte, value []byte) {
+	db.entries.ReplaceOrInsert(&entry{key: key, value: value})
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *MemoryDB) Get(key *database.Key) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.isClosed {
+		return nil, errors.WithStack(errClosed)
+	}
+	found, ok := db.entries.Get(&entry{key: key.Bytes()})
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound,
+			"key %s not found", key)
+	}
+	// Copy the value so that callers can't modify the stored one
+	return append([]byte{}, found.value...), nil
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *MemoryDB) Has(key *database.Key) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.isClosed {
+		return false, errors.WithStack(errClosed)
+	}
+	return db.entries.Has(&entry{key: key.Bytes()}), nil
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *MemoryDB) Delete(key *database.Key) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.isClosed {
+		return errors.WithStack(errClosed)
+	}
+	db.entries.Delete(&entry{key: key.Bytes()})
+	return nil
+}
@@ -0,0 +1,81 @@
+package memorydb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestMemoryDBCursorSnapshot(t *testing.T) {
+	db := NewMemoryDB()
+	defer db.Close()
+
+	bucket := database.MakeBucket([]byte("bucket"))
+	for _, suffix := range []string{"a", "b", "c"} {
+		err := db.Put(bucket.Key([]byte(suffix)), []byte(suffix))
+		if err != nil {
+			t.Fatalf("Put: %s", err)
+		}
+	}
+	// A key that shares the prefix of the bucket path without being in the bucket
+	err := db.Put(database.MakeBucket(nil).Key([]byte("bucketless")), []byte("x"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	cursor, err := db.Cursor(bucket)
+	if err != nil {
+		t.Fatalf("Cursor: %s", err)
+	}
+	defer cursor.Close()
+
+	// Changes made after the cursor was opened are not visible to it
+	err = db.Delete(bucket.Key([]byte("b")))
+	if err != nil {
+		t.Fatalf("Delete: %s", err)
+	}
+	err = db.Put(bucket.Key([]byte("d")), []byte("d"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	var suffixes []byte
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			t.Fatalf("Key: %s", err)
+		}
+		suffixes = append(suffixes, key.Suffix()...)
+	}
+	if !bytes.Equal(suffixes, []byte("abc")) {
+		t.Fatalf("expected the cursor to see the keys abc, got %s", suffixes)
+	}
+}
+
+func TestMemoryDBCopiesValues(t *testing.T) {
+	db := NewMemoryDB()
+	defer db.Close()
+
+	key := database.MakeBucket(nil).Key([]byte("key"))
+	value := []byte("value")
+	err := db.Put(key, value)
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	value[0] = 'x'
+
+	stored, err := db.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	stored[1] = 'x'
+
+	stored, err = db.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if !bytes.Equal(stored, []byte("value")) {
+		t.Fatalf("expected modifying the put and returned values to not change the stored value, got %s", stored)
+	}
+}
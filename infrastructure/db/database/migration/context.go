@@ -0,0 +1,266 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/os/signal"
+	"github.com/pkg/errors"
+)
+
+const progressInterval = 10 * time.Second
+
+// Reader reads the database a migration runs on
+type Reader interface {
+	Get(key *database.Key) ([]byte, error)
+	Has(key *database.Key) (bool, error)
+	Cursor(bucket *database.Bucket) (database.Cursor, error)
+}
+
+// Writer receives the writes of a step. The writes of a step are only
+// visible to the steps that come after it
+type Writer interface {
+	Put(key *database.Key, value []byte) error
+	Delete(key *database.Key) error
+}
+
+// Context runs the steps of a migration
+type Context struct {
+	db        database.Database
+	migration *Migration
+	options   *Options
+	batchSize int
+	report    *MigrationReport
+	stepNames map[string]struct{}
+}
+
+// Reader returns a reader of the database the migration runs on
+func (c *Context) Reader() Reader {
+	return c.db
+}
+
+// IsDryRun returns whether the writes of the migration are only counted
+func (c *Context) IsDryRun() bool {
+	return c.options.DryRun
+}
+
+// ForEach calls migrateEntry for every entry in bucket, and commits the writes
+// it makes every batch of entries. If the step was interrupted before, it
+// resumes after the last entry it committed
+func (c *Context) ForEach(stepName string, bucket *database.Bucket,
+	migrateEntry func(key *database.Key, value []byte, writer Writer) error) error {
+
+	stepReport, state, err := c.beginStep(stepName)
+	if err != nil {
+		return err
+	}
+	if state.isDone {
+		return nil
+	}
+
+	var lastKey *database.Key
+	if state.lastKeySuffix != nil {
+		lastKey = bucket.Key(state.lastKeySuffix)
+		stepReport.Resumed = true
+		log.Infof("Resuming step '%s' of the migration to version %d", stepName, c.migration.Version)
+	}
+
+	lastProgressLog := time.Now()
+	for {
+		keys, values, err := c.readBatch(bucket, lastKey)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			break
+		}
+
+		writer, err := c.beginWriter(stepReport)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			stepReport.Visited++
+			err = migrateEntry(key, values[i], writer)
+			if err != nil {
+				writer.rollback()
+				return errors.Wrapf(err, "step '%s' failed to migrate key %s", stepName, key)
+			}
+		}
+		lastKey = keys[len(keys)-1]
+		err = writer.commit(c.migration.Version, stepName, &stepState{lastKeySuffix: lastKey.Suffix()})
+		if err != nil {
+			return err
+		}
+
+		if signal.InterruptRequested(c.options.Interrupt) {
+			return ErrInterrupted
+		}
+		if time.Since(lastProgressLog) >= progressInterval {
+			log.Infof("Step '%s' of the migration to version %d: migrated %d entries",
+				stepName, c.migration.Version, stepReport.Visited)
+			lastProgressLog = time.Now()
+		}
+	}
+
+	writer, err := c.beginWriter(stepReport)
+	if err != nil {
+		return err
+	}
+	err = writer.commit(c.migration.Version, stepName, &stepState{isDone: true})
+	if err != nil {
+		return err
+	}
+	log.Infof("Step '%s' of the migration to version %d is done: visited %d entries, "+
+		"put %d and deleted %d", stepName, c.migration.Version, stepReport.Visited, stepReport.Puts, stepReport.Deletes)
+	return nil
+}
+
+// Update applies the writes update makes in a single transaction
+func (c *Context) Update(stepName string, update func(writer Writer) error) error {
+	stepReport, state, err := c.beginStep(stepName)
+	if err != nil {
+		return err
+	}
+	if state.isDone {
+		return nil
+	}
+
+	writer, err := c.beginWriter(stepReport)
+	if err != nil {
+		return err
+	}
+	err = update(writer)
+	if err != nil {
+		writer.rollback()
+		return errors.Wrapf(err, "step '%s' failed", stepName)
+	}
+	return writer.commit(c.migration.Version, stepName, &stepState{isDone: true})
+}
+
+// beginStep adds the report of a step, and returns its persisted state
+func (c *Context) beginStep(stepName string) (*StepReport, *stepState, error) {
+	if _, exists := c.stepNames[stepName]; exists {
+		return nil, nil, errors.Errorf("the migration to version %d has more than one step named '%s'",
+			c.migration.Version, stepName)
+	}
+	c.stepNames[stepName] = struct{}{}
+
+	stepReport := &StepReport{Name: stepName}
+	c.report.Steps = append(c.report.Steps, stepReport)
+
+	state, err := readStepState(c.db, c.migration.Version, stepName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state.isDone {
+		stepReport.AlreadyDone = true
+		log.Infof("Step '%s' of the migration to version %d was already done", stepName, c.migration.Version)
+	}
+	return stepReport, state, nil
+}
+
+// readBatch returns the next batch of entries in bucket after lastKey, or
+// from its start if lastKey is nil
+func (c *Context) readBatch(bucket *database.Bucket, lastKey *database.Key) (
+	keys []*database.Key, values [][]byte, err error) {
+
+	cursor, err := c.db.Cursor(bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close()
+
+	var hasEntry bool
+	if lastKey == nil {
+		hasEntry = cursor.First()
+	} else {
+		// Seek fails with ErrNotFound if lastKey was deleted, in which case
+		// the cursor is already at the entry after it, if there's one
+		err = cursor.Seek(lastKey)
+		switch {
+		case err == nil:
+			hasEntry = cursor.Next()
+		case database.IsNotFoundError(err):
+			_, keyErr := cursor.Key()
+			hasEntry = keyErr == nil
+		default:
+			return nil, nil, err
+		}
+	}
+
+	for ; hasEntry && len(keys) < c.batchSize; hasEntry = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, nil, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		// The cursor may reuse the memory of its keys and values
+		keys = append(keys, bucket.Key(append([]byte(nil), key.Suffix()...)))
+		values = append(values, append([]byte(nil), value...))
+	}
+	return keys, values, nil
+}
+
+func (c *Context) beginWriter(stepReport *StepReport) (*stepWriter, error) {
+	if c.options.DryRun {
+		return &stepWriter{report: stepReport}, nil
+	}
+	dbTx, err := c.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &stepWriter{
+		dbTx:   dbTx,
+		report: stepReport,
+	}, nil
+}
+
+// stepWriter counts the writes of a step, and applies them to dbTx unless
+// it's a dry run, in which case dbTx is nil
+type stepWriter struct {
+	dbTx   database.Transaction
+	report *StepReport
+}
+
+func (w *stepWriter) Put(key *database.Key, value []byte) error {
+	w.report.Puts++
+	if w.dbTx == nil {
+		return nil
+	}
+	return w.dbTx.Put(key, value)
+}
+
+func (w *stepWriter) Delete(key *database.Key) error {
+	w.report.Deletes++
+	if w.dbTx == nil {
+		return nil
+	}
+	return w.dbTx.Delete(key)
+}
+
+// commit commits the writes together with the new state of the step
+func (w *stepWriter) commit(version uint32, stepName string, state *stepState) error {
+	if w.dbTx == nil {
+		return nil
+	}
+	err := w.dbTx.Put(stepStateKey(version, stepName), serializeStepState(state))
+	if err != nil {
+		w.rollback()
+		return err
+	}
+	return w.dbTx.Commit()
+}
+
+func (w *stepWriter) rollback() {
+	if w.dbTx == nil {
+		return
+	}
+	err := w.dbTx.RollbackUnlessClosed()
+	if err != nil {
+		log.Warnf("Couldn't roll back a migration transaction: %s", err)
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Package migration upgrades the format of a kaspad database in place.
+
+# Overview
+
+Every change to the format of the database bumps its version, and registers a
+Migration that upgrades databases of the previous version. A Registry holds the
+migrations in order, and Run applies the ones a database is missing, one
+version at a time.
+
+# Steps
+
+A migration is made of named steps, which it runs through its Context:
+
+  - ForEach visits every entry of a bucket, in batches. Each batch is committed
+    in its own transaction together with the last key it visited, so an
+    interrupted step resumes after the last committed batch.
+  - Update makes a single atomic change.
+
+A step that was completed is skipped when the migration runs again, so a
+migration that was interrupted at any point can simply be run again. A ForEach
+step that writes into the bucket it visits should only write keys that sort
+before the entry being migrated, or it would visit them again.
+
+# Dry runs
+
+In a dry run, the steps visit the database as they would in a real run but
+writes are only counted, and nothing, including the progress of the steps, is
+written. Note that every migration of a dry run sees the database as it was
+before the earlier migrations of the run.
+*/
+package migration
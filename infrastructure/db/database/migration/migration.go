@@ -0,0 +1,172 @@
+package migration
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// DefaultBatchSize is the number of entries a ForEach step migrates in every
+// transaction, unless Options.BatchSize says otherwise
+const DefaultBatchSize = 10_000
+
+// ErrInterrupted is returned when a migration stops because kaspad is shutting
+// down. Running the migrations again resumes them
+var ErrInterrupted = errors.New("the database migration was interrupted")
+
+// Migration upgrades a database from Version-1 to Version
+type Migration struct {
+	Version     uint32
+	Description string
+
+	// Migrate applies the migration by running its steps through
+	// migrationContext
+	Migrate func(migrationContext *Context) error
+}
+
+// Registry holds the migrations that upgrade databases of baseVersion to the
+// latest version, in order
+type Registry struct {
+	baseVersion uint32
+	migrations  []*Migration
+}
+
+// NewRegistry returns a registry of the given migrations, which must upgrade
+// the database one version at a time starting from baseVersion
+func NewRegistry(baseVersion uint32, migrations ...*Migration) (*Registry, error) {
+	for i, migration := range migrations {
+		expectedVersion := baseVersion + uint32(i) + 1
+		if migration.Version != expectedVersion {
+			return nil, errors.Errorf("migration %d (%s) upgrades to version %d instead of %d",
+				i, migration.Description, migration.Version, expectedVersion)
+		}
+		if migration.Migrate == nil {
+			return nil, errors.Errorf("the migration to version %d has no Migrate function", migration.Version)
+		}
+	}
+	return &Registry{
+		baseVersion: baseVersion,
+		migrations:  migrations,
+	}, nil
+}
+
+// LatestVersion returns the version databases are upgraded to
+func (r *Registry) LatestVersion() uint32 {
+	return r.baseVersion + uint32(len(r.migrations))
+}
+
+// pending returns the migrations a database of the given version is missing
+func (r *Registry) pending(version uint32) ([]*Migration, error) {
+	if version < r.baseVersion {
+		return nil, errors.Errorf("database version %d is older than %d, the oldest version that "+
+			"can be migrated. The database has to be reset", version, r.baseVersion)
+	}
+	if version > r.LatestVersion() {
+		return nil, errors.Errorf("database version %d is newer than %d, the latest version this "+
+			"kaspad knows. Upgrade kaspad or reset the database", version, r.LatestVersion())
+	}
+	return r.migrations[version-r.baseVersion:], nil
+}
+
+// Options are the options of Run
+type Options struct {
+	// DryRun makes Run report the changes the migrations would make without
+	// writing anything
+	DryRun bool
+
+	// BatchSize is the number of entries a ForEach step migrates in every
+	// transaction. Zero means DefaultBatchSize
+	BatchSize int
+
+	// Interrupt stops the migration between batches when it's closed
+	Interrupt <-chan struct{}
+
+	// OnMigrated, if set, is called with the new version of the database
+	// after every migration that was committed
+	OnMigrated func(version uint32) error
+}
+
+// Run applies the migrations the database is missing in order. version is the
+// version of the database as known by the caller. The database records the
+// version of every migration it commits, and that version is used instead if
+// it's newer, so that a migration that was committed isn't applied again
+func (r *Registry) Run(db database.Database, version uint32, options *Options) (*Report, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	committedVersion, hasCommittedVersion, err := storedVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	if hasCommittedVersion && committedVersion > version {
+		version = committedVersion
+	}
+	pending, err := r.pending(version)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		FromVersion: version,
+		ToVersion:   version,
+		DryRun:      options.DryRun,
+	}
+	if len(pending) == 0 {
+		return report, nil
+	}
+	if options.DryRun && len(pending) > 1 {
+		log.Warnf("Dry running %d migrations. Each of them sees the database as it was before the "+
+			"earlier ones, so the later ones may report changes that are different from a real run", len(pending))
+	}
+
+	for _, migration := range pending {
+		migrationReport := &MigrationReport{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		report.Migrations = append(report.Migrations, migrationReport)
+
+		if options.DryRun {
+			log.Infof("Dry running the migration of the database to version %d: %s",
+				migration.Version, migration.Description)
+		} else {
+			log.Infof("Migrating the database to version %d: %s", migration.Version, migration.Description)
+		}
+		migrationContext := &Context{
+			db:        db,
+			migration: migration,
+			options:   options,
+			batchSize: batchSize,
+			report:    migrationReport,
+			stepNames: make(map[string]struct{}),
+		}
+		err := migration.Migrate(migrationContext)
+		if err != nil {
+			if errors.Is(err, ErrInterrupted) {
+				return report, err
+			}
+			return report, errors.Wrapf(err, "the migration to version %d failed", migration.Version)
+		}
+		report.ToVersion = migration.Version
+
+		if options.DryRun {
+			continue
+		}
+		err = commitVersion(db, migration.Version)
+		if err != nil {
+			return report, err
+		}
+		log.Infof("Migrated the database to version %d", migration.Version)
+		if options.OnMigrated != nil {
+			err = options.OnMigrated(migration.Version)
+			if err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,432 @@
+package migration
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
+	"github.com/pkg/errors"
+)
+
+const testEntryCount = 25
+
+var valuesBucket = database.MakeBucket([]byte("values"))
+var movedBucket = database.MakeBucket([]byte("moved"))
+var markerKey = database.MakeBucket(nil).Key([]byte("marker"))
+
+func testKey(bucket *database.Bucket, i int) *database.Key {
+	return bucket.Key([]byte(fmt.Sprintf("key%02d", i)))
+}
+
+// forAllBackends runs testFunc over a fresh database of every backend that
+// has its own cursor implementation
+func forAllBackends(t *testing.T, testFunc func(t *testing.T, db database.Database)) {
+	t.Run("memorydb", func(t *testing.T) {
+		db := memorydb.NewMemoryDB()
+		defer db.Close()
+		testFunc(t, db)
+	})
+	t.Run("ldb", func(t *testing.T) {
+		db, err := ldb.NewLevelDB(t.TempDir(), 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+		testFunc(t, db)
+	})
+}
+
+// populate puts testEntryCount counters of zero into valuesBucket
+func populate(t *testing.T, db database.Database) {
+	for i := 0; i < testEntryCount; i++ {
+		err := db.Put(testKey(valuesBucket, i), []byte{0})
+		if err != nil {
+			t.Fatalf("Put: %+v", err)
+		}
+	}
+}
+
+// incrementMigration upgrades to version 2 by incrementing every counter in
+// valuesBucket. failAt, if non-negative, makes it fail on that entry
+func incrementMigration(failAt int) *Migration {
+	return &Migration{
+		Version:     2,
+		Description: "increment the counters",
+		Migrate: func(migrationContext *Context) error {
+			return migrationContext.ForEach("increment", valuesBucket,
+				func(key *database.Key, value []byte, writer Writer) error {
+					if failAt >= 0 && bytes.Equal(key.Bytes(), testKey(valuesBucket, failAt).Bytes()) {
+						return errors.New("injected failure")
+					}
+					return writer.Put(key, []byte{value[0] + 1})
+				})
+		},
+	}
+}
+
+// moveMigration upgrades to version 3 by moving the counters to movedBucket
+// and then putting markerKey
+func moveMigration() *Migration {
+	return &Migration{
+		Version:     3,
+		Description: "move the counters",
+		Migrate: func(migrationContext *Context) error {
+			err := migrationContext.ForEach("move", valuesBucket,
+				func(key *database.Key, value []byte, writer Writer) error {
+					err := writer.Put(movedBucket.Key(key.Suffix()), value)
+					if err != nil {
+						return err
+					}
+					return writer.Delete(key)
+				})
+			if err != nil {
+				return err
+			}
+			return migrationContext.Update("mark", func(writer Writer) error {
+				return writer.Put(markerKey, []byte("done"))
+			})
+		},
+	}
+}
+
+func newTestRegistry(t *testing.T, migrations ...*Migration) *Registry {
+	registry, err := NewRegistry(1, migrations...)
+	if err != nil {
+		t.Fatalf("NewRegistry: %+v", err)
+	}
+	return registry
+}
+
+func checkCounters(t *testing.T, db database.Database, bucket *database.Bucket, expected func(i int) byte) {
+	for i := 0; i < testEntryCount; i++ {
+		value, err := db.Get(testKey(bucket, i))
+		if err != nil {
+			t.Fatalf("Get %d: %+v", i, err)
+		}
+		if value[0] != expected(i) {
+			t.Fatalf("expected counter %d to be %d, got %d", i, expected(i), value[0])
+		}
+	}
+}
+
+func countEntries(t *testing.T, db database.Database, bucket *database.Bucket) int {
+	cursor, err := db.Cursor(bucket)
+	if err != nil {
+		t.Fatalf("Cursor: %+v", err)
+	}
+	defer cursor.Close()
+
+	count := 0
+	for cursor.Next() {
+		count++
+	}
+	return count
+}
+
+func TestNewRegistry(t *testing.T) {
+	registry := newTestRegistry(t, incrementMigration(-1), moveMigration())
+	if registry.LatestVersion() != 3 {
+		t.Fatalf("expected the latest version to be 3, got %d", registry.LatestVersion())
+	}
+	if newTestRegistry(t).LatestVersion() != 1 {
+		t.Fatalf("expected a registry without migrations to stay at its base version")
+	}
+
+	_, err := NewRegistry(1, moveMigration())
+	if err == nil {
+		t.Fatalf("expected a registry that skips a version to be rejected")
+	}
+	_, err = NewRegistry(1, &Migration{Version: 2})
+	if err == nil {
+		t.Fatalf("expected a migration without a Migrate function to be rejected")
+	}
+}
+
+func TestRun(t *testing.T) {
+	forAllBackends(t, func(t *testing.T, db database.Database) {
+		populate(t, db)
+		registry := newTestRegistry(t, incrementMigration(-1), moveMigration())
+
+		var migratedVersions []uint32
+		report, err := registry.Run(db, 1, &Options{
+			BatchSize: 10,
+			OnMigrated: func(version uint32) error {
+				migratedVersions = append(migratedVersions, version)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+		if report.FromVersion != 1 || report.ToVersion != 3 || len(report.Migrations) != 2 {
+			t.Fatalf("unexpected report %+v", report)
+		}
+		if len(migratedVersions) != 2 || migratedVersions[0] != 2 || migratedVersions[1] != 3 {
+			t.Fatalf("expected OnMigrated to be called with 2 and 3, got %v", migratedVersions)
+		}
+
+		incrementStep := report.Migrations[0].Steps[0]
+		if incrementStep.Visited != testEntryCount || incrementStep.Puts != testEntryCount ||
+			incrementStep.Deletes != 0 {
+			t.Fatalf("unexpected report of the increment step %+v", incrementStep)
+		}
+		moveStep := report.Migrations[1].Steps[0]
+		if moveStep.Visited != testEntryCount || moveStep.Puts != testEntryCount ||
+			moveStep.Deletes != testEntryCount {
+			t.Fatalf("unexpected report of the move step %+v", moveStep)
+		}
+
+		checkCounters(t, db, movedBucket, func(int) byte { return 1 })
+		if countEntries(t, db, valuesBucket) != 0 {
+			t.Fatalf("expected the move step to empty the values bucket")
+		}
+		marker, err := db.Get(markerKey)
+		if err != nil || string(marker) != "done" {
+			t.Fatalf("expected the mark step to put the marker, got %s, %v", marker, err)
+		}
+
+		version, exists, err := storedVersion(db)
+		if err != nil || !exists || version != 3 {
+			t.Fatalf("expected the stored version to be 3, got %d, %t, %v", version, exists, err)
+		}
+		if countEntries(t, db, stepsBucket) != 0 {
+			t.Fatalf("expected the step states to be removed once their migration was committed")
+		}
+
+		// Running again, even with a stale version, must not migrate anything
+		report, err = registry.Run(db, 2, nil)
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+		if report.FromVersion != 3 || len(report.Migrations) != 0 {
+			t.Fatalf("expected the committed version to be used, got %+v", report)
+		}
+	})
+}
+
+func TestRunResumesInterruptedStep(t *testing.T) {
+	forAllBackends(t, func(t *testing.T, db database.Database) {
+		populate(t, db)
+		registry := newTestRegistry(t, incrementMigration(-1))
+
+		interrupt := make(chan struct{})
+		close(interrupt)
+		_, err := registry.Run(db, 1, &Options{BatchSize: 10, Interrupt: interrupt})
+		if !errors.Is(err, ErrInterrupted) {
+			t.Fatalf("expected ErrInterrupted, got %+v", err)
+		}
+		// Only the first batch was committed
+		checkCounters(t, db, valuesBucket, func(i int) byte {
+			if i < 10 {
+				return 1
+			}
+			return 0
+		})
+		_, exists, err := storedVersion(db)
+		if err != nil || exists {
+			t.Fatalf("expected no version to be stored before the migration is done, got %t, %v", exists, err)
+		}
+
+		report, err := registry.Run(db, 1, &Options{BatchSize: 10})
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+		step := report.Migrations[0].Steps[0]
+		if !step.Resumed || step.Visited != testEntryCount-10 {
+			t.Fatalf("expected the step to resume after the first batch, got %+v", step)
+		}
+		checkCounters(t, db, valuesBucket, func(int) byte { return 1 })
+	})
+}
+
+func TestRunResumesStepThatDeletedItsLastKey(t *testing.T) {
+	forAllBackends(t, func(t *testing.T, db database.Database) {
+		populate(t, db)
+		registry, err := NewRegistry(2, moveMigration())
+		if err != nil {
+			t.Fatalf("NewRegistry: %+v", err)
+		}
+
+		interrupt := make(chan struct{})
+		close(interrupt)
+		_, err = registry.Run(db, 2, &Options{BatchSize: 10, Interrupt: interrupt})
+		if !errors.Is(err, ErrInterrupted) {
+			t.Fatalf("expected ErrInterrupted, got %+v", err)
+		}
+		if countEntries(t, db, movedBucket) != 10 || countEntries(t, db, valuesBucket) != testEntryCount-10 {
+			t.Fatalf("expected only the first batch to be moved")
+		}
+
+		// The last key the step committed was deleted by the step itself
+		report, err := registry.Run(db, 2, &Options{BatchSize: 10})
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+		if report.Migrations[0].Steps[0].Visited != testEntryCount-10 {
+			t.Fatalf("expected the step to resume after the first batch, got %+v", report.Migrations[0].Steps[0])
+		}
+		checkCounters(t, db, movedBucket, func(int) byte { return 0 })
+		if countEntries(t, db, valuesBucket) != 0 {
+			t.Fatalf("expected the move step to empty the values bucket")
+		}
+	})
+}
+
+func TestRunResumesFailedStep(t *testing.T) {
+	forAllBackends(t, func(t *testing.T, db database.Database) {
+		populate(t, db)
+
+		failingRegistry := newTestRegistry(t, incrementMigration(15), moveMigration())
+		_, err := failingRegistry.Run(db, 1, &Options{BatchSize: 10})
+		if err == nil {
+			t.Fatalf("expected the injected failure to fail the run")
+		}
+		// The batch that failed was rolled back
+		checkCounters(t, db, valuesBucket, func(i int) byte {
+			if i < 10 {
+				return 1
+			}
+			return 0
+		})
+
+		registry := newTestRegistry(t, incrementMigration(-1), moveMigration())
+		_, err = registry.Run(db, 1, &Options{BatchSize: 10})
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+		checkCounters(t, db, movedBucket, func(int) byte { return 1 })
+	})
+}
+
+func TestRunSkipsDoneSteps(t *testing.T) {
+	forAllBackends(t, func(t *testing.T, db database.Database) {
+		populate(t, db)
+		registry := newTestRegistry(t, incrementMigration(-1), moveMigration())
+		_, err := registry.Run(db, 1, nil)
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+
+		// Pretend that the second migration was interrupted between its
+		// steps, after the move step was done
+		failingMark := &Migration{
+			Version:     3,
+			Description: "move the counters",
+			Migrate: func(migrationContext *Context) error {
+				err := migrationContext.ForEach("move", valuesBucket,
+					func(key *database.Key, value []byte, writer Writer) error {
+						return writer.Put(movedBucket.Key(key.Suffix()), []byte{100})
+					})
+				if err != nil {
+					return err
+				}
+				return errors.New("injected failure")
+			},
+		}
+		err = db.Delete(versionKey)
+		if err != nil {
+			t.Fatalf("Delete: %+v", err)
+		}
+		err = db.Put(stepStateKey(3, "move"), serializeStepState(&stepState{isDone: true}))
+		if err != nil {
+			t.Fatalf("Put: %+v", err)
+		}
+		_, err = newTestRegistry(t, incrementMigration(-1), failingMark).Run(db, 2, nil)
+		if err == nil {
+			t.Fatalf("expected the injected failure to fail the run")
+		}
+		// The move step was done, so it must not have run again
+		checkCounters(t, db, movedBucket, func(int) byte { return 1 })
+
+		report, err := registry.Run(db, 2, nil)
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+		steps := report.Migrations[0].Steps
+		if !steps[0].AlreadyDone || steps[1].AlreadyDone {
+			t.Fatalf("expected only the move step to be already done, got %+v, %+v", steps[0], steps[1])
+		}
+	})
+}
+
+func TestRunDryRun(t *testing.T) {
+	forAllBackends(t, func(t *testing.T, db database.Database) {
+		populate(t, db)
+		registry := newTestRegistry(t, incrementMigration(-1), moveMigration())
+
+		report, err := registry.Run(db, 1, &Options{
+			DryRun:    true,
+			BatchSize: 10,
+			OnMigrated: func(version uint32) error {
+				t.Fatalf("OnMigrated was called in a dry run")
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Run: %+v", err)
+		}
+		if !report.DryRun || report.ToVersion != 3 || len(report.Migrations) != 2 {
+			t.Fatalf("unexpected report %+v", report)
+		}
+		incrementStep := report.Migrations[0].Steps[0]
+		if incrementStep.Visited != testEntryCount || incrementStep.Puts != testEntryCount {
+			t.Fatalf("unexpected report of the increment step %+v", incrementStep)
+		}
+		markStep := report.Migrations[1].Steps[1]
+		if markStep.Puts != 1 {
+			t.Fatalf("unexpected report of the mark step %+v", markStep)
+		}
+
+		checkCounters(t, db, valuesBucket, func(int) byte { return 0 })
+		if countEntries(t, db, movedBucket) != 0 || countEntries(t, db, migrationBucket) != 0 {
+			t.Fatalf("expected a dry run to write nothing")
+		}
+		hasMarker, err := db.Has(markerKey)
+		if err != nil || hasMarker {
+			t.Fatalf("expected a dry run to write nothing, got %t, %v", hasMarker, err)
+		}
+	})
+}
+
+func TestRunRejectsUnknownVersions(t *testing.T) {
+	db := memorydb.NewMemoryDB()
+	defer db.Close()
+	registry, err := NewRegistry(2, moveMigration())
+	if err != nil {
+		t.Fatalf("NewRegistry: %+v", err)
+	}
+
+	_, err = registry.Run(db, 1, nil)
+	if err == nil {
+		t.Fatalf("expected a version older than the base version to be rejected")
+	}
+	_, err = registry.Run(db, 4, nil)
+	if err == nil {
+		t.Fatalf("expected a version newer than the latest version to be rejected")
+	}
+}
+
+func TestDuplicateStepNames(t *testing.T) {
+	db := memorydb.NewMemoryDB()
+	defer db.Close()
+	registry := newTestRegistry(t, &Migration{
+		Version:     2,
+		Description: "duplicate steps",
+		Migrate: func(migrationContext *Context) error {
+			for i := 0; i < 2; i++ {
+				err := migrationContext.Update("step", func(Writer) error { return nil })
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	_, err := registry.Run(db, 1, nil)
+	if err == nil {
+		t.Fatalf("expected steps with the same name to be rejected")
+	}
+}
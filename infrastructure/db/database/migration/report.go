@@ -0,0 +1,29 @@
+package migration
+
+// Report describes the migrations a Run applied, or would have applied in a
+// dry run
+type Report struct {
+	FromVersion uint32
+	ToVersion   uint32
+	DryRun      bool
+	Migrations  []*MigrationReport
+}
+
+// MigrationReport describes the steps of a single migration
+type MigrationReport struct {
+	Version     uint32
+	Description string
+	Steps       []*StepReport
+}
+
+// StepReport counts the entries a step visited and the writes it made.
+// AlreadyDone is set for steps that were completed by an earlier run, and
+// Resumed for steps that continued the work of an interrupted one
+type StepReport struct {
+	Name        string
+	AlreadyDone bool
+	Resumed     bool
+	Visited     uint64
+	Puts        uint64
+	Deletes     uint64
+}
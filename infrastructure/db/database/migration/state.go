@@ -0,0 +1,125 @@
+package migration
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+var migrationBucket = database.MakeBucket([]byte("database-migration"))
+var versionKey = migrationBucket.Key([]byte("version"))
+var stepsBucket = migrationBucket.Bucket([]byte("steps"))
+
+const (
+	stepInProgress byte = iota
+	stepDone
+)
+
+// stepState is the persisted progress of a step. lastKeySuffix is the suffix
+// of the last key a ForEach step committed, and is nil if it didn't commit
+// any batch yet. Note that an empty suffix is a valid key suffix
+type stepState struct {
+	isDone        bool
+	lastKeySuffix []byte
+}
+
+func stepStatesBucket(version uint32) *database.Bucket {
+	versionBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(versionBytes, version)
+	return stepsBucket.Bucket(versionBytes)
+}
+
+func stepStateKey(version uint32, stepName string) *database.Key {
+	return stepStatesBucket(version).Key([]byte(stepName))
+}
+
+func serializeStepState(state *stepState) []byte {
+	if state.isDone {
+		return []byte{stepDone}
+	}
+	return append([]byte{stepInProgress}, state.lastKeySuffix...)
+}
+
+func deserializeStepState(stateBytes []byte) (*stepState, error) {
+	if len(stateBytes) == 0 {
+		return nil, errors.New("empty migration step state")
+	}
+	switch stateBytes[0] {
+	case stepDone:
+		return &stepState{isDone: true}, nil
+	case stepInProgress:
+		return &stepState{lastKeySuffix: stateBytes[1:]}, nil
+	default:
+		return nil, errors.Errorf("unknown migration step state %d", stateBytes[0])
+	}
+}
+
+func readStepState(reader database.DataAccessor, version uint32, stepName string) (*stepState, error) {
+	stateBytes, err := reader.Get(stepStateKey(version, stepName))
+	if database.IsNotFoundError(err) {
+		return &stepState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deserializeStepState(stateBytes)
+}
+
+// storedVersion returns the version the last migration committed to the
+// database, if any ran
+func storedVersion(reader database.DataAccessor) (version uint32, exists bool, err error) {
+	versionBytes, err := reader.Get(versionKey)
+	if database.IsNotFoundError(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(versionBytes) != 4 {
+		return 0, false, errors.Errorf("invalid stored database version of %d bytes", len(versionBytes))
+	}
+	return binary.LittleEndian.Uint32(versionBytes), true, nil
+}
+
+// commitVersion stores version as the version of the database and removes the
+// progress of the steps of the migration that upgraded to it, atomically
+func commitVersion(db database.Database, version uint32) error {
+	dbTx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	cursor, err := dbTx.Cursor(stepStatesBucket(version))
+	if err != nil {
+		return err
+	}
+	var stepKeys []*database.Key
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			cursor.Close()
+			return err
+		}
+		stepKeys = append(stepKeys, key.Bucket().Key(append([]byte(nil), key.Suffix()...)))
+	}
+	err = cursor.Close()
+	if err != nil {
+		return err
+	}
+	for _, key := range stepKeys {
+		err = dbTx.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	versionBytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(versionBytes, version)
+	err = dbTx.Put(versionKey, versionBytes)
+	if err != nil {
+		return err
+	}
+	return dbTx.Commit()
+}
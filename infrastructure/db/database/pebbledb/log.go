@@ -0,0 +1,21 @@
+package pebbledb
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("KSDB")
+
+// pebbleLogger routes the log messages of pebble to the kaspad log
+type pebbleLogger struct{}
+
+func (pebbleLogger) Infof(format string, args ...interface{}) {
+	log.Infof("Pebble: "+format, args...)
+}
+
+func (pebbleLogger) Fatalf(format string, args ...interface{}) {
+	panics.Exit(log, fmt.Sprintf("Pebble: "+format, args...))
+}
@@ -0,0 +1,40 @@
+package pebbledb
+
+import (
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+// RegisterMetrics exports the internal statistics of the pebble instance
+// as metrics. Only the most recently registered instance is exported
+func (db *PebbleDB) RegisterMetrics() {
+	stat := func(value func(metrics *pebble.Metrics) float64) func() float64 {
+		return func() float64 {
+			return value(db.db.Metrics())
+		}
+	}
+
+	metrics.NewCounterFunc("kaspad_pebble_write_stalls_total", "Writes Pebble stalled to let compaction catch up",
+		func() float64 { return float64(db.writeStalls.Load()) })
+	metrics.NewCounterFunc("kaspad_pebble_write_stall_seconds_total", "Time Pebble writes spent stalled",
+		func() float64 { return time.Duration(db.writeStallNanoseconds.Load()).Seconds() })
+	metrics.NewGaugeFunc("kaspad_pebble_write_stalled", "Whether Pebble writes are currently stalled",
+		func() float64 {
+			if db.writeStallStart.Load() != 0 {
+				return 1
+			}
+			return 0
+		})
+	metrics.NewCounterFunc("kaspad_pebble_compactions_total", "Compactions performed by Pebble",
+		stat(func(metrics *pebble.Metrics) float64 { return float64(metrics.Compact.Count) }))
+	metrics.NewGaugeFunc("kaspad_pebble_read_amplification", "Sublevels a Pebble point read may need to read",
+		stat(func(metrics *pebble.Metrics) float64 { return float64(metrics.ReadAmp()) }))
+	metrics.NewGaugeFunc("kaspad_pebble_size_bytes", "Total disk space used by Pebble",
+		stat(func(metrics *pebble.Metrics) float64 { return float64(metrics.DiskSpaceUsage()) }))
+	metrics.NewGaugeFunc("kaspad_pebble_block_cache_bytes", "Size of the Pebble block cache",
+		stat(func(metrics *pebble.Metrics) float64 { return float64(metrics.BlockCache.Size) }))
+	metrics.NewGaugeFunc("kaspad_pebble_memtable_bytes", "Size of the Pebble memtables",
+		stat(func(metrics *pebble.Metrics) float64 { return float64(metrics.MemTable.Size) }))
+}
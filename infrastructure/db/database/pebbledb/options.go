@@ -0,0 +1,18 @@
+package pebbledb
+
+import "github.com/cockroachdb/pebble"
+
+// Options is a function that returns a pebble
+// Options struct for opening a database.
+func Options() *pebble.Options {
+	options := &pebble.Options{
+		Logger: pebbleLogger{},
+	}
+	// Like the leveldb backend, store the tables uncompressed: most of the
+	// data is hashes, which don't compress
+	options.Levels = make([]pebble.LevelOptions, 7)
+	for i := range options.Levels {
+		options.Levels[i].Compression = pebble.NoCompression
+	}
+	return options.EnsureDefaults()
+}
@@ -0,0 +1,143 @@
+package pebbledb
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+const mib = 1024 * 1024
+
+// writeOptions don't sync every write to disk, like the leveldb backend
+var writeOptions = pebble.NoSync
+
+// PebbleDB defines a thin wrapper around pebble.
+type PebbleDB struct {
+	db *pebble.DB
+
+	// openCursors are released when the database closes, like leveldb does,
+	// because pebble refuses to close with open iterators
+	openCursors     map[*PebbleDBCursor]struct{}
+	openCursorsLock sync.Mutex
+
+	writeStalls           atomic.Int64
+	writeStallNanoseconds atomic.Int64
+	writeStallStart       atomic.Int64
+}
+
+// NewPebbleDB opens a pebble instance defined by the given path.
+func NewPebbleDB(path string, cacheSizeMiB int) (*PebbleDB, error) {
+	db := &PebbleDB{openCursors: make(map[*PebbleDBCursor]struct{})}
+
+	// Open pebble. If it doesn't exist, create it.
+	options := Options()
+	cache := pebble.NewCache(int64(cacheSizeMiB) * mib)
+	defer cache.Unref()
+	options.Cache = cache
+	options.MemTableSize = uint64(cacheSizeMiB) * mib / 2
+	options.EventListener = &pebble.EventListener{
+		WriteStallBegin: func(info pebble.WriteStallBeginInfo) {
+			log.Debugf("Pebble write stall: %s", info.Reason)
+			db.writeStalls.Add(1)
+			db.writeStallStart.Store(time.Now().UnixNano())
+		},
+		WriteStallEnd: func() {
+			db.writeStallNanoseconds.Add(time.Now().UnixNano() - db.writeStallStart.Swap(0))
+		},
+	}
+
+	pebbleDB, err := pebble.Open(path, options)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	db.db = pebbleDB
+	return db, nil
+}
+
+// Compact compacts the pebble instance.
+func (db *PebbleDB) Compact() error {
+	iterator, err := db.db.NewIter(nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !iterator.First() {
+		return errors.WithStack(iterator.Close())
+	}
+	start := append([]byte{}, iterator.Key()...)
+	iterator.Last()
+	// The end of the compacted range is exclusive, so it's right after the last key
+	end := append(append([]byte{}, iterator.Key()...), 0)
+	err = iterator.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(db.db.Compact(start, end, true))
+}
+
+// Close closes the pebble instance.
+func (db *PebbleDB) Close() error {
+	db.openCursorsLock.Lock()
+	openCursors := make([]*PebbleDBCursor, 0, len(db.openCursors))
+	for cursor := range db.openCursors {
+		openCursors = append(openCursors, cursor)
+	}
+	db.openCursorsLock.Unlock()
+	for _, cursor := range openCursors {
+		err := cursor.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := db.db.Close()
+	return errors.WithStack(err)
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *PebbleDB) Put(key *database.Key, value []byte) error {
+	err := db.db.Set(key.Bytes(), value, writeOptions)
+	return errors.WithStack(err)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *PebbleDB) Get(key *database.Key) ([]byte, error) {
+	data, closer, err := db.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, errors.Wrapf(database.ErrNotFound,
+				"key %s not found", key)
+		}
+		return nil, errors.WithStack(err)
+	}
+	defer closer.Close()
+
+	// The data is only valid until the closer is closed
+	return append([]byte{}, data...), nil
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *PebbleDB) Has(key *database.Key) (bool, error) {
+	_, closer, err := db.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return false, nil
+		}
+		return false, errors.WithStack(err)
+	}
+	return true, errors.WithStack(closer.Close())
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *PebbleDB) Delete(key *database.Key) error {
+	err := db.db.Delete(key.Bytes(), writeOptions)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,75 @@
+package pebbledb
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestPebbleDBPersistence(t *testing.T) {
+	path := t.TempDir()
+	db, err := NewPebbleDB(path, 8)
+	if err != nil {
+		t.Fatalf("NewPebbleDB: %s", err)
+	}
+
+	// Compacting an empty database must succeed
+	err = db.Compact()
+	if err != nil {
+		t.Fatalf("Compact of an empty database: %s", err)
+	}
+
+	key := database.MakeBucket([]byte("bucket")).Key([]byte("key"))
+	putData := []byte("Hello world!")
+	err = db.Put(key, putData)
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = db.Compact()
+	if err != nil {
+		t.Fatalf("Compact: %s", err)
+	}
+
+	// A cursor that's left open must not keep the database from closing
+	_, err = db.Cursor(database.MakeBucket([]byte("bucket")))
+	if err != nil {
+		t.Fatalf("Cursor: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	db, err = NewPebbleDB(path, 8)
+	if err != nil {
+		t.Fatalf("NewPebbleDB of an existing database: %s", err)
+	}
+	defer db.Close()
+	getData, err := db.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if !reflect.DeepEqual(getData, putData) {
+		t.Fatalf("expected %s after reopening the database, got %s", putData, getData)
+	}
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	tests := []struct {
+		prefix   []byte
+		expected []byte
+	}{
+		{prefix: []byte{1, 2, 3}, expected: []byte{1, 2, 4}},
+		{prefix: []byte{1, 0xff, 0xff}, expected: []byte{2}},
+		{prefix: []byte{0xff}, expected: nil},
+		{prefix: nil, expected: nil},
+	}
+	for _, test := range tests {
+		actual := prefixUpperBound(test.prefix)
+		if !bytes.Equal(actual, test.expected) || (actual == nil) != (test.expected == nil) {
+			t.Errorf("prefixUpperBound(%x): expected %x but got %x", test.prefix, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// atomicFloat is a float64 that can be updated concurrently
+type atomicFloat struct {
+	bits uint64
+}
+
+func (af *atomicFloat) add(delta float64) {
+	for {
+		oldBits := atomic.LoadUint64(&af.bits)
+		newBits := math.Float64bits(math.Float64frombits(oldBits) + delta)
+		if atomic.CompareAndSwapUint64(&af.bits, oldBits, newBits) {
+			return
+		}
+	}
+}
+
+func (af *atomicFloat) set(value float64) {
+	atomic.StoreUint64(&af.bits, math.Float64bits(value))
+}
+
+func (af *atomicFloat) load() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&af.bits))
+}
+
+// Counter is a value that only goes up, such as the number of
+// processed messages
+type Counter struct {
+	value atomicFloat
+}
+
+// NewCounter creates and registers a new Counter
+func NewCounter(name string, help string) *Counter {
+	counter := &Counter{}
+	defaultRegistry.register(name, help, typeCounter, counter)
+	return counter
+}
+
+// Inc increments the counter by 1
+func (c *Counter) Inc() {
+	c.value.add(1)
+}
+
+// Add adds the given delta to the counter. The delta must not be negative
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		panic("counters cannot decrease")
+	}
+	c.value.add(delta)
+}
+
+// Value returns the current value of the counter
+func (c *Counter) Value() float64 {
+	return c.value.load()
+}
+
+func (c *Counter) collect(writer *sampleWriter) {
+	c.collectWithLabels(writer, nil)
+}
+
+func (c *Counter) collectWithLabels(writer *sampleWriter, labels []label) {
+	writer.writeSample("", labels, c.Value())
+}
+
+// CounterVec is a family of counters that share a name and differ by
+// their label values
+type CounterVec struct {
+	vec *vec
+}
+
+// NewCounterVec creates and registers a new CounterVec with the given label names
+func NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	counterVec := &CounterVec{vec: newVec(labelNames, func() labeledCollector { return &Counter{} })}
+	defaultRegistry.register(name, help, typeCounter, counterVec.vec)
+	return counterVec
+}
+
+// With returns the counter with the given label values, creating it if required
+func (cv *CounterVec) With(labelValues ...string) *Counter {
+	return cv.vec.with(labelValues).(*Counter)
+}
+
+// Delete removes the counter with the given label values
+func (cv *CounterVec) Delete(labelValues ...string) {
+	cv.vec.delete(labelValues)
+}
+
+// DeleteMatching removes all the counters whose given label has the given value
+func (cv *CounterVec) DeleteMatching(labelName string, labelValue string) {
+	cv.vec.deleteMatching(labelName, labelValue)
+}
+
+type counterFunc func() float64
+
+func (cf counterFunc) collect(writer *sampleWriter) {
+	writer.writeSample("", nil, cf())
+}
+
+// NewCounterFunc registers a counter whose value is read from the given function
+// whenever the metrics are exported. Registering a function under the name of an
+// existing one replaces it
+func NewCounterFunc(name string, help string, function func() float64) {
+	defaultRegistry.replace(name, help, typeCounter, counterFunc(function))
+}
@@ -0,0 +1,72 @@
+package metrics
+
+// Gauge is a value that can go up and down, such as the size of a pool
+type Gauge struct {
+	value atomicFloat
+}
+
+// NewGauge creates and registers a new Gauge
+func NewGauge(name string, help string) *Gauge {
+	gauge := &Gauge{}
+	defaultRegistry.register(name, help, typeGauge, gauge)
+	return gauge
+}
+
+// Set sets the gauge to the given value
+func (g *Gauge) Set(value float64) {
+	g.value.set(value)
+}
+
+// Add adds the given delta, which may be negative, to the gauge
+func (g *Gauge) Add(delta float64) {
+	g.value.add(delta)
+}
+
+// Value returns the current value of the gauge
+func (g *Gauge) Value() float64 {
+	return g.value.load()
+}
+
+func (g *Gauge) collect(writer *sampleWriter) {
+	g.collectWithLabels(writer, nil)
+}
+
+func (g *Gauge) collectWithLabels(writer *sampleWriter, labels []label) {
+	writer.writeSample("", labels, g.Value())
+}
+
+// GaugeVec is a family of gauges that share a name and differ by
+// their label values
+type GaugeVec struct {
+	vec *vec
+}
+
+// NewGaugeVec creates and registers a new GaugeVec with the given label names
+func NewGaugeVec(name string, help string, labelNames ...string) *GaugeVec {
+	gaugeVec := &GaugeVec{vec: newVec(labelNames, func() labeledCollector { return &Gauge{} })}
+	defaultRegistry.register(name, help, typeGauge, gaugeVec.vec)
+	return gaugeVec
+}
+
+// With returns the gauge with the given label values, creating it if required
+func (gv *GaugeVec) With(labelValues ...string) *Gauge {
+	return gv.vec.with(labelValues).(*Gauge)
+}
+
+// Delete removes the gauge with the given label values
+func (gv *GaugeVec) Delete(labelValues ...string) {
+	gv.vec.delete(labelValues)
+}
+
+type gaugeFunc func() float64
+
+func (gf gaugeFunc) collect(writer *sampleWriter) {
+	writer.writeSample("", nil, gf())
+}
+
+// NewGaugeFunc registers a gauge whose value is read from the given function
+// whenever the metrics are exported. Registering a function under the name of an
+// existing one replaces it
+func NewGaugeFunc(name string, help string, function func() float64) {
+	defaultRegistry.replace(name, help, typeGauge, gaugeFunc(function))
+}
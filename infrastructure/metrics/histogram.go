@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DurationBuckets are histogram buckets, in seconds, suitable for
+// measuring the duration of most operations of the node
+var DurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Histogram counts observed values, such as durations, in buckets
+type Histogram struct {
+	upperBounds []float64
+
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+	lock         sync.Mutex
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	upperBounds := make([]float64, len(buckets))
+	copy(upperBounds, buckets)
+	sort.Float64s(upperBounds)
+	return &Histogram{
+		upperBounds:  upperBounds,
+		bucketCounts: make([]uint64, len(upperBounds)),
+	}
+}
+
+// NewHistogram creates and registers a new Histogram with the given bucket
+// upper bounds. Values above all of them are counted only in the implicit
+// +Inf bucket
+func NewHistogram(name string, help string, buckets []float64) *Histogram {
+	histogram := newHistogram(buckets)
+	defaultRegistry.register(name, help, typeHistogram, histogram)
+	return histogram
+}
+
+// Observe adds the given value to the histogram
+func (h *Histogram) Observe(value float64) {
+	bucketIndex := sort.SearchFloat64s(h.upperBounds, value)
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if bucketIndex < len(h.bucketCounts) {
+		h.bucketCounts[bucketIndex]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// ObserveDurationSince adds the time passed since the given start, in seconds,
+// to the histogram
+func (h *Histogram) ObserveDurationSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+func (h *Histogram) collect(writer *sampleWriter) {
+	h.collectWithLabels(writer, nil)
+}
+
+func (h *Histogram) collectWithLabels(writer *sampleWriter, labels []label) {
+	h.lock.Lock()
+	bucketCounts := make([]uint64, len(h.bucketCounts))
+	copy(bucketCounts, h.bucketCounts)
+	count := h.count
+	sum := h.sum
+	h.lock.Unlock()
+
+	bucketLabels := make([]label, len(labels)+1)
+	copy(bucketLabels, labels)
+	cumulativeCount := uint64(0)
+	for i, upperBound := range h.upperBounds {
+		cumulativeCount += bucketCounts[i]
+		bucketLabels[len(labels)] = label{name: "le", value: formatValue(upperBound)}
+		writer.writeSample("_bucket", bucketLabels, float64(cumulativeCount))
+	}
+	bucketLabels[len(labels)] = label{name: "le", value: formatValue(math.Inf(1))}
+	writer.writeSample("_bucket", bucketLabels, float64(count))
+	writer.writeSample("_sum", labels, sum)
+	writer.writeSample("_count", labels, float64(count))
+}
+
+// HistogramVec is a family of histograms that share a name and buckets,
+// and differ by their label values
+type HistogramVec struct {
+	vec *vec
+}
+
+// NewHistogramVec creates and registers a new HistogramVec with the given
+// bucket upper bounds and label names
+func NewHistogramVec(name string, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	histogramVec := &HistogramVec{vec: newVec(labelNames, func() labeledCollector { return newHistogram(buckets) })}
+	defaultRegistry.register(name, help, typeHistogram, histogramVec.vec)
+	return histogramVec
+}
+
+// With returns the histogram with the given label values, creating it if required
+func (hv *HistogramVec) With(labelValues ...string) *Histogram {
+	return hv.vec.with(labelValues).(*Histogram)
+}
@@ -0,0 +1,9 @@
+package metrics
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("MTRC")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,133 @@
+// Package metrics keeps counters, gauges and histograms of node internals, and
+// exports them in the Prometheus text exposition format.
+//
+// Metrics are registered once, usually as package variables of the
+// subsystem they measure, and are safe for concurrent use.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// collector writes the samples of a metric family
+type collector interface {
+	collect(writer *sampleWriter)
+}
+
+type family struct {
+	name       string
+	help       string
+	metricType string
+	collector  collector
+}
+
+type registry struct {
+	families map[string]*family
+	lock     sync.RWMutex
+}
+
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+	return &registry{families: make(map[string]*family)}
+}
+
+func (r *registry) register(name string, help string, metricType string, collector collector) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.families[name]; ok {
+		panic(fmt.Sprintf("metric %s is already registered", name))
+	}
+	r.families[name] = &family{name: name, help: help, metricType: metricType, collector: collector}
+}
+
+// replace registers the given metric family, replacing any family
+// previously registered under the same name
+func (r *registry) replace(name string, help string, metricType string, collector collector) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.families[name] = &family{name: name, help: help, metricType: metricType, collector: collector}
+}
+
+// write writes all the registered metric families, ordered by name
+func (r *registry) write(writer io.Writer) error {
+	r.lock.RLock()
+	families := make([]*family, 0, len(r.families))
+	for _, family := range r.families {
+		families = append(families, family)
+	}
+	r.lock.RUnlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	builder := &strings.Builder{}
+	for _, family := range families {
+		builder.WriteString("# HELP " + family.name + " " + escapeHelp(family.help) + "\n")
+		builder.WriteString("# TYPE " + family.name + " " + family.metricType + "\n")
+		family.collector.collect(&sampleWriter{name: family.name, builder: builder})
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// sampleWriter writes the samples of a single metric family
+type sampleWriter struct {
+	name    string
+	builder *strings.Builder
+}
+
+func (sw *sampleWriter) writeSample(suffix string, labels []label, value float64) {
+	sw.builder.WriteString(sw.name + suffix)
+	if len(labels) > 0 {
+		sw.builder.WriteString("{")
+		for i, label := range labels {
+			if i > 0 {
+				sw.builder.WriteString(",")
+			}
+			sw.builder.WriteString(label.name + `="` + escapeLabelValue(label.value) + `"`)
+		}
+		sw.builder.WriteString("}")
+	}
+	sw.builder.WriteString(" " + formatValue(value) + "\n")
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExposition(t *testing.T) {
+	counter := NewCounter("test_counter_total", "A test counter")
+	counter.Inc()
+	counter.Add(2.5)
+
+	gaugeVec := NewGaugeVec("test_gauge", "A test gauge\nwith two lines", "object")
+	gaugeVec.With(`"quoted"`).Set(-2)
+	gaugeVec.With("blocks").Set(0.5)
+
+	counterVec := NewCounterVec("test_messages_total", "A test counter vector", "peer", "command")
+	counterVec.With("peer1", "Ping").Inc()
+	counterVec.With("peer1", "Pong").Inc()
+	counterVec.With("peer2", "Ping").Inc()
+	counterVec.DeleteMatching("peer", "peer1")
+
+	histogram := NewHistogram("test_duration_seconds", "A test histogram", []float64{1, 0.1})
+	histogram.Observe(0.05)
+	histogram.Observe(0.1)
+	histogram.Observe(0.5)
+	histogram.Observe(7)
+
+	NewGaugeFunc("test_gauge_func", "A test gauge function", func() float64 { return 1 })
+	NewGaugeFunc("test_gauge_func", "A test gauge function", func() float64 { return 2 })
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("ReadAll: %+v", err)
+	}
+
+	expected := `# HELP test_counter_total A test counter
+# TYPE test_counter_total counter
+test_counter_total 3.5
+# HELP test_duration_seconds A test histogram
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.1"} 2
+test_duration_seconds_bucket{le="1"} 3
+test_duration_seconds_bucket{le="+Inf"} 4
+test_duration_seconds_sum 7.65
+test_duration_seconds_count 4
+# HELP test_gauge A test gauge\nwith two lines
+# TYPE test_gauge gauge
+test_gauge{object="\"quoted\""} -2
+test_gauge{object="blocks"} 0.5
+# HELP test_gauge_func A test gauge function
+# TYPE test_gauge_func gauge
+test_gauge_func 2
+# HELP test_messages_total A test counter vector
+# TYPE test_messages_total counter
+test_messages_total{peer="peer2",command="Ping"} 1
+`
+	if !strings.Contains(string(body), expected) {
+		t.Fatalf("unexpected exposition. Want it to contain:\n%s\nGot:\n%s", expected, body)
+	}
+}
+
+func TestDuplicateRegistration(t *testing.T) {
+	NewGauge("test_duplicate", "A gauge")
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected registering a metric twice to panic")
+		}
+	}()
+	NewCounter("test_duplicate", "A counter")
+}
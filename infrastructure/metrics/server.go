@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"net/http"
+)
+
+// Handler returns an http.Handler that exports all the registered
+// metrics in the Prometheus text exposition format
+func Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		err := defaultRegistry.write(writer)
+		if err != nil {
+			log.Debugf("Error writing the metrics to %s: %s", request.RemoteAddr, err)
+		}
+	})
+}
+
+// Start starts serving the metrics over HTTP at /metrics of the
+// given listen address
+func Start(listenAddress string) {
+	spawn("metrics.Start", func() {
+		serveMux := http.NewServeMux()
+		serveMux.Handle("/metrics", Handler())
+		log.Infof("Metrics server listening on %s", listenAddress)
+		log.Error(http.ListenAndServe(listenAddress, serveMux))
+	})
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// labeledCollector writes the samples of a single child of a labeled metric
+type labeledCollector interface {
+	collectWithLabels(writer *sampleWriter, labels []label)
+}
+
+type vecChild struct {
+	labels    []label
+	collector labeledCollector
+}
+
+// vec keeps the children of a labeled metric, one for every combination
+// of label values it was used with
+type vec struct {
+	labelNames []string
+	newChild   func() labeledCollector
+
+	children map[string]*vecChild
+	lock     sync.RWMutex
+}
+
+func newVec(labelNames []string, newChild func() labeledCollector) *vec {
+	return &vec{
+		labelNames: labelNames,
+		newChild:   newChild,
+		children:   make(map[string]*vecChild),
+	}
+}
+
+func childKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func (v *vec) with(labelValues []string) labeledCollector {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("expected %d label values but got %d", len(v.labelNames), len(labelValues)))
+	}
+	key := childKey(labelValues)
+
+	v.lock.RLock()
+	child, ok := v.children[key]
+	v.lock.RUnlock()
+	if ok {
+		return child.collector
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	child, ok = v.children[key]
+	if !ok {
+		labels := make([]label, len(labelValues))
+		for i, labelValue := range labelValues {
+			labels[i] = label{name: v.labelNames[i], value: labelValue}
+		}
+		child = &vecChild{labels: labels, collector: v.newChild()}
+		v.children[key] = child
+	}
+	return child.collector
+}
+
+func (v *vec) delete(labelValues []string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	delete(v.children, childKey(labelValues))
+}
+
+func (v *vec) deleteMatching(labelName string, labelValue string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for key, child := range v.children {
+		for _, label := range child.labels {
+			if label.name == labelName && label.value == labelValue {
+				delete(v.children, key)
+				break
+			}
+		}
+	}
+}
+
+func (v *vec) collect(writer *sampleWriter) {
+	v.lock.RLock()
+	keys := make([]string, 0, len(v.children))
+	for key := range v.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	children := make([]*vecChild, len(keys))
+	for i, key := range keys {
+		children[i] = v.children[key]
+	}
+	v.lock.RUnlock()
+
+	for _, child := range children {
+		child.collector.collectWithLabels(writer, child.labels)
+	}
+}
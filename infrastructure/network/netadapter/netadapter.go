@@ -1,6 +1,9 @@
 package netadapter
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
 	"sync"
 	"sync/atomic"
 
@@ -10,6 +13,7 @@ import (
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/jsonrpcserver"
 	"github.com/pkg/errors"
 )
 
@@ -28,6 +32,7 @@ type NetAdapter struct {
 	p2pServer            server.P2PServer
 	p2pRouterInitializer RouterInitializer
 	rpcServer            server.Server
+	jsonRPCServer        server.Server
 	rpcRouterInitializer RouterInitializer
 	stop                 uint32
 
@@ -46,7 +51,13 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	if err != nil {
 		return nil, err
 	}
-	rpcServer, err := grpcserver.NewRPCServer(cfg.RPCListeners, cfg.RPCMaxClients)
+	rpcTLSConfig, err := newRPCTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// gRPC, HTTP and WebSocket RPC clients are all counted against --rpcmaxclients
+	rpcConnectionLimiter := server.NewConnectionLimiter(cfg.RPCMaxClients)
+	rpcServer, err := grpcserver.NewRPCServer(cfg.RPCListeners, rpcConnectionLimiter, rpcTLSConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -62,9 +73,50 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	adapter.p2pServer.SetOnConnectedHandler(adapter.onP2PConnectedHandler)
 	adapter.rpcServer.SetOnConnectedHandler(adapter.onRPCConnectedHandler)
 
+	// The JSON-RPC server shares the router initializer of the gRPC one, so both
+	// serve the same RPC handlers
+	if len(cfg.RPCJSONListeners) > 0 {
+		adapter.jsonRPCServer, err = jsonrpcserver.NewJSONRPCServer(
+			cfg.RPCJSONListeners, rpcConnectionLimiter, cfg.RPCMaxWebsockets, rpcTLSConfig,
+			cfg.RPCJSONAllowedOrigins)
+		if err != nil {
+			return nil, err
+		}
+		adapter.jsonRPCServer.SetOnConnectedHandler(adapter.onRPCConnectedHandler)
+	}
+
 	return &adapter, nil
 }
 
+// newRPCTLSConfig returns the TLS configuration of the RPC servers, or nil if
+// RPC client certificates aren't enabled. Clients that don't present a
+// certificate are still accepted, so that they may authenticate with a token
+func newRPCTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	if cfg.RPCClientCAFile == "" {
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(cfg.RPCCert, cfg.RPCKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading the RPC certificate")
+	}
+	clientCAs, err := os.ReadFile(cfg.RPCClientCAFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the RPC client CA file")
+	}
+	clientCAPool := x509.NewCertPool()
+	if !clientCAPool.AppendCertsFromPEM(clientCAs) {
+		return nil, errors.Errorf("no certificates found in the RPC client CA file %s", cfg.RPCClientCAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    clientCAPool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
 // Start begins the operation of the NetAdapter
 func (na *NetAdapter) Start() error {
 	if na.p2pRouterInitializer == nil {
@@ -82,6 +134,12 @@ func (na *NetAdapter) Start() error {
 	if err != nil {
 		return err
 	}
+	if na.jsonRPCServer != nil {
+		err = na.jsonRPCServer.Start()
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -95,6 +153,12 @@ func (na *NetAdapter) Stop() error {
 	if err != nil {
 		return err
 	}
+	if na.jsonRPCServer != nil {
+		err = na.jsonRPCServer.Stop()
+		if err != nil {
+			return err
+		}
+	}
 	return na.rpcServer.Stop()
 }
 
@@ -128,7 +192,11 @@ func (na *NetAdapter) P2PConnectionCount() int {
 }
 
 func (na *NetAdapter) onP2PConnectedHandler(connection server.Connection) error {
-	netConnection := newNetConnection(connection, na.p2pRouterInitializer, "on P2P connected")
+	routerInitializer := func(router *routerpkg.Router, netConnection *NetConnection) {
+		router.EnableMessageMetrics(connection.Address().String())
+		na.p2pRouterInitializer(router, netConnection)
+	}
+	netConnection := newNetConnection(connection, routerInitializer, "on P2P connected")
 
 	na.p2pConnectionsLock.Lock()
 	defer na.p2pConnectionsLock.Unlock()
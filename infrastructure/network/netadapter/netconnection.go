@@ -70,6 +70,11 @@ func (c *NetConnection) Address() string {
 	return c.connection.Address().String()
 }
 
+// Credentials returns the credentials the client presented when it connected
+func (c *NetConnection) Credentials() *server.Credentials {
+	return c.connection.Credentials()
+}
+
 // IsOutbound returns whether the connection is outbound
 func (c *NetConnection) IsOutbound() bool {
 	return c.connection.IsOutbound()
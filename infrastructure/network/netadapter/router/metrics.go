@@ -0,0 +1,55 @@
+package router
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var (
+	receivedMessages = metrics.NewCounterVec("kaspad_peer_received_messages_total",
+		"Messages received from each connected peer, by command", "peer", "command")
+	sentMessages = metrics.NewCounterVec("kaspad_peer_sent_messages_total",
+		"Messages queued to be sent to each connected peer, by command", "peer", "command")
+)
+
+// EnableMessageMetrics makes the router count the messages it routes
+// under the given peer until it's closed. It must be called before any
+// message is routed
+func (r *Router) EnableMessageMetrics(peer string) {
+	r.metricsPeer = peer
+	r.outgoingRoute.onEnqueued = func(message appmessage.Message) {
+		r.countMessage(sentMessages, message)
+	}
+}
+
+func (r *Router) countReceivedMessage(message appmessage.Message) {
+	r.countMessage(receivedMessages, message)
+}
+
+// countMessage counts the given message, unless the router was closed
+// in the meantime, since that would recreate the deleted series
+func (r *Router) countMessage(counter *metrics.CounterVec, message appmessage.Message) {
+	if r.metricsPeer == "" {
+		return
+	}
+
+	r.metricsLock.Lock()
+	defer r.metricsLock.Unlock()
+
+	if r.isClosed {
+		return
+	}
+	counter.With(r.metricsPeer, message.Command().String()).Inc()
+}
+
+func (r *Router) deleteMessageMetrics() {
+	r.metricsLock.Lock()
+	defer r.metricsLock.Unlock()
+
+	r.isClosed = true
+	if r.metricsPeer == "" {
+		return
+	}
+	receivedMessages.DeleteMatching("peer", r.metricsPeer)
+	sentMessages.DeleteMatching("peer", r.metricsPeer)
+}
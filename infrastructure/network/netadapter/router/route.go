@@ -35,6 +35,9 @@ type Route struct {
 	closed    bool
 	closeLock sync.Mutex
 	capacity  int
+
+	// onEnqueued, if set, is called with every message that was enqueued
+	onEnqueued func(message appmessage.Message)
 }
 
 // NewRoute create a new Route
@@ -63,6 +66,9 @@ func (r *Route) Enqueue(message appmessage.Message) error {
 		return errors.Wrapf(ErrRouteCapacityReached, "route '%s' reached capacity of %d", r.name, r.capacity)
 	}
 	r.channel <- message
+	if r.onEnqueued != nil {
+		r.onEnqueued(message)
+	}
 	return nil
 }
 
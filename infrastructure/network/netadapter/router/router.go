@@ -21,6 +21,15 @@ type Router struct {
 	incomingRoutesLock sync.RWMutex
 
 	outgoingRoute *Route
+
+	// metricsPeer is the peer the routed messages are counted under,
+	// if message metrics are enabled
+	metricsPeer string
+
+	// isClosed and metricsLock make sure no message is counted after
+	// Close deletes the message metrics of the peer
+	isClosed    bool
+	metricsLock sync.Mutex
 }
 
 // NewRouter creates a new empty router
@@ -83,7 +92,12 @@ func (r *Router) EnqueueIncomingMessage(message appmessage.Message) error {
 	if !ok {
 		return errors.Errorf("a route for '%s' does not exist", message.Command())
 	}
-	return route.Enqueue(message)
+	err := route.Enqueue(message)
+	if err != nil {
+		return err
+	}
+	r.countReceivedMessage(message)
+	return nil
 }
 
 // OutgoingRoute returns the outgoing route
@@ -105,6 +119,7 @@ func (r *Router) Close() {
 		route.Close()
 	}
 	r.outgoingRoute.Close()
+	r.deleteMessageMetrics()
 }
 
 func (r *Router) incomingRoute(messageType appmessage.MessageCommand) (*Route, bool) {
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ConnectionLimiter counts the connections of one or more servers against a
+// single limit, so that clients can't exceed it by spreading their connections
+// over the servers
+type ConnectionLimiter struct {
+	maxConnections  int
+	connectionCount int
+	lock            sync.Mutex
+}
+
+// NewConnectionLimiter creates a ConnectionLimiter that allows up to
+// maxConnections connections at once. 0 means no limit
+func NewConnectionLimiter(maxConnections int) *ConnectionLimiter {
+	return &ConnectionLimiter{maxConnections: maxConnections}
+}
+
+// Acquire counts a new connection and returns the number of connections including
+// it, unless the limit is reached. Every successful Acquire must be followed by a
+// Release once the connection closes
+func (cl *ConnectionLimiter) Acquire() (int, error) {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+
+	if cl.maxConnections > 0 && cl.connectionCount >= cl.maxConnections {
+		return cl.connectionCount, errors.Errorf("limit of %d connections has been exceeded", cl.maxConnections)
+	}
+	cl.connectionCount++
+	return cl.connectionCount, nil
+}
+
+// Release stops counting a connection counted by Acquire
+func (cl *ConnectionLimiter) Release() {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+
+	cl.connectionCount--
+}
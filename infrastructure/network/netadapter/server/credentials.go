@@ -0,0 +1,37 @@
+package server
+
+import (
+	"crypto/tls"
+	"strings"
+)
+
+// Credentials are the credentials a client presented when it connected
+type Credentials struct {
+	// AuthToken is the bearer token the client sent, if any
+	AuthToken string
+
+	// CertificateCommonName is the common name of the verified
+	// certificate the client presented, if any
+	CertificateCommonName string
+}
+
+// BearerToken returns the token of the given HTTP Authorization header value,
+// or an empty string if it isn't of the Bearer scheme
+func BearerToken(authorization string) string {
+	const prefix = "bearer "
+	if len(authorization) < len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(prefix):])
+}
+
+// CertificateCommonName returns the common name of the client certificate
+// that was verified during the given TLS handshake, or an empty string if
+// the client presented none
+func CertificateCommonName(connectionState *tls.ConnectionState) string {
+	if connectionState == nil || len(connectionState.VerifiedChains) == 0 ||
+		len(connectionState.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return connectionState.VerifiedChains[0][0].Subject.CommonName
+}
@@ -21,6 +21,7 @@ type gRPCConnection struct {
 	stream                   grpcStream
 	router                   *router.Router
 	lowLevelClientConnection *grpc.ClientConn
+	credentials              *server.Credentials
 
 	// streamLock protects concurrent access to stream.
 	// Note that it's an RWMutex. Despite what the name
@@ -127,6 +128,17 @@ func (c *gRPCConnection) Address() *net.TCPAddr {
 	return c.address
 }
 
+// Credentials returns the credentials the client presented when it connected.
+// Outbound connections have no credentials
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) Credentials() *server.Credentials {
+	if c.credentials == nil {
+		return &server.Credentials{}
+	}
+	return c.credentials
+}
+
 func (c *gRPCConnection) receive() (*protowire.KaspadMessage, error) {
 	// We use RLock here and in send() because they can work
 	// in parallel. closeSend(), however, must not have either
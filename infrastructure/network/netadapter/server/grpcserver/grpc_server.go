@@ -2,14 +2,16 @@ package grpcserver
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/util/panics"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	grpccredentials "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"net"
-	"sync"
 	"time"
 )
 
@@ -19,21 +21,24 @@ type gRPCServer struct {
 	server             *grpc.Server
 	name               string
 
-	maxInboundConnections      int
-	inboundConnectionCount     int
-	inboundConnectionCountLock *sync.Mutex
+	connectionLimiter *server.ConnectionLimiter
 }
 
-// newGRPCServer creates a gRPC server
-func newGRPCServer(listeningAddresses []string, maxMessageSize int, maxInboundConnections int, name string) *gRPCServer {
-	log.Debugf("Created new %s GRPC server with maxMessageSize %d and maxInboundConnections %d", name, maxMessageSize, maxInboundConnections)
+// newGRPCServer creates a gRPC server. Its inbound connections are counted by connectionLimiter,
+// which may be shared with other servers. The server uses TLS if tlsConfig isn't nil
+func newGRPCServer(listeningAddresses []string, maxMessageSize int, connectionLimiter *server.ConnectionLimiter,
+	name string, tlsConfig *tls.Config) *gRPCServer {
+
+	log.Debugf("Created new %s GRPC server with maxMessageSize %d", name, maxMessageSize)
+	serverOptions := []grpc.ServerOption{grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)}
+	if tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(grpccredentials.NewTLS(tlsConfig)))
+	}
 	return &gRPCServer{
-		server:                     grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)),
-		listeningAddresses:         listeningAddresses,
-		name:                       name,
-		maxInboundConnections:      maxInboundConnections,
-		inboundConnectionCount:     0,
-		inboundConnectionCountLock: &sync.Mutex{},
+		server:             grpc.NewServer(serverOptions...),
+		listeningAddresses: listeningAddresses,
+		name:               name,
+		connectionLimiter:  connectionLimiter,
 	}
 }
 
@@ -94,11 +99,12 @@ func (s *gRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnected
 }
 
 func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStream) error {
-	connectionCount, err := s.incrementInboundConnectionCountAndLimitIfRequired()
+	connectionCount, err := s.connectionLimiter.Acquire()
 	if err != nil {
+		log.Warnf("Rejected a %s inbound connection: %s", s.name, err)
 		return err
 	}
-	defer s.decrementInboundConnectionCount()
+	defer s.connectionLimiter.Release()
 
 	peerInfo, ok := peer.FromContext(ctx)
 	if !ok {
@@ -110,6 +116,7 @@ func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStr
 	}
 
 	connection := newConnection(s, tcpAddress, stream, nil)
+	connection.credentials = credentialsFromContext(ctx, peerInfo)
 
 	err = s.onConnectedHandler(connection)
 	if err != nil {
@@ -122,22 +129,20 @@ func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStr
 	return nil
 }
 
-func (s *gRPCServer) incrementInboundConnectionCountAndLimitIfRequired() (int, error) {
-	s.inboundConnectionCountLock.Lock()
-	defer s.inboundConnectionCountLock.Unlock()
-
-	if s.maxInboundConnections > 0 && s.inboundConnectionCount == s.maxInboundConnections {
-		log.Warnf("Limit of %d %s inbound connections has been exceeded", s.maxInboundConnections, s.name)
-		return s.inboundConnectionCount, errors.Errorf("limit of %d %s inbound connections has been exceeded", s.maxInboundConnections, s.name)
+// credentialsFromContext extracts the bearer token sent in the stream metadata
+// and the common name of the verified client certificate, if any
+func credentialsFromContext(ctx context.Context, peerInfo *peer.Peer) *server.Credentials {
+	credentials := &server.Credentials{}
+	incomingMetadata, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		authorization := incomingMetadata.Get("authorization")
+		if len(authorization) > 0 {
+			credentials.AuthToken = server.BearerToken(authorization[0])
+		}
 	}
-
-	s.inboundConnectionCount++
-	return s.inboundConnectionCount, nil
-}
-
-func (s *gRPCServer) decrementInboundConnectionCount() {
-	s.inboundConnectionCountLock.Lock()
-	defer s.inboundConnectionCountLock.Unlock()
-
-	s.inboundConnectionCount--
+	tlsInfo, ok := peerInfo.AuthInfo.(grpccredentials.TLSInfo)
+	if ok {
+		credentials.CertificateCommonName = server.CertificateCommonName(&tlsInfo.State)
+	}
+	return credentials
 }
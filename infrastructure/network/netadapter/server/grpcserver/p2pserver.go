@@ -28,7 +28,8 @@ const p2pMaxInboundConnections = 0
 
 // NewP2PServer creates a new P2PServer
 func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
-	gRPCServer := newGRPCServer(listeningAddresses, p2pMaxMessageSize, p2pMaxInboundConnections, "P2P")
+	gRPCServer := newGRPCServer(listeningAddresses, p2pMaxMessageSize,
+		server.NewConnectionLimiter(p2pMaxInboundConnections), "P2P", nil)
 	p2pServer := &p2pServer{gRPCServer: *gRPCServer}
 	protowire.RegisterP2PServer(gRPCServer.server, p2pServer)
 	return p2pServer, nil
@@ -1,11 +1,14 @@
 // RPC-related types. Request messages, response messages, and dependant types.
 //
-// Clients are expected to build RequestMessages and wrap them in KaspadMessage. (see messages.proto)
+// Clients are expected to build RequestMessages and wrap them in KaspadMessage.
+// (see messages.proto)
 //
-// Having received a RequestMessage, (wrapped in a KaspadMessage) the RPC server will respond with a
-// ResponseMessage (likewise wrapped in a KaspadMessage) respective to the original RequestMessage.
+// Having received a RequestMessage, (wrapped in a KaspadMessage) the RPC server
+// will respond with a ResponseMessage (likewise wrapped in a KaspadMessage)
+// respective to the original RequestMessage.
 //
-// **IMPORTANT:** This API is a work in progress and is subject to break between versions.
+// **IMPORTANT:** This API is a work in progress and is subject to break between
+// versions.
 //
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
@@ -81,7 +84,8 @@ func (SubmitBlockResponseMessage_RejectReason) EnumDescriptor() ([]byte, []int)
 
 // RPCError represents a generic non-internal error.
 //
-// Receivers of any ResponseMessage are expected to check whether its error field is not null.
+// Receivers of any ResponseMessage are expected to check whether its error
+// field is not null.
 type RPCError struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -488,7 +492,7 @@ type RpcTransaction struct {
 	Gas           uint64                     `protobuf:"varint,6,opt,name=gas,proto3" json:"gas,omitempty"`
 	Payload       string                     `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
 	VerboseData   *RpcTransactionVerboseData `protobuf:"bytes,9,opt,name=verboseData,proto3" json:"verboseData,omitempty"`
-	Mass          uint64                     `protobuf:"varint,10,opt,name=mass,proto3" json:"mass,omitempty"` // <<< BPS10 - Add mass to RpcTransaction
+	Mass          uint64                     `protobuf:"varint,10,opt,name=mass,proto3" json:"mass,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1058,7 +1062,8 @@ func (x *RpcTransactionOutputVerboseData) GetScriptPublicKeyAddress() string {
 	return ""
 }
 
-// GetCurrentNetworkRequestMessage requests the network kaspad is currently running against.
+// GetCurrentNetworkRequestMessage requests the network kaspad is currently
+// running against.
 //
 // Possible networks are: Mainnet, Testnet, Simnet, Devnet
 type GetCurrentNetworkRequestMessage struct {
@@ -1150,7 +1155,8 @@ func (x *GetCurrentNetworkResponseMessage) GetError() *RPCError {
 }
 
 // SubmitBlockRequestMessage requests to submit a block into the DAG.
-// Blocks are generally expected to have been generated using the getBlockTemplate call.
+// Blocks are generally expected to have been generated using the
+// getBlockTemplate call.
 //
 // See: GetBlockTemplateRequestMessage
 type SubmitBlockRequestMessage struct {
@@ -1258,7 +1264,8 @@ func (x *SubmitBlockResponseMessage) GetError() *RPCError {
 }
 
 // GetBlockTemplateRequestMessage requests a current block template.
-// Callers are expected to solve the block template and submit it using the submitBlock call
+// Callers are expected to solve the block template and submit it using the
+// submitBlock call
 //
 // See: SubmitBlockRequestMessage
 type GetBlockTemplateRequestMessage struct {
@@ -1318,9 +1325,10 @@ type GetBlockTemplateResponseMessage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Block *RpcBlock              `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
 	// Whether kaspad thinks that it's synced.
-	// Callers are discouraged (but not forbidden) from solving blocks when kaspad is not synced.
-	// That is because when kaspad isn't in sync with the rest of the network there's a high
-	// chance the block will never be accepted, thus the solving effort would have been wasted.
+	// Callers are discouraged (but not forbidden) from solving blocks when kaspad
+	// is not synced. That is because when kaspad isn't in sync with the rest of
+	// the network there's a high chance the block will never be accepted, thus
+	// the solving effort would have been wasted.
 	IsSynced      bool      `protobuf:"varint,2,opt,name=isSynced,proto3" json:"isSynced,omitempty"`
 	Error         *RPCError `protobuf:"bytes,1000,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -1378,7 +1386,8 @@ func (x *GetBlockTemplateResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// NotifyBlockAddedRequestMessage registers this connection for blockAdded notifications.
+// NotifyBlockAddedRequestMessage registers this connection for blockAdded
+// notifications.
 //
 // See: BlockAddedNotificationMessage
 type NotifyBlockAddedRequestMessage struct {
@@ -1461,8 +1470,8 @@ func (x *NotifyBlockAddedResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// BlockAddedNotificationMessage is sent whenever a blocks has been added (NOT accepted)
-// into the DAG.
+// BlockAddedNotificationMessage is sent whenever a blocks has been added (NOT
+// accepted) into the DAG.
 //
 // See: NotifyBlockAddedRequestMessage
 type BlockAddedNotificationMessage struct {
@@ -1509,8 +1518,8 @@ func (x *BlockAddedNotificationMessage) GetBlock() *RpcBlock {
 	return nil
 }
 
-// GetPeerAddressesRequestMessage requests the list of known kaspad addresses in the
-// current network. (mainnet, testnet, etc.)
+// GetPeerAddressesRequestMessage requests the list of known kaspad addresses in
+// the current network. (mainnet, testnet, etc.)
 type GetPeerAddressesRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1741,8 +1750,8 @@ func (x *GetSelectedTipHashResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetMempoolEntryRequestMessage requests information about a specific transaction
-// in the mempool.
+// GetMempoolEntryRequestMessage requests information about a specific
+// transaction in the mempool.
 type GetMempoolEntryRequestMessage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The transaction's TransactionID.
@@ -1856,8 +1865,8 @@ func (x *GetMempoolEntryResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetMempoolEntriesRequestMessage requests information about all the transactions
-// currently in the mempool.
+// GetMempoolEntriesRequestMessage requests information about all the
+// transactions currently in the mempool.
 type GetMempoolEntriesRequestMessage struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	IncludeOrphanPool     bool                   `protobuf:"varint,1,opt,name=includeOrphanPool,proto3" json:"includeOrphanPool,omitempty"`
@@ -2022,8 +2031,8 @@ func (x *MempoolEntry) GetIsOrphan() bool {
 	return false
 }
 
-// GetConnectedPeerInfoRequestMessage requests information about all the p2p peers
-// currently connected to this kaspad.
+// GetConnectedPeerInfoRequestMessage requests information about all the p2p
+// peers currently connected to this kaspad.
 type GetConnectedPeerInfoRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -2430,7 +2439,8 @@ func (x *SubmitTransactionResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// NotifyVirtualSelectedParentChainChangedRequestMessage registers this connection for virtualSelectedParentChainChanged notifications.
+// NotifyVirtualSelectedParentChainChangedRequestMessage registers this
+// connection for virtualSelectedParentChainChanged notifications.
 //
 // See: VirtualSelectedParentChainChangedNotificationMessage
 type NotifyVirtualSelectedParentChainChangedRequestMessage struct {
@@ -2521,8 +2531,8 @@ func (x *NotifyVirtualSelectedParentChainChangedResponseMessage) GetError() *RPC
 	return nil
 }
 
-// VirtualSelectedParentChainChangedNotificationMessage is sent whenever the DAG's selected parent
-// chain had changed.
+// VirtualSelectedParentChainChangedNotificationMessage is sent whenever the
+// DAG's selected parent chain had changed.
 //
 // See: NotifyVirtualSelectedParentChainChangedRequestMessage
 type VirtualSelectedParentChainChangedNotificationMessage struct {
@@ -2531,7 +2541,8 @@ type VirtualSelectedParentChainChangedNotificationMessage struct {
 	RemovedChainBlockHashes []string `protobuf:"bytes,1,rep,name=removedChainBlockHashes,proto3" json:"removedChainBlockHashes,omitempty"`
 	// The chain blocks that were added, in low-to-high order
 	AddedChainBlockHashes []string `protobuf:"bytes,3,rep,name=addedChainBlockHashes,proto3" json:"addedChainBlockHashes,omitempty"`
-	// Will be filled only if `includeAcceptedTransactionIds = true` in the notify request.
+	// Will be filled only if `includeAcceptedTransactionIds = true` in the notify
+	// request.
 	AcceptedTransactionIds []*AcceptedTransactionIds `protobuf:"bytes,2,rep,name=acceptedTransactionIds,proto3" json:"acceptedTransactionIds,omitempty"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
@@ -2794,8 +2805,8 @@ func (x *GetSubnetworkResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetVirtualSelectedParentChainFromBlockRequestMessage requests the virtual selected
-// parent chain from some startHash to this kaspad's current virtual
+// GetVirtualSelectedParentChainFromBlockRequestMessage requests the virtual
+// selected parent chain from some startHash to this kaspad's current virtual
 type GetVirtualSelectedParentChainFromBlockRequestMessage struct {
 	state                         protoimpl.MessageState `protogen:"open.v1"`
 	StartHash                     string                 `protobuf:"bytes,1,opt,name=startHash,proto3" json:"startHash,omitempty"`
@@ -2907,7 +2918,8 @@ type GetVirtualSelectedParentChainFromBlockResponseMessage struct {
 	// The chain blocks that were added, in low-to-high order
 	AddedChainBlockHashes []string `protobuf:"bytes,3,rep,name=addedChainBlockHashes,proto3" json:"addedChainBlockHashes,omitempty"`
 	// The transactions accepted by each block in addedChainBlockHashes.
-	// Will be filled only if `includeAcceptedTransactionIds = true` in the request.
+	// Will be filled only if `includeAcceptedTransactionIds = true` in the
+	// request.
 	AcceptedTransactionIds []*AcceptedTransactionIds `protobuf:"bytes,2,rep,name=acceptedTransactionIds,proto3" json:"acceptedTransactionIds,omitempty"`
 	Error                  *RPCError                 `protobuf:"bytes,1000,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields          protoimpl.UnknownFields
@@ -2972,8 +2984,8 @@ func (x *GetVirtualSelectedParentChainFromBlockResponseMessage) GetError() *RPCE
 	return nil
 }
 
-// GetBlocksRequestMessage requests blocks between a certain block lowHash up to this
-// kaspad's current virtual.
+// GetBlocksRequestMessage requests blocks between a certain block lowHash up to
+// this kaspad's current virtual.
 type GetBlocksRequestMessage struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	LowHash             string                 `protobuf:"bytes,1,opt,name=lowHash,proto3" json:"lowHash,omitempty"`
@@ -3094,8 +3106,8 @@ func (x *GetBlocksResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetBlockCountRequestMessage requests the current number of blocks in this kaspad.
-// Note that this number may decrease as pruning occurs.
+// GetBlockCountRequestMessage requests the current number of blocks in this
+// kaspad. Note that this number may decrease as pruning occurs.
 type GetBlockCountRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -3192,8 +3204,8 @@ func (x *GetBlockCountResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetBlockDagInfoRequestMessage requests general information about the current state
-// of this kaspad's DAG.
+// GetBlockDagInfoRequestMessage requests general information about the current
+// state of this kaspad's DAG.
 type GetBlockDagInfoRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -3797,8 +3809,8 @@ func (x *GetHeadersResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// NotifyUtxosChangedRequestMessage registers this connection for utxoChanged notifications
-// for the given addresses.
+// NotifyUtxosChangedRequestMessage registers this connection for utxoChanged
+// notifications for the given addresses.
 //
 // This call is only available when this kaspad was started with `--utxoindex`
 //
@@ -3891,7 +3903,8 @@ func (x *NotifyUtxosChangedResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// UtxosChangedNotificationMessage is sent whenever the UTXO index had been updated.
+// UtxosChangedNotificationMessage is sent whenever the UTXO index had been
+// updated.
 //
 // See: NotifyUtxosChangedRequestMessage
 type UtxosChangedNotificationMessage struct {
@@ -4006,8 +4019,8 @@ func (x *UtxosByAddressesEntry) GetUtxoEntry() *RpcUtxoEntry {
 	return nil
 }
 
-// StopNotifyingUtxosChangedRequestMessage unregisters this connection for utxoChanged notifications
-// for the given addresses.
+// StopNotifyingUtxosChangedRequestMessage unregisters this connection for
+// utxoChanged notifications for the given addresses.
 //
 // This call is only available when this kaspad was started with `--utxoindex`
 //
@@ -4100,7 +4113,8 @@ func (x *StopNotifyingUtxosChangedResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetUtxosByAddressesRequestMessage requests all current UTXOs for the given kaspad addresses
+// GetUtxosByAddressesRequestMessage requests all current UTXOs for the given
+// kaspad addresses
 //
 // This call is only available when this kaspad was started with `--utxoindex`
 type GetUtxosByAddressesRequestMessage struct {
@@ -4199,7 +4213,8 @@ func (x *GetUtxosByAddressesResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetBalanceByAddressRequest returns the total balance in unspent transactions towards a given address
+// GetBalanceByAddressRequest returns the total balance in unspent transactions
+// towards a given address
 //
 // This call is only available when this kaspad was started with `--utxoindex`
 type GetBalanceByAddressRequestMessage struct {
@@ -4454,8 +4469,8 @@ func (x *GetBalancesByAddressesResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// GetVirtualSelectedParentBlueScoreRequestMessage requests the blue score of the current selected parent
-// of the virtual block.
+// GetVirtualSelectedParentBlueScoreRequestMessage requests the blue score of
+// the current selected parent of the virtual block.
 type GetVirtualSelectedParentBlueScoreRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -4544,8 +4559,8 @@ func (x *GetVirtualSelectedParentBlueScoreResponseMessage) GetError() *RPCError
 	return nil
 }
 
-// NotifyVirtualSelectedParentBlueScoreChangedRequestMessage registers this connection for
-// virtualSelectedParentBlueScoreChanged notifications.
+// NotifyVirtualSelectedParentBlueScoreChangedRequestMessage registers this
+// connection for virtualSelectedParentBlueScoreChanged notifications.
 //
 // See: VirtualSelectedParentBlueScoreChangedNotificationMessage
 type NotifyVirtualSelectedParentBlueScoreChangedRequestMessage struct {
@@ -4628,8 +4643,8 @@ func (x *NotifyVirtualSelectedParentBlueScoreChangedResponseMessage) GetError()
 	return nil
 }
 
-// VirtualSelectedParentBlueScoreChangedNotificationMessage is sent whenever the blue score
-// of the virtual's selected parent changes.
+// VirtualSelectedParentBlueScoreChangedNotificationMessage is sent whenever the
+// blue score of the virtual's selected parent changes.
 //
 // See NotifyVirtualSelectedParentBlueScoreChangedRequestMessage
 type VirtualSelectedParentBlueScoreChangedNotificationMessage struct {
@@ -4894,8 +4909,8 @@ func (x *NotifyPruningPointUTXOSetOverrideResponseMessage) GetError() *RPCError
 	return nil
 }
 
-// PruningPointUTXOSetOverrideNotificationMessage is sent whenever the UTXO index
-// resets due to pruning point change via IBD.
+// PruningPointUTXOSetOverrideNotificationMessage is sent whenever the UTXO
+// index resets due to pruning point change via IBD.
 //
 // See NotifyPruningPointUTXOSetOverrideRequestMessage
 type PruningPointUTXOSetOverrideNotificationMessage struct {
@@ -4934,8 +4949,8 @@ func (*PruningPointUTXOSetOverrideNotificationMessage) Descriptor() ([]byte, []i
 	return file_rpc_proto_rawDescGZIP(), []int{89}
 }
 
-// StopNotifyingPruningPointUTXOSetOverrideRequestMessage unregisters this connection for
-// pruning point UTXO set override notifications.
+// StopNotifyingPruningPointUTXOSetOverrideRequestMessage unregisters this
+// connection for pruning point UTXO set override notifications.
 //
 // This call is only available when this kaspad was started with `--utxoindex`
 //
@@ -5507,8 +5522,8 @@ func (x *NotifyNewBlockTemplateResponseMessage) GetError() *RPCError {
 	return nil
 }
 
-// NewBlockTemplateNotificationMessage is sent whenever a new updated block template is
-// available for miners.
+// NewBlockTemplateNotificationMessage is sent whenever a new updated block
+// template is available for miners.
 //
 // See NotifyNewBlockTemplateRequestMessage
 type NewBlockTemplateNotificationMessage struct {
@@ -5756,10 +5771,11 @@ func (*GetCoinSupplyRequestMessage) Descriptor() ([]byte, []int) {
 }
 
 type GetCoinSupplyResponseMessage struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	MaxSompi         uint64                 `protobuf:"varint,1,opt,name=maxSompi,proto3" json:"maxSompi,omitempty"` // note: this is a hard coded maxSupply, actual maxSupply is expected to deviate by upto -5%, but cannot be measured exactly.
-	CirculatingSompi uint64                 `protobuf:"varint,2,opt,name=circulatingSompi,proto3" json:"circulatingSompi,omitempty"`
-	Error            *RPCError              `protobuf:"bytes,1000,opt,name=error,proto3" json:"error,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MaxSompi uint64                 `protobuf:"varint,1,opt,name=maxSompi,proto3" json:"maxSompi,omitempty"` // note: this is a hard coded maxSupply, actual maxSupply is expected
+	// to deviate by upto -5%, but cannot be measured exactly.
+	CirculatingSompi uint64    `protobuf:"varint,2,opt,name=circulatingSompi,proto3" json:"circulatingSompi,omitempty"`
+	Error            *RPCError `protobuf:"bytes,1000,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -7795,6 +7811,554 @@ func (x *SubmitTransactionReplacementResponseMessage) GetError() *RPCError {
 	return nil
 }
 
+// GetTransactionRequestMessage requests the selected chain block that accepted
+// the given transaction.
+//
+// This call is only available when this kaspad was started with `--txindex`
+type GetTransactionRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transactionId,proto3" json:"transactionId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequestMessage) Reset() {
+	*x = GetTransactionRequestMessage{}
+	mi := &file_rpc_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequestMessage) ProtoMessage() {}
+
+func (x *GetTransactionRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequestMessage.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequestMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *GetTransactionRequestMessage) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type GetTransactionResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transactionId,proto3" json:"transactionId,omitempty"`
+	// The block that contains the transaction
+	IncludingBlockHash string `protobuf:"bytes,2,opt,name=includingBlockHash,proto3" json:"includingBlockHash,omitempty"`
+	// The selected chain block that merged includingBlockHash and accepted the
+	// transaction
+	AcceptingBlockHash     string    `protobuf:"bytes,3,opt,name=acceptingBlockHash,proto3" json:"acceptingBlockHash,omitempty"`
+	AcceptingBlockDaaScore uint64    `protobuf:"varint,4,opt,name=acceptingBlockDaaScore,proto3" json:"acceptingBlockDaaScore,omitempty"`
+	Error                  *RPCError `protobuf:"bytes,1000,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetTransactionResponseMessage) Reset() {
+	*x = GetTransactionResponseMessage{}
+	mi := &file_rpc_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResponseMessage) ProtoMessage() {}
+
+func (x *GetTransactionResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResponseMessage.ProtoReflect.Descriptor instead.
+func (*GetTransactionResponseMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *GetTransactionResponseMessage) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *GetTransactionResponseMessage) GetIncludingBlockHash() string {
+	if x != nil {
+		return x.IncludingBlockHash
+	}
+	return ""
+}
+
+func (x *GetTransactionResponseMessage) GetAcceptingBlockHash() string {
+	if x != nil {
+		return x.AcceptingBlockHash
+	}
+	return ""
+}
+
+func (x *GetTransactionResponseMessage) GetAcceptingBlockDaaScore() uint64 {
+	if x != nil {
+		return x.AcceptingBlockDaaScore
+	}
+	return 0
+}
+
+func (x *GetTransactionResponseMessage) GetError() *RPCError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GetAddressTransactionsRequestMessage requests the credits and debits made to
+// the given address by transactions accepted by the selected chain, ordered by
+// the DAA score of their accepting blocks.
+//
+// This call is only available when this kaspad was started with
+// `--addresshistoryindex`
+type GetAddressTransactionsRequestMessage struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The number of entries to skip
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The maximum number of entries to return. Zero means the server maximum
+	Limit         uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressTransactionsRequestMessage) Reset() {
+	*x = GetAddressTransactionsRequestMessage{}
+	mi := &file_rpc_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressTransactionsRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressTransactionsRequestMessage) ProtoMessage() {}
+
+func (x *GetAddressTransactionsRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressTransactionsRequestMessage.ProtoReflect.Descriptor instead.
+func (*GetAddressTransactionsRequestMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *GetAddressTransactionsRequestMessage) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAddressTransactionsRequestMessage) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetAddressTransactionsRequestMessage) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RpcAddressTransaction struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId          string                 `protobuf:"bytes,1,opt,name=transactionId,proto3" json:"transactionId,omitempty"`
+	AcceptingBlockHash     string                 `protobuf:"bytes,2,opt,name=acceptingBlockHash,proto3" json:"acceptingBlockHash,omitempty"`
+	AcceptingBlockDaaScore uint64                 `protobuf:"varint,3,opt,name=acceptingBlockDaaScore,proto3" json:"acceptingBlockDaaScore,omitempty"`
+	Amount                 uint64                 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Whether the transaction spends `amount` from the address rather than
+	// paying it to the address
+	IsDebit       bool `protobuf:"varint,5,opt,name=isDebit,proto3" json:"isDebit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RpcAddressTransaction) Reset() {
+	*x = RpcAddressTransaction{}
+	mi := &file_rpc_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RpcAddressTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcAddressTransaction) ProtoMessage() {}
+
+func (x *RpcAddressTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcAddressTransaction.ProtoReflect.Descriptor instead.
+func (*RpcAddressTransaction) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *RpcAddressTransaction) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *RpcAddressTransaction) GetAcceptingBlockHash() string {
+	if x != nil {
+		return x.AcceptingBlockHash
+	}
+	return ""
+}
+
+func (x *RpcAddressTransaction) GetAcceptingBlockDaaScore() uint64 {
+	if x != nil {
+		return x.AcceptingBlockDaaScore
+	}
+	return 0
+}
+
+func (x *RpcAddressTransaction) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RpcAddressTransaction) GetIsDebit() bool {
+	if x != nil {
+		return x.IsDebit
+	}
+	return false
+}
+
+type GetAddressTransactionsResponseMessage struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Address       string                   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Entries       []*RpcAddressTransaction `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Error         *RPCError                `protobuf:"bytes,1000,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressTransactionsResponseMessage) Reset() {
+	*x = GetAddressTransactionsResponseMessage{}
+	mi := &file_rpc_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressTransactionsResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressTransactionsResponseMessage) ProtoMessage() {}
+
+func (x *GetAddressTransactionsResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressTransactionsResponseMessage.ProtoReflect.Descriptor instead.
+func (*GetAddressTransactionsResponseMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *GetAddressTransactionsResponseMessage) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAddressTransactionsResponseMessage) GetEntries() []*RpcAddressTransaction {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAddressTransactionsResponseMessage) GetError() *RPCError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GetRpcUsageRequestMessage requests the rate limit usage of every RPC client
+// that recently sent rate limited requests
+//
+// This call is only available when this kaspad was started with `--rpcratelimit`
+type GetRpcUsageRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRpcUsageRequestMessage) Reset() {
+	*x = GetRpcUsageRequestMessage{}
+	mi := &file_rpc_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRpcUsageRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRpcUsageRequestMessage) ProtoMessage() {}
+
+func (x *GetRpcUsageRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRpcUsageRequestMessage.ProtoReflect.Descriptor instead.
+func (*GetRpcUsageRequestMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{144}
+}
+
+type RpcCostClassUsage struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CostClass         string                 `protobuf:"bytes,1,opt,name=costClass,proto3" json:"costClass,omitempty"`
+	Requests          uint64                 `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	ThrottledRequests uint64                 `protobuf:"varint,3,opt,name=throttledRequests,proto3" json:"throttledRequests,omitempty"`
+	// The tokens left in the bucket of the client. Negative after a request
+	// that cost more than the burst of the class
+	AvailableTokens float64 `protobuf:"fixed64,4,opt,name=availableTokens,proto3" json:"availableTokens,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RpcCostClassUsage) Reset() {
+	*x = RpcCostClassUsage{}
+	mi := &file_rpc_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RpcCostClassUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcCostClassUsage) ProtoMessage() {}
+
+func (x *RpcCostClassUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcCostClassUsage.ProtoReflect.Descriptor instead.
+func (*RpcCostClassUsage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *RpcCostClassUsage) GetCostClass() string {
+	if x != nil {
+		return x.CostClass
+	}
+	return ""
+}
+
+func (x *RpcCostClassUsage) GetRequests() uint64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *RpcCostClassUsage) GetThrottledRequests() uint64 {
+	if x != nil {
+		return x.ThrottledRequests
+	}
+	return 0
+}
+
+func (x *RpcCostClassUsage) GetAvailableTokens() float64 {
+	if x != nil {
+		return x.AvailableTokens
+	}
+	return 0
+}
+
+type RpcClientUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The token hash, certificate common name or IP the client is identified by
+	ClientId         string               `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	CostClassesUsage []*RpcCostClassUsage `protobuf:"bytes,2,rep,name=costClassesUsage,proto3" json:"costClassesUsage,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RpcClientUsage) Reset() {
+	*x = RpcClientUsage{}
+	mi := &file_rpc_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RpcClientUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcClientUsage) ProtoMessage() {}
+
+func (x *RpcClientUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcClientUsage.ProtoReflect.Descriptor instead.
+func (*RpcClientUsage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *RpcClientUsage) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RpcClientUsage) GetCostClassesUsage() []*RpcCostClassUsage {
+	if x != nil {
+		return x.CostClassesUsage
+	}
+	return nil
+}
+
+type GetRpcUsageResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clients       []*RpcClientUsage      `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	Error         *RPCError              `protobuf:"bytes,1000,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRpcUsageResponseMessage) Reset() {
+	*x = GetRpcUsageResponseMessage{}
+	mi := &file_rpc_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRpcUsageResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRpcUsageResponseMessage) ProtoMessage() {}
+
+func (x *GetRpcUsageResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRpcUsageResponseMessage.ProtoReflect.Descriptor instead.
+func (*GetRpcUsageResponseMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *GetRpcUsageResponseMessage) GetClients() []*RpcClientUsage {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *GetRpcUsageResponseMessage) GetError() *RPCError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
 var File_rpc_proto protoreflect.FileDescriptor
 
 var file_rpc_proto_rawDesc = []byte{
@@ -8947,10 +9511,90 @@ var file_rpc_proto_rawDesc = []byte{
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0xe8,
 	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
 	0x65, 0x2e, 0x52, 0x50, 0x43, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x6b, 0x61, 0x73, 0x70, 0x61, 0x6e, 0x65, 0x74, 0x2f, 0x6b, 0x61, 0x73, 0x70, 0x61, 0x64, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x72, 0x22, 0x44, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x89, 0x02, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x2e, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x2e, 0x0a, 0x12, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x36, 0x0a, 0x16, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x44, 0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x16, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44,
+	0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x52, 0x50, 0x43, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x6e, 0x0a, 0x24, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0xd7, 0x01, 0x0a, 0x15, 0x52, 0x70, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a,
+	0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x12, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x12, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x16, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x16, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x44, 0x61, 0x61, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x73, 0x44, 0x65, 0x62, 0x69, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x44, 0x65, 0x62, 0x69, 0x74, 0x22, 0xa9, 0x01,
+	0x0a, 0x25, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x3a, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x52,
+	0x70, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x52, 0x50, 0x43, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74,
+	0x52, 0x70, 0x63, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa5, 0x01, 0x0a, 0x11, 0x52, 0x70, 0x63, 0x43, 0x6f,
+	0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6f, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6f, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74,
+	0x6c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x11, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x22, 0x76,
+	0x0a, 0x0e, 0x52, 0x70, 0x63, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x48, 0x0a, 0x10,
+	0x63, 0x6f, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x52, 0x70, 0x63, 0x43, 0x6f, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x10, 0x63, 0x6f, 0x73, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65,
+	0x73, 0x55, 0x73, 0x61, 0x67, 0x65, 0x22, 0x7d, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x52, 0x70, 0x63,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x52, 0x70, 0x63, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x52, 0x50, 0x43, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x61, 0x73, 0x70, 0x61, 0x6e, 0x65, 0x74, 0x2f, 0x6b, 0x61, 0x73,
+	0x70, 0x61, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -8966,7 +9610,7 @@ func file_rpc_proto_rawDescGZIP() []byte {
 }
 
 var file_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 139)
+var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 148)
 var file_rpc_proto_goTypes = []any{
 	(SubmitBlockResponseMessage_RejectReason)(0), // 0: protowire.SubmitBlockResponseMessage.RejectReason
 	(*RPCError)(nil),                                                   // 1: protowire.RPCError
@@ -9108,6 +9752,15 @@ var file_rpc_proto_goTypes = []any{
 	(*GetCurrentBlockColorResponseMessage)(nil),                        // 137: protowire.GetCurrentBlockColorResponseMessage
 	(*SubmitTransactionReplacementRequestMessage)(nil),                 // 138: protowire.SubmitTransactionReplacementRequestMessage
 	(*SubmitTransactionReplacementResponseMessage)(nil),                // 139: protowire.SubmitTransactionReplacementResponseMessage
+	(*GetTransactionRequestMessage)(nil),                               // 140: protowire.GetTransactionRequestMessage
+	(*GetTransactionResponseMessage)(nil),                              // 141: protowire.GetTransactionResponseMessage
+	(*GetAddressTransactionsRequestMessage)(nil),                       // 142: protowire.GetAddressTransactionsRequestMessage
+	(*RpcAddressTransaction)(nil),                                      // 143: protowire.RpcAddressTransaction
+	(*GetAddressTransactionsResponseMessage)(nil),                      // 144: protowire.GetAddressTransactionsResponseMessage
+	(*GetRpcUsageRequestMessage)(nil),                                  // 145: protowire.GetRpcUsageRequestMessage
+	(*RpcCostClassUsage)(nil),                                          // 146: protowire.RpcCostClassUsage
+	(*RpcClientUsage)(nil),                                             // 147: protowire.RpcClientUsage
+	(*GetRpcUsageResponseMessage)(nil),                                 // 148: protowire.GetRpcUsageResponseMessage
 }
 var file_rpc_proto_depIdxs = []int32{
 	3,   // 0: protowire.RpcBlock.header:type_name -> protowire.RpcBlockHeader
@@ -9211,11 +9864,17 @@ var file_rpc_proto_depIdxs = []int32{
 	6,   // 98: protowire.SubmitTransactionReplacementRequestMessage.transaction:type_name -> protowire.RpcTransaction
 	6,   // 99: protowire.SubmitTransactionReplacementResponseMessage.replacedTransaction:type_name -> protowire.RpcTransaction
 	1,   // 100: protowire.SubmitTransactionReplacementResponseMessage.error:type_name -> protowire.RPCError
-	101, // [101:101] is the sub-list for method output_type
-	101, // [101:101] is the sub-list for method input_type
-	101, // [101:101] is the sub-list for extension type_name
-	101, // [101:101] is the sub-list for extension extendee
-	0,   // [0:101] is the sub-list for field type_name
+	1,   // 101: protowire.GetTransactionResponseMessage.error:type_name -> protowire.RPCError
+	143, // 102: protowire.GetAddressTransactionsResponseMessage.entries:type_name -> protowire.RpcAddressTransaction
+	1,   // 103: protowire.GetAddressTransactionsResponseMessage.error:type_name -> protowire.RPCError
+	146, // 104: protowire.RpcClientUsage.costClassesUsage:type_name -> protowire.RpcCostClassUsage
+	147, // 105: protowire.GetRpcUsageResponseMessage.clients:type_name -> protowire.RpcClientUsage
+	1,   // 106: protowire.GetRpcUsageResponseMessage.error:type_name -> protowire.RPCError
+	107, // [107:107] is the sub-list for method output_type
+	107, // [107:107] is the sub-list for method input_type
+	107, // [107:107] is the sub-list for extension type_name
+	107, // [107:107] is the sub-list for extension extendee
+	0,   // [0:107] is the sub-list for field type_name
 }
 
 func init() { file_rpc_proto_init() }
@@ -9229,7 +9888,7 @@ func file_rpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpc_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   139,
+			NumMessages:   148,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,39 @@
+package protowire
+
+import (
+	"strings"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewErrorResponse returns the response message of the given RPC request,
+// carrying only the given error. The response is found by its KaspadMessage
+// payload field, which is named after the one of the request
+func NewErrorResponse(request appmessage.Message, rpcError *appmessage.RPCError) (appmessage.Message, error) {
+	requestMessage, err := FromAppMessage(request)
+	if err != nil {
+		return nil, err
+	}
+	payloadOneof := requestMessage.ProtoReflect().Descriptor().Oneofs().ByName("payload")
+	requestField := requestMessage.ProtoReflect().WhichOneof(payloadOneof)
+	if requestField == nil || !strings.HasSuffix(string(requestField.Name()), "Request") {
+		return nil, errors.Errorf("message %s is not an RPC request", request.Command())
+	}
+	responseField := payloadOneof.Fields().ByName(
+		protoreflect.Name(strings.TrimSuffix(string(requestField.Name()), "Request") + "Response"))
+	if responseField == nil {
+		return nil, errors.Errorf("RPC request %s has no response", request.Command())
+	}
+	errorField := responseField.Message().Fields().ByName("error")
+	if errorField == nil {
+		return nil, errors.Errorf("the response of RPC request %s has no error field", request.Command())
+	}
+
+	responseMessage := &KaspadMessage{}
+	response := responseMessage.ProtoReflect().NewField(responseField)
+	response.Message().Set(errorField, protoreflect.ValueOfMessage((&RPCError{Message: rpcError.Message}).ProtoReflect()))
+	responseMessage.ProtoReflect().Set(responseField, response)
+	return responseMessage.ToAppMessage()
+}
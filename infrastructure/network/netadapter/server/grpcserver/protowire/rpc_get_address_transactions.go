@@ -0,0 +1,111 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetAddressTransactionsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAddressTransactionsRequest is nil")
+	}
+	return x.GetAddressTransactionsRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetAddressTransactionsRequest) fromAppMessage(message *appmessage.GetAddressTransactionsRequestMessage) error {
+	x.GetAddressTransactionsRequest = &GetAddressTransactionsRequestMessage{
+		Address: message.Address,
+		Offset:  message.Offset,
+		Limit:   message.Limit,
+	}
+	return nil
+}
+
+func (x *GetAddressTransactionsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetAddressTransactionsRequestMessage is nil")
+	}
+	return &appmessage.GetAddressTransactionsRequestMessage{
+		Address: x.Address,
+		Offset:  x.Offset,
+		Limit:   x.Limit,
+	}, nil
+}
+
+func (x *KaspadMessage_GetAddressTransactionsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAddressTransactionsResponse is nil")
+	}
+	return x.GetAddressTransactionsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetAddressTransactionsResponse) fromAppMessage(message *appmessage.GetAddressTransactionsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	entries := make([]*RpcAddressTransaction, len(message.Entries))
+	for i, entry := range message.Entries {
+		entries[i] = &RpcAddressTransaction{}
+		entries[i].fromAppMessage(entry)
+	}
+	x.GetAddressTransactionsResponse = &GetAddressTransactionsResponseMessage{
+		Address: message.Address,
+		Entries: entries,
+		Error:   err,
+	}
+	return nil
+}
+
+func (x *GetAddressTransactionsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetAddressTransactionsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Entries) != 0 {
+		return nil, errors.New("GetAddressTransactionsResponseMessage contains both an error and a response")
+	}
+
+	entries := make([]*appmessage.AddressTransaction, len(x.Entries))
+	for i, entry := range x.Entries {
+		entryAsAppMessage, err := entry.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entryAsAppMessage
+	}
+
+	return &appmessage.GetAddressTransactionsResponseMessage{
+		Address: x.Address,
+		Entries: entries,
+		Error:   rpcErr,
+	}, nil
+}
+
+func (x *RpcAddressTransaction) toAppMessage() (*appmessage.AddressTransaction, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcAddressTransaction is nil")
+	}
+	return &appmessage.AddressTransaction{
+		TransactionID:          x.TransactionId,
+		AcceptingBlockHash:     x.AcceptingBlockHash,
+		AcceptingBlockDAAScore: x.AcceptingBlockDaaScore,
+		Amount:                 x.Amount,
+		IsDebit:                x.IsDebit,
+	}, nil
+}
+
+func (x *RpcAddressTransaction) fromAppMessage(message *appmessage.AddressTransaction) {
+	*x = RpcAddressTransaction{
+		TransactionId:          message.TransactionID,
+		AcceptingBlockHash:     message.AcceptingBlockHash,
+		AcceptingBlockDaaScore: message.AcceptingBlockDAAScore,
+		Amount:                 message.Amount,
+		IsDebit:                message.IsDebit,
+	}
+}
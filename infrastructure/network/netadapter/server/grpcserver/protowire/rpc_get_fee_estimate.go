@@ -10,6 +10,7 @@ func (x *KaspadMessage_GetFeeEstimateRequest) toAppMessage() (appmessage.Message
 }
 
 func (x *KaspadMessage_GetFeeEstimateRequest) fromAppMessage(_ *appmessage.GetFeeEstimateRequestMessage) error {
+	x.GetFeeEstimateRequest = &GetFeeEstimateRequestMessage{}
 	return nil
 }
 
@@ -20,6 +21,25 @@ func (x *KaspadMessage_GetFeeEstimateResponse) toAppMessage() (appmessage.Messag
 	return x.GetFeeEstimateResponse.toAppMessage()
 }
 
+func (x *KaspadMessage_GetFeeEstimateResponse) fromAppMessage(message *appmessage.GetFeeEstimateResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetFeeEstimateResponse = &GetFeeEstimateResponseMessage{
+		Estimate: &RpcFeeEstimate{
+			PriorityBucket: &RpcFeerateBucket{
+				Feerate:          message.Estimate.PriorityBucket.Feerate,
+				EstimatedSeconds: message.Estimate.PriorityBucket.EstimatedSeconds,
+			},
+			NormalBuckets: feeRateBucketsFromAppMessage(message.Estimate.NormalBuckets),
+			LowBuckets:    feeRateBucketsFromAppMessage(message.Estimate.LowBuckets),
+		},
+		Error: err,
+	}
+	return nil
+}
+
 func (x *GetFeeEstimateResponseMessage) toAppMessage() (appmessage.Message, error) {
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "GetFeeEstimateResponseMessage is nil")
@@ -29,6 +49,10 @@ func (x *GetFeeEstimateResponseMessage) toAppMessage() (appmessage.Message, erro
 	if err != nil && !errors.Is(err, errorNil) {
 		return nil, err
 	}
+	// An error response carries no estimate
+	if rpcErr != nil {
+		return &appmessage.GetFeeEstimateResponseMessage{Error: rpcErr}, nil
+	}
 
 	estimate, err := x.Estimate.toAppMessage()
 	if err != nil {
@@ -45,6 +69,9 @@ func (x *RpcFeeEstimate) toAppMessage() (appmessage.RPCFeeEstimate, error) {
 	if x == nil {
 		return appmessage.RPCFeeEstimate{}, errors.Wrapf(errorNil, "RpcFeeEstimate is nil")
 	}
+	if x.PriorityBucket == nil {
+		return appmessage.RPCFeeEstimate{}, errors.Wrapf(errorNil, "RpcFeeEstimate.PriorityBucket is nil")
+	}
 	return appmessage.RPCFeeEstimate{
 		PriorityBucket: appmessage.RPCFeeRateBucket{
 			Feerate:          x.PriorityBucket.Feerate,
@@ -65,3 +92,14 @@ func feeRateBucketsToAppMessage(protoBuckets []*RpcFeerateBucket) []appmessage.R
 	}
 	return appMsgBuckets
 }
+
+func feeRateBucketsFromAppMessage(appMsgBuckets []appmessage.RPCFeeRateBucket) []*RpcFeerateBucket {
+	protoBuckets := make([]*RpcFeerateBucket, len(appMsgBuckets))
+	for i, bucket := range appMsgBuckets {
+		protoBuckets[i] = &RpcFeerateBucket{
+			Feerate:          bucket.Feerate,
+			EstimatedSeconds: bucket.EstimatedSeconds,
+		}
+	}
+	return protoBuckets
+}
@@ -0,0 +1,86 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetRpcUsageRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetRpcUsageRequest is nil")
+	}
+	return &appmessage.GetRPCUsageRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetRpcUsageRequest) fromAppMessage(_ *appmessage.GetRPCUsageRequestMessage) error {
+	x.GetRpcUsageRequest = &GetRpcUsageRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetRpcUsageResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetRpcUsageResponse is nil")
+	}
+	return x.GetRpcUsageResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetRpcUsageResponse) fromAppMessage(message *appmessage.GetRPCUsageResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	clients := make([]*RpcClientUsage, len(message.Clients))
+	for i, client := range message.Clients {
+		costClassesUsage := make([]*RpcCostClassUsage, len(client.CostClassesUsage))
+		for j, costClassUsage := range client.CostClassesUsage {
+			costClassesUsage[j] = &RpcCostClassUsage{
+				CostClass:         costClassUsage.CostClass,
+				Requests:          costClassUsage.Requests,
+				ThrottledRequests: costClassUsage.ThrottledRequests,
+				AvailableTokens:   costClassUsage.AvailableTokens,
+			}
+		}
+		clients[i] = &RpcClientUsage{
+			ClientId:         client.ClientID,
+			CostClassesUsage: costClassesUsage,
+		}
+	}
+	x.GetRpcUsageResponse = &GetRpcUsageResponseMessage{
+		Clients: clients,
+		Error:   err,
+	}
+	return nil
+}
+
+func (x *GetRpcUsageResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetRpcUsageResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	clients := make([]*appmessage.RPCClientUsage, len(x.Clients))
+	for i, client := range x.Clients {
+		costClassesUsage := make([]*appmessage.RPCCostClassUsage, len(client.CostClassesUsage))
+		for j, costClassUsage := range client.CostClassesUsage {
+			costClassesUsage[j] = &appmessage.RPCCostClassUsage{
+				CostClass:         costClassUsage.CostClass,
+				Requests:          costClassUsage.Requests,
+				ThrottledRequests: costClassUsage.ThrottledRequests,
+				AvailableTokens:   costClassUsage.AvailableTokens,
+			}
+		}
+		clients[i] = &appmessage.RPCClientUsage{
+			ClientID:         client.ClientId,
+			CostClassesUsage: costClassesUsage,
+		}
+	}
+
+	return &appmessage.GetRPCUsageResponseMessage{
+		Clients: clients,
+		Error:   rpcErr,
+	}, nil
+}
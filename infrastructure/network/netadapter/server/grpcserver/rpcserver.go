@@ -1,6 +1,8 @@
 package grpcserver
 
 import (
+	"crypto/tls"
+
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/kaspanet/kaspad/util/panics"
@@ -14,9 +16,12 @@ type rpcServer struct {
 // RPCMaxMessageSize is the max message size for the RPC server to send and receive
 const RPCMaxMessageSize = 1024 * 1024 * 1024 // 1 GB
 
-// NewRPCServer creates a new RPCServer
-func NewRPCServer(listeningAddresses []string, rpcMaxInboundConnections int) (server.Server, error) {
-	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, rpcMaxInboundConnections, "RPC")
+// NewRPCServer creates a new RPCServer, whose clients are counted by connectionLimiter. The
+// server uses TLS if tlsConfig isn't nil
+func NewRPCServer(listeningAddresses []string, connectionLimiter *server.ConnectionLimiter,
+	tlsConfig *tls.Config) (server.Server, error) {
+
+	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, connectionLimiter, "RPC", tlsConfig)
 	rpcServer := &rpcServer{gRPCServer: *gRPCServer}
 	protowire.RegisterRPCServer(gRPCServer.server, rpcServer)
 	return rpcServer, nil
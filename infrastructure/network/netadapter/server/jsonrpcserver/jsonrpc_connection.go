@@ -0,0 +1,243 @@
+package jsonrpcserver
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/pkg/errors"
+)
+
+// writeFunc writes a serialized JSON-RPC message to the client. It's called
+// with nil once a request sent as a JSON-RPC notification was handled
+type writeFunc func(serializedMessage []byte) error
+
+// pendingRequest is a request that was passed to the router and is waiting
+// for its response
+type pendingRequest struct {
+	id             json.RawMessage
+	isNotification bool
+}
+
+type jsonRPCConnection struct {
+	server             *jsonRPCServer
+	address            *net.TCPAddr
+	credentials        *server.Credentials
+	router             *router.Router
+	write              writeFunc
+	allowSubscriptions bool
+	transport          string
+
+	// pendingRequests holds the requests that wait for their response in
+	// the order they were received. The RPC handlers handle the requests
+	// of a connection one by one, so the responses leave the router in the
+	// same order
+	pendingRequests     []*pendingRequest
+	pendingRequestsLock sync.Mutex
+
+	stopChan                chan struct{}
+	onDisconnectedHandler   server.OnDisconnectedHandler
+	onInvalidMessageHandler server.OnInvalidMessageHandler
+
+	isConnected uint32
+}
+
+func newConnection(s *jsonRPCServer, address *net.TCPAddr, transport string, allowSubscriptions bool,
+	credentials *server.Credentials, write writeFunc) *jsonRPCConnection {
+
+	return &jsonRPCConnection{
+		server:             s,
+		address:            address,
+		credentials:        credentials,
+		write:              write,
+		allowSubscriptions: allowSubscriptions,
+		transport:          transport,
+		stopChan:           make(chan struct{}),
+		isConnected:        1,
+	}
+}
+
+func (c *jsonRPCConnection) Start(router *router.Router) {
+	if c.onDisconnectedHandler == nil {
+		panic(errors.New("onDisconnectedHandler is nil"))
+	}
+
+	c.router = router
+
+	spawn("jsonRPCConnection.Start-sendLoop", func() {
+		err := c.sendLoop()
+		if err != nil {
+			log.Errorf("error from sendLoop for %s: %s", c, err)
+		}
+		c.Disconnect()
+	})
+}
+
+func (c *jsonRPCConnection) String() string {
+	return c.transport + "://" + c.Address().String()
+}
+
+func (c *jsonRPCConnection) IsConnected() bool {
+	return atomic.LoadUint32(&c.isConnected) != 0
+}
+
+func (c *jsonRPCConnection) IsOutbound() bool {
+	return false
+}
+
+func (c *jsonRPCConnection) SetOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
+	c.onDisconnectedHandler = onDisconnectedHandler
+}
+
+func (c *jsonRPCConnection) SetOnInvalidMessageHandler(onInvalidMessageHandler server.OnInvalidMessageHandler) {
+	c.onInvalidMessageHandler = onInvalidMessageHandler
+}
+
+func (c *jsonRPCConnection) Address() *net.TCPAddr {
+	return c.address
+}
+
+func (c *jsonRPCConnection) Credentials() *server.Credentials {
+	return c.credentials
+}
+
+// Disconnect disconnects the connection
+// Calling this function a second time doesn't do anything
+//
+// This is part of the Connection interface
+func (c *jsonRPCConnection) Disconnect() {
+	if !atomic.CompareAndSwapUint32(&c.isConnected, 1, 0) {
+		return
+	}
+
+	close(c.stopChan)
+
+	log.Debugf("Disconnecting from %s", c)
+	if c.onDisconnectedHandler != nil {
+		c.onDisconnectedHandler()
+	}
+}
+
+// handleRequest parses the given JSON-RPC request and passes it to the router.
+// It returns the serialized response if the request was answered without
+// reaching the router, and whether a response is expected from the router
+func (c *jsonRPCConnection) handleRequest(serializedRequest []byte) (serializedResponse []byte, isPending bool) {
+	var request jsonRPCRequest
+	err := json.Unmarshal(serializedRequest, &request)
+	if err != nil {
+		if strings.HasPrefix(strings.TrimSpace(string(serializedRequest)), "[") {
+			return serializeErrorResponse(nil, &jsonRPCError{Code: errorCodeInvalidRequest,
+				Message: "batch requests are not supported"}), false
+		}
+		return serializeErrorResponse(nil, &jsonRPCError{Code: errorCodeParseError, Message: err.Error()}), false
+	}
+	if request.JSONRPC != jsonRPCVersion || request.Method == "" {
+		return serializeErrorResponse(request.ID, &jsonRPCError{Code: errorCodeInvalidRequest,
+			Message: `a request must have a method and a "jsonrpc" member of "2.0"`}), false
+	}
+	if !c.allowSubscriptions && isSubscriptionMethod(request.Method) {
+		return serializeErrorResponse(request.ID, &jsonRPCError{Code: errorCodeMethodNotFound,
+			Message: "notifications are only available over WebSocket"}), false
+	}
+
+	message, jsonRPCErr := requestToAppMessage(request.Method, request.Params)
+	if jsonRPCErr != nil {
+		return serializeErrorResponse(request.ID, jsonRPCErr), false
+	}
+
+	log.Debugf("incoming '%s' message from %s", message.Command(), c)
+
+	// The request is added to the pending ones before it's passed to the router,
+	// since its response may be sent before EnqueueIncomingMessage returns
+	c.pendingRequestsLock.Lock()
+	c.pendingRequests = append(c.pendingRequests, &pendingRequest{id: request.ID, isNotification: request.ID == nil})
+	c.pendingRequestsLock.Unlock()
+
+	err = c.router.EnqueueIncomingMessage(message)
+	if err != nil {
+		// Requests are handled one at a time per connection, so the last pending
+		// request is the one that was just added
+		c.pendingRequestsLock.Lock()
+		c.pendingRequests = c.pendingRequests[:len(c.pendingRequests)-1]
+		c.pendingRequestsLock.Unlock()
+
+		if errors.Is(err, router.ErrRouteCapacityReached) {
+			return serializeErrorResponse(request.ID, &jsonRPCError{Code: errorCodeServerError, Message: err.Error()}), false
+		}
+		return serializeErrorResponse(request.ID, &jsonRPCError{Code: errorCodeMethodNotFound,
+			Message: "method not found: " + request.Method}), false
+	}
+	return nil, true
+}
+
+func (c *jsonRPCConnection) sendLoop() error {
+	outgoingRoute := c.router.OutgoingRoute()
+	for c.IsConnected() {
+		message, err := outgoingRoute.Dequeue()
+		if err != nil {
+			if errors.Is(err, router.ErrRouteClosed) {
+				return nil
+			}
+			return err
+		}
+
+		log.Debugf("outgoing '%s' message to %s", message.Command(), c)
+
+		name, payload, err := appMessageToJSON(message)
+		if err != nil {
+			return err
+		}
+
+		var serializedMessage []byte
+		switch {
+		case strings.HasSuffix(name, notificationSuffix):
+			serializedMessage, err = json.Marshal(&jsonRPCNotification{
+				JSONRPC: jsonRPCVersion,
+				Method:  strings.TrimSuffix(name, notificationSuffix),
+				Params:  payload,
+			})
+		case strings.HasSuffix(name, responseSuffix):
+			request, ok := c.popPendingRequest()
+			if !ok {
+				return errors.Errorf("got response %s without a pending request", message.Command())
+			}
+			if request.isNotification {
+				break
+			}
+			result, jsonRPCErr := responseResult(payload)
+			serializedMessage, err = json.Marshal(&jsonRPCResponse{
+				JSONRPC: jsonRPCVersion,
+				ID:      request.id,
+				Result:  result,
+				Error:   jsonRPCErr,
+			})
+		default:
+			return errors.Errorf("message %s is neither a response nor a notification", message.Command())
+		}
+		if err != nil {
+			return err
+		}
+
+		err = c.write(serializedMessage)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *jsonRPCConnection) popPendingRequest() (*pendingRequest, bool) {
+	c.pendingRequestsLock.Lock()
+	defer c.pendingRequestsLock.Unlock()
+
+	if len(c.pendingRequests) == 0 {
+		return nil, false
+	}
+	request := c.pendingRequests[0]
+	c.pendingRequests = c.pendingRequests[1:]
+	return request, true
+}
@@ -0,0 +1,312 @@
+package jsonrpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+// MaxMessageSize is the max size of a JSON-RPC request the server reads
+const MaxMessageSize = 32 * 1024 * 1024 // 32 MB
+
+type jsonRPCServer struct {
+	onConnectedHandler server.OnConnectedHandler
+	listeningAddresses []string
+	httpServer         *http.Server
+	websocketServer    *websocket.Server
+	stopChan           chan struct{}
+	tlsConfig          *tls.Config
+	allowedOrigins     map[string]struct{}
+
+	connectionLimiter *server.ConnectionLimiter
+	websocketLimiter  *server.ConnectionLimiter
+}
+
+// NewJSONRPCServer creates a new server that serves the RPC as JSON-RPC 2.0, both
+// over plain HTTP POST requests and over WebSocket connections.
+//
+// Every HTTP request is handled as a short-lived connection, so notifications
+// are only available over WebSocket. Both the HTTP requests handled concurrently
+// and the open WebSocket connections are counted by connectionLimiter, which is
+// shared with the gRPC RPC server, and the WebSocket connections are further
+// limited to maxWebsockets. The server uses TLS if tlsConfig isn't nil.
+//
+// Browsers send an Origin header with WebSocket handshakes, so WebSocket
+// connections that send one are rejected unless it's in allowedOrigins.
+// Clients that aren't browsers don't send an Origin header and are accepted
+func NewJSONRPCServer(listeningAddresses []string, connectionLimiter *server.ConnectionLimiter, maxWebsockets int,
+	tlsConfig *tls.Config, allowedOrigins []string) (server.Server, error) {
+
+	s := &jsonRPCServer{
+		listeningAddresses: listeningAddresses,
+		stopChan:           make(chan struct{}),
+		tlsConfig:          tlsConfig,
+		allowedOrigins:     make(map[string]struct{}, len(allowedOrigins)),
+		connectionLimiter:  connectionLimiter,
+		websocketLimiter:   server.NewConnectionLimiter(maxWebsockets),
+	}
+	for _, allowedOrigin := range allowedOrigins {
+		s.allowedOrigins[normalizeOrigin(allowedOrigin)] = struct{}{}
+	}
+	s.httpServer = &http.Server{Handler: s}
+	s.websocketServer = &websocket.Server{Handshake: s.checkOrigin, Handler: s.handleWebsocket}
+	return s, nil
+}
+
+// checkOrigin rejects WebSocket handshakes that send an Origin header which isn't allowed,
+// so that web pages can't open WebSocket connections to the server from the browsers of its users
+func (s *jsonRPCServer) checkOrigin(_ *websocket.Config, request *http.Request) error {
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	if _, ok := s.allowedOrigins[normalizeOrigin(origin)]; !ok {
+		log.Warnf("Rejected a JSON-RPC WebSocket connection from %s with origin %s", request.RemoteAddr, origin)
+		return errors.Errorf("origin %s is not allowed", origin)
+	}
+	return nil
+}
+
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
+
+func (s *jsonRPCServer) Start() error {
+	if s.onConnectedHandler == nil {
+		return errors.New("onConnectedHandler is nil")
+	}
+
+	for _, listenAddress := range s.listeningAddresses {
+		err := s.listenOn(listenAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *jsonRPCServer) listenOn(listenAddr string) error {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return errors.Wrapf(err, "JSON-RPC error listening on %s", listenAddr)
+	}
+	if s.tlsConfig != nil {
+		listener = tls.NewListener(listener, s.tlsConfig)
+	}
+
+	spawn("jsonRPCServer.listenOn-Serve", func() {
+		err := s.httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panics.Exit(log, fmt.Sprintf("error serving JSON-RPC on %s: %+v", listenAddr, err))
+		}
+	})
+
+	log.Infof("JSON-RPC Server listening on %s", listener.Addr())
+	return nil
+}
+
+func (s *jsonRPCServer) Stop() error {
+	const stopTimeout = 2 * time.Second
+
+	// WebSocket connections are hijacked from the HTTP server, so they're
+	// closed separately
+	close(s.stopChan)
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	err := s.httpServer.Shutdown(ctx)
+	if err != nil {
+		log.Warnf("Could not gracefully stop the JSON-RPC server: %s", err)
+		return s.httpServer.Close()
+	}
+	return nil
+}
+
+// SetOnConnectedHandler sets the client connected handler
+// function for the server
+func (s *jsonRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnectedHandler) {
+	s.onConnectedHandler = onConnectedHandler
+}
+
+func (s *jsonRPCServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	defer panics.HandlePanic(log, "jsonRPCServer.ServeHTTP", nil)
+
+	if strings.EqualFold(request.Header.Get("Upgrade"), "websocket") {
+		_, err := s.websocketLimiter.Acquire()
+		if err != nil {
+			s.rejectClient(writer, "WebSocket", err)
+			return
+		}
+		defer s.websocketLimiter.Release()
+		_, err = s.connectionLimiter.Acquire()
+		if err != nil {
+			s.rejectClient(writer, "WebSocket", err)
+			return
+		}
+		defer s.connectionLimiter.Release()
+
+		s.websocketServer.ServeHTTP(writer, request)
+		return
+	}
+
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		http.Error(writer, "JSON-RPC requests must be sent with POST", http.StatusMethodNotAllowed)
+		return
+	}
+	// Browsers only send cross-origin POST requests without a preflight request if their content
+	// type is a form or plain text, so requiring JSON keeps web pages from sending requests to the server
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		http.Error(writer, "JSON-RPC requests must be sent with the application/json content type",
+			http.StatusUnsupportedMediaType)
+		return
+	}
+	_, err = s.connectionLimiter.Acquire()
+	if err != nil {
+		s.rejectClient(writer, "HTTP", err)
+		return
+	}
+	defer s.connectionLimiter.Release()
+
+	s.handleHTTP(writer, request)
+}
+
+func (s *jsonRPCServer) handleHTTP(writer http.ResponseWriter, request *http.Request) {
+	address, err := net.ResolveTCPAddr("tcp", request.RemoteAddr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	serializedRequest, err := io.ReadAll(io.LimitReader(request.Body, MaxMessageSize))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	responseChan := make(chan []byte, 1)
+	connection := newConnection(s, address, "http", false, requestCredentials(request), func(serializedMessage []byte) error {
+		select {
+		case responseChan <- serializedMessage:
+		default:
+		}
+		return nil
+	})
+	err = s.onConnectedHandler(connection)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer connection.Disconnect()
+
+	serializedResponse, isPending := connection.handleRequest(serializedRequest)
+	if isPending {
+		select {
+		case serializedResponse = <-responseChan:
+		case <-connection.stopChan:
+			http.Error(writer, "the connection was closed before the request was handled", http.StatusServiceUnavailable)
+			return
+		case <-request.Context().Done():
+			return
+		}
+	}
+
+	// Requests sent as JSON-RPC notifications get no response
+	if serializedResponse == nil {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	_, err = writer.Write(serializedResponse)
+	if err != nil {
+		log.Debugf("Could not write the response to %s: %s", connection, err)
+	}
+}
+
+func (s *jsonRPCServer) handleWebsocket(websocketConnection *websocket.Conn) {
+	websocketConnection.MaxPayloadBytes = MaxMessageSize
+
+	address, err := net.ResolveTCPAddr("tcp", websocketConnection.Request().RemoteAddr)
+	if err != nil {
+		log.Warnf("Could not resolve the address of a WebSocket client: %s", err)
+		return
+	}
+
+	var writeLock sync.Mutex
+	write := func(serializedMessage []byte) error {
+		if serializedMessage == nil {
+			return nil
+		}
+		writeLock.Lock()
+		defer writeLock.Unlock()
+		return websocket.Message.Send(websocketConnection, string(serializedMessage))
+	}
+	connection := newConnection(s, address, "ws", true, requestCredentials(websocketConnection.Request()), write)
+
+	err = s.onConnectedHandler(connection)
+	if err != nil {
+		log.Warnf("Could not handle the WebSocket connection from %s: %s", address, err)
+		return
+	}
+	log.Infof("JSON-RPC Incoming WebSocket connection from %s", address)
+
+	// Closing the WebSocket connection stops the receive loop below
+	spawn("jsonRPCServer.handleWebsocket-close", func() {
+		select {
+		case <-connection.stopChan:
+		case <-s.stopChan:
+		}
+		err := websocketConnection.Close()
+		if err != nil {
+			log.Debugf("Could not close the WebSocket connection of %s: %s", connection, err)
+		}
+	})
+
+	for connection.IsConnected() {
+		var serializedRequest []byte
+		err := websocket.Message.Receive(websocketConnection, &serializedRequest)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && connection.IsConnected() {
+				log.Debugf("Could not receive from %s: %s", connection, err)
+			}
+			break
+		}
+
+		serializedResponse, _ := connection.handleRequest(serializedRequest)
+		if serializedResponse != nil {
+			err := write(serializedResponse)
+			if err != nil {
+				log.Debugf("Could not send to %s: %s", connection, err)
+				break
+			}
+		}
+	}
+	connection.Disconnect()
+}
+
+// requestCredentials returns the bearer token sent in the Authorization header
+// of the given request and the common name of the verified client certificate, if any
+func requestCredentials(request *http.Request) *server.Credentials {
+	return &server.Credentials{
+		AuthToken:             server.BearerToken(request.Header.Get("Authorization")),
+		CertificateCommonName: server.CertificateCommonName(request.TLS),
+	}
+}
+
+func (s *jsonRPCServer) rejectClient(writer http.ResponseWriter, clientType string, err error) {
+	log.Warnf("Rejected a JSON-RPC %s client: %s", clientType, err)
+	http.Error(writer, err.Error(), http.StatusServiceUnavailable)
+}
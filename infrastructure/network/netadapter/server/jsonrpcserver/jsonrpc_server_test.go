@@ -0,0 +1,287 @@
+package jsonrpcserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"golang.org/x/net/websocket"
+)
+
+// testOrigin is the only origin allowed to open WebSocket connections to the test server
+const testOrigin = "http://localhost"
+
+// newTestServer returns a JSON-RPC server whose connections are handled by a
+// minimal RPC manager that knows getCurrentNetwork and notifyVirtualDaaScoreChanged
+func newTestServer(t *testing.T, connectionLimiter *server.ConnectionLimiter, maxWebsockets int) *httptest.Server {
+	rpcServer, err := NewJSONRPCServer(nil, connectionLimiter, maxWebsockets, nil, []string{testOrigin})
+	if err != nil {
+		t.Fatalf("NewJSONRPCServer: %+v", err)
+	}
+	rpcServer.SetOnConnectedHandler(func(connection server.Connection) error {
+		connectionRouter := router.NewRouter("test")
+		incomingRoute, err := connectionRouter.AddIncomingRoute("test", []appmessage.MessageCommand{
+			appmessage.CmdGetCurrentNetworkRequestMessage,
+			appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage,
+		})
+		if err != nil {
+			return err
+		}
+		connection.SetOnDisconnectedHandler(connectionRouter.Close)
+
+		go func() {
+			outgoingRoute := connectionRouter.OutgoingRoute()
+			for {
+				request, err := incomingRoute.Dequeue()
+				if err != nil {
+					return
+				}
+				switch request.Command() {
+				case appmessage.CmdGetCurrentNetworkRequestMessage:
+					_ = outgoingRoute.Enqueue(appmessage.NewGetCurrentNetworkResponseMessage("kaspa-testnet"))
+				case appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage:
+					_ = outgoingRoute.Enqueue(appmessage.NewNotifyVirtualDaaScoreChangedResponseMessage())
+					_ = outgoingRoute.Enqueue(appmessage.NewVirtualDaaScoreChangedNotificationMessage(1234))
+				}
+			}
+		}()
+
+		connection.Start(connectionRouter)
+		return nil
+	})
+	return httptest.NewServer(rpcServer.(*jsonRPCServer))
+}
+
+func postJSONRPC(t *testing.T, url string, serializedRequest string) (int, map[string]interface{}) {
+	response, err := http.Post(url, "application/json", bytes.NewBufferString(serializedRequest))
+	if err != nil {
+		t.Fatalf("Post: %+v", err)
+	}
+	defer response.Body.Close()
+
+	serializedResponse, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("ReadAll: %+v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return response.StatusCode, nil
+	}
+	var jsonRPCResponse map[string]interface{}
+	err = json.Unmarshal(serializedResponse, &jsonRPCResponse)
+	if err != nil {
+		t.Fatalf("Unmarshal %s: %+v", serializedResponse, err)
+	}
+	return response.StatusCode, jsonRPCResponse
+}
+
+func TestHTTP(t *testing.T) {
+	testServer := newTestServer(t, server.NewConnectionLimiter(0), 0)
+	defer testServer.Close()
+
+	_, response := postJSONRPC(t, testServer.URL, `{"jsonrpc":"2.0","id":"a","method":"getCurrentNetwork"}`)
+	if response["id"] != "a" {
+		t.Fatalf("unexpected response ID %v", response["id"])
+	}
+	result, ok := response["result"].(map[string]interface{})
+	if !ok || result["currentNetwork"] != "kaspa-testnet" {
+		t.Fatalf("unexpected response %v", response)
+	}
+
+	tests := []struct {
+		name              string
+		serializedRequest string
+		expectedErrorCode float64
+	}{
+		{"parse error", `{"jsonrpc":`, errorCodeParseError},
+		{"missing version", `{"id":1,"method":"getCurrentNetwork"}`, errorCodeInvalidRequest},
+		{"unknown method", `{"jsonrpc":"2.0","id":1,"method":"getEverything"}`, errorCodeMethodNotFound},
+		{"unrouted method", `{"jsonrpc":"2.0","id":1,"method":"getBlockDagInfo"}`, errorCodeMethodNotFound},
+		{"subscription", `{"jsonrpc":"2.0","id":1,"method":"notifyVirtualDaaScoreChanged"}`, errorCodeMethodNotFound},
+		{"invalid params", `{"jsonrpc":"2.0","id":1,"method":"getCurrentNetwork","params":{"nothing":1}}`,
+			errorCodeInvalidParams},
+	}
+	for _, test := range tests {
+		_, response := postJSONRPC(t, testServer.URL, test.serializedRequest)
+		jsonRPCError, ok := response["error"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("%s: expected an error, got %v", test.name, response)
+		}
+		if jsonRPCError["code"] != test.expectedErrorCode {
+			t.Fatalf("%s: expected error code %f, got %v", test.name, test.expectedErrorCode, jsonRPCError["code"])
+		}
+	}
+
+	// Requests sent as JSON-RPC notifications are handled without a response
+	statusCode, _ := postJSONRPC(t, testServer.URL, `{"jsonrpc":"2.0","method":"getCurrentNetwork"}`)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, statusCode)
+	}
+
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded", "multipart/form-data"} {
+		response, err := http.Post(testServer.URL, contentType,
+			bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"getCurrentNetwork"}`))
+		if err != nil {
+			t.Fatalf("Post: %+v", err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusUnsupportedMediaType {
+			t.Fatalf("content type %q: expected status %d, got %d",
+				contentType, http.StatusUnsupportedMediaType, response.StatusCode)
+		}
+	}
+	httpResponse, err := http.Post(testServer.URL, "application/json; charset=utf-8",
+		bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"getCurrentNetwork"}`))
+	if err != nil {
+		t.Fatalf("Post: %+v", err)
+	}
+	httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d for a JSON content type with parameters, got %d", http.StatusOK, httpResponse.StatusCode)
+	}
+}
+
+func TestWebsocket(t *testing.T) {
+	testServer := newTestServer(t, server.NewConnectionLimiter(0), 1)
+	defer testServer.Close()
+
+	websocketURL := "ws" + strings.TrimPrefix(testServer.URL, "http")
+	websocketConnection, err := websocket.Dial(websocketURL, "", testOrigin)
+	if err != nil {
+		t.Fatalf("Dial: %+v", err)
+	}
+	defer websocketConnection.Close()
+
+	// The server is limited to a single WebSocket connection
+	_, err = websocket.Dial(websocketURL, "", testOrigin)
+	if err == nil {
+		t.Fatalf("expected a second WebSocket connection to be rejected")
+	}
+
+	send := func(serializedRequest string) {
+		err := websocket.Message.Send(websocketConnection, serializedRequest)
+		if err != nil {
+			t.Fatalf("Send: %+v", err)
+		}
+	}
+	receive := func() map[string]interface{} {
+		var serializedMessage []byte
+		err := websocket.Message.Receive(websocketConnection, &serializedMessage)
+		if err != nil {
+			t.Fatalf("Receive: %+v", err)
+		}
+		var message map[string]interface{}
+		err = json.Unmarshal(serializedMessage, &message)
+		if err != nil {
+			t.Fatalf("Unmarshal %s: %+v", serializedMessage, err)
+		}
+		return message
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"notifyVirtualDaaScoreChanged"}`)
+	send(`{"jsonrpc":"2.0","id":2,"method":"getCurrentNetwork"}`)
+
+	response := receive()
+	if response["id"] != float64(1) || response["result"] == nil {
+		t.Fatalf("unexpected response %v", response)
+	}
+	notification := receive()
+	if notification["method"] != "virtualDaaScoreChanged" || notification["id"] != nil {
+		t.Fatalf("unexpected notification %v", notification)
+	}
+	params, ok := notification["params"].(map[string]interface{})
+	if !ok || params["virtualDaaScore"] != "1234" {
+		t.Fatalf("unexpected notification params %v", notification["params"])
+	}
+	response = receive()
+	if response["id"] != float64(2) {
+		t.Fatalf("unexpected response %v", response)
+	}
+}
+
+func TestWebsocketOrigin(t *testing.T) {
+	testServer := newTestServer(t, server.NewConnectionLimiter(0), 0)
+	defer testServer.Close()
+	websocketURL := "ws" + strings.TrimPrefix(testServer.URL, "http")
+
+	for _, origin := range []string{testOrigin, strings.ToUpper(testOrigin) + "/"} {
+		websocketConnection, err := websocket.Dial(websocketURL, "", origin)
+		if err != nil {
+			t.Fatalf("Dial with origin %s: %+v", origin, err)
+		}
+		websocketConnection.Close()
+	}
+
+	for _, origin := range []string{"http://example.com", "http://localhost:8080", "null"} {
+		_, err := websocket.Dial(websocketURL, "", origin)
+		if err == nil {
+			t.Fatalf("expected a WebSocket connection with origin %s to be rejected", origin)
+		}
+	}
+
+	// Clients that aren't browsers don't send an Origin header
+	request, err := http.NewRequest(http.MethodGet, testServer.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %+v", err)
+	}
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Do: %+v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected a WebSocket connection without an origin to be accepted, got status %d", response.StatusCode)
+	}
+}
+
+// TestSharedConnectionLimit verifies that HTTP and WebSocket clients are counted against the limit
+// shared with the gRPC RPC server
+func TestSharedConnectionLimit(t *testing.T) {
+	connectionLimiter := server.NewConnectionLimiter(1)
+	testServer := newTestServer(t, connectionLimiter, 0)
+	defer testServer.Close()
+
+	websocketURL := "ws" + strings.TrimPrefix(testServer.URL, "http")
+	websocketConnection, err := websocket.Dial(websocketURL, "", testOrigin)
+	if err != nil {
+		t.Fatalf("Dial: %+v", err)
+	}
+
+	statusCode, _ := postJSONRPC(t, testServer.URL, `{"jsonrpc":"2.0","id":1,"method":"getCurrentNetwork"}`)
+	if statusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected an HTTP client to be rejected while a WebSocket client is connected, got status %d",
+			statusCode)
+	}
+	_, err = connectionLimiter.Acquire()
+	if err == nil {
+		t.Fatalf("expected a gRPC client to be rejected while a WebSocket client is connected")
+	}
+
+	err = websocketConnection.Close()
+	if err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		statusCode, _ = postJSONRPC(t, testServer.URL, `{"jsonrpc":"2.0","id":1,"method":"getCurrentNetwork"}`)
+		if statusCode == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected an HTTP client to be accepted once the WebSocket client disconnected, got "+
+				"status %d", statusCode)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
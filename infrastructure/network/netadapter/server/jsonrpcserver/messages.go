@@ -0,0 +1,162 @@
+package jsonrpcserver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const jsonRPCVersion = "2.0"
+
+// Error codes defined by the JSON-RPC 2.0 specification, and the one
+// used for errors returned by the RPC handlers themselves
+const (
+	errorCodeParseError     = -32700
+	errorCodeInvalidRequest = -32600
+	errorCodeMethodNotFound = -32601
+	errorCodeInvalidParams  = -32602
+	errorCodeInternalError  = -32603
+	errorCodeServerError    = -32000
+)
+
+const (
+	requestSuffix      = "Request"
+	responseSuffix     = "Response"
+	notificationSuffix = "Notification"
+)
+
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+type jsonRPCNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+var payloadOneof = (&protowire.KaspadMessage{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+
+// requestFields maps every JSON-RPC method to the KaspadMessage payload field
+// of its request. The method of the request field `getBlockDagInfoRequest`
+// is `getBlockDagInfo`
+var requestFields = func() map[string]protoreflect.FieldDescriptor {
+	requestFields := make(map[string]protoreflect.FieldDescriptor)
+	fields := payloadOneof.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if strings.HasSuffix(field.JSONName(), requestSuffix) {
+			requestFields[strings.TrimSuffix(field.JSONName(), requestSuffix)] = field
+		}
+	}
+	return requestFields
+}()
+
+// isSubscriptionMethod returns whether the given method starts or stops
+// a notification stream
+func isSubscriptionMethod(method string) bool {
+	return strings.HasPrefix(method, "notify") || strings.HasPrefix(method, "stopNotifying")
+}
+
+// requestToAppMessage converts the params of a JSON-RPC request to the
+// appmessage of the request of the given method. params use the canonical
+// protobuf JSON mapping of the request message
+func requestToAppMessage(method string, params json.RawMessage) (appmessage.Message, *jsonRPCError) {
+	field, ok := requestFields[method]
+	if !ok {
+		return nil, &jsonRPCError{Code: errorCodeMethodNotFound, Message: "method not found: " + method}
+	}
+
+	kaspadMessage := &protowire.KaspadMessage{}
+	reflectMessage := kaspadMessage.ProtoReflect()
+	value := reflectMessage.NewField(field)
+	if len(params) > 0 && string(params) != "null" {
+		err := protojson.Unmarshal(params, value.Message().Interface())
+		if err != nil {
+			return nil, &jsonRPCError{Code: errorCodeInvalidParams, Message: err.Error()}
+		}
+	}
+	reflectMessage.Set(field, value)
+
+	message, err := kaspadMessage.ToAppMessage()
+	if err != nil {
+		return nil, &jsonRPCError{Code: errorCodeInvalidParams, Message: err.Error()}
+	}
+	return message, nil
+}
+
+// appMessageToJSON converts the given appmessage to its canonical protobuf
+// JSON mapping. It returns the JSON name of the KaspadMessage payload field
+// of the message along with the fields of the message
+func appMessageToJSON(message appmessage.Message) (string, map[string]json.RawMessage, error) {
+	kaspadMessage, err := protowire.FromAppMessage(message)
+	if err != nil {
+		return "", nil, err
+	}
+	reflectMessage := kaspadMessage.ProtoReflect()
+	field := reflectMessage.WhichOneof(payloadOneof)
+	if field == nil {
+		return "", nil, errors.Errorf("message %s has no payload", message.Command())
+	}
+
+	serializedPayload, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(
+		reflectMessage.Get(field).Message().Interface())
+	if err != nil {
+		return "", nil, err
+	}
+	var payload map[string]json.RawMessage
+	err = json.Unmarshal(serializedPayload, &payload)
+	if err != nil {
+		return "", nil, err
+	}
+	return field.JSONName(), payload, nil
+}
+
+// responseResult splits the fields of a response into the JSON-RPC result
+// and error. The `error` field every response carries is turned into a
+// JSON-RPC error
+func responseResult(payload map[string]json.RawMessage) (interface{}, *jsonRPCError) {
+	serializedRPCError, ok := payload["error"]
+	delete(payload, "error")
+	if !ok || string(serializedRPCError) == "null" {
+		return payload, nil
+	}
+
+	var rpcError struct {
+		Message string `json:"message"`
+	}
+	err := json.Unmarshal(serializedRPCError, &rpcError)
+	if err != nil {
+		return nil, &jsonRPCError{Code: errorCodeInternalError, Message: err.Error()}
+	}
+	return nil, &jsonRPCError{Code: errorCodeServerError, Message: rpcError.Message}
+}
+
+func serializeErrorResponse(id json.RawMessage, jsonRPCError *jsonRPCError) []byte {
+	serializedResponse, err := json.Marshal(&jsonRPCResponse{JSONRPC: jsonRPCVersion, ID: id, Error: jsonRPCError})
+	if err != nil {
+		// A response made only of an ID that was already parsed and an error can always be serialized
+		panic(err)
+	}
+	return serializedResponse
+}
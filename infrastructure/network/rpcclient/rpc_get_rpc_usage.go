@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetRPCUsage sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetRPCUsage() (*appmessage.GetRPCUsageResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetRPCUsageRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetRPCUsageResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getRPCUsageResponse := response.(*appmessage.GetRPCUsageResponseMessage)
+	if getRPCUsageResponse.Error != nil {
+		return nil, c.convertRPCError(getRPCUsageResponse.Error)
+	}
+	return getRPCUsageResponse, nil
+}
@@ -21,9 +21,10 @@ func (c *RPCClient) RegisterForBlockAddedNotifications(onBlockAdded func(notific
 	if notifyBlockAddedResponse.Error != nil {
 		return c.convertRPCError(notifyBlockAddedResponse.Error)
 	}
+	notificationRoute := c.route(appmessage.CmdBlockAddedNotificationMessage)
 	spawn("RegisterForBlockAddedNotifications", func() {
 		for {
-			notification, err := c.route(appmessage.CmdBlockAddedNotificationMessage).Dequeue()
+			notification, err := notificationRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
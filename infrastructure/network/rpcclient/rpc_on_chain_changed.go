@@ -24,9 +24,10 @@ func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotifications(in
 	if notifyChainChangedResponse.Error != nil {
 		return c.convertRPCError(notifyChainChangedResponse.Error)
 	}
+	notificationRoute := c.route(appmessage.CmdVirtualSelectedParentChainChangedNotificationMessage)
 	spawn("RegisterForVirtualSelectedParentChainChangedNotifications", func() {
 		for {
-			notification, err := c.route(appmessage.CmdVirtualSelectedParentChainChangedNotificationMessage).Dequeue()
+			notification, err := notificationRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
@@ -24,9 +24,10 @@ func (c *RPCClient) RegisterForFinalityConflictsNotifications(
 	if notifyFinalityConflictsResponse.Error != nil {
 		return c.convertRPCError(notifyFinalityConflictsResponse.Error)
 	}
+	finalityConflictRoute := c.route(appmessage.CmdFinalityConflictNotificationMessage)
 	spawn("RegisterForFinalityConflictsNotifications-finalityConflict", func() {
 		for {
-			notification, err := c.route(appmessage.CmdFinalityConflictNotificationMessage).Dequeue()
+			notification, err := finalityConflictRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
@@ -37,9 +38,10 @@ func (c *RPCClient) RegisterForFinalityConflictsNotifications(
 			onFinalityConflict(finalityConflictNotification)
 		}
 	})
+	finalityConflictResolvedRoute := c.route(appmessage.CmdFinalityConflictResolvedNotificationMessage)
 	spawn("RegisterForFinalityConflictsNotifications-finalityConflictResolved", func() {
 		for {
-			notification, err := c.route(appmessage.CmdFinalityConflictResolvedNotificationMessage).Dequeue()
+			notification, err := finalityConflictResolvedRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
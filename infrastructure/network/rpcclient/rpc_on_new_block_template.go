@@ -21,9 +21,10 @@ func (c *RPCClient) RegisterForNewBlockTemplateNotifications(onNewBlockTemplate
 	if notifyNewBlockTemplateResponse.Error != nil {
 		return c.convertRPCError(notifyNewBlockTemplateResponse.Error)
 	}
+	notificationRoute := c.route(appmessage.CmdNewBlockTemplateNotificationMessage)
 	spawn("RegisterForNewBlockTemplateNotifications", func() {
 		for {
-			notification, err := c.route(appmessage.CmdNewBlockTemplateNotificationMessage).Dequeue()
+			notification, err := notificationRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
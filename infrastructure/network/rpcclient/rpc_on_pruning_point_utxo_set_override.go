@@ -22,9 +22,10 @@ func (c *RPCClient) RegisterPruningPointUTXOSetNotifications(onPruningPointUTXOS
 	if notifyPruningPointUTXOSetOverrideResponse.Error != nil {
 		return c.convertRPCError(notifyPruningPointUTXOSetOverrideResponse.Error)
 	}
+	notificationRoute := c.route(appmessage.CmdPruningPointUTXOSetOverrideNotificationMessage)
 	spawn("RegisterPruningPointUTXOSetNotifications", func() {
 		for {
-			notification, err := c.route(appmessage.CmdPruningPointUTXOSetOverrideNotificationMessage).Dequeue()
+			notification, err := notificationRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
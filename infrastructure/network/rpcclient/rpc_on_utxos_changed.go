@@ -11,21 +11,14 @@ import (
 func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string,
 	onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyUTXOsChangedRequestMessage(addresses))
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyUTXOsChangedResponseMessage).DequeueWithTimeout(c.timeout)
+	err := c.RegisterAddressesForUTXOsChangedNotifications(addresses)
 	if err != nil {
 		return err
 	}
-	notifyUTXOsChangedResponse := response.(*appmessage.NotifyUTXOsChangedResponseMessage)
-	if notifyUTXOsChangedResponse.Error != nil {
-		return c.convertRPCError(notifyUTXOsChangedResponse.Error)
-	}
+	notificationRoute := c.route(appmessage.CmdUTXOsChangedNotificationMessage)
 	spawn("RegisterForUTXOsChangedNotifications", func() {
 		for {
-			notification, err := c.route(appmessage.CmdUTXOsChangedNotificationMessage).Dequeue()
+			notification, err := notificationRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
@@ -38,3 +31,23 @@ func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string,
 	})
 	return nil
 }
+
+// RegisterAddressesForUTXOsChangedNotifications adds the given addresses to the ones
+// the RPC server sends UTXOs changed notifications for. Unlike RegisterForUTXOsChangedNotifications,
+// it doesn't start listening for the notifications, so it's meant for growing the address set
+// of an existing registration
+func (c *RPCClient) RegisterAddressesForUTXOsChangedNotifications(addresses []string) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyUTXOsChangedRequestMessage(addresses))
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyUTXOsChangedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyUTXOsChangedResponse := response.(*appmessage.NotifyUTXOsChangedResponseMessage)
+	if notifyUTXOsChangedResponse.Error != nil {
+		return c.convertRPCError(notifyUTXOsChangedResponse.Error)
+	}
+	return nil
+}
@@ -24,9 +24,10 @@ func (c *RPCClient) RegisterForVirtualDaaScoreChangedNotifications(
 	if notifyVirtualDaaScoreChangedResponse.Error != nil {
 		return c.convertRPCError(notifyVirtualDaaScoreChangedResponse.Error)
 	}
+	notificationRoute := c.route(appmessage.CmdVirtualDaaScoreChangedNotificationMessage)
 	spawn("RegisterForVirtualDaaScoreChangedNotifications", func() {
 		for {
-			notification, err := c.route(appmessage.CmdVirtualDaaScoreChangedNotificationMessage).Dequeue()
+			notification, err := notificationRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
@@ -24,9 +24,10 @@ func (c *RPCClient) RegisterForVirtualSelectedParentBlueScoreChangedNotification
 	if notifyVirtualSelectedParentBlueScoreChangedResponse.Error != nil {
 		return c.convertRPCError(notifyVirtualSelectedParentBlueScoreChangedResponse.Error)
 	}
+	notificationRoute := c.route(appmessage.CmdVirtualSelectedParentBlueScoreChangedNotificationMessage)
 	spawn("RegisterForVirtualSelectedParentBlueScoreChangedNotifications", func() {
 		for {
-			notification, err := c.route(appmessage.CmdVirtualSelectedParentBlueScoreChangedNotificationMessage).Dequeue()
+			notification, err := notificationRoute.Dequeue()
 			if err != nil {
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					break
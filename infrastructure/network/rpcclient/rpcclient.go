@@ -20,19 +20,28 @@ type RPCClient struct {
 	*grpcclient.GRPCClient
 
 	rpcAddress           string
+	authToken            string
 	rpcRouter            *rpcRouter
 	isConnected          uint32
 	isClosed             uint32
 	isReconnecting       uint32
 	lastDisconnectedTime time.Time
+	onReconnectedHandler func()
 
 	timeout time.Duration
 }
 
 // NewRPCClient сreates a new RPC client with a default call timeout value
 func NewRPCClient(rpcAddress string) (*RPCClient, error) {
+	return NewRPCClientWithAuthToken(rpcAddress, "")
+}
+
+// NewRPCClientWithAuthToken creates a new RPC client that authenticates with the
+// given bearer token
+func NewRPCClientWithAuthToken(rpcAddress string, authToken string) (*RPCClient, error) {
 	rpcClient := &RPCClient{
 		rpcAddress: rpcAddress,
+		authToken:  authToken,
 		timeout:    defaultTimeout,
 	}
 	err := rpcClient.connect()
@@ -44,7 +53,7 @@ func NewRPCClient(rpcAddress string) (*RPCClient, error) {
 }
 
 func (c *RPCClient) connect() error {
-	rpcClient, err := grpcclient.Connect(c.rpcAddress)
+	rpcClient, err := grpcclient.ConnectWithAuthToken(c.rpcAddress, c.authToken)
 	if err != nil {
 		return errors.Wrapf(err, "error connecting to address %s", c.rpcAddress)
 	}
@@ -59,7 +68,7 @@ func (c *RPCClient) connect() error {
 	rpcClient.AttachRouter(rpcRouter.router)
 
 	c.GRPCClient = rpcClient
-	c.rpcRouter = rpcRouter
+	c.setRPCRouter(rpcRouter)
 
 	log.Infof("Connected to %s", c.rpcAddress)
 
@@ -78,6 +87,17 @@ func (c *RPCClient) connect() error {
 	return nil
 }
 
+// setRPCRouter replaces the router of the previous connection, if any, with the given one.
+// The notification listeners of the previous connection read from its router, so closing it
+// stops them, and a handler registered again after a reconnection is the only one left active
+func (c *RPCClient) setRPCRouter(rpcRouter *rpcRouter) {
+	previousRPCRouter := c.rpcRouter
+	c.rpcRouter = rpcRouter
+	if previousRPCRouter != nil {
+		previousRPCRouter.router.Close()
+	}
+}
+
 func (c *RPCClient) disconnect() error {
 	err := c.GRPCClient.Disconnect()
 	if err != nil {
@@ -118,6 +138,9 @@ func (c *RPCClient) Reconnect() error {
 		if time.Since(c.lastDisconnectedTime) > retryDelay {
 			err := c.connect()
 			if err == nil {
+				if c.onReconnectedHandler != nil {
+					c.onReconnectedHandler()
+				}
 				return nil
 			}
 			log.Warnf("Could not automatically reconnect to %s: %s", c.rpcAddress, err)
@@ -150,6 +173,14 @@ func (c *RPCClient) handleClientError(err error) {
 	c.handleClientDisconnected()
 }
 
+// SetOnReconnectedHandler sets a handler to be called whenever the client
+// reconnects. Notification registrations don't survive a reconnection, and
+// their listeners are stopped, so the handler is the place to register for
+// them again
+func (c *RPCClient) SetOnReconnectedHandler(onReconnectedHandler func()) {
+	c.onReconnectedHandler = onReconnectedHandler
+}
+
 // SetTimeout sets the timeout by which to wait for RPC responses
 func (c *RPCClient) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
@@ -0,0 +1,79 @@
+package rpcclient
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// TestReconnectStopsNotificationListeners verifies that registering for notifications again
+// after every reconnection leaves exactly one listener active
+func TestReconnectStopsNotificationListeners(t *testing.T) {
+	client := &RPCClient{timeout: time.Second}
+	var handledCount int32
+	onUTXOsChanged := func(*appmessage.UTXOsChangedNotificationMessage) {
+		atomic.AddInt32(&handledCount, 1)
+	}
+
+	goroutinesBefore := runtime.NumGoroutine()
+	const connections = 5
+	previousRPCRouters := make([]*rpcRouter, 0, connections)
+	for i := 0; i < connections; i++ {
+		if client.rpcRouter != nil {
+			previousRPCRouters = append(previousRPCRouters, client.rpcRouter)
+		}
+		rpcRouter, err := buildRPCRouter()
+		if err != nil {
+			t.Fatalf("buildRPCRouter: %+v", err)
+		}
+		client.setRPCRouter(rpcRouter)
+
+		// Answer the registration request the way the RPC server does
+		go func() {
+			_, err := rpcRouter.outgoingRoute().Dequeue()
+			if err != nil {
+				t.Errorf("Dequeue: %+v", err)
+				return
+			}
+			err = rpcRouter.router.EnqueueIncomingMessage(appmessage.NewNotifyUTXOsChangedResponseMessage())
+			if err != nil {
+				t.Errorf("EnqueueIncomingMessage: %+v", err)
+			}
+		}()
+		err = client.RegisterForUTXOsChangedNotifications([]string{"kaspa:address"}, onUTXOsChanged)
+		if err != nil {
+			t.Fatalf("RegisterForUTXOsChangedNotifications: %+v", err)
+		}
+	}
+	defer client.rpcRouter.router.Close()
+
+	for _, previousRPCRouter := range previousRPCRouters {
+		err := previousRPCRouter.router.EnqueueIncomingMessage(appmessage.NewUTXOsChangedNotificationMessage())
+		if !errors.Is(err, routerpkg.ErrRouteClosed) {
+			t.Fatalf("Expected the router of a previous connection to be closed, got: %v", err)
+		}
+	}
+
+	err := client.rpcRouter.router.EnqueueIncomingMessage(appmessage.NewUTXOsChangedNotificationMessage())
+	if err != nil {
+		t.Fatalf("EnqueueIncomingMessage: %+v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&handledCount) == 0 || runtime.NumGoroutine() > goroutinesBefore+1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected a single active listener to handle the notification, got %d handled "+
+				"notifications and %d goroutines instead of %d", atomic.LoadInt32(&handledCount),
+				runtime.NumGoroutine(), goroutinesBefore+1)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if handledCount := atomic.LoadInt32(&handledCount); handledCount != 1 {
+		t.Fatalf("Expected the notification to be handled once, got %d", handledCount)
+	}
+}
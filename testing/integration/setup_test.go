@@ -1,12 +1,11 @@
 package integration
 
 import (
-	"path/filepath"
 	"testing"
 
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 
-	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memorydb"
 
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
 
@@ -127,14 +126,7 @@ func setApp(t *testing.T, harness *appHarness) {
 }
 
 func setDatabaseContext(t *testing.T, harness *appHarness) {
-	var err error
-	harness.database, err = openDB(harness.config)
-	if err != nil {
-		t.Fatalf("Error openning database: %+v", err)
-	}
-}
-
-func openDB(cfg *config.Config) (database.Database, error) {
-	dbPath := filepath.Join(cfg.AppDir, "db")
-	return ldb.NewLevelDB(dbPath, 8)
+	// The harnesses never outlive the test, so there's no need to keep
+	// their databases on disk
+	harness.database = memorydb.NewMemoryDB()
 }